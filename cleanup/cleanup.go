@@ -0,0 +1,143 @@
+//go:generate mockgen -destination mock/cleanup.go -package mock -source=cleanup.go Storage ContentStorage
+package cleanup
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/sirupsen/logrus"
+)
+
+const batchSize = 100
+
+// ListFunc returns the next batch of files to delete.
+type ListFunc func(
+	ctx context.Context, limit int, headers http.Header,
+) ([]controller.FileMetadata, *controller.APIError)
+
+type Storage interface {
+	ListFileVersions(
+		ctx context.Context, fileID string, headers http.Header,
+	) ([]controller.FileVersion, *controller.APIError)
+	DeleteFileByID(
+		ctx context.Context,
+		fileID string,
+		condition controller.FileCondition,
+		events []controller.WebhookEvent,
+		headers http.Header,
+	) *controller.APIError
+}
+
+type ContentStorage interface {
+	DeleteFile(ctx context.Context, filepath string) *controller.APIError
+}
+
+// Job permanently deletes the files returned by a list query along with their content
+// and versions, for instance the files in the trash whose retention period is over.
+type Job struct {
+	name           string
+	list           ListFunc
+	storage        Storage
+	contentStorage ContentStorage
+	adminSecret    controller.AdminSecret
+	logger         *logrus.Logger
+}
+
+// New returns a job deleting the files returned by list, name describes them in logs.
+func New(
+	name string,
+	list ListFunc,
+	storage Storage,
+	contentStorage ContentStorage,
+	hasuraAdminSecret controller.AdminSecret,
+	logger *logrus.Logger,
+) *Job {
+	return &Job{
+		name:           name,
+		list:           list,
+		storage:        storage,
+		contentStorage: contentStorage,
+		adminSecret:    hasuraAdminSecret,
+		logger:         logger,
+	}
+}
+
+func (j *Job) headers() http.Header {
+	return http.Header{"x-hasura-admin-secret": []string{j.adminSecret.Primary()}}
+}
+
+// delete deletes the row before the objects so a file that changes in the meantime, for
+// instance because it's restored or updated, is kept as its updated_at doesn't match
+// anymore. Objects that can't be deleted are left as orphans.
+func (j *Job) delete(ctx context.Context, file controller.FileMetadata) bool {
+	logger := j.logger.WithField("file_id", file.ID)
+
+	// versions are deleted with the file so they need to be listed beforehand
+	versions, apiErr := j.storage.ListFileVersions(ctx, file.ID, j.headers())
+	if apiErr != nil {
+		logger.WithError(apiErr).Error("problem listing file versions")
+		return false
+	}
+
+	if apiErr := j.storage.DeleteFileByID(
+		ctx, file.ID, controller.FileCondition{UpdatedAt: file.UpdatedAt}, nil, j.headers(), //nolint: exhaustruct
+	); apiErr != nil {
+		logger.WithError(apiErr).Error("problem deleting file")
+		return false
+	}
+
+	if apiErr := j.contentStorage.DeleteFile(ctx, controller.FileObjectKey(file)); apiErr != nil {
+		logger.WithError(apiErr).Error("problem deleting file from storage")
+	}
+
+	for _, version := range versions {
+		if apiErr := j.contentStorage.DeleteFile(ctx, version.ObjectKey); apiErr != nil {
+			logger.WithError(apiErr).WithField("version_id", version.ID).Error(
+				"problem deleting file version from storage",
+			)
+		}
+	}
+
+	return true
+}
+
+// DeleteAll deletes all the files returned by the list query.
+func (j *Job) DeleteAll(ctx context.Context) {
+	for {
+		files, apiErr := j.list(ctx, batchSize, j.headers())
+		if apiErr != nil {
+			j.logger.WithError(apiErr).Error("problem listing " + j.name)
+			return
+		}
+
+		deleted := 0
+		for _, file := range files {
+			if j.delete(ctx, file) {
+				deleted++
+			}
+		}
+
+		// failed files would be listed again so they are left for the next run
+		if len(files) < batchSize || deleted < len(files) || ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// Run deletes the files periodically until the context is cancelled.
+func (j *Job) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		j.DeleteAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package cleanup_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/nhost/hasura-storage/cleanup"
+	"github.com/nhost/hasura-storage/cleanup/mock"
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/middleware/auth"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func expiredFiles(n int) []controller.FileMetadata {
+	files := make([]controller.FileMetadata, n)
+	for i := range files {
+		files[i] = controller.FileMetadata{ //nolint: exhaustruct
+			ID:        fmt.Sprintf("file-%d", i),
+			BucketID:  "default",
+			UpdatedAt: "2021-12-16T13:26:52.082485+00:00",
+		}
+		if i%2 == 1 {
+			files[i].ObjectKey = fmt.Sprintf("reports/file-%d", i)
+		}
+	}
+	return files
+}
+
+func TestDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		batches    [][]controller.FileMetadata
+		versions   []controller.FileVersion
+		storageErr *controller.APIError
+	}{
+		{
+			name:    "nothing expired",
+			batches: [][]controller.FileMetadata{{}},
+		},
+		{
+			name:    "single batch",
+			batches: [][]controller.FileMetadata{expiredFiles(2)},
+		},
+		{
+			name:    "versions are deleted with the file",
+			batches: [][]controller.FileMetadata{expiredFiles(1)},
+			versions: []controller.FileVersion{
+				{ID: "version-0", ObjectKey: ".versions/version-0/file-0"}, //nolint: exhaustruct
+			},
+		},
+		{
+			name:    "full batch lists again",
+			batches: [][]controller.FileMetadata{expiredFiles(100), expiredFiles(1)},
+		},
+		{
+			name:       "failed files are left for the next run",
+			batches:    [][]controller.FileMetadata{expiredFiles(100)},
+			storageErr: controller.ErrPreconditionFailed,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			storage := mock.NewMockStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			for _, batch := range tc.batches {
+				for _, f := range batch {
+					storage.EXPECT().ListFileVersions(
+						gomock.Any(), f.ID, gomock.Any(),
+					).Return(tc.versions, nil)
+
+					storage.EXPECT().DeleteFileByID(
+						gomock.Any(), f.ID, controller.FileCondition{UpdatedAt: f.UpdatedAt}, //nolint: exhaustruct
+						nil, gomock.Any(),
+					).Return(tc.storageErr)
+
+					if tc.storageErr != nil {
+						continue
+					}
+
+					objectKey := f.ObjectKey
+					if objectKey == "" {
+						objectKey = f.ID
+					}
+					contentStorage.EXPECT().DeleteFile(gomock.Any(), objectKey).Return(nil)
+
+					for _, v := range tc.versions {
+						contentStorage.EXPECT().DeleteFile(gomock.Any(), v.ObjectKey).Return(nil)
+					}
+				}
+			}
+
+			listed := 0
+			list := func(
+				_ context.Context, limit int, _ http.Header,
+			) ([]controller.FileMetadata, *controller.APIError) {
+				if limit != 100 || listed == len(tc.batches) {
+					t.Fatalf("unexpected list call with limit %d", limit)
+				}
+				listed++
+				return tc.batches[listed-1], nil
+			}
+
+			job := cleanup.New(
+				"expired files", list, storage, contentStorage, auth.NewAdminSecrets("asdasd"), logger,
+			)
+			job.DeleteAll(context.Background())
+
+			if listed != len(tc.batches) {
+				t.Errorf("listed %d batches, wanted %d", listed, len(tc.batches))
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cleanup.go
+//
+// Generated by this command:
+//
+//	mockgen -destination mock/cleanup.go -package mock -source=cleanup.go Storage ContentStorage
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	http "net/http"
+	reflect "reflect"
+
+	controller "github.com/nhost/hasura-storage/controller"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStorage is a mock of Storage interface.
+type MockStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageMockRecorder
+}
+
+// MockStorageMockRecorder is the mock recorder for MockStorage.
+type MockStorageMockRecorder struct {
+	mock *MockStorage
+}
+
+// NewMockStorage creates a new mock instance.
+func NewMockStorage(ctrl *gomock.Controller) *MockStorage {
+	mock := &MockStorage{ctrl: ctrl}
+	mock.recorder = &MockStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
+	return m.recorder
+}
+
+// DeleteFileByID mocks base method.
+func (m *MockStorage) DeleteFileByID(ctx context.Context, fileID string, condition controller.FileCondition, events []controller.WebhookEvent, headers http.Header) *controller.APIError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFileByID", ctx, fileID, condition, events, headers)
+	ret0, _ := ret[0].(*controller.APIError)
+	return ret0
+}
+
+// DeleteFileByID indicates an expected call of DeleteFileByID.
+func (mr *MockStorageMockRecorder) DeleteFileByID(ctx, fileID, condition, events, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFileByID", reflect.TypeOf((*MockStorage)(nil).DeleteFileByID), ctx, fileID, condition, events, headers)
+}
+
+// ListFileVersions mocks base method.
+func (m *MockStorage) ListFileVersions(ctx context.Context, fileID string, headers http.Header) ([]controller.FileVersion, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFileVersions", ctx, fileID, headers)
+	ret0, _ := ret[0].([]controller.FileVersion)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// ListFileVersions indicates an expected call of ListFileVersions.
+func (mr *MockStorageMockRecorder) ListFileVersions(ctx, fileID, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFileVersions", reflect.TypeOf((*MockStorage)(nil).ListFileVersions), ctx, fileID, headers)
+}
+
+// MockContentStorage is a mock of ContentStorage interface.
+type MockContentStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockContentStorageMockRecorder
+}
+
+// MockContentStorageMockRecorder is the mock recorder for MockContentStorage.
+type MockContentStorageMockRecorder struct {
+	mock *MockContentStorage
+}
+
+// NewMockContentStorage creates a new mock instance.
+func NewMockContentStorage(ctrl *gomock.Controller) *MockContentStorage {
+	mock := &MockContentStorage{ctrl: ctrl}
+	mock.recorder = &MockContentStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContentStorage) EXPECT() *MockContentStorageMockRecorder {
+	return m.recorder
+}
+
+// DeleteFile mocks base method.
+func (m *MockContentStorage) DeleteFile(ctx context.Context, filepath string) *controller.APIError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFile", ctx, filepath)
+	ret0, _ := ret[0].(*controller.APIError)
+	return ret0
+}
+
+// DeleteFile indicates an expected call of DeleteFile.
+func (mr *MockContentStorageMockRecorder) DeleteFile(ctx, filepath interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockContentStorage)(nil).DeleteFile), ctx, filepath)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/gin-gonic/gin"
+	"github.com/nhost/hasura-storage/cleanup"
 	"github.com/nhost/hasura-storage/controller"
 	"github.com/nhost/hasura-storage/image"
 	"github.com/nhost/hasura-storage/metadata"
@@ -19,6 +20,9 @@ import (
 	"github.com/nhost/hasura-storage/middleware/cdn/fastly"
 	"github.com/nhost/hasura-storage/migrations"
 	"github.com/nhost/hasura-storage/storage"
+	"github.com/nhost/hasura-storage/uploadhook"
+	"github.com/nhost/hasura-storage/versions"
+	"github.com/nhost/hasura-storage/webhook"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -31,7 +35,10 @@ const (
 	trustedProxiesFlag           = "trusted-proxies"
 	hasuraEndpointFlag           = "hasura-endpoint"
 	hasuraMetadataFlag           = "hasura-metadata"
-	hasuraAdminSecretFlag        = "hasura-graphql-admin-secret" //nolint: gosec
+	hasuraAdminSecretFlag        = "hasura-graphql-admin-secret"       //nolint: gosec
+	hasuraAdminSecretsFlag       = "hasura-graphql-admin-secrets"      //nolint: gosec
+	hasuraAdminSecretsFileFlag   = "hasura-graphql-admin-secrets-file" //nolint: gosec
+	hasuraJWTSecretFlag          = "hasura-graphql-jwt-secret"         //nolint: gosec
 	s3EndpointFlag               = "s3-endpoint"
 	s3AccessKeyFlag              = "s3-access-key"
 	s3SecretKeyFlag              = "s3-secret-key" //nolint: gosec
@@ -39,6 +46,7 @@ const (
 	s3BucketFlag                 = "s3-bucket"
 	s3RootFolderFlag             = "s3-root-folder"
 	s3DisableHTTPS               = "s3-disable-http"
+	s3ObjectLockFlag             = "s3-object-lock"
 	postgresMigrationsFlag       = "postgres-migrations"
 	postgresMigrationsSourceFlag = "postgres-migrations-source"
 	fastlyServiceFlag            = "fastly-service"
@@ -47,8 +55,19 @@ const (
 	corsAllowCredentialsFlag     = "cors-allow-credentials" //nolint: gosec
 	clamavServerFlag             = "clamav-server"
 	hasuraDBNameFlag             = "hasura-db-name"
+	webhookURLFlag               = "webhook-url"
+	webhookSecretFlag            = "webhook-secret" //nolint: gosec
+	webhookIntervalFlag          = "webhook-interval"
+	versionsPruneIntervalFlag    = "file-versions-prune-interval"
+	trashPurgeIntervalFlag       = "trash-purge-interval"
+	expiredFilesReapIntervalFlag = "expired-files-reap-interval"
+	uploadHookURLFlag            = "upload-hook-url"
+	uploadHookSecretFlag         = "upload-hook-secret" //nolint: gosec
+	signedURLKeysFlag            = "signed-url-keys"
 )
 
+const adminSecretsReloadInterval = 10 * time.Second
+
 func ginLogger(logger *logrus.Logger) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		startTime := time.Now()
@@ -80,13 +99,32 @@ func ginLogger(logger *logrus.Logger) gin.HandlerFunc {
 	}
 }
 
+func getUploadHook(url, secret string) controller.UploadHook { //nolint:ireturn
+	if url == "" {
+		return nil
+	}
+
+	return uploadhook.New(url, secret)
+}
+
+// getURLSigner returns nil if no keys are configured, in which case presigned URLs
+// are generated by the storage backend.
+func getURLSigner(keys []string) (*controller.URLSigner, error) {
+	if len(keys) == 0 {
+		return nil, nil //nolint: nilnil
+	}
+
+	return controller.NewURLSigner(keys...) //nolint: wrapcheck
+}
+
 func getGin(
 	publicURL string,
 	apiRootPrefix string,
-	hasuraAdminSecret string,
+	hasuraAdminSecrets *auth.AdminSecrets,
 	metadataStorage controller.MetadataStorage,
 	contentStorage controller.ContentStorage,
 	imageTransformer *image.Transformer,
+	notifier controller.EventNotifier,
 	trustedProxies []string,
 	logger *logrus.Logger,
 	debug bool,
@@ -102,14 +140,22 @@ func getGin(
 		return nil, fmt.Errorf("problem trying to get av: %w", err)
 	}
 
+	urlSigner, err := getURLSigner(viper.GetStringSlice(signedURLKeysFlag))
+	if err != nil {
+		return nil, fmt.Errorf("problem parsing signed url keys: %w", err)
+	}
+
 	ctrl := controller.New(
 		publicURL,
 		apiRootPrefix,
-		hasuraAdminSecret,
+		hasuraAdminSecrets,
 		metadataStorage,
 		contentStorage,
 		imageTransformer,
 		av,
+		notifier,
+		getUploadHook(viper.GetString(uploadHookURLFlag), viper.GetString(uploadHookSecretFlag)),
+		urlSigner,
 		logger,
 	)
 
@@ -120,7 +166,17 @@ func getGin(
 
 	middlewares := []gin.HandlerFunc{
 		ginLogger(logger),
-		auth.NeedsAdmin(opsPath, hasuraAdminSecret),
+		auth.AcceptAdminSecrets(hasuraAdminSecrets),
+		auth.NeedsAdmin(opsPath, hasuraAdminSecrets),
+	}
+
+	if jwtSecret := viper.GetString(hasuraJWTSecretFlag); jwtSecret != "" {
+		verifier, err := auth.NewJWTVerifier(jwtSecret)
+		if err != nil {
+			return nil, fmt.Errorf("problem parsing jwt secret: %w", err)
+		}
+		logger.Info("enabling jwt verification")
+		middlewares = append(middlewares, auth.VerifyJWT(verifier, hasuraAdminSecrets))
 	}
 
 	fastlyService := viper.GetString(fastlyServiceFlag)
@@ -137,6 +193,27 @@ func getGin(
 	)
 }
 
+// getAdminSecrets returns the primary admin secret followed by the additional ones. If a
+// file is configured it takes precedence and it is watched for changes.
+func getAdminSecrets(ctx context.Context, logger *logrus.Logger) *auth.AdminSecrets {
+	secrets := auth.NewAdminSecrets(
+		append(
+			[]string{viper.GetString(hasuraAdminSecretFlag)},
+			viper.GetStringSlice(hasuraAdminSecretsFlag)...,
+		)...,
+	)
+
+	if path := viper.GetString(hasuraAdminSecretsFileFlag); path != "" {
+		if err := secrets.LoadFile(path); err != nil {
+			logger.Errorf("problem loading admin secrets: %s", err.Error())
+			os.Exit(1)
+		}
+		go secrets.WatchFile(ctx, path, adminSecretsReloadInterval, logger)
+	}
+
+	return secrets
+}
+
 func getMetadataStorage(endpoint string) *metadata.Hasura {
 	return metadata.NewHasura(endpoint)
 }
@@ -145,6 +222,7 @@ func getContentStorage(
 	ctx context.Context,
 	s3Endpoint, region, s3AccessKey, s3SecretKey, bucket, rootFolder string,
 	disableHTTPS bool,
+	objectLock bool,
 	logger *logrus.Logger,
 ) *storage.S3 {
 	var cfg aws.Config
@@ -173,7 +251,7 @@ func getContentStorage(
 			o.EndpointOptions.DisableHTTPS = disableHTTPS
 		},
 	)
-	st := storage.NewS3(client, bucket, rootFolder, s3Endpoint, logger)
+	st := storage.NewS3(client, bucket, rootFolder, s3Endpoint, objectLock, logger)
 
 	return st
 }
@@ -249,6 +327,12 @@ func init() { //nolint:funlen
 			"",
 			"All buckets will be created inside this root",
 		)
+		addBoolFlag(
+			serveCmd.Flags(),
+			s3ObjectLockFlag,
+			false,
+			"Lock objects with S3 Object Lock, the bucket needs to have it enabled",
+		)
 	}
 
 	{
@@ -265,6 +349,24 @@ func init() { //nolint:funlen
 	{
 		addBoolFlag(serveCmd.Flags(), hasuraMetadataFlag, false, "Apply Hasura's metadata")
 		addStringFlag(serveCmd.Flags(), hasuraAdminSecretFlag, "", "")
+		addStringArrayFlag(
+			serveCmd.Flags(),
+			hasuraAdminSecretsFlag,
+			[]string{},
+			"Additional admin secrets accepted in incoming requests. Can be passed many times",
+		)
+		addStringFlag(
+			serveCmd.Flags(),
+			hasuraAdminSecretsFileFlag,
+			"",
+			"File with the admin secrets, one per line and the primary first. Reloaded on changes",
+		)
+		addStringFlag(
+			serveCmd.Flags(),
+			hasuraJWTSecretFlag,
+			"",
+			"If set, access tokens are verified using this configuration. Same format as hasura's",
+		)
 	}
 
 	{
@@ -292,6 +394,79 @@ func init() { //nolint:funlen
 			"If set, use ClamAV to scan files. Example: tcp://clamavd:3310",
 		)
 	}
+
+	{
+		addStringFlag(
+			serveCmd.Flags(),
+			webhookURLFlag,
+			"",
+			"If set, file events of all buckets are sent to this URL",
+		)
+		addStringFlag(
+			serveCmd.Flags(),
+			webhookSecretFlag,
+			"",
+			"Secret used to sign the events sent to the global webhook",
+		)
+		addStringFlag(
+			serveCmd.Flags(),
+			webhookIntervalFlag,
+			"5s",
+			"How often pending webhook events are delivered",
+		)
+	}
+
+	{
+		addStringFlag(
+			serveCmd.Flags(),
+			versionsPruneIntervalFlag,
+			"1h",
+			"How often file versions outside of the retention policy of their bucket are deleted",
+		)
+	}
+
+	{
+		addStringFlag(
+			serveCmd.Flags(),
+			trashPurgeIntervalFlag,
+			"1h",
+			"How often files past the trash retention period of their bucket are deleted",
+		)
+	}
+
+	{
+		addStringFlag(
+			serveCmd.Flags(),
+			expiredFilesReapIntervalFlag,
+			"1h",
+			"How often files past their expiry date are deleted",
+		)
+	}
+
+	{
+		addStringFlag(
+			serveCmd.Flags(),
+			uploadHookURLFlag,
+			"",
+			"If set, uploads are sent to this URL for approval before they are initialized",
+		)
+		addStringFlag(
+			serveCmd.Flags(),
+			uploadHookSecretFlag,
+			"",
+			"Secret used to sign the requests sent to the pre-upload hook",
+		)
+	}
+
+	{
+		addStringArrayFlag(
+			serveCmd.Flags(),
+			signedURLKeysFlag,
+			[]string{},
+			"If set, presigned URLs are signed by the service instead of the storage backend. "+
+				"Format is <id>:<secret>, the first key signs new URLs. Can be passed many times",
+		)
+	}
 }
 
 var serveCmd = &cobra.Command{
@@ -327,6 +502,8 @@ var serveCmd = &cobra.Command{
 				s3RootFolderFlag:       viper.GetString(s3RootFolderFlag),
 				clamavServerFlag:       viper.GetString(clamavServerFlag),
 				hasuraDBNameFlag:       viper.GetString(hasuraDBNameFlag),
+				webhookURLFlag:         viper.GetString(webhookURLFlag),
+				uploadHookURLFlag:      viper.GetString(uploadHookURLFlag),
 			},
 		).Debug("parameters")
 
@@ -339,15 +516,18 @@ var serveCmd = &cobra.Command{
 			viper.GetString(s3BucketFlag),
 			viper.GetString(s3RootFolderFlag),
 			viper.GetBool(s3DisableHTTPS),
+			viper.GetBool(s3ObjectLockFlag),
 			logger,
 		)
 
+		adminSecrets := getAdminSecrets(cmd.Context(), logger)
+
 		applymigrations(
 			viper.GetBool(postgresMigrationsFlag),
 			viper.GetString(postgresMigrationsSourceFlag),
 			viper.GetBool(hasuraMetadataFlag),
 			viper.GetString(hasuraEndpointFlag),
-			viper.GetString(hasuraAdminSecretFlag),
+			adminSecrets.Primary(),
 			viper.GetString(hasuraDBNameFlag),
 			logger,
 		)
@@ -355,13 +535,59 @@ var serveCmd = &cobra.Command{
 		metadataStorage := getMetadataStorage(
 			viper.GetString(hasuraEndpointFlag) + "/graphql",
 		)
+
+		webhookInterval, err := time.ParseDuration(viper.GetString(webhookIntervalFlag))
+		cobra.CheckErr(err)
+
+		dispatcher := webhook.New(
+			metadataStorage,
+			viper.GetString(webhookURLFlag),
+			viper.GetString(webhookSecretFlag),
+			adminSecrets,
+			logger,
+		)
+		go dispatcher.Run(cmd.Context(), webhookInterval)
+
+		pruneInterval, err := time.ParseDuration(viper.GetString(versionsPruneIntervalFlag))
+		cobra.CheckErr(err)
+
+		pruner := versions.New(metadataStorage, contentStorage, adminSecrets, logger)
+		go pruner.Run(cmd.Context(), pruneInterval)
+
+		purgeInterval, err := time.ParseDuration(viper.GetString(trashPurgeIntervalFlag))
+		cobra.CheckErr(err)
+
+		purger := cleanup.New(
+			"expired files in the trash",
+			metadataStorage.ListExpiredTrash,
+			metadataStorage,
+			contentStorage,
+			adminSecrets,
+			logger,
+		)
+		go purger.Run(cmd.Context(), purgeInterval)
+
+		reapInterval, err := time.ParseDuration(viper.GetString(expiredFilesReapIntervalFlag))
+		cobra.CheckErr(err)
+
+		reaper := cleanup.New(
+			"expired files",
+			metadataStorage.ListExpiredFiles,
+			metadataStorage,
+			contentStorage,
+			adminSecrets,
+			logger,
+		)
+		go reaper.Run(cmd.Context(), reapInterval)
+
 		router, err := getGin(
 			viper.GetString(publicURLFlag),
 			viper.GetString(apiRootPrefixFlag),
-			viper.GetString(hasuraAdminSecretFlag),
+			adminSecrets,
 			metadataStorage,
 			contentStorage,
 			imageTransformer,
+			dispatcher,
 			viper.GetStringSlice(trustedProxiesFlag),
 			logger,
 			viper.GetBool(debugFlag),
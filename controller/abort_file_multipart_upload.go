@@ -49,7 +49,9 @@ func (ctrl *Controller) abortFileMultipartUploadProcess(ctx *gin.Context) *APIEr
 	delErr := ctrl.metadataStorage.DeleteFileByID(
 		ctx,
 		fileMetadata.ID,
-		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret}},
+		FileCondition{},
+		nil,
+		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}},
 	)
 	if delErr != nil {
 		return delErr.ExtendError(
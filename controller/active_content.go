@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/nhost/hasura-storage/svg"
+)
+
+const activeContentSecurityPolicy = "default-src 'none'; style-src 'unsafe-inline'; sandbox"
+
+// isActiveContent returns true for types browsers can execute scripts from if they are
+// rendered from our domain.
+func isActiveContent(contentType string) bool {
+	mt := mediaType(contentType)
+	switch mt {
+	case "text/html", "application/xhtml+xml", "image/svg+xml", "text/xml", "application/xml":
+		return true
+	}
+	return strings.HasSuffix(mt, "+xml")
+}
+
+// isScript returns true for types browsers run when they are loaded as scripts.
+func isScript(contentType string) bool {
+	switch mediaType(contentType) {
+	case "text/javascript", "application/javascript", "application/x-javascript",
+		"text/ecmascript", "application/ecmascript":
+		return true
+	}
+	return false
+}
+
+// declaredTypeMatches checks the content type sent by the client is consistent with the
+// content. Types the detected one descends from are accepted, for instance text/plain
+// for a CSV file, and anything but active content and scripts is accepted if the
+// content couldn't be identified beyond plain text or binary data.
+func declaredTypeMatches(declared string, detected *mimetype.MIME) bool {
+	declared = mediaType(declared)
+	for mt := detected; mt != nil; mt = mt.Parent() {
+		if mt.Is(declared) {
+			return true
+		}
+	}
+
+	if isActiveContent(declared) || isScript(declared) {
+		return false
+	}
+
+	return detected.Is("text/plain") || detected.Is("application/octet-stream")
+}
+
+type memoryFile struct {
+	*bytes.Reader
+}
+
+func (memoryFile) Close() error {
+	return nil
+}
+
+// sanitizeFile strips scripts from SVG files so they can't run if the file is opened
+// directly. The returned fileData reflects the size of the sanitized content.
+func sanitizeFile(
+	content multipart.File, file fileData, detectedType string,
+) (multipart.File, fileData, *APIError) {
+	if mediaType(detectedType) != "image/svg+xml" {
+		return content, file, nil
+	}
+
+	b, err := svg.Sanitize(content)
+	if err != nil {
+		return nil, fileData{}, BadDataError(
+			fmt.Errorf("problem sanitizing svg %s: %w", file.Name, err), "couldn't parse svg file",
+		)
+	}
+
+	header := *file.header
+	header.Size = int64(len(b))
+	file.header = &header
+
+	return memoryFile{bytes.NewReader(b)}, file, nil
+}
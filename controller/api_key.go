@@ -0,0 +1,235 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	APIKeyOperationRead   = "read"
+	APIKeyOperationWrite  = "write"
+	APIKeyOperationDelete = "delete"
+	APIKeyOperationOps    = "ops"
+
+	apiKeyHeader        = "X-Nhost-Api-Key"
+	apiKeyHeaderPrefix  = "X-Hasura-Api-Key-"
+	apiKeyIDHeader      = apiKeyHeaderPrefix + "Id"
+	apiKeyBucketsHeader = apiKeyHeaderPrefix + "Buckets"
+
+	// last_used_at is only updated if it is older than this so busy keys don't cause a
+	// write on every request
+	apiKeyLastUsedInterval = time.Minute
+)
+
+// apiKeyRouteOperations maps the routes, relative to the api root, to the operation a
+// key needs to use them. The method isn't enough as archives are downloaded with a POST
+// and multipart upload urls are issued with a GET.
+var apiKeyRouteOperations = map[string]string{ //nolint: gochecknoglobals
+	"GET /openapi.yaml":                             APIKeyOperationRead,
+	"GET /version":                                  APIKeyOperationRead,
+	"GET /quota":                                    APIKeyOperationRead,
+	"GET /trash":                                    APIKeyOperationRead,
+	"GET /shares/:token":                            APIKeyOperationRead,
+	"POST /files":                                   APIKeyOperationWrite,
+	"POST /files/":                                  APIKeyOperationWrite,
+	"GET /files/:id":                                APIKeyOperationRead,
+	"HEAD /files/:id":                               APIKeyOperationRead,
+	"PUT /files/:id":                                APIKeyOperationWrite,
+	"PATCH /files/:id":                              APIKeyOperationWrite,
+	"DELETE /files/:id":                             APIKeyOperationDelete,
+	"POST /files/:id/restore":                       APIKeyOperationWrite,
+	"GET /files/:id/presignedurl":                   APIKeyOperationRead,
+	"GET /files/:id/presignedurl/content":           APIKeyOperationRead,
+	"GET /files/:id/download/:name":                 APIKeyOperationRead,
+	"GET /files/:id/archive/entries":                APIKeyOperationRead,
+	"GET /files/:id/archive/entries/*path":          APIKeyOperationRead,
+	"POST /files/:id/copy":                          APIKeyOperationWrite,
+	"POST /files/:id/move":                          APIKeyOperationWrite,
+	"GET /files/:id/versions":                       APIKeyOperationRead,
+	"POST /files/:id/versions/:versionId/restore":   APIKeyOperationWrite,
+	"POST /files/:id/shares":                        APIKeyOperationWrite,
+	"DELETE /files/:id/shares/:shareId":             APIKeyOperationDelete,
+	"GET /files/:id/multipart":                      APIKeyOperationRead,
+	"GET /files/:id/multipart/presignedurl":         APIKeyOperationWrite,
+	"PUT /files/:id/multipart/presignedurl/content": APIKeyOperationWrite,
+	"POST /files/archive":                           APIKeyOperationRead,
+	"POST /files/multipart":                         APIKeyOperationWrite,
+	"POST /files/:id/multipart/complete":            APIKeyOperationWrite,
+	"POST /files/:id/multipart/abort":               APIKeyOperationWrite,
+}
+
+// HashAPIKey returns the value stored in the key_hash column for a given key.
+func HashAPIKey(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(h[:])
+}
+
+func (key APIKey) allows(operation string) bool {
+	for _, op := range key.Operations {
+		if op == operation {
+			return true
+		}
+	}
+	return false
+}
+
+func (key APIKey) expired(now time.Time) bool {
+	if key.ExpiresAt == "" {
+		return false
+	}
+	expiresAt, err := time.Parse(time.RFC3339, key.ExpiresAt)
+	if err != nil {
+		// better safe than sorry
+		return true
+	}
+	return now.After(expiresAt)
+}
+
+func (key APIKey) usedRecently(now time.Time) bool {
+	if key.LastUsedAt == "" {
+		return false
+	}
+	lastUsedAt, err := time.Parse(time.RFC3339, key.LastUsedAt)
+	if err != nil {
+		return false
+	}
+	return now.Sub(lastUsedAt) < apiKeyLastUsedInterval
+}
+
+func (ctrl *Controller) apiKeyOperation(ctx *gin.Context) string {
+	route := strings.TrimPrefix(ctx.FullPath(), ctrl.apiRootPrefix)
+	if strings.HasPrefix(route, "/ops/") {
+		return APIKeyOperationOps
+	}
+
+	if operation, ok := apiKeyRouteOperations[ctx.Request.Method+" "+route]; ok {
+		return operation
+	}
+
+	// routes that aren't listed, like the health check or unknown paths, only need to
+	// be readable
+	if ctx.Request.Method == http.MethodGet || ctx.Request.Method == http.MethodHead {
+		return APIKeyOperationRead
+	}
+	return APIKeyOperationWrite
+}
+
+func (ctrl *Controller) authenticateAPIKey(ctx *gin.Context, secret string) (APIKey, *APIError) {
+	adminHeaders := http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}}
+
+	key, apiErr := ctrl.metadataStorage.GetAPIKeyByHash(
+		ctx.Request.Context(), HashAPIKey(secret), adminHeaders,
+	)
+	if apiErr != nil {
+		return APIKey{}, apiErr
+	}
+
+	now := time.Now()
+	if key.expired(now) {
+		return APIKey{}, NewAPIError(
+			http.StatusUnauthorized,
+			"api key expired",
+			fmt.Errorf("api key %s expired", key.ID), //nolint: goerr113
+			nil,
+		)
+	}
+
+	operation := ctrl.apiKeyOperation(ctx)
+	if !key.allows(operation) {
+		return APIKey{}, ForbiddenError(
+			fmt.Errorf("api key %s can't perform %s operations", key.ID, operation), //nolint: goerr113
+			"operation not allowed for this api key",
+		)
+	}
+
+	if !key.usedRecently(now) {
+		if apiErr := ctrl.metadataStorage.SetAPIKeyLastUsed(
+			ctx.Request.Context(), key.ID, adminHeaders,
+		); apiErr != nil {
+			return APIKey{}, apiErr
+		}
+	}
+
+	return key, nil
+}
+
+// setAPIKeySession replaces the session of the request with the one of the api key so
+// the rest of the service, and hasura, see the request as coming from its role.
+func (ctrl *Controller) setAPIKeySession(ctx *gin.Context, key APIKey) {
+	header := ctx.Request.Header
+	for k := range header {
+		if strings.HasPrefix(http.CanonicalHeaderKey(k), "X-Hasura-") {
+			header.Del(k)
+		}
+	}
+	header.Del(apiKeyHeader)
+	header.Del("Authorization")
+
+	header.Set("X-Hasura-Admin-Secret", ctrl.hasuraAdminSecret.Primary())
+	// ops endpoints are only available to admins
+	if ctrl.apiKeyOperation(ctx) != APIKeyOperationOps {
+		header.Set("X-Hasura-Role", key.Role)
+	}
+	header.Set(apiKeyIDHeader, key.ID)
+	if len(key.Buckets) > 0 {
+		header.Set(apiKeyBucketsHeader, "{"+strings.Join(key.Buckets, ",")+"}")
+	}
+}
+
+// APIKeyAuth authenticates requests with the X-Nhost-Api-Key header. The key has to
+// allow the operation being performed and its role is used for the rest of the
+// request. Requests without the header only lose the api key headers so they can't
+// pretend to come from a key.
+func (ctrl *Controller) APIKeyAuth(ctx *gin.Context) {
+	secret := ctx.Request.Header.Get(apiKeyHeader)
+	if secret == "" {
+		for k := range ctx.Request.Header {
+			if strings.HasPrefix(http.CanonicalHeaderKey(k), apiKeyHeaderPrefix) {
+				ctx.Request.Header.Del(k)
+			}
+		}
+
+		ctx.Next()
+		return
+	}
+
+	key, apiErr := ctrl.authenticateAPIKey(ctx, secret)
+	if apiErr != nil {
+		_ = ctx.Error(fmt.Errorf("problem authenticating api key: %w", apiErr))
+
+		ctx.AbortWithStatusJSON(apiErr.statusCode, CommonResponse{
+			Code:    apiErr.statusCode,
+			Message: apiErr.PublicResponse().Message,
+		})
+
+		return
+	}
+
+	ctrl.setAPIKeySession(ctx, key)
+	ctx.Next()
+}
+
+// checkAPIKeyBucket rejects requests authenticated with an api key that isn't allowed
+// to access the bucket.
+func checkAPIKeyBucket(headers http.Header, bucketID string) *APIError {
+	buckets := headers.Get(apiKeyBucketsHeader)
+	if buckets == "" {
+		return nil
+	}
+
+	for _, b := range strings.Split(strings.Trim(buckets, "{}"), ",") {
+		if b == bucketID {
+			return nil
+		}
+	}
+
+	msg := "bucket not allowed for this api key"
+	return ForbiddenError(errors.New(msg), msg) //nolint: goerr113
+}
@@ -0,0 +1,274 @@
+package controller_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestAPIKeyAuth(t *testing.T) {
+	t.Parallel()
+
+	const fileID = "55af1e60-0f28-454e-885e-ea6aab2bb288"
+
+	validKey := controller.APIKey{
+		ID:         "0f3b1e3c-7d0f-4f3e-9a5e-2f5b0e3f1a11",
+		Name:       "backups",
+		Role:       "service",
+		Buckets:    []string{"backups"},
+		Operations: []string{"read", "delete"},
+		ExpiresAt:  "",
+		LastUsedAt: "",
+	}
+
+	expiredKey := validKey
+	expiredKey.ExpiresAt = time.Now().Add(-time.Hour).Format(time.RFC3339)
+
+	recentlyUsedKey := validKey
+	recentlyUsedKey.LastUsedAt = time.Now().Add(-time.Second).Format(time.RFC3339Nano)
+
+	cases := []struct {
+		name           string
+		method         string
+		path           string
+		key            string
+		headers        http.Header
+		body           string
+		setup          func(*mock.MockMetadataStorage, *mock.MockContentStorage)
+		expectedStatus int
+	}{
+		{
+			name:           "no api key",
+			method:         http.MethodGet,
+			path:           "/v1/version",
+			key:            "",
+			setup:          func(*mock.MockMetadataStorage, *mock.MockContentStorage) {},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "unknown api key",
+			method: http.MethodGet,
+			path:   "/v1/version",
+			key:    "nope",
+			setup: func(ms *mock.MockMetadataStorage, _ *mock.MockContentStorage) {
+				ms.EXPECT().GetAPIKeyByHash(
+					gomock.Any(), controller.HashAPIKey("nope"), gomock.Any(),
+				).Return(controller.APIKey{}, controller.ErrAPIKeyNotFound) //nolint: exhaustruct
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "expired api key",
+			method: http.MethodGet,
+			path:   "/v1/version",
+			key:    "secret",
+			setup: func(ms *mock.MockMetadataStorage, _ *mock.MockContentStorage) {
+				ms.EXPECT().GetAPIKeyByHash(
+					gomock.Any(), controller.HashAPIKey("secret"), gomock.Any(),
+				).Return(expiredKey, nil)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "valid api key",
+			method: http.MethodGet,
+			path:   "/v1/version",
+			key:    "secret",
+			setup: func(ms *mock.MockMetadataStorage, _ *mock.MockContentStorage) {
+				ms.EXPECT().GetAPIKeyByHash(
+					gomock.Any(), controller.HashAPIKey("secret"), gomock.Any(),
+				).Return(validKey, nil)
+				ms.EXPECT().SetAPIKeyLastUsed(gomock.Any(), validKey.ID, gomock.Any()).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "recently used api key",
+			method: http.MethodGet,
+			path:   "/v1/version",
+			key:    "secret",
+			setup: func(ms *mock.MockMetadataStorage, _ *mock.MockContentStorage) {
+				ms.EXPECT().GetAPIKeyByHash(
+					gomock.Any(), controller.HashAPIKey("secret"), gomock.Any(),
+				).Return(recentlyUsedKey, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "operation not allowed",
+			method: http.MethodPost,
+			path:   "/v1/files/multipart",
+			key:    "secret",
+			setup: func(ms *mock.MockMetadataStorage, _ *mock.MockContentStorage) {
+				ms.EXPECT().GetAPIKeyByHash(
+					gomock.Any(), controller.HashAPIKey("secret"), gomock.Any(),
+				).Return(validKey, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "bucket not allowed",
+			method: http.MethodDelete,
+			path:   "/v1/files/" + fileID,
+			key:    "secret",
+			setup: func(ms *mock.MockMetadataStorage, _ *mock.MockContentStorage) {
+				ms.EXPECT().GetAPIKeyByHash(
+					gomock.Any(), controller.HashAPIKey("secret"), gomock.Any(),
+				).Return(validKey, nil)
+				ms.EXPECT().SetAPIKeyLastUsed(gomock.Any(), validKey.ID, gomock.Any()).Return(nil)
+				ms.EXPECT().GetFileByID(gomock.Any(), fileID, gomock.Any()).Return(
+					controller.FileMetadata{ID: fileID, BucketID: "default"}, nil, //nolint: exhaustruct
+				)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "delete in allowed bucket",
+			method: http.MethodDelete,
+			path:   "/v1/files/" + fileID,
+			key:    "secret",
+			setup: func(ms *mock.MockMetadataStorage, cs *mock.MockContentStorage) {
+				ms.EXPECT().GetAPIKeyByHash(
+					gomock.Any(), controller.HashAPIKey("secret"), gomock.Any(),
+				).Return(validKey, nil)
+				ms.EXPECT().SetAPIKeyLastUsed(gomock.Any(), validKey.ID, gomock.Any()).Return(nil)
+				ms.EXPECT().GetFileByID(gomock.Any(), fileID, gomock.Any()).Return(
+					controller.FileMetadata{ID: fileID, BucketID: "backups"}, nil, //nolint: exhaustruct
+				)
+				ms.EXPECT().GetBucketByID(gomock.Any(), "backups", gomock.Any()).Return(
+					controller.BucketMetadata{ID: "backups"}, nil, //nolint: exhaustruct
+				)
+				ms.EXPECT().ListFileVersions(gomock.Any(), fileID, gomock.Any()).Return(nil, nil)
+				ms.EXPECT().DeleteFileByID(
+					gomock.Any(),
+					fileID,
+					controller.FileCondition{},
+					gomock.Any(),
+					http.Header{
+						"X-Hasura-Admin-Secret":    []string{"asdasd"},
+						"X-Hasura-Role":            []string{"service"},
+						"X-Hasura-Api-Key-Id":      []string{validKey.ID},
+						"X-Hasura-Api-Key-Buckets": []string{"{backups}"},
+					},
+				).Return(nil)
+				cs.EXPECT().DeleteFile(gomock.Any(), fileID).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:   "archive download is a read",
+			method: http.MethodPost,
+			path:   "/v1/files/archive",
+			key:    "secret",
+			body:   "{}",
+			setup: func(ms *mock.MockMetadataStorage, _ *mock.MockContentStorage) {
+				ms.EXPECT().GetAPIKeyByHash(
+					gomock.Any(), controller.HashAPIKey("secret"), gomock.Any(),
+				).Return(validKey, nil)
+				ms.EXPECT().SetAPIKeyLastUsed(gomock.Any(), validKey.ID, gomock.Any()).Return(nil)
+			},
+			// the key is accepted, the request fails later on as it selects no files
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "multipart upload url is a write",
+			method: http.MethodGet,
+			path:   "/v1/files/" + fileID + "/multipart/presignedurl",
+			key:    "secret",
+			setup: func(ms *mock.MockMetadataStorage, _ *mock.MockContentStorage) {
+				ms.EXPECT().GetAPIKeyByHash(
+					gomock.Any(), controller.HashAPIKey("secret"), gomock.Any(),
+				).Return(validKey, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "forged api key headers",
+			method: http.MethodDelete,
+			path:   "/v1/files/" + fileID,
+			key:    "",
+			headers: http.Header{
+				"X-Hasura-Api-Key-Id":      []string{validKey.ID},
+				"X-Hasura-Api-Key-Buckets": []string{"{default}"},
+			},
+			setup: func(ms *mock.MockMetadataStorage, cs *mock.MockContentStorage) {
+				ms.EXPECT().GetFileByID(gomock.Any(), fileID, gomock.Any()).Return(
+					controller.FileMetadata{ID: fileID, BucketID: "default"}, nil, //nolint: exhaustruct
+				)
+				ms.EXPECT().GetBucketByID(gomock.Any(), "default", gomock.Any()).Return(
+					controller.BucketMetadata{ID: "default"}, nil, //nolint: exhaustruct
+				)
+				ms.EXPECT().ListFileVersions(gomock.Any(), fileID, gomock.Any()).Return(nil, nil)
+				ms.EXPECT().DeleteFileByID(
+					gomock.Any(),
+					fileID,
+					controller.FileCondition{},
+					gomock.Any(),
+					http.Header{
+						"X-Hasura-Role": []string{"admin"},
+					},
+				).Return(nil)
+				cs.EXPECT().DeleteFile(gomock.Any(), fileID).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+			tc.setup(metadataStorage, contentStorage)
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(), tc.method, tc.path, strings.NewReader(tc.body),
+			)
+			for k, v := range tc.headers {
+				req.Header[k] = v
+			}
+			req.Header.Set("X-Hasura-Role", "admin")
+			if tc.key != "" {
+				req.Header.Set("X-Nhost-Api-Key", tc.key)
+			}
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package controller
+
+import (
+	"archive/zip"
+	"compress/flate"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// size of the reads done to the storage backend when reading the central directory of
+// an archive, it is read sequentially so a single block is kept in memory.
+const archiveReadBlockSize = 64 * 1024
+
+type ArchiveEntry struct {
+	Name           string `json:"name"`
+	Size           int64  `json:"size"`
+	CompressedSize int64  `json:"compressedSize"`
+	ModifiedAt     string `json:"modifiedAt"`
+	IsDirectory    bool   `json:"isDirectory"`
+}
+
+type ListArchiveEntriesResponse struct {
+	Entries []ArchiveEntry `json:"entries"`
+}
+
+// storageReaderAt reads an object from the storage backend using range requests.
+type storageReaderAt struct {
+	readRange   func(byteRange) (io.ReadCloser, *APIError)
+	size        int64
+	block       []byte
+	blockOffset int64
+}
+
+func (ctrl *Controller) readRange(
+	ctx context.Context, objectKey string, r byteRange,
+) (io.ReadCloser, *APIError) {
+	download, apiErr := ctrl.contentStorage.GetFile(
+		ctx, objectKey, http.Header{"Range": []string{r.header()}},
+	)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	if apiErr := limitToRange(download, r); apiErr != nil {
+		return nil, apiErr
+	}
+
+	return download.Body, nil
+}
+
+func (s *storageReaderAt) fetch(off, length int64) error {
+	body, apiErr := s.readRange(byteRange{off, length})
+	if apiErr != nil {
+		return apiErr
+	}
+	defer body.Close()
+
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("problem reading range: %w", err)
+	}
+
+	s.block = b
+	s.blockOffset = off
+	return nil
+}
+
+func (s *storageReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off >= s.size {
+		return 0, io.EOF
+	}
+
+	end := min(off+int64(len(p)), s.size)
+	if off < s.blockOffset || end > s.blockOffset+int64(len(s.block)) {
+		length := min(max(int64(len(p)), archiveReadBlockSize), s.size-off)
+		if err := s.fetch(off, length); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, s.block[off-s.blockOffset:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// openArchive reads the central directory of the zip archive stored in the file.
+func (ctrl *Controller) openArchive(
+	ctx *gin.Context,
+) (FileMetadata, BucketMetadata, *zip.Reader, *APIError) {
+	fileMetadata, bucketMetadata, apiErr := ctrl.getFileMetadata(
+		ctx.Request.Context(), ctx.Param("id"), true, ctx.Request.Header,
+	)
+	if apiErr != nil {
+		return FileMetadata{}, BucketMetadata{}, nil, apiErr
+	}
+
+	objectKey := fileMetadata.ID
+	if len(fileMetadata.ObjectKey) > 0 {
+		objectKey = fileMetadata.ObjectKey
+	}
+
+	r, err := zip.NewReader(&storageReaderAt{ //nolint: exhaustruct
+		readRange: func(r byteRange) (io.ReadCloser, *APIError) {
+			return ctrl.readRange(ctx.Request.Context(), objectKey, r)
+		},
+		size: fileMetadata.Size,
+	}, fileMetadata.Size)
+	if err != nil {
+		return FileMetadata{}, BucketMetadata{}, nil, BadDataError(
+			fmt.Errorf("problem reading archive %s: %w", fileMetadata.ID, err),
+			"file is not a zip archive",
+		)
+	}
+
+	return fileMetadata, bucketMetadata, r, nil
+}
+
+func (ctrl *Controller) listArchiveEntries(ctx *gin.Context) (ListArchiveEntriesResponse, *APIError) {
+	_, _, r, apiErr := ctrl.openArchive(ctx)
+	if apiErr != nil {
+		return ListArchiveEntriesResponse{}, apiErr
+	}
+
+	entries := make([]ArchiveEntry, len(r.File))
+	for i, f := range r.File {
+		entries[i] = ArchiveEntry{
+			Name:           f.Name,
+			Size:           int64(f.UncompressedSize64),
+			CompressedSize: int64(f.CompressedSize64),
+			ModifiedAt:     f.Modified.UTC().Format(time.RFC3339),
+			IsDirectory:    f.FileInfo().IsDir(),
+		}
+	}
+
+	return ListArchiveEntriesResponse{entries}, nil
+}
+
+func (ctrl *Controller) ListArchiveEntries(ctx *gin.Context) {
+	response, apiErr := ctrl.listArchiveEntries(ctx)
+	if apiErr != nil {
+		_ = ctx.Error(fmt.Errorf("problem processing request: %w", apiErr))
+
+		ctx.JSON(apiErr.statusCode, CommonResponse{
+			Code:    apiErr.statusCode,
+			Message: apiErr.PublicResponse().Message,
+		})
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, CommonResponse{http.StatusOK, "ok", response})
+}
+
+// openArchiveEntry returns the decompressed content of the entry, only the compressed
+// data of the entry is requested to the storage backend.
+func (ctrl *Controller) openArchiveEntry(
+	ctx context.Context, objectKey string, f *zip.File,
+) (io.ReadCloser, *APIError) {
+	if f.CompressedSize64 == 0 {
+		return http.NoBody, nil
+	}
+
+	offset, err := f.DataOffset()
+	if err != nil {
+		return nil, InternalServerError(fmt.Errorf("problem reading entry %s: %w", f.Name, err))
+	}
+
+	body, apiErr := ctrl.readRange(ctx, objectKey, byteRange{offset, int64(f.CompressedSize64)})
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	switch f.Method {
+	case zip.Store:
+		return body, nil
+	case zip.Deflate:
+		// the declared size is enforced so the entry can't be used as a zip bomb
+		return readCloser{
+			io.LimitReader(flate.NewReader(body), int64(f.UncompressedSize64)), body,
+		}, nil
+	default:
+		body.Close()
+		return nil, BadDataError(
+			fmt.Errorf("unsupported compression method %d", f.Method), //nolint: goerr113
+			"unsupported compression method",
+		)
+	}
+}
+
+func (ctrl *Controller) getArchiveEntry(ctx *gin.Context) (*FileResponse, *APIError) {
+	fileMetadata, bucketMetadata, r, apiErr := ctrl.openArchive(ctx)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	name := strings.TrimPrefix(ctx.Param("path"), "/")
+
+	var entry *zip.File
+	for _, f := range r.File {
+		if f.Name == name && !f.FileInfo().IsDir() {
+			entry = f
+			break
+		}
+	}
+	if entry == nil {
+		return nil, ErrArchiveEntryNotFound
+	}
+
+	objectKey := fileMetadata.ID
+	if len(fileMetadata.ObjectKey) > 0 {
+		objectKey = fileMetadata.ObjectKey
+	}
+
+	body, apiErr := ctrl.openArchiveEntry(ctx.Request.Context(), objectKey, entry)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(name))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return NewFileResponse(
+		fileMetadata.ID,
+		contentType,
+		int64(entry.UncompressedSize64),
+		fmt.Sprintf(`"%s-%08x"`, fileMetadata.ID, entry.CRC32),
+		bucketMetadata.CacheControl,
+		entry.Modified.UTC().Format(time.RFC1123),
+		http.StatusOK,
+		body,
+		path.Base(name),
+		make(http.Header),
+	), nil
+}
+
+func (ctrl *Controller) GetArchiveEntry(ctx *gin.Context) {
+	response, apiErr := ctrl.getArchiveEntry(ctx)
+	if apiErr != nil {
+		_ = ctx.Error(fmt.Errorf("problem processing request: %w", apiErr))
+
+		ctx.JSON(apiErr.statusCode, CommonResponse{
+			Code:    apiErr.statusCode,
+			Message: apiErr.PublicResponse().Message,
+		})
+
+		return
+	}
+
+	defer response.body.Close()
+
+	response.Write(ctx)
+}
@@ -0,0 +1,232 @@
+package controller_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func createArchive(t *testing.T) []byte {
+	t.Helper()
+
+	modified := time.Date(2021, 12, 27, 9, 58, 11, 0, time.UTC)
+
+	b := &bytes.Buffer{}
+	w := zip.NewWriter(b)
+	for _, h := range []*zip.FileHeader{
+		{Name: "docs/", Method: zip.Store, Modified: modified},
+		{Name: "docs/readme.txt", Method: zip.Deflate, Modified: modified},
+		{Name: "data.csv", Method: zip.Store, Modified: modified},
+	} {
+		f, err := w.CreateHeader(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch h.Name {
+		case "docs/readme.txt":
+			_, err = io.WriteString(f, strings.Repeat("Hello, world!\n", 100))
+		case "data.csv":
+			_, err = io.WriteString(f, "a,b\n1,2\n")
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return b.Bytes()
+}
+
+// rangeGetFile serves the ranges requested to the storage backend from content.
+func rangeGetFile(content []byte) func(
+	context.Context, string, http.Header,
+) (*controller.File, *controller.APIError) {
+	return func(
+		_ context.Context, _ string, headers http.Header,
+	) (*controller.File, *controller.APIError) {
+		var start, end int64
+		if _, err := fmt.Sscanf(headers.Get("Range"), "bytes=%d-%d", &start, &end); err != nil {
+			panic(err)
+		}
+
+		return &controller.File{
+			StatusCode:    http.StatusPartialContent,
+			Body:          io.NopCloser(bytes.NewReader(content[start : end+1])),
+			ContentLength: end - start + 1,
+			ExtraHeaders:  make(http.Header),
+		}, nil
+	}
+}
+
+func TestArchiveEntries(t *testing.T) {
+	t.Parallel()
+
+	const fileID = "55af1e60-0f28-454e-885e-ea6aab2bb288"
+
+	archive := createArchive(t)
+
+	cases := []struct {
+		name             string
+		path             string
+		content          []byte
+		expectedStatus   int
+		expectedBody     string
+		expectedEntries  []controller.ArchiveEntry
+		expectedFilename string
+	}{
+		{
+			name:           "list",
+			path:           "/v1/files/" + fileID + "/archive/entries",
+			content:        archive,
+			expectedStatus: http.StatusOK,
+			expectedEntries: []controller.ArchiveEntry{
+				{
+					Name:           "docs/",
+					Size:           0,
+					CompressedSize: 0,
+					ModifiedAt:     "2021-12-27T09:58:11Z",
+					IsDirectory:    true,
+				},
+				{
+					Name:           "docs/readme.txt",
+					Size:           1400,
+					CompressedSize: 0, // ignored
+					ModifiedAt:     "2021-12-27T09:58:11Z",
+					IsDirectory:    false,
+				},
+				{
+					Name:           "data.csv",
+					Size:           8,
+					CompressedSize: 8,
+					ModifiedAt:     "2021-12-27T09:58:11Z",
+					IsDirectory:    false,
+				},
+			},
+		},
+		{
+			name:             "deflated entry",
+			path:             "/v1/files/" + fileID + "/archive/entries/docs/readme.txt",
+			content:          archive,
+			expectedStatus:   http.StatusOK,
+			expectedBody:     strings.Repeat("Hello, world!\n", 100),
+			expectedFilename: `inline; filename="readme.txt"`,
+		},
+		{
+			name:             "stored entry",
+			path:             "/v1/files/" + fileID + "/archive/entries/data.csv",
+			content:          archive,
+			expectedStatus:   http.StatusOK,
+			expectedBody:     "a,b\n1,2\n",
+			expectedFilename: `inline; filename="data.csv"`,
+		},
+		{
+			name:           "missing entry",
+			path:           "/v1/files/" + fileID + "/archive/entries/docs",
+			content:        archive,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "not an archive",
+			path:           "/v1/files/" + fileID + "/archive/entries",
+			content:        []byte("just some text"),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			metadataStorage.EXPECT().GetFileByID(
+				gomock.Any(), fileID, gomock.Any(),
+			).Return(controller.FileMetadata{ //nolint: exhaustruct
+				ID:         fileID,
+				Name:       "archive.zip",
+				Size:       int64(len(tc.content)),
+				BucketID:   "default",
+				ObjectKey:  "uploads/" + fileID,
+				IsUploaded: true,
+				MimeType:   "application/zip",
+			}, nil)
+
+			metadataStorage.EXPECT().GetBucketByID(
+				gomock.Any(), "default", gomock.Any(),
+			).Return(controller.BucketMetadata{ID: "default"}, nil) //nolint: exhaustruct
+
+			contentStorage.EXPECT().GetFile(
+				gomock.Any(), "uploads/"+fileID, gomock.Any(),
+			).DoAndReturn(rangeGetFile(tc.content)).AnyTimes()
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(context.Background(), "GET", tc.path, nil)
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+
+			switch {
+			case tc.expectedStatus != http.StatusOK:
+			case tc.expectedEntries != nil:
+				var resp struct {
+					Data controller.ListArchiveEntriesResponse `json:"data"`
+				}
+				if err := json.Unmarshal(responseRecorder.Body.Bytes(), &resp); err != nil {
+					t.Fatal(err)
+				}
+				for i := range resp.Data.Entries {
+					if resp.Data.Entries[i].Name == "docs/readme.txt" {
+						resp.Data.Entries[i].CompressedSize = 0
+					}
+				}
+				assert(t, tc.expectedEntries, resp.Data.Entries)
+			default:
+				assert(t, tc.expectedBody, responseRecorder.Body.String())
+				assert(
+					t, tc.expectedFilename, responseRecorder.Header().Get("Content-Disposition"),
+				)
+			}
+		})
+	}
+}
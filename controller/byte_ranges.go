@@ -0,0 +1,365 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requests with more ranges than this are served in full.
+const maxRanges = 16
+
+var (
+	errInvalidRange       = errors.New("invalid range")         //nolint: gochecknoglobals
+	errUnsatisfiableRange = errors.New("range not satisfiable") //nolint: gochecknoglobals
+)
+
+type byteRange struct {
+	start  int64
+	length int64
+}
+
+func (r byteRange) header() string {
+	return fmt.Sprintf("bytes=%d-%d", r.start, r.start+r.length-1)
+}
+
+func (r byteRange) contentRange(size int64) string {
+	return fmt.Sprintf("bytes %d-%d/%d", r.start, r.start+r.length-1, size)
+}
+
+func parseRangeBound(s string) (int64, error) {
+	if s == "" || s[0] == '-' || s[0] == '+' {
+		return 0, errInvalidRange
+	}
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, errInvalidRange
+	}
+	return i, nil
+}
+
+func parseByteRange(s string, size int64) (byteRange, bool, error) {
+	first, last, ok := strings.Cut(s, "-")
+	if !ok {
+		return byteRange{}, false, errInvalidRange
+	}
+	first, last = textproto.TrimString(first), textproto.TrimString(last)
+
+	if first == "" {
+		// suffix range, i.e. the last N bytes
+		n, err := parseRangeBound(last)
+		if err != nil {
+			return byteRange{}, false, err
+		}
+		n = min(n, size)
+		return byteRange{size - n, n}, n > 0, nil
+	}
+
+	start, err := parseRangeBound(first)
+	if err != nil {
+		return byteRange{}, false, err
+	}
+
+	end := size - 1
+	if last != "" {
+		if end, err = parseRangeBound(last); err != nil {
+			return byteRange{}, false, err
+		}
+		if end < start {
+			return byteRange{}, false, errInvalidRange
+		}
+		end = min(end, size-1)
+	}
+
+	if start >= size {
+		return byteRange{}, false, nil
+	}
+
+	return byteRange{start, end - start + 1}, true, nil
+}
+
+// parseRange parses a Range header according to RFC 7233 for a representation of the
+// given size. errInvalidRange means the header must be ignored and errUnsatisfiableRange
+// that none of the ranges overlap with the representation.
+func parseRange(s string, size int64) ([]byteRange, error) {
+	spec, ok := strings.CutPrefix(s, "bytes=")
+	if !ok {
+		return nil, errInvalidRange
+	}
+
+	var ranges []byteRange
+	for _, ra := range strings.Split(spec, ",") {
+		if ra = textproto.TrimString(ra); ra == "" {
+			continue
+		}
+
+		r, satisfiable, err := parseByteRange(ra, size)
+		if err != nil {
+			return nil, err
+		}
+		if satisfiable {
+			ranges = append(ranges, r)
+		}
+	}
+
+	if len(ranges) == 0 {
+		return nil, errUnsatisfiableRange
+	}
+
+	var total int64
+	for _, r := range ranges {
+		total += r.length
+	}
+	if len(ranges) > maxRanges || total > size {
+		// overlapping or too many ranges, serving the whole content is cheaper
+		return nil, errInvalidRange
+	}
+
+	return ranges, nil
+}
+
+// lazyReader opens the underlying reader on first use so parts of a multipart response
+// are only fetched when they are written.
+type lazyReader struct {
+	open func() (io.ReadCloser, error)
+	rc   io.ReadCloser
+}
+
+func (l *lazyReader) Read(p []byte) (int, error) {
+	if l.rc == nil {
+		rc, err := l.open()
+		if err != nil {
+			return 0, err
+		}
+		l.rc = rc
+	}
+	return l.rc.Read(p) //nolint: wrapcheck
+}
+
+func (l *lazyReader) Close() error {
+	if l.rc == nil {
+		return nil
+	}
+	return l.rc.Close() //nolint: wrapcheck
+}
+
+type multiReadCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (m *multiReadCloser) Close() error {
+	var errs []error
+	for _, c := range m.closers {
+		errs = append(errs, c.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// multipartByteRanges returns a multipart/byteranges body with a part for each range,
+// its length and its content type. openPart is called when the part is written.
+func multipartByteRanges(
+	ranges []byteRange,
+	size int64,
+	contentType string,
+	openPart func(byteRange) (io.ReadCloser, error),
+) (io.ReadCloser, int64, string) {
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+
+	readers := make([]io.Reader, 0, 2*len(ranges)+1) //nolint: gomnd
+	closers := make([]io.Closer, 0, len(ranges))
+	var length int64
+	for i, r := range ranges {
+		header := fmt.Sprintf(
+			"--%s\r\nContent-Range: %s\r\nContent-Type: %s\r\n\r\n",
+			boundary, r.contentRange(size), contentType,
+		)
+		if i > 0 {
+			header = "\r\n" + header
+		}
+
+		part := &lazyReader{
+			open: func() (io.ReadCloser, error) { return openPart(r) },
+			rc:   nil,
+		}
+		readers = append(readers, strings.NewReader(header), part)
+		closers = append(closers, part)
+		length += int64(len(header)) + r.length
+	}
+
+	trailer := fmt.Sprintf("\r\n--%s--\r\n", boundary)
+	readers = append(readers, strings.NewReader(trailer))
+	length += int64(len(trailer))
+
+	return &multiReadCloser{io.MultiReader(readers...), closers},
+		length,
+		"multipart/byteranges; boundary=" + boundary
+}
+
+// ifRangeMatches returns true if the Range header has to be honored according to If-Range,
+// which can contain either an etag or a date.
+func ifRangeMatches(ifRange, etag, updatedAt string) bool {
+	if ifRange == "" {
+		return true
+	}
+	if strings.HasPrefix(ifRange, `"`) || strings.HasPrefix(ifRange, "W/") {
+		// weak etags never match
+		return ifRange == etag && !strings.HasPrefix(etag, "W/")
+	}
+	return ifRange == updatedAt
+}
+
+// requestedRanges returns the ranges of the request that have to be served, nil means the
+// whole representation. The only error returned is errUnsatisfiableRange.
+func requestedRanges(
+	headers http.Header, size int64, etag, updatedAt string,
+) ([]byteRange, error) {
+	rangeHeader := headers.Get("Range")
+	if rangeHeader == "" || !ifRangeMatches(headers.Get("If-Range"), etag, updatedAt) {
+		return nil, nil
+	}
+
+	ranges, err := parseRange(rangeHeader, size)
+	if errors.Is(err, errInvalidRange) {
+		return nil, nil
+	}
+	return ranges, err
+}
+
+func rangeNotSatisfiable(
+	fileMetadata FileMetadata, etag, cacheControl, updateAt string, size int64,
+) *FileResponse {
+	return NewFileResponse(
+		fileMetadata.ID,
+		fileMetadata.MimeType,
+		0,
+		etag,
+		cacheControl,
+		updateAt,
+		http.StatusRequestedRangeNotSatisfiable,
+		http.NoBody,
+		fileMetadata.Name,
+		http.Header{"Content-Range": []string{fmt.Sprintf("bytes */%d", size)}},
+	)
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// limitToRange makes sure the response of the storage backend to a range request only
+// contains the range. Backends that ignore the range and return the whole object are
+// also supported.
+func limitToRange(download *File, r byteRange) *APIError {
+	switch download.StatusCode {
+	case http.StatusOK:
+		if _, err := io.CopyN(io.Discard, download.Body, r.start); err != nil {
+			download.Body.Close()
+			return InternalServerError(fmt.Errorf("problem skipping to range: %w", err))
+		}
+		download.Body = readCloser{io.LimitReader(download.Body, r.length), download.Body}
+		download.StatusCode = http.StatusPartialContent
+		download.ContentLength = r.length
+	case http.StatusPartialContent:
+		download.ContentLength = r.length
+	}
+
+	return nil
+}
+
+// downloadRange requests a range to the storage backend.
+func downloadRange(
+	ctx *gin.Context, downloadFunc getFileFunc, headers http.Header, r byteRange,
+) (*File, *APIError) {
+	h := headers.Clone()
+	h.Set("Range", r.header())
+
+	download, apiErr := downloadWithHeaders(ctx, downloadFunc, h)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	if apiErr := limitToRange(download, r); apiErr != nil {
+		return nil, apiErr
+	}
+
+	return download, nil
+}
+
+// rangesResponse returns a partial response with the requested ranges, a single range is
+// served as is and many of them as multipart/byteranges.
+func rangesResponse(
+	fileMetadata FileMetadata,
+	ranges []byteRange,
+	size int64,
+	etag, cacheControl, updateAt string,
+	openPart func(byteRange) (*File, *APIError),
+) (*FileResponse, *APIError) {
+	if len(ranges) == 1 {
+		part, apiErr := openPart(ranges[0])
+		if apiErr != nil {
+			return nil, apiErr
+		}
+
+		headers := make(http.Header)
+		if part.StatusCode == http.StatusPartialContent {
+			headers.Set("Accept-Ranges", "bytes")
+			headers.Set("Content-Range", ranges[0].contentRange(size))
+		}
+
+		return NewFileResponse(
+			fileMetadata.ID,
+			fileMetadata.MimeType,
+			part.ContentLength,
+			etag,
+			cacheControl,
+			updateAt,
+			part.StatusCode,
+			part.Body,
+			fileMetadata.Name,
+			headers,
+		), nil
+	}
+
+	body, length, contentType := multipartByteRanges(
+		ranges, size, fileMetadata.MimeType,
+		func(r byteRange) (io.ReadCloser, error) {
+			part, apiErr := openPart(r)
+			if apiErr != nil {
+				return nil, apiErr
+			}
+			if part.StatusCode != http.StatusPartialContent {
+				part.Body.Close()
+				return nil, fmt.Errorf( //nolint: goerr113
+					"unexpected status code %d fetching range", part.StatusCode,
+				)
+			}
+			return part.Body, nil
+		},
+	)
+
+	response := NewFileResponse(
+		fileMetadata.ID,
+		contentType,
+		length,
+		etag,
+		cacheControl,
+		updateAt,
+		http.StatusPartialContent,
+		body,
+		fileMetadata.Name,
+		http.Header{"Accept-Ranges": []string{"bytes"}},
+	)
+	// the parts keep the content type of the file
+	response.partsContentType = fileMetadata.MimeType
+
+	return response, nil
+}
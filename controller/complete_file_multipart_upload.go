@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -43,13 +44,51 @@ func checkFileMultipartUploadParts(fileMetadata FileMetadata, parts []MultipartF
 	return true, nil
 }
 
+// checkMultipartContent checks the declared type matches the assembled object and
+// enforces the file type policy of the bucket on it as the parts were uploaded without
+// being inspected. Rejected files are removed
+// altogether, as if the upload had failed.
+func (ctrl *Controller) checkMultipartContent(
+	ctx context.Context, fileMetadata FileMetadata, bucket BucketMetadata, objectKey string,
+) *APIError {
+	detected, apiErr := ctrl.sniffObject(ctx, objectKey, fileMetadata.Size)
+	if apiErr != nil {
+		return apiErr.ExtendError("problem figuring out content type for file " + fileMetadata.Name)
+	}
+
+	if fileMetadata.MimeType != "" && !declaredTypeMatches(fileMetadata.MimeType, detected) {
+		apiErr = ContentTypeMismatchError(fileMetadata.Name, fileMetadata.MimeType, detected.String())
+	} else {
+		apiErr = checkContentType(bucket, fileMetadata.Name, fileMetadata.MimeType, detected.String())
+	}
+	if apiErr == nil {
+		return nil
+	}
+
+	if err := ctrl.contentStorage.DeleteFile(ctx, objectKey); err != nil {
+		ctrl.logger.WithError(err).Error("problem deleting rejected file " + fileMetadata.ID)
+	}
+
+	if err := ctrl.metadataStorage.DeleteFileByID(
+		ctx,
+		fileMetadata.ID,
+		FileCondition{},
+		nil,
+		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}},
+	); err != nil {
+		ctrl.logger.WithError(err).Error("problem deleting metadata of rejected file " + fileMetadata.ID)
+	}
+
+	return apiErr
+}
+
 func (ctrl *Controller) completeFileMultipartUploadProcess(ctx *gin.Context) (FileMetadata, *APIError) {
 	req, apiErr := parseCompleteFileMultipartUploadRequest(ctx)
 	if apiErr != nil {
 		return FileMetadata{}, apiErr
 	}
 
-	fileMetadata, _, apiErr := ctrl.getFileMetadata(
+	fileMetadata, bucket, apiErr := ctrl.getFileMetadata(
 		ctx.Request.Context(), req.FileID, false, ctx.Request.Header,
 	)
 	if apiErr != nil {
@@ -83,10 +122,19 @@ func (ctrl *Controller) completeFileMultipartUploadProcess(ctx *gin.Context) (Fi
 		return FileMetadata{}, apiErr
 	}
 
+	if apiErr := ctrl.checkMultipartContent(ctx, fileMetadata, bucket, objectKey); apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
 	metadata, apiErr := ctrl.metadataStorage.PopulateMetadata(
 		ctx,
-		fileMetadata.ID, fileMetadata.Name, fileMetadata.Size, fileMetadata.BucketID, etag, true, fileMetadata.MimeType, objectKey, fileMetadata.ChunkSize, fileMetadata.ChunkCount, fileMetadata.UploadID, fileMetadata.Metadata,
-		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret}},
+		fileMetadata.ID, fileMetadata.Name, fileMetadata.Size, fileMetadata.BucketID, etag, true, fileMetadata.MimeType, objectKey, fileMetadata.ChunkSize, fileMetadata.ChunkCount, fileMetadata.UploadID, "", "", fileMetadata.Metadata,
+		ctrl.events(ctx, Event{
+			Type:     EventFileMultipartCompleted,
+			BucketID: fileMetadata.BucketID,
+			FileID:   fileMetadata.ID,
+		}),
+		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}},
 	)
 	if apiErr != nil {
 		return FileMetadata{}, apiErr.ExtendError(
@@ -94,6 +142,8 @@ func (ctrl *Controller) completeFileMultipartUploadProcess(ctx *gin.Context) (Fi
 		)
 	}
 
+	ctrl.lockObject(ctx, metadata, bucket)
+
 	return metadata, nil
 }
 
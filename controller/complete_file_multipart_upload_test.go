@@ -0,0 +1,171 @@
+package controller_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+)
+
+const pngContent = "\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR\x00\x00\x00\x01\x00\x00\x00\x01"
+
+func TestCompleteFileMultipartUploadContentType(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name             string
+		mimeType         string
+		content          string
+		allowedMimeTypes []string
+		expectedStatus   int
+	}{
+		{
+			name:             "allowed",
+			mimeType:         "image/png",
+			content:          pngContent,
+			allowedMimeTypes: []string{"image/*"},
+			expectedStatus:   http.StatusOK,
+		},
+		{
+			name:             "detected type not allowed",
+			mimeType:         "image/png",
+			content:          "not a picture",
+			allowedMimeTypes: []string{"image/*"},
+			expectedStatus:   http.StatusBadRequest,
+		},
+		{
+			name:           "plain text",
+			mimeType:       "text/csv",
+			content:        "a,b\n1,2\n",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "active content not confirmed",
+			mimeType:       "text/html",
+			content:        "alert(document.cookie)",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "script not confirmed",
+			mimeType:       "text/javascript",
+			content:        "alert(document.cookie)",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			fileMetadata := controller.FileMetadata{ //nolint: exhaustruct
+				ID:         "55af1e60-0f28-454e-885e-ea6aab2bb288",
+				Name:       "picture.png",
+				Size:       int64(len(tc.content)),
+				BucketID:   "default",
+				MimeType:   tc.mimeType,
+				ChunkSize:  int64(len(tc.content)),
+				ChunkCount: 1,
+				UploadID:   "upload-id",
+			}
+
+			metadataStorage.EXPECT().GetFileByID(
+				gomock.Any(), fileMetadata.ID, gomock.Any(),
+			).Return(fileMetadata, nil)
+			metadataStorage.EXPECT().GetBucketByID(
+				gomock.Any(), "default", gomock.Any(),
+			).Return(controller.BucketMetadata{ //nolint: exhaustruct
+				ID:               "default",
+				AllowedMimeTypes: tc.allowedMimeTypes,
+			}, nil)
+
+			contentStorage.EXPECT().ListParts(
+				gomock.Any(), fileMetadata.ID, "upload-id",
+			).Return([]controller.MultipartFragment{ //nolint: exhaustruct
+				{PartNumber: 1, Size: int64(len(tc.content))},
+			}, nil)
+			contentStorage.EXPECT().CompleteMultipartUpload(
+				gomock.Any(), fileMetadata.ID, "upload-id",
+			).Return(`"some-etag"`, nil)
+			contentStorage.EXPECT().GetFile(
+				gomock.Any(), fileMetadata.ID, http.Header{"Range": []string{"bytes=0-3071"}},
+			).Return(&controller.File{ //nolint: exhaustruct
+				StatusCode: http.StatusPartialContent,
+				Body:       io.NopCloser(strings.NewReader(tc.content)),
+			}, nil)
+
+			if tc.expectedStatus == http.StatusOK {
+				metadataStorage.EXPECT().PopulateMetadata(
+					gomock.Any(),
+					fileMetadata.ID,
+					fileMetadata.Name,
+					fileMetadata.Size,
+					"default",
+					`"some-etag"`,
+					true,
+					tc.mimeType,
+					fileMetadata.ID,
+					fileMetadata.ChunkSize,
+					fileMetadata.ChunkCount,
+					"upload-id",
+					"",
+					"",
+					gomock.Any(),
+					gomock.Any(),
+					gomock.Any(),
+				).Return(fileMetadata, nil)
+			} else {
+				// rejected content is removed as if the upload had failed
+				contentStorage.EXPECT().DeleteFile(gomock.Any(), fileMetadata.ID).Return(nil)
+				metadataStorage.EXPECT().DeleteFileByID(
+					gomock.Any(), fileMetadata.ID, controller.FileCondition{}, gomock.Any(), gomock.Any(),
+				).Return(nil)
+			}
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(),
+				"POST",
+				"/v1/files/"+fileMetadata.ID+"/multipart/complete",
+				nil,
+			)
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FileCondition restricts a change to the version of the file the request was checked
+// against so concurrent changes can't sneak in between, empty fields match any file.
+type FileCondition struct {
+	ETag      string
+	UpdatedAt string
+}
+
+func (c FileCondition) IsZero() bool {
+	return c.ETag == "" && c.UpdatedAt == ""
+}
+
+type conditionalWriteHeaders struct {
+	IfMatch           []string `header:"If-Match"`
+	IfUnmodifiedSince string   `header:"If-Unmodified-Since"`
+}
+
+// checkWritePreconditions evaluates the If-Match and If-Unmodified-Since headers of
+// the request against the file and returns the condition the change needs to be
+// stored with. Requests without them aren't conditional.
+func checkWritePreconditions(ctx *gin.Context, fileMetadata FileMetadata) (FileCondition, *APIError) {
+	var headers conditionalWriteHeaders
+	if err := ctx.ShouldBindHeader(&headers); err != nil {
+		return FileCondition{}, InternalServerError(
+			fmt.Errorf("problem parsing request headers: %w", err),
+		)
+	}
+
+	if len(headers.IfMatch) == 0 && headers.IfUnmodifiedSince == "" {
+		return FileCondition{}, nil
+	}
+
+	if len(headers.IfMatch) > 0 && !etagFound("*", headers.IfMatch) &&
+		!etagFound(fileMetadata.ETag, headers.IfMatch) {
+		return FileCondition{}, ErrPreconditionFailed
+	}
+
+	if headers.IfUnmodifiedSince != "" {
+		updatedAt, apiErr := timeFromRFC3339ToRFC1123(fileMetadata.UpdatedAt)
+		if apiErr != nil {
+			return FileCondition{}, apiErr
+		}
+
+		modified, apiErr := modifiedSince(updatedAt, headers.IfUnmodifiedSince)
+		if apiErr != nil {
+			return FileCondition{}, apiErr
+		}
+
+		if modified {
+			return FileCondition{}, ErrPreconditionFailed
+		}
+	}
+
+	return FileCondition{ETag: fileMetadata.ETag, UpdatedAt: fileMetadata.UpdatedAt}, nil
+}
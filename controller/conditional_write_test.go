@@ -0,0 +1,187 @@
+package controller_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestConditionalWrites(t *testing.T) {
+	t.Parallel()
+
+	const (
+		fileID    = "55af1e60-0f28-454e-885e-ea6aab2bb288"
+		updatedAt = "2021-12-15T13:26:52.082485+00:00"
+	)
+
+	cases := []struct {
+		name              string
+		method            string
+		headers           http.Header
+		expectedCondition *controller.FileCondition
+		storageErr        *controller.APIError
+		expectedStatus    int
+	}{
+		{
+			name:   "delete with matching etag",
+			method: "DELETE",
+			headers: http.Header{
+				"If-Match": []string{`"some-etag"`},
+			},
+			expectedCondition: &controller.FileCondition{ETag: `"some-etag"`, UpdatedAt: updatedAt},
+			expectedStatus:    http.StatusNoContent,
+		},
+		{
+			name:   "delete with stale etag",
+			method: "DELETE",
+			headers: http.Header{
+				"If-Match": []string{`"old-etag"`},
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:   "delete modified concurrently",
+			method: "DELETE",
+			headers: http.Header{
+				"If-Match": []string{"*"},
+			},
+			expectedCondition: &controller.FileCondition{ETag: `"some-etag"`, UpdatedAt: updatedAt},
+			storageErr:        controller.ErrPreconditionFailed,
+			expectedStatus:    http.StatusPreconditionFailed,
+		},
+		{
+			name:   "patch unmodified since",
+			method: "PATCH",
+			headers: http.Header{
+				"If-Unmodified-Since": []string{"Wed, 15 Dec 2021 13:26:52 UTC"},
+			},
+			expectedCondition: &controller.FileCondition{ETag: `"some-etag"`, UpdatedAt: updatedAt},
+			expectedStatus:    http.StatusOK,
+		},
+		{
+			name:   "patch modified since",
+			method: "PATCH",
+			headers: http.Header{
+				"If-Unmodified-Since": []string{"Wed, 15 Dec 2021 13:26:51 UTC"},
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:   "patch with wrong date",
+			method: "PATCH",
+			headers: http.Header{
+				"If-Unmodified-Since": []string{"yesterday"},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "patch modified concurrently",
+			method: "PATCH",
+			headers: http.Header{
+				"If-Match": []string{`"some-etag"`},
+			},
+			expectedCondition: &controller.FileCondition{ETag: `"some-etag"`, UpdatedAt: updatedAt},
+			storageErr:        controller.ErrPreconditionFailed,
+			expectedStatus:    http.StatusPreconditionFailed,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			file := controller.FileMetadata{ //nolint: exhaustruct
+				ID:         fileID,
+				Name:       "report.pdf",
+				Size:       1024,
+				BucketID:   "default",
+				ETag:       `"some-etag"`,
+				UpdatedAt:  updatedAt,
+				IsUploaded: true,
+				MimeType:   "application/pdf",
+			}
+			metadataStorage.EXPECT().GetFileByID(
+				gomock.Any(), fileID, gomock.Any(),
+			).Return(file, nil)
+
+			var body *strings.Reader
+			switch tc.method {
+			case "DELETE":
+				body = strings.NewReader("")
+
+				metadataStorage.EXPECT().GetBucketByID(
+					gomock.Any(), "default", gomock.Any(),
+				).Return(controller.BucketMetadata{ID: "default"}, nil) //nolint: exhaustruct
+
+				if tc.expectedCondition != nil {
+					metadataStorage.EXPECT().ListFileVersions(gomock.Any(), fileID, gomock.Any()).Return(nil, nil)
+					metadataStorage.EXPECT().DeleteFileByID(
+						gomock.Any(), fileID, *tc.expectedCondition, gomock.Any(), gomock.Any(),
+					).Return(tc.storageErr)
+				}
+				if tc.expectedStatus == http.StatusNoContent {
+					contentStorage.EXPECT().DeleteFile(gomock.Any(), fileID).Return(nil)
+				}
+			case "PATCH":
+				body = strings.NewReader(`{"metadata":{"year":"2021"}}`)
+
+				metadataStorage.EXPECT().GetBucketByID(
+					gomock.Any(), "default", gomock.Any(),
+				).Return(controller.BucketMetadata{ID: "default"}, nil) //nolint: exhaustruct
+
+				if tc.expectedCondition != nil {
+					metadataStorage.EXPECT().UpdateFileMetadata(
+						gomock.Any(), fileID, "report.pdf", "default",
+						map[string]any{"year": "2021"}, *tc.expectedCondition, gomock.Any(),
+					).Return(file, tc.storageErr)
+				}
+			}
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(), tc.method, "/v1/files/"+fileID, body,
+			)
+			for k, v := range tc.headers {
+				req.Header[k] = v
+			}
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+		})
+	}
+}
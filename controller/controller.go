@@ -16,10 +16,24 @@ import (
 )
 
 type FileSummary struct {
-	ID         string `json:"id"`
-	Name       string `json:"name"`
-	IsUploaded bool   `json:"isUploaded"`
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	IsUploaded       bool   `json:"isUploaded"`
+	BucketID         string `json:"bucketId"`
+	UploadedByUserID string `json:"uploadedByUserId"`
+}
+
+type FileFilter struct {
+	UploadedBy string `json:"uploadedBy"`
 	BucketID   string `json:"bucketId"`
+	// ObjectPrefix matches the files whose object key starts with it
+	ObjectPrefix string `json:"objectPrefix"`
+	// Trashed matches the files in the trash if true and the ones outside of it if false
+	Trashed *bool `json:"trashed"`
+	// Uploaded matches the files that finished uploading if true and the rest if false
+	Uploaded *bool `json:"uploaded"`
+	// Limit caps the number of files returned, zero means no limit
+	Limit int `json:"limit"`
 }
 
 type BucketMetadata struct {
@@ -32,6 +46,19 @@ type BucketMetadata struct {
 	UpdatedAt            string
 	CacheControl         string
 	UploadExpiration     int
+	WebhookURL           string
+	AllowedMimeTypes     []string
+	DeniedMimeTypes      []string
+	AllowedExtensions    []string
+	DeniedExtensions     []string
+	RedirectDownloads    bool
+	VersioningEnabled    bool
+	MaxVersions          int
+	VersionRetentionDays int
+	SoftDeleteEnabled    bool
+	TrashRetentionDays   int
+	RetentionDays        int
+	RetentionMode        string
 }
 
 type FileMetadata struct {
@@ -50,6 +77,113 @@ type FileMetadata struct {
 	ChunkSize        int64          `json:"chunkSize"`
 	ChunkCount       int64          `json:"chunkCount"`
 	UploadID         string         `json:"uploadId"`
+	DeletedAt        string         `json:"deletedAt,omitempty"`
+	DeletedByUserID  string         `json:"deletedByUserId,omitempty"`
+	RetainUntil      string         `json:"retainUntil,omitempty"`
+	LegalHold        bool           `json:"legalHold,omitempty"`
+	ExpiresAt        string         `json:"expiresAt,omitempty"`
+}
+
+type VirusMetadata struct {
+	ID            string           `json:"id"`
+	FileID        string           `json:"fileId"`
+	Filename      string           `json:"filename"`
+	BucketID      string           `json:"bucketId"`
+	Virus         string           `json:"virus"`
+	FalsePositive bool             `json:"falsePositive"`
+	UserSession   map[string]any   `json:"userSession,omitempty"`
+	Quarantine    *QuarantinedFile `json:"quarantine,omitempty"`
+	CreatedAt     string           `json:"createdAt"`
+	UpdatedAt     string           `json:"updatedAt"`
+}
+
+// QuarantinedFile is the content rejected by the antivirus. It is kept under its own
+// object key so the file it was meant for is left untouched.
+type QuarantinedFile struct {
+	ObjectKey string         `json:"objectKey"`
+	Name      string         `json:"name"`
+	Size      int64          `json:"size"`
+	MimeType  string         `json:"mimeType"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+}
+
+// VirusFilter narrows down the virus findings returned by MetadataStorage.ListViruses.
+// Empty fields are ignored.
+type VirusFilter struct {
+	BucketID      string `json:"bucketId"`
+	UserID        string `json:"userId"`
+	Virus         string `json:"virus"`
+	CreatedAfter  string `json:"createdAfter"`
+	CreatedBefore string `json:"createdBefore"`
+	FalsePositive *bool  `json:"falsePositive"`
+}
+
+// Quota limits what a user can store. Empty UserID, Role or BucketID match everything
+// and zero MaxBytes or MaxFiles mean no limit.
+type Quota struct {
+	ID       string `json:"id"`
+	UserID   string `json:"userId,omitempty"`
+	Role     string `json:"role,omitempty"`
+	BucketID string `json:"bucketId,omitempty"`
+	MaxBytes int64  `json:"maxBytes"`
+	MaxFiles int64  `json:"maxFiles"`
+}
+
+// APIKey grants access to the service without an access token. Empty Buckets means
+// all of them.
+type APIKey struct {
+	ID         string
+	Name       string
+	Role       string
+	Buckets    []string
+	Operations []string
+	ExpiresAt  string
+	LastUsedAt string
+}
+
+// Share is a link that grants access to a file without authentication. Zero
+// MaxDownloads means no limit.
+type Share struct {
+	ID                     string   `json:"id"`
+	FileID                 string   `json:"fileId"`
+	CreatedByUserID        string   `json:"createdByUserId,omitempty"`
+	ExpiresAt              string   `json:"expiresAt,omitempty"`
+	PasswordHash           string   `json:"-"`
+	MaxDownloads           int64    `json:"maxDownloads,omitempty"`
+	DownloadCount          int64    `json:"downloadCount"`
+	AllowedTransformations []string `json:"allowedTransformations,omitempty"`
+	CreatedAt              string   `json:"createdAt"`
+}
+
+// FileVersion is a previous content of a file, kept when the file is replaced in a
+// bucket with versioning enabled.
+type FileVersion struct {
+	ID               string         `json:"id"`
+	FileID           string         `json:"fileId"`
+	Name             string         `json:"name"`
+	Size             int64          `json:"size"`
+	MimeType         string         `json:"mimeType"`
+	ETag             string         `json:"etag"`
+	ObjectKey        string         `json:"-"`
+	UploadedByUserID string         `json:"uploadedByUserId,omitempty"`
+	Metadata         map[string]any `json:"metadata,omitempty"`
+	CreatedAt        string         `json:"createdAt"`
+}
+
+// FileAuditEvent records a change to the locks of a file, who made it and why.
+type FileAuditEvent struct {
+	FileID   string
+	Action   string
+	Reason   string
+	UserID   string
+	APIKeyID string
+}
+
+// Usage is what a user is storing in a bucket.
+type Usage struct {
+	BucketID string `json:"bucketId"`
+	Bytes    int64  `json:"bytes"`
+	Files    int64  `json:"files"`
 }
 
 type MetadataStorage interface {
@@ -60,29 +194,106 @@ type MetadataStorage interface {
 		ctx context.Context,
 		id, name string, size int64, bucketID, mimeType string,
 		objectKey string, chunkSize int64, chunkCount int64, uploadId string,
+		expiresAt string,
 		headers http.Header,
 	) *APIError
+	// PopulateMetadata queues the events in the same transaction as the change, they
+	// are dropped if no file was changed
 	PopulateMetadata(
 		ctx context.Context,
 		id, name string, size int64, bucketID, etag string, IsUploaded bool, mimeType string,
 		objectKey string, chunkSize int64, chunkCount int64, uploadId string,
+		uploadedByUserID, uploadedByRole string,
 		metadata map[string]any,
+		events []WebhookEvent,
 		headers http.Header) (FileMetadata, *APIError,
 	)
+	// UpdateFileMetadata only updates the name, bucket and metadata of the file
+	UpdateFileMetadata(
+		ctx context.Context,
+		fileID, name, bucketID string,
+		metadata map[string]any,
+		condition FileCondition,
+		headers http.Header,
+	) (FileMetadata, *APIError)
 	SetIsUploaded(
 		ctx context.Context,
 		fileID string,
 		isUploaded bool,
+		condition FileCondition,
 		headers http.Header,
 	) *APIError
-	DeleteFileByID(ctx context.Context, fileID string, headers http.Header) *APIError
-	ListFiles(ctx context.Context, headers http.Header) ([]FileSummary, *APIError)
+	DeleteFileByID(
+		ctx context.Context,
+		fileID string,
+		condition FileCondition,
+		events []WebhookEvent,
+		headers http.Header,
+	) *APIError
+	ListFiles(ctx context.Context, filter FileFilter, headers http.Header) ([]FileSummary, *APIError)
+	// TrashFile moves the file to the trash, it stays there until it's restored or the
+	// retention period of the bucket is over
+	TrashFile(
+		ctx context.Context,
+		fileID, deletedByUserID string,
+		condition FileCondition,
+		headers http.Header,
+	) (FileMetadata, *APIError)
+	RestoreFile(
+		ctx context.Context,
+		fileID string,
+		events []WebhookEvent,
+		headers http.Header,
+	) (FileMetadata, *APIError)
+	// ListTrashedFiles returns the files in the trash, last deleted first
+	ListTrashedFiles(ctx context.Context, filter FileFilter, headers http.Header) ([]FileMetadata, *APIError)
+	// SetLegalHold sets or clears the legal hold of the file and records the event in
+	// the audit log in the same transaction
+	SetLegalHold(
+		ctx context.Context,
+		fileID string,
+		legalHold bool,
+		event FileAuditEvent,
+		headers http.Header,
+	) (FileMetadata, *APIError)
 	InsertVirus(
 		ctx context.Context,
-		fileID, filename, virus string,
-		userSession map[string]any,
+		virus VirusMetadata,
+		events []WebhookEvent,
 		headers http.Header,
 	) *APIError
+	ListViruses(ctx context.Context, filter VirusFilter, headers http.Header) ([]VirusMetadata, *APIError)
+	GetVirusByID(ctx context.Context, id string, headers http.Header) (VirusMetadata, *APIError)
+	SetVirusFalsePositive(
+		ctx context.Context,
+		id string,
+		falsePositive bool,
+		headers http.Header,
+	) *APIError
+	DeleteVirusesByFileID(ctx context.Context, fileID string, headers http.Header) *APIError
+	// GetQuotas returns the quotas that apply to the user in the bucket, or in any
+	// bucket if bucketID is empty
+	GetQuotas(
+		ctx context.Context,
+		userID, role, bucketID string,
+		headers http.Header,
+	) ([]Quota, *APIError)
+	GetUsage(ctx context.Context, userID string, headers http.Header) ([]Usage, *APIError)
+	GetAPIKeyByHash(ctx context.Context, keyHash string, headers http.Header) (APIKey, *APIError)
+	SetAPIKeyLastUsed(ctx context.Context, id string, headers http.Header) *APIError
+	InsertShare(ctx context.Context, share Share, tokenHash string, headers http.Header) (Share, *APIError)
+	GetShareByTokenHash(ctx context.Context, tokenHash string, headers http.Header) (Share, *APIError)
+	// DeleteShare deletes the share of the file, if createdByUserID isn't empty only
+	// shares created by that user are deleted
+	DeleteShare(ctx context.Context, id, fileID, createdByUserID string, headers http.Header) *APIError
+	// IncrementShareDownloads returns false if the share reached maxDownloads
+	IncrementShareDownloads(
+		ctx context.Context, id string, maxDownloads int64, headers http.Header,
+	) (bool, *APIError)
+	InsertFileVersion(ctx context.Context, version FileVersion, headers http.Header) (FileVersion, *APIError)
+	// ListFileVersions returns the versions of the file, newest first
+	ListFileVersions(ctx context.Context, fileID string, headers http.Header) ([]FileVersion, *APIError)
+	GetFileVersionByID(ctx context.Context, id string, headers http.Header) (FileVersion, *APIError)
 }
 
 type ContentStorage interface {
@@ -97,6 +308,14 @@ type ContentStorage interface {
 		filepath string,
 		expire time.Duration,
 	) (string, *APIError)
+	// CreateGetObjectRedirectURL returns a full presigned URL of the storage backend
+	// clients can be redirected to
+	CreateGetObjectRedirectURL(
+		ctx context.Context,
+		filepath string,
+		contentDisposition string,
+		expire time.Duration,
+	) (string, *APIError)
 	GetFileWithPresignedURL(
 		ctx context.Context, filepath, signature string, headers http.Header,
 	) (*File, *APIError)
@@ -104,6 +323,9 @@ type ContentStorage interface {
 		ctx context.Context, filepath, signature string, headers http.Header,
 	) (*httputil.ReverseProxy, *APIError)
 	DeleteFile(ctx context.Context, filepath string) *APIError
+	// CopyFile copies the object without going through hasura-storage and returns the
+	// etag of the copy
+	CopyFile(ctx context.Context, srcPath, dstPath string, size int64) (string, *APIError)
 	ListFiles(ctx context.Context) ([]string, *APIError)
 	CreateMultipartUpload(
 		ctx context.Context,
@@ -145,31 +367,53 @@ type ContentStorage interface {
 		filepath string,
 		uploadId string,
 	) *APIError
+	// SetObjectRetention and SetObjectLegalHold lock the object in the storage backend,
+	// they do nothing if the backend doesn't support it
+	SetObjectRetention(
+		ctx context.Context, filepath, mode string, retainUntil time.Time,
+	) *APIError
+	SetObjectLegalHold(ctx context.Context, filepath string, legalHold bool) *APIError
 }
 
 type Antivirus interface {
 	ScanReader(r io.ReaderAt) *APIError
 }
 
+// AdminSecret returns the admin secret used for privileged calls to hasura, it may
+// change while the service is running if secrets are rotated.
+type AdminSecret interface {
+	Primary() string
+}
+
+type UploadHook interface {
+	CheckUpload(ctx context.Context, req UploadHookRequest) (UploadHookResponse, *APIError)
+}
+
 type Controller struct {
 	publicURL         string
 	apiRootPrefix     string
-	hasuraAdminSecret string
+	hasuraAdminSecret AdminSecret
 	metadataStorage   MetadataStorage
 	contentStorage    ContentStorage
 	imageTransformer  *image.Transformer
 	av                Antivirus
+	notifier          EventNotifier
+	uploadHook        UploadHook
+	urlSigner         *URLSigner
 	logger            *logrus.Logger
 }
 
 func New(
 	publicURL string,
 	apiRootPrefix string,
-	hasuraAdminSecret string,
+	hasuraAdminSecret AdminSecret,
 	metadataStorage MetadataStorage,
 	contentStorage ContentStorage,
 	imageTransformer *image.Transformer,
 	av Antivirus,
+	notifier EventNotifier,
+	uploadHook UploadHook,
+	urlSigner *URLSigner,
 	logger *logrus.Logger,
 ) *Controller {
 	return &Controller{
@@ -180,6 +424,9 @@ func New(
 		contentStorage,
 		imageTransformer,
 		av,
+		notifier,
+		uploadHook,
+		urlSigner,
 		logger,
 	}
 }
@@ -187,14 +434,16 @@ func New(
 func corsConfig(allowedOrigins []string) cors.Config {
 	return cors.Config{
 		AllowOrigins: allowedOrigins,
-		AllowMethods: []string{"GET", "PUT", "POST", "HEAD", "DELETE"},
+		AllowMethods: []string{"GET", "PUT", "PATCH", "POST", "HEAD", "DELETE"},
 		AllowHeaders: []string{
 			"Authorization", "Origin", "if-match", "if-none-match", "if-modified-since", "if-unmodified-since",
+			"if-range", "range",
 			"x-hasura-admin-secret", "x-nhost-bucket-id", "x-nhost-file-name", "x-nhost-file-id",
-			"x-hasura-role",
+			"x-hasura-role", "x-nhost-api-key", "x-nhost-share-password",
 		},
 		ExposeHeaders: []string{
 			"Content-Length", "Content-Type", "Cache-Control", "ETag", "Last-Modified", "X-Error",
+			"Content-Range", "Accept-Ranges",
 		},
 		MaxAge: 12 * time.Hour, //nolint: mnd
 	}
@@ -215,6 +464,9 @@ func (ctrl *Controller) SetupRouter(
 	// lower values make uploads slower but keeps service memory usage low
 	router.MaxMultipartMemory = 1 << 20 //nolint:mnd  // 1 MB
 	router.Use(gin.Recovery())
+	// api keys are translated into an admin secret and a role so this needs to run
+	// before the middlewares checking them
+	router.Use(ctrl.APIKeyAuth)
 
 	for _, mw := range middleware {
 		router.Use(mw)
@@ -233,6 +485,9 @@ func (ctrl *Controller) SetupRouter(
 	{
 		apiRoot.GET("/openapi.yaml", ctrl.OpenAPI)
 		apiRoot.GET("/version", ctrl.Version)
+		apiRoot.GET("/quota", ctrl.GetQuota)
+		apiRoot.GET("/trash", ctrl.ListTrash)
+		apiRoot.GET("/shares/:token", ctrl.GetSharedFile)
 	}
 	files := apiRoot.Group("/files")
 	{
@@ -241,13 +496,24 @@ func (ctrl *Controller) SetupRouter(
 		files.GET("/:id", ctrl.GetFile)
 		files.HEAD("/:id", ctrl.GetFileInformation)
 		files.PUT("/:id", ctrl.UpdateFile)
+		files.PATCH("/:id", ctrl.PatchFile)
 		files.DELETE("/:id", ctrl.DeleteFile)
+		files.POST("/:id/restore", ctrl.RestoreFile)
 		files.GET("/:id/presignedurl", ctrl.GetFilePresignedURL)
 		files.GET("/:id/presignedurl/content", ctrl.GetFileWithPresignedURL)
 		files.GET("/:id/download/:name", ctrl.DownloadFile)
+		files.GET("/:id/archive/entries", ctrl.ListArchiveEntries)
+		files.GET("/:id/archive/entries/*path", ctrl.GetArchiveEntry)
+		files.POST("/:id/copy", ctrl.CopyFile)
+		files.POST("/:id/move", ctrl.MoveFile)
+		files.GET("/:id/versions", ctrl.ListFileVersions)
+		files.POST("/:id/versions/:versionId/restore", ctrl.RestoreFileVersion)
+		files.POST("/:id/shares", ctrl.CreateShare)
+		files.DELETE("/:id/shares/:shareId", ctrl.DeleteShare)
 		files.GET("/:id/multipart", ctrl.GetFileMultipartUploadInfo)
 		files.GET("/:id/multipart/presignedurl", ctrl.GetFileMultipartPresignedURL)
 		files.PUT("/:id/multipart/presignedurl/content", ctrl.UploadFileMultipartWithPresignedURL)
+		files.POST("/archive", ctrl.DownloadArchive)
 		files.POST("/multipart", ctrl.CreateFileMultipartUpload)
 		files.POST("/:id/multipart/complete", ctrl.CompleteFileMultipartUpload)
 		files.POST("/:id/multipart/abort", ctrl.AbortFileMultipartUpload)
@@ -260,6 +526,12 @@ func (ctrl *Controller) SetupRouter(
 		ops.POST("list-broken-metadata", ctrl.ListBrokenMetadata)
 		ops.POST("delete-broken-metadata", ctrl.DeleteBrokenMetadata)
 		ops.POST("list-not-uploaded", ctrl.ListNotUploaded)
+		ops.POST("list-viruses", ctrl.ListViruses)
+		ops.GET("viruses/:id", ctrl.GetVirus)
+		ops.POST("viruses/:id/false-positive", ctrl.MarkVirusFalsePositive)
+		ops.POST("viruses/:id/purge", ctrl.PurgeVirus)
+		ops.PUT("files/:id/legal-hold", ctrl.SetLegalHold)
+		ops.DELETE("files/:id/legal-hold", ctrl.ClearLegalHold)
 	}
 	return router, nil
 }
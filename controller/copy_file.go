@@ -0,0 +1,271 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CopyFileRequest struct {
+	// BucketID defaults to the bucket of the file
+	BucketID string `json:"bucketId"`
+	// Name defaults to the name of the file
+	Name string `json:"name"`
+	// ObjectPrefix defaults to the object prefix of the file
+	ObjectPrefix *string `json:"objectPrefix"`
+}
+
+// FileObjectKey returns the key of the object holding the content of the file, files
+// stored before object keys were introduced use their id.
+func FileObjectKey(fileMetadata FileMetadata) string {
+	if fileMetadata.ObjectKey == "" {
+		return fileMetadata.ID
+	}
+	return fileMetadata.ObjectKey
+}
+
+// fileObjectPrefix returns the prefix the object key of the file was built from.
+func fileObjectPrefix(fileMetadata FileMetadata) string {
+	return strings.TrimSuffix(
+		strings.TrimSuffix(FileObjectKey(fileMetadata), fileMetadata.ID), "/",
+	)
+}
+
+// copyTarget checks the file can be stored as requested and returns where it has to go.
+func (ctrl *Controller) copyTarget(
+	ctx *gin.Context, operation string, source FileMetadata, fileID string,
+) (UploadHookRequest, BucketMetadata, *APIError) {
+	var req CopyFileRequest
+	if err := json.NewDecoder(ctx.Request.Body).Decode(&req); err != nil &&
+		!errors.Is(err, io.EOF) {
+		return UploadHookRequest{}, BucketMetadata{}, BadDataError(err, "couldn't decode request")
+	}
+
+	if req.BucketID == "" {
+		req.BucketID = source.BucketID
+	}
+	if req.Name == "" {
+		req.Name = source.Name
+	}
+	if req.ObjectPrefix == nil {
+		prefix := fileObjectPrefix(source)
+		req.ObjectPrefix = &prefix
+	}
+
+	if apiErr := checkAPIKeyBucket(ctx.Request.Header, req.BucketID); apiErr != nil {
+		return UploadHookRequest{}, BucketMetadata{}, apiErr
+	}
+
+	bucket, apiErr := ctrl.metadataStorage.GetBucketByID(
+		ctx,
+		req.BucketID,
+		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}},
+	)
+	if apiErr != nil {
+		return UploadHookRequest{}, BucketMetadata{}, apiErr
+	}
+
+	if apiErr := checkSize(
+		req.Name, source.Size, bucket.MinUploadFile, bucket.MaxUploadFile,
+	); apiErr != nil {
+		return UploadHookRequest{}, BucketMetadata{}, apiErr
+	}
+
+	if apiErr := checkFileType(bucket, req.Name, source.MimeType); apiErr != nil {
+		return UploadHookRequest{}, BucketMetadata{}, apiErr
+	}
+
+	hookReq, apiErr := ctrl.checkUpload(ctx, UploadHookRequest{ //nolint: exhaustruct
+		Operation:    operation,
+		FileID:       fileID,
+		BucketID:     bucket.ID,
+		Name:         req.Name,
+		Size:         source.Size,
+		MimeType:     source.MimeType,
+		ObjectPrefix: *req.ObjectPrefix,
+		Metadata:     source.Metadata,
+	}, ctx.Request.Header)
+	if apiErr != nil {
+		return UploadHookRequest{}, BucketMetadata{}, apiErr
+	}
+
+	return hookReq, bucket, nil
+}
+
+func (ctrl *Controller) copyFile(ctx *gin.Context) (FileMetadata, *APIError) {
+	source, _, apiErr := ctrl.getFileMetadata(
+		ctx.Request.Context(), ctx.Param("id"), true, ctx.Request.Header,
+	)
+	if apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	fileID := uuid.New().String()
+
+	target, bucket, apiErr := ctrl.copyTarget(ctx, UploadOperationCopy, source, fileID)
+	if apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	if apiErr := ctrl.checkQuota(
+		ctx, ctx.Request.Header, bucket.ID, source.Size, 1,
+	); apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	objectKey, err := url.JoinPath(target.ObjectPrefix, fileID)
+	if err != nil {
+		return FileMetadata{}, InternalServerError(fmt.Errorf("problem joining path: %w", err))
+	}
+
+	// copies don't keep the expiry of the source, they get the default TTL of the bucket
+	if apiErr := ctrl.metadataStorage.InitializeFile(
+		ctx, fileID, target.Name, source.Size, bucket.ID, source.MimeType, objectKey, source.Size, 1, "", "",
+		ctx.Request.Header,
+	); apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	etag, apiErr := ctrl.contentStorage.CopyFile(
+		ctx, FileObjectKey(source), objectKey, source.Size,
+	)
+	if apiErr != nil {
+		_ = ctrl.metadataStorage.DeleteFileByID(
+			ctx,
+			fileID,
+			FileCondition{},
+			nil,
+			http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}},
+		)
+		return FileMetadata{}, apiErr.ExtendError("problem copying file in storage")
+	}
+
+	uploadedBy, uploadedByRole := ctrl.sessionVariables(ctx, ctx.Request.Header)
+	metadata, apiErr := ctrl.metadataStorage.PopulateMetadata(
+		ctx,
+		fileID, target.Name, source.Size, bucket.ID, etag, true, source.MimeType, objectKey, source.Size, 1, "", uploadedBy, uploadedByRole, target.Metadata,
+		ctrl.events(ctx, Event{Type: EventFileCopied, BucketID: bucket.ID, FileID: fileID}),
+		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}},
+	)
+	if apiErr != nil {
+		return FileMetadata{}, apiErr.ExtendError("problem populating file metadata for file " + fileID)
+	}
+
+	ctrl.lockObject(ctx, metadata, bucket)
+
+	return metadata, nil
+}
+
+func (ctrl *Controller) CopyFile(ctx *gin.Context) {
+	metadata, apiErr := ctrl.copyFile(ctx)
+	if apiErr != nil {
+		_ = ctx.Error(fmt.Errorf("problem processing request: %w", apiErr))
+
+		ctx.JSON(apiErr.statusCode, CommonResponse{
+			Code:    apiErr.statusCode,
+			Message: apiErr.PublicResponse().Message,
+		})
+
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, CommonResponse{http.StatusCreated, "ok", metadata})
+}
+
+func (ctrl *Controller) moveFile(ctx *gin.Context) (FileMetadata, *APIError) { //nolint: funlen
+	source, _, apiErr := ctrl.getFileMetadata(
+		ctx.Request.Context(), ctx.Param("id"), true, ctx.Request.Header,
+	)
+	if apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	if apiErr := checkFileLock(source); apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	target, bucket, apiErr := ctrl.copyTarget(ctx, UploadOperationMove, source, source.ID)
+	if apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	if bucket.ID != source.BucketID {
+		if apiErr := ctrl.checkQuota(
+			ctx, ctx.Request.Header, bucket.ID, source.Size, 1,
+		); apiErr != nil {
+			return FileMetadata{}, apiErr
+		}
+	}
+
+	objectKey, err := url.JoinPath(target.ObjectPrefix, source.ID)
+	if err != nil {
+		return FileMetadata{}, InternalServerError(fmt.Errorf("problem joining path: %w", err))
+	}
+
+	// renaming a file or moving it to another bucket doesn't require copying the object
+	// unless the object prefix changes
+	sourceKey := FileObjectKey(source)
+	moved := objectKey != sourceKey
+	etag := source.ETag
+	chunkSize, chunkCount := source.ChunkSize, source.ChunkCount
+	if moved {
+		etag, apiErr = ctrl.contentStorage.CopyFile(ctx, sourceKey, objectKey, source.Size)
+		if apiErr != nil {
+			return FileMetadata{}, apiErr.ExtendError("problem copying file in storage")
+		}
+		chunkSize, chunkCount = source.Size, 1
+	}
+
+	metadata, apiErr := ctrl.metadataStorage.PopulateMetadata(
+		ctx,
+		source.ID, target.Name, source.Size, bucket.ID, etag, true, source.MimeType, objectKey, chunkSize, chunkCount, "", "", "", target.Metadata,
+		nil,
+		ctx.Request.Header,
+	)
+	if apiErr != nil {
+		if moved {
+			if apiErr := ctrl.contentStorage.DeleteFile(ctx, objectKey); apiErr != nil {
+				ctrl.logger.WithError(apiErr).Error("problem deleting copy of file " + source.ID)
+			}
+		}
+		return FileMetadata{}, apiErr.ExtendError("problem populating file metadata for file " + source.ID)
+	}
+
+	if moved {
+		if apiErr := ctrl.contentStorage.DeleteFile(ctx, sourceKey); apiErr != nil {
+			ctrl.logger.WithError(apiErr).Error("problem deleting moved file " + source.ID)
+		}
+	}
+
+	if moved {
+		ctrl.lockObject(ctx, metadata, bucket)
+	}
+
+	ctx.Set("FileChanged", source.ID)
+	ctrl.notify(ctx, Event{Type: EventFileMoved, BucketID: bucket.ID, Data: metadata})
+
+	return metadata, nil
+}
+
+func (ctrl *Controller) MoveFile(ctx *gin.Context) {
+	metadata, apiErr := ctrl.moveFile(ctx)
+	if apiErr != nil {
+		_ = ctx.Error(fmt.Errorf("problem processing request: %w", apiErr))
+
+		ctx.JSON(apiErr.statusCode, CommonResponse{
+			Code:    apiErr.statusCode,
+			Message: apiErr.PublicResponse().Message,
+		})
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, CommonResponse{http.StatusOK, "ok", metadata})
+}
@@ -0,0 +1,306 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+)
+
+const copySourceID = "55af1e60-0f28-454e-885e-ea6aab2bb288"
+
+func copySource() controller.FileMetadata {
+	return controller.FileMetadata{ //nolint: exhaustruct
+		ID:         copySourceID,
+		Name:       "report.pdf",
+		Size:       1024,
+		BucketID:   "default",
+		ETag:       `"some-etag"`,
+		IsUploaded: true,
+		MimeType:   "application/pdf",
+		ObjectKey:  "reports/" + copySourceID,
+		ChunkSize:  1024,
+		ChunkCount: 1,
+		Metadata:   map[string]any{"year": "2021"},
+	}
+}
+
+func populatedMetadata(
+	_ context.Context,
+	id, name string,
+	size int64,
+	bucketID, etag string,
+	isUploaded bool,
+	mimeType, objectKey string,
+	_, _ int64,
+	_, _, _ string,
+	md map[string]any,
+	_ []controller.WebhookEvent,
+	_ http.Header,
+) (controller.FileMetadata, *controller.APIError) {
+	return controller.FileMetadata{ //nolint: exhaustruct
+		ID:         id,
+		Name:       name,
+		Size:       size,
+		BucketID:   bucketID,
+		ETag:       etag,
+		IsUploaded: isUploaded,
+		MimeType:   mimeType,
+		ObjectKey:  objectKey,
+		Metadata:   md,
+	}, nil
+}
+
+func TestCopyFile(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name           string
+		body           string
+		bucket         controller.BucketMetadata
+		expectedStatus int
+		expectedName   string
+		expectedPrefix string
+	}{
+		{
+			name: "to another bucket",
+			body: `{"bucketId":"archive","name":"report-2021.pdf","objectPrefix":"2021"}`,
+			bucket: controller.BucketMetadata{ //nolint: exhaustruct
+				ID:            "archive",
+				MaxUploadFile: 2048,
+			},
+			expectedStatus: http.StatusCreated,
+			expectedName:   "report-2021.pdf",
+			expectedPrefix: "2021/",
+		},
+		{
+			name: "defaults to the source",
+			body: ``,
+			bucket: controller.BucketMetadata{ //nolint: exhaustruct
+				ID:            "default",
+				MaxUploadFile: 2048,
+			},
+			expectedStatus: http.StatusCreated,
+			expectedName:   "report.pdf",
+			expectedPrefix: "reports/",
+		},
+		{
+			name: "too big",
+			body: `{"bucketId":"archive"}`,
+			bucket: controller.BucketMetadata{ //nolint: exhaustruct
+				ID:            "archive",
+				MaxUploadFile: 512,
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "type not allowed",
+			body: `{"bucketId":"archive"}`,
+			bucket: controller.BucketMetadata{ //nolint: exhaustruct
+				ID:               "archive",
+				MaxUploadFile:    2048,
+				AllowedMimeTypes: []string{"image/*"},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			metadataStorage.EXPECT().GetFileByID(
+				gomock.Any(), copySourceID, gomock.Any(),
+			).Return(copySource(), nil)
+			metadataStorage.EXPECT().GetBucketByID(
+				gomock.Any(), "default", gomock.Any(),
+			).Return(controller.BucketMetadata{ID: "default"}, nil) //nolint: exhaustruct
+			metadataStorage.EXPECT().GetBucketByID(
+				gomock.Any(), tc.bucket.ID, gomock.Any(),
+			).Return(tc.bucket, nil)
+
+			if tc.expectedStatus == http.StatusCreated {
+				metadataStorage.EXPECT().InitializeFile(
+					gomock.Any(), gomock.Any(), tc.expectedName, int64(1024), tc.bucket.ID,
+					"application/pdf", gomock.Any(), int64(1024), int64(1), "", "", gomock.Any(),
+				).Return(nil)
+				contentStorage.EXPECT().CopyFile(
+					gomock.Any(), "reports/"+copySourceID, gomock.Any(), int64(1024),
+				).Return(`"copy-etag"`, nil)
+				metadataStorage.EXPECT().PopulateMetadata(
+					gomock.Any(), gomock.Any(), tc.expectedName, int64(1024), tc.bucket.ID,
+					`"copy-etag"`, true, "application/pdf", gomock.Any(), int64(1024), int64(1),
+					"", "", "", map[string]any{"year": "2021"}, gomock.Any(), gomock.Any(),
+				).DoAndReturn(populatedMetadata)
+			}
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(),
+				"POST",
+				"/v1/files/"+copySourceID+"/copy",
+				strings.NewReader(tc.body),
+			)
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+
+			if tc.expectedStatus != http.StatusCreated {
+				return
+			}
+
+			var resp struct {
+				Data controller.FileMetadata `json:"data"`
+			}
+			if err := json.Unmarshal(responseRecorder.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.Data.ID == copySourceID ||
+				resp.Data.ObjectKey != tc.expectedPrefix+resp.Data.ID {
+				t.Errorf("unexpected copy %+v", resp.Data)
+			}
+		})
+	}
+}
+
+func TestMoveFile(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name              string
+		body              string
+		expectedBucket    string
+		expectedName      string
+		expectedObjectKey string
+	}{
+		{
+			name:              "rename",
+			body:              `{"name":"report-2021.pdf"}`,
+			expectedBucket:    "default",
+			expectedName:      "report-2021.pdf",
+			expectedObjectKey: "reports/" + copySourceID,
+		},
+		{
+			name:              "to another bucket and prefix",
+			body:              `{"bucketId":"archive","objectPrefix":"2021"}`,
+			expectedBucket:    "archive",
+			expectedName:      "report.pdf",
+			expectedObjectKey: "2021/" + copySourceID,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			metadataStorage.EXPECT().GetFileByID(
+				gomock.Any(), copySourceID, gomock.Any(),
+			).Return(copySource(), nil)
+			metadataStorage.EXPECT().GetBucketByID(
+				gomock.Any(), "default", gomock.Any(),
+			).Return(controller.BucketMetadata{ //nolint: exhaustruct
+				ID:            "default",
+				MaxUploadFile: 2048,
+			}, nil).MinTimes(1)
+			if tc.expectedBucket != "default" {
+				metadataStorage.EXPECT().GetBucketByID(
+					gomock.Any(), tc.expectedBucket, gomock.Any(),
+				).Return(controller.BucketMetadata{ //nolint: exhaustruct
+					ID:            tc.expectedBucket,
+					MaxUploadFile: 2048,
+				}, nil)
+			}
+
+			etag := `"some-etag"`
+			if tc.expectedObjectKey != "reports/"+copySourceID {
+				etag = `"copy-etag"`
+				contentStorage.EXPECT().CopyFile(
+					gomock.Any(), "reports/"+copySourceID, tc.expectedObjectKey, int64(1024),
+				).Return(etag, nil)
+				contentStorage.EXPECT().DeleteFile(
+					gomock.Any(), "reports/"+copySourceID,
+				).Return(nil)
+			}
+
+			metadataStorage.EXPECT().PopulateMetadata(
+				gomock.Any(), copySourceID, tc.expectedName, int64(1024), tc.expectedBucket,
+				etag, true, "application/pdf", tc.expectedObjectKey, int64(1024), int64(1),
+				"", "", "", map[string]any{"year": "2021"}, gomock.Any(), gomock.Any(),
+			).DoAndReturn(populatedMetadata)
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(),
+				"POST",
+				"/v1/files/"+copySourceID+"/move",
+				strings.NewReader(tc.body),
+			)
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, http.StatusOK, responseRecorder.Code)
+		})
+	}
+}
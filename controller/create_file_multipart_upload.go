@@ -17,6 +17,7 @@ type fileMultipartUploadData struct {
 	ChunkCount  int64  `json:"chunkCount"`
 	FileName    string `json:"fileName"`
 	ContentType string `json:"contentType"`
+	ExpiresAt   string `json:"expiresAt"`
 }
 
 type createFileMultipartUploadRequest struct {
@@ -67,6 +68,12 @@ func parseCreateFileMultipartUploadRequest(ctx *gin.Context) (createFileMultipar
 			json.Files[i].ContentType = "application/octet-stream"
 		}
 
+		expiresAt, apiErr := parseExpiresAt(file.ExpiresAt)
+		if apiErr != nil {
+			return createFileMultipartUploadRequest{}, apiErr
+		}
+		json.Files[i].ExpiresAt = expiresAt
+
 		json.Files[i].ChunkCount = int64(math.Ceil(float64(file.Size) / float64(file.ChunkSize)))
 
 		if json.Files[i].ChunkCount > 1 && file.ChunkSize < 5*1024*1024 {
@@ -84,10 +91,14 @@ func (ctrl *Controller) createFileMultipartUploadProcess(ctx *gin.Context) ([]Fi
 		return nil, apiErr
 	}
 
+	if apiErr := checkAPIKeyBucket(ctx.Request.Header, req.BucketID); apiErr != nil {
+		return nil, apiErr
+	}
+
 	bucket, err := ctrl.metadataStorage.GetBucketByID(
 		ctx,
 		req.BucketID,
-		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret}},
+		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}},
 	)
 	if err != nil {
 		return nil, err
@@ -96,6 +107,8 @@ func (ctrl *Controller) createFileMultipartUploadProcess(ctx *gin.Context) ([]Fi
 	minSize := bucket.MinUploadFile
 	maxSize := bucket.MaxUploadFile
 
+	uploadedBy, uploadedByRole := ctrl.sessionVariables(ctx, ctx.Request.Header)
+
 	fileMetadatas := make([]FileMetadata, 0, len(req.Files))
 
 	for _, file := range req.Files {
@@ -105,8 +118,33 @@ func (ctrl *Controller) createFileMultipartUploadProcess(ctx *gin.Context) ([]Fi
 			return nil, FileTooBigError(file.FileName, int(file.Size), maxSize)
 		}
 
+		// the content isn't available yet so we can only check the declared type
+		if apiErr := checkFileType(bucket, file.FileName, file.ContentType); apiErr != nil {
+			return nil, apiErr
+		}
+
 		fileId := uuid.New().String()
-		objectKey, joinErr := url.JoinPath(req.ObjectPrefix, fileId)
+
+		hookReq, apiErr := ctrl.checkUpload(ctx, UploadHookRequest{ //nolint: exhaustruct
+			Operation:    UploadOperationMultipart,
+			FileID:       fileId,
+			BucketID:     bucket.ID,
+			Name:         file.FileName,
+			Size:         file.Size,
+			MimeType:     file.ContentType,
+			ObjectPrefix: req.ObjectPrefix,
+		}, ctx.Request.Header)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+
+		if apiErr := ctrl.checkQuota(
+			ctx, ctx.Request.Header, bucket.ID, file.Size, 1,
+		); apiErr != nil {
+			return nil, apiErr
+		}
+
+		objectKey, joinErr := url.JoinPath(hookReq.ObjectPrefix, fileId)
 		if joinErr != nil {
 			return nil, InternalServerError(
 				fmt.Errorf("problem joining path: %w", joinErr),
@@ -119,12 +157,26 @@ func (ctrl *Controller) createFileMultipartUploadProcess(ctx *gin.Context) ([]Fi
 		}
 
 		if err := ctrl.metadataStorage.InitializeFile(
-			ctx, fileId, file.FileName, file.Size, bucket.ID, file.ContentType, objectKey, file.ChunkSize, file.ChunkCount, uploadId, ctx.Request.Header,
+			ctx, fileId, file.FileName, file.Size, bucket.ID, file.ContentType, objectKey, file.ChunkSize, file.ChunkCount, uploadId, file.ExpiresAt, ctx.Request.Header,
 		); err != nil {
 			return nil, err
 		}
 
-		fileMetadata, apiErr := ctrl.metadataStorage.GetFileByID(ctx, fileId, http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret}})
+		// InitializeFile doesn't take metadata so the one injected by the hook is stored
+		// here and picked up again when the upload is completed. The uploader is written
+		// here as well, with the admin secret so roles don't need to be allowed to set it
+		if len(hookReq.Metadata) > 0 || uploadedBy != "" {
+			if _, apiErr := ctrl.metadataStorage.PopulateMetadata(
+				ctx,
+				fileId, file.FileName, file.Size, bucket.ID, "", false, file.ContentType, objectKey, file.ChunkSize, file.ChunkCount, uploadId, uploadedBy, uploadedByRole, hookReq.Metadata,
+				nil,
+				http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}},
+			); apiErr != nil {
+				return nil, apiErr.ExtendError("problem populating file metadata for file " + file.FileName)
+			}
+		}
+
+		fileMetadata, apiErr := ctrl.metadataStorage.GetFileByID(ctx, fileId, http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}})
 		if apiErr != nil {
 			return nil, apiErr
 		}
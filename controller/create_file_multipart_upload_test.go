@@ -0,0 +1,559 @@
+package controller_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestCreateFileMultipartUploadHook(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name              string
+		hookResponse      controller.UploadHookResponse
+		expectedStatus    int
+		expectedObjectKey string
+		expectedMetadata  map[string]any
+	}{
+		{
+			name: "allowed",
+			hookResponse: controller.UploadHookResponse{ //nolint: exhaustruct
+				Allow: true,
+			},
+			expectedStatus:    http.StatusOK,
+			expectedObjectKey: "user-prefix/",
+		},
+		{
+			name: "prefix rewritten and metadata injected",
+			hookResponse: controller.UploadHookResponse{ //nolint: exhaustruct
+				Allow:        true,
+				ObjectPrefix: ptr("tenant-1"),
+				Metadata:     map[string]any{"tenant": "tenant-1"},
+			},
+			expectedStatus:    http.StatusOK,
+			expectedObjectKey: "tenant-1/",
+			expectedMetadata:  map[string]any{"tenant": "tenant-1"},
+		},
+		{
+			name: "denied",
+			hookResponse: controller.UploadHookResponse{ //nolint: exhaustruct
+				Allow:   false,
+				Message: "quota exceeded",
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+			uploadHook := mock.NewMockUploadHook(c)
+
+			metadataStorage.EXPECT().GetBucketByID(
+				gomock.Any(), "default", gomock.Any(),
+			).Return(controller.BucketMetadata{ //nolint: exhaustruct
+				ID:            "default",
+				MinUploadFile: 0,
+				MaxUploadFile: 100,
+			}, nil)
+
+			uploadHook.EXPECT().CheckUpload(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, req controller.UploadHookRequest) (controller.UploadHookResponse, *controller.APIError) {
+					assert(t, controller.UploadOperationMultipart, req.Operation)
+					assert(t, "default", req.BucketID)
+					assert(t, "file.txt", req.Name)
+					assert(t, int64(10), req.Size)
+					assert(t, "text/plain", req.MimeType)
+					assert(t, "user-prefix", req.ObjectPrefix)
+					return tc.hookResponse, nil
+				},
+			)
+
+			if tc.expectedStatus == http.StatusOK {
+				contentStorage.EXPECT().CreateMultipartUpload(
+					gomock.Any(), gomock.Any(), "text/plain",
+				).DoAndReturn(func(_ context.Context, filepath, _ string) (string, *controller.APIError) {
+					if !strings.HasPrefix(filepath, tc.expectedObjectKey) {
+						t.Errorf("unexpected object key %s", filepath)
+					}
+					return "upload-id", nil
+				})
+
+				metadataStorage.EXPECT().InitializeFile(
+					gomock.Any(), gomock.Any(), "file.txt", int64(10), "default", "text/plain",
+					gomock.Any(), int64(10), int64(1), "upload-id", "", gomock.Any(),
+				).Return(nil)
+
+				if tc.expectedMetadata != nil {
+					metadataStorage.EXPECT().PopulateMetadata(
+						gomock.Any(), gomock.Any(), "file.txt", int64(10), "default", "", false, "text/plain",
+						gomock.Any(), int64(10), int64(1), "upload-id", "", "", tc.expectedMetadata, gomock.Any(), gomock.Any(),
+					).Return(controller.FileMetadata{}, nil) //nolint: exhaustruct
+				}
+
+				metadataStorage.EXPECT().GetFileByID(
+					gomock.Any(), gomock.Any(), gomock.Any(),
+				).Return(controller.FileMetadata{}, nil) //nolint: exhaustruct
+			}
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				uploadHook,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(),
+				"POST",
+				"/v1/files/multipart",
+				strings.NewReader(
+					`{"objectPrefix":"user-prefix","files":[{"size":10,"chunkSize":10,"fileName":"file.txt","contentType":"text/plain"}]}`,
+				),
+			)
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+		})
+	}
+}
+
+func TestCreateFileMultipartUploadFileTypePolicy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name           string
+		bucket         controller.BucketMetadata
+		fileName       string
+		contentType    string
+		expectedStatus int
+	}{
+		{
+			name: "no policy",
+			bucket: controller.BucketMetadata{ //nolint: exhaustruct
+				ID:            "default",
+				MaxUploadFile: 100,
+			},
+			fileName:       "script.sh",
+			contentType:    "text/x-shellscript",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "wildcard allowed",
+			bucket: controller.BucketMetadata{ //nolint: exhaustruct
+				ID:               "default",
+				MaxUploadFile:    100,
+				AllowedMimeTypes: []string{"image/*", "application/pdf"},
+			},
+			fileName:       "picture.png",
+			contentType:    "image/png",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "mime type not allowed",
+			bucket: controller.BucketMetadata{ //nolint: exhaustruct
+				ID:               "default",
+				MaxUploadFile:    100,
+				AllowedMimeTypes: []string{"image/*", "application/pdf"},
+			},
+			fileName:       "page.html",
+			contentType:    "text/html; charset=utf-8",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "mime type denied",
+			bucket: controller.BucketMetadata{ //nolint: exhaustruct
+				ID:              "default",
+				MaxUploadFile:   100,
+				DeniedMimeTypes: []string{"image/svg+xml"},
+			},
+			fileName:       "picture.svg",
+			contentType:    "image/svg+xml",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "extension denied",
+			bucket: controller.BucketMetadata{ //nolint: exhaustruct
+				ID:               "default",
+				MaxUploadFile:    100,
+				DeniedExtensions: []string{".exe"},
+			},
+			fileName:       "setup.EXE",
+			contentType:    "application/octet-stream",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "extension not allowed",
+			bucket: controller.BucketMetadata{ //nolint: exhaustruct
+				ID:                "default",
+				MaxUploadFile:     100,
+				AllowedExtensions: []string{"jpg", "png"},
+			},
+			fileName:       "picture",
+			contentType:    "image/png",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			metadataStorage.EXPECT().GetBucketByID(
+				gomock.Any(), "default", gomock.Any(),
+			).Return(tc.bucket, nil)
+
+			if tc.expectedStatus == http.StatusOK {
+				contentStorage.EXPECT().CreateMultipartUpload(
+					gomock.Any(), gomock.Any(), tc.contentType,
+				).Return("upload-id", nil)
+
+				metadataStorage.EXPECT().InitializeFile(
+					gomock.Any(), gomock.Any(), tc.fileName, int64(10), "default", tc.contentType,
+					gomock.Any(), int64(10), int64(1), "upload-id", "", gomock.Any(),
+				).Return(nil)
+
+				metadataStorage.EXPECT().GetFileByID(
+					gomock.Any(), gomock.Any(), gomock.Any(),
+				).Return(controller.FileMetadata{}, nil) //nolint: exhaustruct
+			}
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+			responseRecorder := httptest.NewRecorder()
+
+			body, _ := json.Marshal(map[string]any{
+				"files": []map[string]any{
+					{"size": 10, "chunkSize": 10, "fileName": tc.fileName, "contentType": tc.contentType},
+				},
+			})
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(),
+				"POST",
+				"/v1/files/multipart",
+				bytes.NewReader(body),
+			)
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+		})
+	}
+}
+
+func TestCreateFileMultipartUploadQuota(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name           string
+		quota          controller.Quota
+		expectedStatus int
+	}{
+		{
+			name: "within quota",
+			quota: controller.Quota{ //nolint: exhaustruct
+				ID:       "a",
+				MaxBytes: 110,
+				MaxFiles: 4,
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "bytes exceeded",
+			quota: controller.Quota{ //nolint: exhaustruct
+				ID:       "a",
+				MaxBytes: 104,
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "files exceeded",
+			quota: controller.Quota{ //nolint: exhaustruct
+				ID:       "a",
+				BucketID: "default",
+				MaxFiles: 2,
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "other bucket",
+			quota: controller.Quota{ //nolint: exhaustruct
+				ID:       "a",
+				BucketID: "images",
+				MaxFiles: 1,
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			metadataStorage.EXPECT().GetBucketByID(
+				gomock.Any(), "default", gomock.Any(),
+			).Return(controller.BucketMetadata{ //nolint: exhaustruct
+				ID:            "default",
+				MaxUploadFile: 100,
+			}, nil)
+
+			metadataStorage.EXPECT().GetQuotas(
+				gomock.Any(), "ab5ba58e-932a-40dc-87e8-733998794ec2", "user", "default", gomock.Any(),
+			).Return([]controller.Quota{tc.quota}, nil)
+
+			metadataStorage.EXPECT().GetUsage(
+				gomock.Any(), "ab5ba58e-932a-40dc-87e8-733998794ec2", gomock.Any(),
+			).Return([]controller.Usage{
+				{BucketID: "default", Bytes: 90, Files: 2},
+				{BucketID: "images", Bytes: 10, Files: 1},
+			}, nil)
+
+			if tc.expectedStatus == http.StatusOK {
+				contentStorage.EXPECT().CreateMultipartUpload(
+					gomock.Any(), gomock.Any(), "text/plain",
+				).Return("upload-id", nil)
+
+				metadataStorage.EXPECT().InitializeFile(
+					gomock.Any(), gomock.Any(), "file.txt", int64(5), "default", "text/plain",
+					gomock.Any(), int64(5), int64(1), "upload-id", "", gomock.Any(),
+				).Return(nil)
+
+				metadataStorage.EXPECT().PopulateMetadata(
+					gomock.Any(), gomock.Any(), "file.txt", int64(5), "default", "", false, "text/plain",
+					gomock.Any(), int64(5), int64(1), "upload-id", "ab5ba58e-932a-40dc-87e8-733998794ec2",
+					"user", gomock.Any(), gomock.Any(), gomock.Any(),
+				).Return(controller.FileMetadata{}, nil) //nolint: exhaustruct
+
+				metadataStorage.EXPECT().GetFileByID(
+					gomock.Any(), gomock.Any(), gomock.Any(),
+				).Return(controller.FileMetadata{}, nil) //nolint: exhaustruct
+			}
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(
+				nil, "/v1", []string{"*"}, false, ginLogger(logger), verifyJWT(t),
+			)
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(),
+				"POST",
+				"/v1/files/multipart",
+				strings.NewReader(
+					`{"files":[{"size":5,"chunkSize":5,"fileName":"file.txt","contentType":"text/plain"}]}`,
+				),
+			)
+			req.Header.Set("Authorization", bearer(t, "ab5ba58e-932a-40dc-87e8-733998794ec2", "user"))
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+		})
+	}
+}
+
+func TestCreateFileMultipartUploadUploadedBy(t *testing.T) {
+	t.Parallel()
+
+	const userID = "ab5ba58e-932a-40dc-87e8-733998794ec2"
+
+	cases := []struct {
+		name               string
+		headers            http.Header
+		expectedUploadedBy string
+	}{
+		{
+			name: "verified token",
+			headers: http.Header{
+				"Authorization": []string{bearer(t, userID, "user")},
+			},
+			expectedUploadedBy: userID,
+		},
+		{
+			name: "admin secret",
+			headers: http.Header{
+				"X-Hasura-Admin-Secret": []string{"asdasd"},
+				"X-Hasura-User-Id":      []string{userID},
+				"X-Hasura-Role":         []string{"user"},
+			},
+			expectedUploadedBy: userID,
+		},
+		{
+			name: "spoofed session headers",
+			headers: http.Header{
+				"X-Hasura-User-Id": []string{userID},
+				"X-Hasura-Role":    []string{"user"},
+			},
+			expectedUploadedBy: "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			metadataStorage.EXPECT().GetBucketByID(
+				gomock.Any(), "default", gomock.Any(),
+			).Return(controller.BucketMetadata{ //nolint: exhaustruct
+				ID:            "default",
+				MaxUploadFile: 100,
+			}, nil)
+
+			if tc.expectedUploadedBy != "" {
+				metadataStorage.EXPECT().GetQuotas(
+					gomock.Any(), tc.expectedUploadedBy, "user", "default", gomock.Any(),
+				).Return(nil, nil)
+
+				metadataStorage.EXPECT().GetUsage(
+					gomock.Any(), tc.expectedUploadedBy, gomock.Any(),
+				).Return(nil, nil)
+			}
+
+			contentStorage.EXPECT().CreateMultipartUpload(
+				gomock.Any(), gomock.Any(), "text/plain",
+			).Return("upload-id", nil)
+
+			// the uploader is left out of the insert, which runs with the headers of the
+			// caller, and written with the admin secret afterwards
+			metadataStorage.EXPECT().InitializeFile(
+				gomock.Any(), gomock.Any(), "file.txt", int64(5), "default", "text/plain",
+				gomock.Any(), int64(5), int64(1), "upload-id", "", tc.headers,
+			).Return(nil)
+
+			if tc.expectedUploadedBy != "" {
+				metadataStorage.EXPECT().PopulateMetadata(
+					gomock.Any(), gomock.Any(), "file.txt", int64(5), "default", "", false, "text/plain",
+					gomock.Any(), int64(5), int64(1), "upload-id", tc.expectedUploadedBy,
+					"user", gomock.Any(), gomock.Any(),
+					http.Header{"x-hasura-admin-secret": []string{"asdasd"}},
+				).Return(controller.FileMetadata{}, nil) //nolint: exhaustruct
+			}
+
+			metadataStorage.EXPECT().GetFileByID(
+				gomock.Any(), gomock.Any(), gomock.Any(),
+			).Return(controller.FileMetadata{}, nil) //nolint: exhaustruct
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(
+				nil, "/v1", []string{"*"}, false, ginLogger(logger), verifyJWT(t),
+			)
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(),
+				"POST",
+				"/v1/files/multipart",
+				strings.NewReader(
+					`{"files":[{"size":5,"chunkSize":5,"fileName":"file.txt","contentType":"text/plain"}]}`,
+				),
+			)
+			req.Header = tc.headers
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, http.StatusOK, responseRecorder.Code)
+		})
+	}
+}
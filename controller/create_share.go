@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const shareTokenBytes = 32
+
+// image manipulation parameters that can be allowed in a share.
+var shareTransformations = []string{"w", "h", "q", "b"} //nolint: gochecknoglobals
+
+type CreateShareRequest struct {
+	// ExpiresIn is the number of seconds the share is valid for, zero means it never
+	// expires
+	ExpiresIn              int64    `json:"expiresIn"`
+	Password               string   `json:"password"`
+	MaxDownloads           int64    `json:"maxDownloads"`
+	AllowedTransformations []string `json:"allowedTransformations"`
+}
+
+type CreateShareResponse struct {
+	Share Share  `json:"share"`
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
+
+func hashShareToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+func newShareToken() (string, error) {
+	b := make([]byte, shareTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("problem generating token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func parseCreateShareRequest(ctx *gin.Context) (CreateShareRequest, *APIError) {
+	var req CreateShareRequest
+	if err := json.NewDecoder(ctx.Request.Body).Decode(&req); err != nil &&
+		!errors.Is(err, io.EOF) {
+		return CreateShareRequest{}, BadDataError(err, "couldn't decode request")
+	}
+
+	if req.ExpiresIn < 0 || req.MaxDownloads < 0 {
+		msg := "expiresIn and maxDownloads can't be negative"
+		return CreateShareRequest{}, BadDataError(errors.New(msg), msg) //nolint: goerr113
+	}
+
+	for _, t := range req.AllowedTransformations {
+		if !slices.Contains(shareTransformations, t) {
+			msg := fmt.Sprintf("unknown transformation %s", t)
+			return CreateShareRequest{}, BadDataError(errors.New(msg), msg) //nolint: goerr113
+		}
+	}
+
+	return req, nil
+}
+
+func (ctrl *Controller) createShare(ctx *gin.Context) (CreateShareResponse, *APIError) {
+	req, apiErr := parseCreateShareRequest(ctx)
+	if apiErr != nil {
+		return CreateShareResponse{}, apiErr
+	}
+
+	// only users who can read the file can share it
+	fileMetadata, _, apiErr := ctrl.getFileMetadata(
+		ctx.Request.Context(), ctx.Param("id"), true, ctx.Request.Header,
+	)
+	if apiErr != nil {
+		return CreateShareResponse{}, apiErr
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return CreateShareResponse{}, InternalServerError(err)
+	}
+
+	// callers without a verified session are left to the permissions of the shares
+	// table, which can preset the creator
+	userID, headers, _ := ctrl.sessionAccess(ctx, ctx.Request.Header)
+	share := Share{ //nolint: exhaustruct
+		FileID:                 fileMetadata.ID,
+		CreatedByUserID:        userID,
+		MaxDownloads:           req.MaxDownloads,
+		AllowedTransformations: req.AllowedTransformations,
+	}
+
+	if req.ExpiresIn > 0 {
+		share.ExpiresAt = time.Now().Add(time.Duration(req.ExpiresIn) * time.Second).
+			Format(time.RFC3339)
+	}
+
+	if req.Password != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return CreateShareResponse{}, BadDataError(err, "invalid password")
+		}
+		share.PasswordHash = string(hash)
+	}
+
+	share, apiErr = ctrl.metadataStorage.InsertShare(
+		ctx.Request.Context(),
+		share,
+		hashShareToken(token),
+		headers,
+	)
+	if apiErr != nil {
+		return CreateShareResponse{}, apiErr
+	}
+
+	return CreateShareResponse{
+		Share: share,
+		Token: token,
+		URL:   fmt.Sprintf("%s%s/shares/%s", ctrl.publicURL, ctrl.apiRootPrefix, token),
+	}, nil
+}
+
+func (ctrl *Controller) CreateShare(ctx *gin.Context) {
+	res, apiErr := ctrl.createShare(ctx)
+	if apiErr != nil {
+		_ = ctx.Error(fmt.Errorf("problem processing request: %w", apiErr))
+
+		ctx.JSON(apiErr.statusCode, CommonResponse{
+			Code:    apiErr.statusCode,
+			Message: apiErr.PublicResponse().Message,
+		})
+
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, CommonResponse{http.StatusCreated, "ok", res})
+}
@@ -0,0 +1,183 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type shareMatcher struct {
+	password  string
+	createdBy string
+}
+
+func (m shareMatcher) Matches(x interface{}) bool {
+	share, ok := x.(controller.Share)
+	if !ok {
+		return false
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(share.PasswordHash), []byte(m.password)) != nil {
+		return false
+	}
+
+	return share.FileID == "55af1e60-0f28-454e-885e-ea6aab2bb288" &&
+		share.CreatedByUserID == m.createdBy &&
+		share.MaxDownloads == 3 &&
+		share.ExpiresAt != "" &&
+		len(share.AllowedTransformations) == 1 && share.AllowedTransformations[0] == "w"
+}
+
+func (m shareMatcher) String() string {
+	return "share with password " + m.password
+}
+
+func TestCreateShare(t *testing.T) {
+	t.Parallel()
+
+	const fileID = "55af1e60-0f28-454e-885e-ea6aab2bb288"
+
+	const body = `{"expiresIn":3600,"password":"hunter2","maxDownloads":3,"allowedTransformations":["w"]}`
+
+	cases := []struct {
+		name              string
+		body              string
+		headers           http.Header
+		unverified        bool
+		expectedCreatedBy string
+		expectedHeaders   http.Header
+		expectedStatus    int
+	}{
+		{
+			name: "success",
+			body: body,
+			headers: http.Header{
+				"Authorization": []string{bearer(t, "ab5ba58e-932a-40dc-87e8-733998794ec2", "user")},
+			},
+			expectedCreatedBy: "ab5ba58e-932a-40dc-87e8-733998794ec2",
+			expectedHeaders:   http.Header{"x-hasura-admin-secret": []string{"asdasd"}},
+			expectedStatus:    http.StatusCreated,
+		},
+		{
+			name: "spoofed session headers",
+			body: body,
+			headers: http.Header{
+				"X-Hasura-User-Id": []string{"ab5ba58e-932a-40dc-87e8-733998794ec2"},
+				"X-Hasura-Role":    []string{"user"},
+			},
+			unverified:        true,
+			expectedCreatedBy: "",
+			expectedHeaders: http.Header{
+				"X-Hasura-User-Id": []string{"ab5ba58e-932a-40dc-87e8-733998794ec2"},
+				"X-Hasura-Role":    []string{"user"},
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "unknown transformation",
+			body:           `{"allowedTransformations":["rotate"]}`,
+			headers:        http.Header{},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			if tc.expectedStatus == http.StatusCreated {
+				metadataStorage.EXPECT().GetFileByID(
+					gomock.Any(), fileID, gomock.Any(),
+				).Return(controller.FileMetadata{ //nolint: exhaustruct
+					ID:         fileID,
+					BucketID:   "default",
+					IsUploaded: true,
+				}, nil)
+
+				metadataStorage.EXPECT().GetBucketByID(
+					gomock.Any(), "default", gomock.Any(),
+				).Return(controller.BucketMetadata{ID: "default"}, nil) //nolint: exhaustruct
+
+				metadataStorage.EXPECT().InsertShare(
+					gomock.Any(),
+					shareMatcher{"hunter2", tc.expectedCreatedBy},
+					gomock.Any(),
+					tc.expectedHeaders,
+				).DoAndReturn(func(
+					_ context.Context, share controller.Share, _ string, _ http.Header,
+				) (controller.Share, *controller.APIError) {
+					share.ID = "8a9c3d6b-55a4-4b0a-bc5c-3f1e7f3c2a10"
+					return share, nil
+				})
+			}
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			middleware := []gin.HandlerFunc{ginLogger(logger)}
+			if !tc.unverified {
+				middleware = append(middleware, verifyJWT(t))
+			}
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, middleware...)
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(), "POST", "/v1/files/"+fileID+"/shares", strings.NewReader(tc.body),
+			)
+			req.Header = tc.headers
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+
+			if tc.expectedStatus != http.StatusCreated {
+				return
+			}
+
+			var resp struct {
+				Data controller.CreateShareResponse `json:"data"`
+			}
+			if err := json.Unmarshal(responseRecorder.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.Data.Token == "" ||
+				resp.Data.URL != "http://asd/v1/shares/"+resp.Data.Token {
+				t.Errorf("unexpected response %+v", resp.Data)
+			}
+		})
+	}
+}
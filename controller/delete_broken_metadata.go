@@ -14,7 +14,9 @@ func (ctrl *Controller) deleteBrokenMetadata(ctx *gin.Context) ([]FileSummary, *
 	}
 
 	for _, m := range missing {
-		if apiErr := ctrl.metadataStorage.DeleteFileByID(ctx.Request.Context(), m.ID, ctx.Request.Header); apiErr != nil {
+		if apiErr := ctrl.metadataStorage.DeleteFileByID(
+			ctx.Request.Context(), m.ID, FileCondition{}, nil, ctx.Request.Header,
+		); apiErr != nil {
 			return nil, apiErr
 		}
 	}
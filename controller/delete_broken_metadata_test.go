@@ -9,6 +9,7 @@ import (
 
 	"github.com/nhost/hasura-storage/controller"
 	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
 	"github.com/sirupsen/logrus"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -55,7 +56,7 @@ func TestDeleteBrokenMetadata(t *testing.T) {
 			contentStorage := mock.NewMockContentStorage(c)
 
 			metadataStorage.EXPECT().ListFiles(
-				gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), gomock.Any(),
 			).Return(
 				[]controller.FileSummary{
 					{
@@ -92,20 +93,27 @@ func TestDeleteBrokenMetadata(t *testing.T) {
 			)
 
 			metadataStorage.EXPECT().DeleteFileByID(
-				gomock.Any(), "b3b4e653-ca59-412c-a165-92d251c3fe86", gomock.Any(),
+				gomock.Any(), "b3b4e653-ca59-412c-a165-92d251c3fe86", controller.FileCondition{},
+				gomock.Any(),
+				gomock.Any(),
 			).Return(nil)
 			metadataStorage.EXPECT().DeleteFileByID(
-				gomock.Any(), "e6aad336-ad79-4df7-a09b-5782f71948f4", gomock.Any(),
+				gomock.Any(), "e6aad336-ad79-4df7-a09b-5782f71948f4", controller.FileCondition{},
+				gomock.Any(),
+				gomock.Any(),
 			).Return(nil)
 
 			ctrl := controller.New(
 				"http://asd",
 				"/v1",
-				"asdasd",
+				auth.NewAdminSecrets("asdasd"),
 				metadataStorage,
 				contentStorage,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
 				logger,
 			)
 
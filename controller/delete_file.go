@@ -7,19 +7,103 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// trashFile moves the file to the trash instead of deleting it, the content is kept
+// until the file is purged.
+func (ctrl *Controller) trashFile(
+	ctx *gin.Context, fileMetadata FileMetadata, condition FileCondition,
+) *APIError {
+	deletedBy, _ := ctrl.sessionVariables(ctx, ctx.Request.Header)
+
+	fileMetadata, apiErr := ctrl.metadataStorage.TrashFile(
+		ctx.Request.Context(), fileMetadata.ID, deletedBy, condition, ctx.Request.Header,
+	)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	ctx.Set("FileChanged", fileMetadata.ID)
+	ctrl.notify(ctx, Event{Type: EventFileTrashed, BucketID: fileMetadata.BucketID, Data: fileMetadata})
+
+	return nil
+}
+
 func (ctrl *Controller) deleteFile(ctx *gin.Context) *APIError {
 	id := ctx.Param("id")
 
-	apiErr := ctrl.metadataStorage.DeleteFileByID(ctx.Request.Context(), id, ctx.Request.Header)
+	// metadata is gone once the file is deleted so we need to fetch it beforehand to
+	// include it in the event, check the bucket of the api key, evaluate the
+	// preconditions of the request and know if the bucket keeps deleted files in the
+	// trash. Permissions are checked when the file is deleted.
+	fileMetadata, apiErr := ctrl.metadataStorage.GetFileByID(
+		ctx.Request.Context(),
+		id,
+		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}},
+	)
 	if apiErr != nil {
 		return apiErr
 	}
 
-	if apiErr := ctrl.contentStorage.DeleteFile(ctx, id); apiErr != nil {
+	if apiErr := checkAPIKeyBucket(ctx.Request.Header, fileMetadata.BucketID); apiErr != nil {
 		return apiErr
 	}
 
+	if fileMetadata.DeletedAt != "" {
+		return ErrFileNotFound
+	}
+
+	if apiErr := checkFileLock(fileMetadata); apiErr != nil {
+		return apiErr
+	}
+
+	bucketMetadata, apiErr := ctrl.metadataStorage.GetBucketByID(
+		ctx.Request.Context(),
+		fileMetadata.BucketID,
+		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}},
+	)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	condition, apiErr := checkWritePreconditions(ctx, fileMetadata)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	if bucketMetadata.SoftDeleteEnabled {
+		return ctrl.trashFile(ctx, fileMetadata, condition)
+	}
+
+	// versions are deleted with the file so they need to be listed beforehand to delete
+	// their objects
+	versions, apiErr := ctrl.metadataStorage.ListFileVersions(
+		ctx.Request.Context(),
+		id,
+		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}},
+	)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	apiErr = ctrl.metadataStorage.DeleteFileByID(
+		ctx.Request.Context(), id, condition, nil, ctx.Request.Header,
+	)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	if apiErr := ctrl.contentStorage.DeleteFile(ctx, FileObjectKey(fileMetadata)); apiErr != nil {
+		return apiErr
+	}
+
+	// the file is already gone so versions that can't be deleted are left as orphans
+	for _, version := range versions {
+		if apiErr := ctrl.contentStorage.DeleteFile(ctx, version.ObjectKey); apiErr != nil {
+			ctrl.logger.WithError(apiErr).Error("problem deleting version " + version.ID + " of file " + id)
+		}
+	}
+
 	ctx.Set("FileChanged", id)
+	ctrl.notify(ctx, Event{Type: EventFileDeleted, BucketID: fileMetadata.BucketID, Data: fileMetadata})
 
 	return nil
 }
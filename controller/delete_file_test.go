@@ -8,6 +8,7 @@ import (
 
 	"github.com/nhost/hasura-storage/controller"
 	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
 	"github.com/sirupsen/logrus"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -16,14 +17,20 @@ func TestDeleteFile(t *testing.T) {
 	t.Parallel()
 
 	cases := []struct {
-		name             string
-		expectedStatus   int
-		expectedResponse []byte
+		name              string
+		softDeleteEnabled bool
+		expectedStatus    int
+		expectedResponse  []byte
 	}{
 		{
 			name:           "success",
 			expectedStatus: 204,
 		},
+		{
+			name:              "soft delete",
+			softDeleteEnabled: true,
+			expectedStatus:    204,
+		},
 	}
 
 	logger := logrus.New()
@@ -39,25 +46,72 @@ func TestDeleteFile(t *testing.T) {
 			metadataStorage := mock.NewMockMetadataStorage(c)
 			contentStorage := mock.NewMockContentStorage(c)
 
-			metadataStorage.EXPECT().DeleteFileByID(
+			metadataStorage.EXPECT().GetFileByID(
 				gomock.Any(), "55af1e60-0f28-454e-885e-ea6aab2bb288", gomock.Any(),
-			).Return(nil)
-
-			contentStorage.EXPECT().DeleteFile(
-				gomock.Any(),
-				"55af1e60-0f28-454e-885e-ea6aab2bb288",
-			).Return(
-				nil,
-			)
+			).Return(controller.FileMetadata{ //nolint: exhaustruct
+				ID:        "55af1e60-0f28-454e-885e-ea6aab2bb288",
+				BucketID:  "default",
+				ObjectKey: "photos/55af1e60-0f28-454e-885e-ea6aab2bb288",
+			}, nil)
+
+			metadataStorage.EXPECT().GetBucketByID(
+				gomock.Any(), "default", gomock.Any(),
+			).Return(controller.BucketMetadata{ //nolint: exhaustruct
+				ID:                "default",
+				SoftDeleteEnabled: tc.softDeleteEnabled,
+			}, nil)
+
+			if tc.softDeleteEnabled {
+				metadataStorage.EXPECT().TrashFile(
+					gomock.Any(), "55af1e60-0f28-454e-885e-ea6aab2bb288", "",
+					controller.FileCondition{}, gomock.Any(),
+				).Return(controller.FileMetadata{ //nolint: exhaustruct
+					ID:        "55af1e60-0f28-454e-885e-ea6aab2bb288",
+					BucketID:  "default",
+					DeletedAt: "2021-12-15T13:26:52.082485+00:00",
+				}, nil)
+			} else {
+				metadataStorage.EXPECT().ListFileVersions(
+					gomock.Any(), "55af1e60-0f28-454e-885e-ea6aab2bb288", gomock.Any(),
+				).Return([]controller.FileVersion{ //nolint: exhaustruct
+					{
+						ID:        "8f2e9f5c-3a4c-4d1b-9b62-5c4e1f0d2a7b",
+						ObjectKey: ".versions/8f2e9f5c-3a4c-4d1b-9b62-5c4e1f0d2a7b/55af1e60-0f28-454e-885e-ea6aab2bb288",
+					},
+				}, nil)
+
+				metadataStorage.EXPECT().DeleteFileByID(
+					gomock.Any(), "55af1e60-0f28-454e-885e-ea6aab2bb288", controller.FileCondition{},
+					gomock.Any(),
+					gomock.Any(),
+				).Return(nil)
+
+				contentStorage.EXPECT().DeleteFile(
+					gomock.Any(),
+					"photos/55af1e60-0f28-454e-885e-ea6aab2bb288",
+				).Return(
+					nil,
+				)
+
+				contentStorage.EXPECT().DeleteFile(
+					gomock.Any(),
+					".versions/8f2e9f5c-3a4c-4d1b-9b62-5c4e1f0d2a7b/55af1e60-0f28-454e-885e-ea6aab2bb288",
+				).Return(
+					nil,
+				)
+			}
 
 			ctrl := controller.New(
 				"http://asd",
 				"/v1",
-				"asdasd",
+				auth.NewAdminSecrets("asdasd"),
 				metadataStorage,
 				contentStorage,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
 				logger,
 			)
 
@@ -19,6 +19,10 @@ func (ctrl *Controller) deleteOrphans(ctx *gin.Context) ([]string, *APIError) {
 		}
 	}
 
+	if len(toDelete) > 0 {
+		ctrl.notify(ctx, Event{Type: EventOrphansDeleted, Data: ListOrphansResponse{toDelete}})
+	}
+
 	return toDelete, nil
 }
 
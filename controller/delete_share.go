@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (ctrl *Controller) deleteShare(ctx *gin.Context) *APIError {
+	fileMetadata, _, apiErr := ctrl.getFileMetadata(
+		ctx.Request.Context(), ctx.Param("id"), false, ctx.Request.Header,
+	)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	// users can only revoke their own shares, callers without a verified session are
+	// left to the permissions of the shares table
+	createdBy, headers, _ := ctrl.sessionAccess(ctx, ctx.Request.Header)
+
+	return ctrl.metadataStorage.DeleteShare(
+		ctx.Request.Context(),
+		ctx.Param("shareId"),
+		fileMetadata.ID,
+		createdBy,
+		headers,
+	)
+}
+
+func (ctrl *Controller) DeleteShare(ctx *gin.Context) {
+	if apiErr := ctrl.deleteShare(ctx); apiErr != nil {
+		_ = ctx.Error(fmt.Errorf("problem processing request: %w", apiErr))
+
+		ctx.JSON(apiErr.statusCode, CommonResponse{
+			Code:    apiErr.statusCode,
+			Message: apiErr.PublicResponse().Message,
+		})
+
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,129 @@
+package controller_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestDeleteShare(t *testing.T) {
+	t.Parallel()
+
+	const fileID = "55af1e60-0f28-454e-885e-ea6aab2bb288"
+
+	cases := []struct {
+		name              string
+		headers           http.Header
+		unverified        bool
+		expectedCreatedBy string
+		expectedHeaders   http.Header
+		expectedStatus    int
+	}{
+		{
+			name: "user",
+			headers: http.Header{
+				"Authorization": []string{bearer(t, "ab5ba58e-932a-40dc-87e8-733998794ec2", "user")},
+			},
+			expectedCreatedBy: "ab5ba58e-932a-40dc-87e8-733998794ec2",
+			expectedHeaders:   http.Header{"x-hasura-admin-secret": []string{"asdasd"}},
+			expectedStatus:    http.StatusNoContent,
+		},
+		{
+			name: "admin",
+			headers: http.Header{
+				"X-Hasura-Admin-Secret": []string{"asdasd"},
+			},
+			expectedCreatedBy: "",
+			expectedHeaders:   http.Header{"x-hasura-admin-secret": []string{"asdasd"}},
+			expectedStatus:    http.StatusNoContent,
+		},
+		{
+			name: "spoofed session headers",
+			headers: http.Header{
+				"X-Hasura-User-Id": []string{"ab5ba58e-932a-40dc-87e8-733998794ec2"},
+				"X-Hasura-Role":    []string{"user"},
+			},
+			unverified:        true,
+			expectedCreatedBy: "",
+			expectedHeaders: http.Header{
+				"X-Hasura-User-Id": []string{"ab5ba58e-932a-40dc-87e8-733998794ec2"},
+				"X-Hasura-Role":    []string{"user"},
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			metadataStorage.EXPECT().GetFileByID(
+				gomock.Any(), fileID, gomock.Any(),
+			).Return(controller.FileMetadata{ID: fileID, BucketID: "default"}, nil) //nolint: exhaustruct
+
+			metadataStorage.EXPECT().GetBucketByID(
+				gomock.Any(), "default", gomock.Any(),
+			).Return(controller.BucketMetadata{ID: "default"}, nil) //nolint: exhaustruct
+
+			metadataStorage.EXPECT().DeleteShare(
+				gomock.Any(),
+				"8a9c3d6b-55a4-4b0a-bc5c-3f1e7f3c2a10",
+				fileID,
+				tc.expectedCreatedBy,
+				tc.expectedHeaders,
+			).Return(nil)
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			middleware := []gin.HandlerFunc{ginLogger(logger)}
+			if !tc.unverified {
+				middleware = append(middleware, verifyJWT(t))
+			}
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, middleware...)
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(),
+				"DELETE",
+				"/v1/files/"+fileID+"/shares/8a9c3d6b-55a4-4b0a-bc5c-3f1e7f3c2a10",
+				nil,
+			)
+			req.Header = tc.headers
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+		})
+	}
+}
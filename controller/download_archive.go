@@ -0,0 +1,305 @@
+package controller
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	ArchiveFormatZip   = "zip"
+	ArchiveFormatTarGz = "tar.gz"
+
+	// maximum number of files in a single archive
+	maxArchiveFiles = 1000
+)
+
+type DownloadArchiveRequest struct {
+	// FileIDs or BucketID, optionally with ObjectPrefix, select the files to archive
+	FileIDs      []string `json:"fileIds"`
+	BucketID     string   `json:"bucketId"`
+	ObjectPrefix string   `json:"objectPrefix"`
+	// Format is either zip, the default, or tar.gz
+	Format string `json:"format"`
+	// Name of the archive without extension, defaults to archive
+	Name string `json:"name"`
+}
+
+type archiveEntry struct {
+	name     string
+	metadata FileMetadata
+}
+
+// archiveWriter abstracts the archive formats, entries are written sequentially.
+type archiveWriter interface {
+	create(name string, size int64, modified time.Time) (io.Writer, error)
+	Close() error
+}
+
+type zipArchive struct {
+	*zip.Writer
+}
+
+func (z zipArchive) create(name string, _ int64, modified time.Time) (io.Writer, error) {
+	// most files are already compressed, i.e. images and videos
+	return z.CreateHeader(&zip.FileHeader{ //nolint: exhaustruct,wrapcheck
+		Name:     name,
+		Method:   zip.Store,
+		Modified: modified,
+	})
+}
+
+type tarGzArchive struct {
+	gz *gzip.Writer
+	tw *tar.Writer
+}
+
+func newTarGzArchive(w io.Writer) *tarGzArchive {
+	gz := gzip.NewWriter(w)
+	return &tarGzArchive{gz, tar.NewWriter(gz)}
+}
+
+func (t *tarGzArchive) create(name string, size int64, modified time.Time) (io.Writer, error) {
+	if err := t.tw.WriteHeader(&tar.Header{ //nolint: exhaustruct
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     size,
+		Mode:     0o644, //nolint: gomnd
+		ModTime:  modified,
+	}); err != nil {
+		return nil, fmt.Errorf("problem writing tar header: %w", err)
+	}
+	return t.tw, nil
+}
+
+func (t *tarGzArchive) Close() error {
+	if err := t.tw.Close(); err != nil {
+		return fmt.Errorf("problem closing tar: %w", err)
+	}
+	return t.gz.Close() //nolint: wrapcheck
+}
+
+// archiveEntryName returns a relative path for the file that doesn't collide with the
+// names already used, colliding names get a counter before the extension.
+func archiveEntryName(name, fileID string, used map[string]struct{}) string {
+	name = strings.TrimLeft(path.Clean("/"+strings.ReplaceAll(name, `\`, "/")), "/")
+	if name == "" {
+		name = fileID
+	}
+
+	candidate := name
+	ext := path.Ext(name)
+	for i := 1; ; i++ {
+		if _, ok := used[strings.ToLower(candidate)]; !ok {
+			break
+		}
+		candidate = fmt.Sprintf("%s (%d)%s", strings.TrimSuffix(name, ext), i, ext)
+	}
+
+	used[strings.ToLower(candidate)] = struct{}{}
+	return candidate
+}
+
+func parseDownloadArchiveRequest(ctx *gin.Context) (DownloadArchiveRequest, *APIError) {
+	var req DownloadArchiveRequest
+	if err := json.NewDecoder(ctx.Request.Body).Decode(&req); err != nil {
+		return DownloadArchiveRequest{}, BadDataError(err, "couldn't decode request")
+	}
+
+	switch {
+	case len(req.FileIDs) == 0 && req.BucketID == "":
+		msg := "either fileIds or bucketId are required"
+		return DownloadArchiveRequest{}, BadDataError(errors.New(msg), msg) //nolint: goerr113
+	case len(req.FileIDs) > 0 && req.BucketID != "":
+		msg := "fileIds and bucketId can't be used together"
+		return DownloadArchiveRequest{}, BadDataError(errors.New(msg), msg) //nolint: goerr113
+	case len(req.FileIDs) > maxArchiveFiles:
+		msg := fmt.Sprintf("archives can't have more than %d files", maxArchiveFiles)
+		return DownloadArchiveRequest{}, BadDataError(errors.New(msg), msg) //nolint: goerr113
+	}
+
+	switch req.Format {
+	case "":
+		req.Format = ArchiveFormatZip
+	case ArchiveFormatZip, ArchiveFormatTarGz:
+	default:
+		msg := "format must be zip or tar.gz"
+		return DownloadArchiveRequest{}, BadDataError(errors.New(msg), msg) //nolint: goerr113
+	}
+
+	if req.Name == "" {
+		req.Name = "archive"
+	}
+
+	return req, nil
+}
+
+// archiveFileIDs returns the files selected by the request, listing the bucket if needed.
+func (ctrl *Controller) archiveFileIDs(
+	ctx *gin.Context, req DownloadArchiveRequest,
+) ([]string, *APIError) {
+	if len(req.FileIDs) > 0 {
+		return req.FileIDs, nil
+	}
+
+	if apiErr := checkAPIKeyBucket(ctx.Request.Header, req.BucketID); apiErr != nil {
+		return nil, apiErr
+	}
+
+	trashed := false
+	uploaded := true
+	// one more file than allowed is enough to know the bucket has too many
+	files, apiErr := ctrl.metadataStorage.ListFiles(
+		ctx.Request.Context(),
+		FileFilter{
+			BucketID:     req.BucketID,
+			ObjectPrefix: req.ObjectPrefix,
+			Trashed:      &trashed,
+			Uploaded:     &uploaded,
+			Limit:        maxArchiveFiles + 1,
+		},
+		ctx.Request.Header,
+	)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	if len(files) > maxArchiveFiles {
+		msg := fmt.Sprintf("archives can't have more than %d files", maxArchiveFiles)
+		return nil, BadDataError(errors.New(msg), msg) //nolint: goerr113
+	}
+
+	ids := make([]string, len(files))
+	for i, f := range files {
+		ids[i] = f.ID
+	}
+
+	return ids, nil
+}
+
+// downloadArchiveEntries checks the user can read every file before anything is streamed.
+func (ctrl *Controller) downloadArchiveEntries(
+	ctx *gin.Context,
+) (DownloadArchiveRequest, []archiveEntry, *APIError) {
+	req, apiErr := parseDownloadArchiveRequest(ctx)
+	if apiErr != nil {
+		return DownloadArchiveRequest{}, nil, apiErr
+	}
+
+	ids, apiErr := ctrl.archiveFileIDs(ctx, req)
+	if apiErr != nil {
+		return DownloadArchiveRequest{}, nil, apiErr
+	}
+
+	used := make(map[string]struct{}, len(ids))
+	entries := make([]archiveEntry, 0, len(ids))
+	for _, id := range ids {
+		fileMetadata, _, apiErr := ctrl.getFileMetadata(
+			ctx.Request.Context(), id, true, ctx.Request.Header,
+		)
+		if apiErr != nil {
+			return DownloadArchiveRequest{}, nil, apiErr.ExtendError("problem getting file " + id)
+		}
+
+		entries = append(entries, archiveEntry{
+			name:     archiveEntryName(fileMetadata.Name, fileMetadata.ID, used),
+			metadata: fileMetadata,
+		})
+	}
+
+	return req, entries, nil
+}
+
+func (ctrl *Controller) writeArchiveEntry(
+	ctx *gin.Context, archive archiveWriter, entry archiveEntry,
+) error {
+	objectKey := entry.metadata.ObjectKey
+	if objectKey == "" {
+		objectKey = entry.metadata.ID
+	}
+
+	download, apiErr := ctrl.contentStorage.GetFile(ctx, objectKey, make(http.Header))
+	if apiErr != nil {
+		return apiErr
+	}
+	defer download.Body.Close()
+
+	modified, err := time.Parse(time.RFC3339, entry.metadata.UpdatedAt)
+	if err != nil {
+		modified = time.Now()
+	}
+
+	w, err := archive.create(entry.name, download.ContentLength, modified)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(w, download.Body); err != nil {
+		return fmt.Errorf("problem writing file %s: %w", entry.metadata.ID, err)
+	}
+
+	return nil
+}
+
+func (ctrl *Controller) writeArchive(
+	ctx *gin.Context, format string, entries []archiveEntry,
+) error {
+	var archive archiveWriter
+	switch format {
+	case ArchiveFormatTarGz:
+		archive = newTarGzArchive(ctx.Writer)
+	default:
+		archive = zipArchive{zip.NewWriter(ctx.Writer)}
+	}
+
+	for _, entry := range entries {
+		// the archive is left incomplete on errors so clients can tell it is broken
+		if err := ctrl.writeArchiveEntry(ctx, archive, entry); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+func (ctrl *Controller) DownloadArchive(ctx *gin.Context) {
+	req, entries, apiErr := ctrl.downloadArchiveEntries(ctx)
+	if apiErr != nil {
+		_ = ctx.Error(fmt.Errorf("problem processing request: %w", apiErr))
+
+		ctx.JSON(apiErr.statusCode, CommonResponse{
+			Code:    apiErr.statusCode,
+			Message: apiErr.PublicResponse().Message,
+		})
+
+		return
+	}
+
+	contentType := "application/zip"
+	if req.Format == ArchiveFormatTarGz {
+		contentType = "application/gzip"
+	}
+
+	ctx.Header("Content-Type", contentType)
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header(
+		"Content-Disposition",
+		fmt.Sprintf(`attachment; filename="%s.%s"`, url.QueryEscape(req.Name), req.Format),
+	)
+	ctx.Status(http.StatusOK)
+
+	if err := ctrl.writeArchive(ctx, req.Format, entries); err != nil {
+		_ = ctx.Error(fmt.Errorf("problem writing archive: %w", err))
+	}
+}
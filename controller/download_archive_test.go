@@ -0,0 +1,281 @@
+package controller_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func archiveFile(id, name, content string) (controller.FileMetadata, *controller.File) {
+	return controller.FileMetadata{ //nolint: exhaustruct
+		ID:         id,
+		Name:       name,
+		Size:       int64(len(content)),
+		BucketID:   "default",
+		ObjectKey:  "photos/" + id,
+		CreatedAt:  "2021-12-27T09:58:11Z",
+		UpdatedAt:  "2021-12-27T09:58:11Z",
+		IsUploaded: true,
+	}, &controller.File{
+		StatusCode:    http.StatusOK,
+		Body:          io.NopCloser(strings.NewReader(content)),
+		ContentLength: int64(len(content)),
+		ExtraHeaders:  make(http.Header),
+	}
+}
+
+func readZip(t *testing.T, b []byte) map[string]string {
+	t.Helper()
+
+	r, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]string)
+	for _, f := range r.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rc.Close()
+		got[f.Name] = string(content)
+	}
+
+	return got
+}
+
+func readTarGz(t *testing.T, b []byte) map[string]string {
+	t.Helper()
+
+	gz, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]string)
+	tr := tar.NewReader(gz)
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got[h.Name] = string(content)
+	}
+
+	return got
+}
+
+func TestDownloadArchive(t *testing.T) { //nolint: maintidx
+	t.Parallel()
+
+	const (
+		file1 = "55af1e60-0f28-454e-885e-ea6aab2bb288"
+		file2 = "8a9c3d6b-55a4-4b0a-bc5c-3f1e7f3c2a10"
+	)
+
+	cases := []struct {
+		name                string
+		body                string
+		setup               func(*mock.MockMetadataStorage, *mock.MockContentStorage)
+		expectedStatus      int
+		expectedType        string
+		expectedDisposition string
+		expectedFiles       map[string]string
+	}{
+		{
+			name: "zip with colliding names",
+			body: `{"fileIds":["` + file1 + `","` + file2 + `"],"name":"holidays"}`,
+			setup: func(ms *mock.MockMetadataStorage, cs *mock.MockContentStorage) {
+				md1, f1 := archiveFile(file1, "photo.jpg", "first")
+				md2, f2 := archiveFile(file2, "../Photo.jpg", "second")
+				ms.EXPECT().GetFileByID(gomock.Any(), file1, gomock.Any()).Return(md1, nil)
+				ms.EXPECT().GetFileByID(gomock.Any(), file2, gomock.Any()).Return(md2, nil)
+				ms.EXPECT().GetBucketByID(gomock.Any(), "default", gomock.Any()).Return(
+					controller.BucketMetadata{ID: "default"}, nil, //nolint: exhaustruct
+				).Times(2)
+				cs.EXPECT().GetFile(gomock.Any(), "photos/"+file1, gomock.Any()).Return(f1, nil)
+				cs.EXPECT().GetFile(gomock.Any(), "photos/"+file2, gomock.Any()).Return(f2, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedType:        "application/zip",
+			expectedDisposition: `attachment; filename="holidays.zip"`,
+			expectedFiles: map[string]string{
+				"photo.jpg":     "first",
+				"Photo (1).jpg": "second",
+			},
+		},
+		{
+			name: "tar.gz",
+			body: `{"fileIds":["` + file1 + `"],"format":"tar.gz"}`,
+			setup: func(ms *mock.MockMetadataStorage, cs *mock.MockContentStorage) {
+				md1, f1 := archiveFile(file1, "notes/todo.txt", "buy milk")
+				ms.EXPECT().GetFileByID(gomock.Any(), file1, gomock.Any()).Return(md1, nil)
+				ms.EXPECT().GetBucketByID(gomock.Any(), "default", gomock.Any()).Return(
+					controller.BucketMetadata{ID: "default"}, nil, //nolint: exhaustruct
+				)
+				cs.EXPECT().GetFile(gomock.Any(), "photos/"+file1, gomock.Any()).Return(f1, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedType:        "application/gzip",
+			expectedDisposition: `attachment; filename="archive.tar.gz"`,
+			expectedFiles: map[string]string{
+				"notes/todo.txt": "buy milk",
+			},
+		},
+		{
+			name: "bucket and prefix",
+			body: `{"bucketId":"default","objectPrefix":"photos/"}`,
+			setup: func(ms *mock.MockMetadataStorage, cs *mock.MockContentStorage) {
+				md1, f1 := archiveFile(file1, "photo.jpg", "first")
+				ms.EXPECT().ListFiles(
+					gomock.Any(),
+					controller.FileFilter{ //nolint: exhaustruct
+						BucketID:     "default",
+						ObjectPrefix: "photos/",
+						Trashed:      ptr(false),
+						Uploaded:     ptr(true),
+						Limit:        1001,
+					},
+					gomock.Any(),
+				).Return([]controller.FileSummary{
+					{ID: file1, IsUploaded: true}, //nolint: exhaustruct
+				}, nil)
+				ms.EXPECT().GetFileByID(gomock.Any(), file1, gomock.Any()).Return(md1, nil)
+				ms.EXPECT().GetBucketByID(gomock.Any(), "default", gomock.Any()).Return(
+					controller.BucketMetadata{ID: "default"}, nil, //nolint: exhaustruct
+				)
+				cs.EXPECT().GetFile(gomock.Any(), "photos/"+file1, gomock.Any()).Return(f1, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedType:        "application/zip",
+			expectedDisposition: `attachment; filename="archive.zip"`,
+			expectedFiles: map[string]string{
+				"photo.jpg": "first",
+			},
+		},
+		{
+			name: "forbidden file",
+			body: `{"fileIds":["` + file1 + `","` + file2 + `"]}`,
+			setup: func(ms *mock.MockMetadataStorage, _ *mock.MockContentStorage) {
+				md1, _ := archiveFile(file1, "photo.jpg", "first")
+				ms.EXPECT().GetFileByID(gomock.Any(), file1, gomock.Any()).Return(md1, nil)
+				ms.EXPECT().GetBucketByID(gomock.Any(), "default", gomock.Any()).Return(
+					controller.BucketMetadata{ID: "default"}, nil, //nolint: exhaustruct
+				)
+				ms.EXPECT().GetFileByID(gomock.Any(), file2, gomock.Any()).Return(
+					controller.FileMetadata{}, //nolint: exhaustruct
+					controller.ErrFileNotFound,
+				)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "nothing selected",
+			body:           `{}`,
+			setup:          func(*mock.MockMetadataStorage, *mock.MockContentStorage) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "too many files in bucket",
+			body: `{"bucketId":"default"}`,
+			setup: func(ms *mock.MockMetadataStorage, _ *mock.MockContentStorage) {
+				ms.EXPECT().ListFiles(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+					make([]controller.FileSummary, 1001), nil,
+				)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown format",
+			body:           `{"fileIds":["` + file1 + `"],"format":"rar"}`,
+			setup:          func(*mock.MockMetadataStorage, *mock.MockContentStorage) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			tc.setup(metadataStorage, contentStorage)
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(), "POST", "/v1/files/archive", strings.NewReader(tc.body),
+			)
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			assert(t, tc.expectedType, responseRecorder.Header().Get("Content-Type"))
+			assert(
+				t, tc.expectedDisposition, responseRecorder.Header().Get("Content-Disposition"),
+			)
+
+			var got map[string]string
+			if tc.expectedType == "application/zip" {
+				got = readZip(t, responseRecorder.Body.Bytes())
+			} else {
+				got = readTarGz(t, responseRecorder.Body.Bytes())
+			}
+			assert(t, tc.expectedFiles, got)
+		})
+	}
+}
@@ -42,7 +42,7 @@ func (ctrl *Controller) downloadFileProcess(ctx *gin.Context) (*FileResponse, *A
 	}
 
 	headers := http.Header(ctx.Request.Header)
-	headers["x-hasura-admin-secret"] = []string{ctrl.hasuraAdminSecret}
+	headers["x-hasura-admin-secret"] = []string{ctrl.hasuraAdminSecret.Primary()}
 
 	fileMetadata, bucketMetadata, apiErr := ctrl.getFileMetadata(
 		ctx.Request.Context(), req.fileID, true,
@@ -10,6 +10,7 @@ import (
 
 	"github.com/nhost/hasura-storage/controller"
 	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
 	"github.com/sirupsen/logrus"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -65,29 +66,35 @@ func TestDownloadFile(t *testing.T) {
 				UploadExpiration:     600,
 			}, nil)
 
-			contentStorage.EXPECT().GetFile(
-				gomock.Any(),
-				"55af1e60-0f28-454e-885e-ea6aab2bb288",
-				gomock.Any(),
-			).Return(
-				&controller.File{
-					StatusCode:    200,
-					Etag:          `"55af1e60-0f28-454e-885e-ea6aab2bb288"`,
-					Body:          io.NopCloser(strings.NewReader("Hello, world!")),
-					ContentLength: 64,
-					ExtraHeaders:  make(http.Header),
-				},
-				nil,
-			)
+			// failed conditionals are answered from the metadata
+			if tc.expectedStatus == http.StatusOK {
+				contentStorage.EXPECT().GetFile(
+					gomock.Any(),
+					"55af1e60-0f28-454e-885e-ea6aab2bb288",
+					gomock.Any(),
+				).Return(
+					&controller.File{
+						StatusCode:    200,
+						Etag:          `"55af1e60-0f28-454e-885e-ea6aab2bb288"`,
+						Body:          io.NopCloser(strings.NewReader("Hello, world!")),
+						ContentLength: 64,
+						ExtraHeaders:  make(http.Header),
+					},
+					nil,
+				)
+			}
 
 			ctrl := controller.New(
 				"http://asd",
 				"/v1",
-				"asdasd",
+				auth.NewAdminSecrets("asdasd"),
 				metadataStorage,
 				contentStorage,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
 				logger,
 			)
 
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 var (
@@ -43,6 +44,60 @@ var (
 		errors.New("file not found"), //nolint
 		nil,
 	}
+	ErrVirusNotFound = &APIError{
+		http.StatusNotFound,
+		"virus not found",
+		errors.New("virus not found"), //nolint
+		nil,
+	}
+	ErrAPIKeyNotFound = &APIError{
+		http.StatusUnauthorized,
+		"invalid api key",
+		errors.New("api key not found"), //nolint
+		nil,
+	}
+	ErrShareNotFound = &APIError{
+		http.StatusNotFound,
+		"share not found",
+		errors.New("share not found"), //nolint
+		nil,
+	}
+	ErrArchiveEntryNotFound = &APIError{
+		http.StatusNotFound,
+		"archive entry not found",
+		errors.New("archive entry not found"), //nolint
+		nil,
+	}
+	ErrFileVersionNotFound = &APIError{
+		http.StatusNotFound,
+		"file version not found",
+		errors.New("file version not found"), //nolint
+		nil,
+	}
+	ErrPreconditionFailed = &APIError{
+		http.StatusPreconditionFailed,
+		"file was modified",
+		errors.New("file was modified"), //nolint
+		nil,
+	}
+	ErrFileExpired = &APIError{
+		http.StatusGone,
+		"file has expired",
+		errors.New("file has expired"), //nolint
+		nil,
+	}
+	ErrFileLegalHold = &APIError{
+		http.StatusForbidden,
+		"file is under legal hold",
+		errors.New("file is under legal hold"), //nolint
+		nil,
+	}
+	ErrQuotaExceeded = &APIError{
+		http.StatusForbidden,
+		"quota exceeded",
+		errors.New("quota exceeded"), //nolint
+		nil,
+	}
 	ErrFileNotUploaded = &APIError{
 		http.StatusForbidden,
 		"file not uploaded",
@@ -117,6 +172,55 @@ func FileTooSmallError(filename string, size, minSize int) *APIError {
 	}
 }
 
+func FileTypeNotAllowedError(filename, mimeType string, bucket BucketMetadata) *APIError {
+	msg := "file type not allowed"
+	if len(bucket.AllowedMimeTypes) > 0 {
+		msg += ", allowed types: " + strings.Join(bucket.AllowedMimeTypes, ", ")
+	}
+	if len(bucket.AllowedExtensions) > 0 {
+		msg += ", allowed extensions: " + strings.Join(bucket.AllowedExtensions, ", ")
+	}
+
+	return &APIError{
+		statusCode:    http.StatusBadRequest,
+		publicMessage: msg,
+		err:           fmt.Errorf("file %s of type %s not allowed in bucket %s", filename, mimeType, bucket.ID), //nolint
+		data: map[string]interface{}{
+			"filename":          filename,
+			"mimeType":          mimeType,
+			"allowedMimeTypes":  bucket.AllowedMimeTypes,
+			"allowedExtensions": bucket.AllowedExtensions,
+		},
+	}
+}
+
+func ContentTypeMismatchError(filename, declared, detected string) *APIError {
+	return &APIError{
+		statusCode:    http.StatusBadRequest,
+		publicMessage: "content type doesn't match the content of the file",
+		err:           fmt.Errorf("file %s declared as %s but detected as %s", filename, declared, detected), //nolint
+		data: map[string]interface{}{
+			"filename": filename,
+			"declared": declared,
+			"detected": detected,
+		},
+	}
+}
+
+func QuotaExceededError(quota Quota, usedBytes, usedFiles int64) *APIError {
+	return &APIError{
+		statusCode:    http.StatusForbidden,
+		publicMessage: "quota exceeded",
+		err:           fmt.Errorf("quota %s exceeded", quota.ID),
+		data: map[string]interface{}{
+			"maxBytes":  quota.MaxBytes,
+			"maxFiles":  quota.MaxFiles,
+			"usedBytes": usedBytes,
+			"usedFiles": usedFiles,
+		},
+	}
+}
+
 func WrongMetadataFormatError(err error) *APIError {
 	return &APIError{
 		statusCode:    http.StatusBadRequest,
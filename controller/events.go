@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"context"
+)
+
+const (
+	EventFileUploaded           = "file.uploaded"
+	EventFileMultipartCompleted = "file.multipart_completed"
+	EventFileUpdated            = "file.updated"
+	EventFileDeleted            = "file.deleted"
+	EventFileTrashed            = "file.trashed"
+	EventFileRestored           = "file.restored"
+	EventFileCopied             = "file.copied"
+	EventFileMoved              = "file.moved"
+	EventVirusDetected          = "file.virus_detected"
+	EventOrphansDeleted         = "ops.orphans_deleted"
+)
+
+// Event describes something that happened to a file. BucketID is empty for events
+// that aren't related to a single bucket. Events queued before the change is made set
+// FileID instead of Data, the file is then stored with the event as it is once the
+// change is made.
+type Event struct {
+	Type     string
+	BucketID string
+	FileID   string
+	Data     any
+}
+
+// WebhookEvent is an event waiting in the outbox to be delivered to a webhook.
+// BucketID is empty when the destination is the global webhook.
+type WebhookEvent struct {
+	ID            string
+	CreatedAt     string
+	EventType     string
+	BucketID      string
+	FileID        string
+	Payload       map[string]any
+	Attempts      int
+	NextAttemptAt string
+}
+
+type EventNotifier interface {
+	// Events returns the outbox entries for the event without queueing them so they
+	// can be inserted in the same transaction as the change they describe
+	Events(ctx context.Context, event Event) ([]WebhookEvent, *APIError)
+	Notify(ctx context.Context, event Event) *APIError
+}
+
+// events returns the outbox entries to queue along with a change made with the admin
+// secret, changes made with the permissions of the caller can't include them and use
+// notify once they are done instead. Like notify it never fails the request.
+func (ctrl *Controller) events(ctx context.Context, event Event) []WebhookEvent {
+	if ctrl.notifier == nil {
+		return nil
+	}
+
+	events, apiErr := ctrl.notifier.Events(ctx, event)
+	if apiErr != nil {
+		ctrl.logger.WithError(apiErr).Errorf("problem queueing %s event", event.Type)
+		return nil
+	}
+
+	return events
+}
+
+// notify never fails the request, events that can't be queued are only logged.
+func (ctrl *Controller) notify(ctx context.Context, event Event) {
+	if ctrl.notifier == nil {
+		return
+	}
+
+	if apiErr := ctrl.notifier.Notify(ctx, event); apiErr != nil {
+		ctrl.logger.WithError(apiErr).Errorf("problem queueing %s event", event.Type)
+	}
+}
@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+)
+
+// parseExpiresAt validates the expiry date requested for a file, an empty string means
+// the default TTL of the bucket applies.
+func parseExpiresAt(value string) (string, *APIError) {
+	if value == "" {
+		return "", nil
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return "", BadDataError(err, "expiresAt must be an RFC3339 date")
+	}
+
+	if !expiresAt.After(time.Now()) {
+		return "", BadDataError(
+			fmt.Errorf("expiresAt %s is in the past", value), //nolint: goerr113
+			"expiresAt must be in the future",
+		)
+	}
+
+	return expiresAt.UTC().Format(time.RFC3339), nil
+}
+
+func (fileMetadata FileMetadata) expired(now time.Time) bool {
+	if fileMetadata.ExpiresAt == "" {
+		return false
+	}
+	expiresAt, err := time.Parse(time.RFC3339, fileMetadata.ExpiresAt)
+	if err != nil {
+		return true
+	}
+	return !now.Before(expiresAt)
+}
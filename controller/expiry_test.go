@@ -0,0 +1,169 @@
+package controller_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestGetExpiredFile(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	metadataStorage := mock.NewMockMetadataStorage(c)
+	contentStorage := mock.NewMockContentStorage(c)
+
+	metadataStorage.EXPECT().GetFileByID(
+		gomock.Any(), "55af1e60-0f28-454e-885e-ea6aab2bb288", gomock.Any(),
+	).Return(controller.FileMetadata{ //nolint: exhaustruct
+		ID:         "55af1e60-0f28-454e-885e-ea6aab2bb288",
+		Name:       "export.csv",
+		Size:       64,
+		BucketID:   "default",
+		ETag:       `"some-etag"`,
+		IsUploaded: true,
+		MimeType:   "text/csv",
+		ExpiresAt:  "2021-12-16T13:26:52.082485+00:00",
+	}, nil)
+
+	ctrl := controller.New(
+		"http://asd",
+		"/v1",
+		auth.NewAdminSecrets("asdasd"),
+		metadataStorage,
+		contentStorage,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		logger,
+	)
+
+	router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+	responseRecorder := httptest.NewRecorder()
+
+	req, _ := http.NewRequestWithContext(
+		context.Background(), "GET", "/v1/files/55af1e60-0f28-454e-885e-ea6aab2bb288", nil,
+	)
+
+	router.ServeHTTP(responseRecorder, req)
+
+	assert(t, http.StatusGone, responseRecorder.Code)
+}
+
+func TestCreateFileMultipartUploadExpiresAt(t *testing.T) {
+	t.Parallel()
+
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	cases := []struct {
+		name              string
+		expiresAt         string
+		expectedExpiresAt string
+		expectedStatus    int
+	}{
+		{
+			name:              "in the future",
+			expiresAt:         expiresAt.In(time.FixedZone("CET", 3600)).Format(time.RFC3339),
+			expectedExpiresAt: expiresAt.Format(time.RFC3339),
+			expectedStatus:    http.StatusOK,
+		},
+		{
+			name:           "in the past",
+			expiresAt:      "2021-12-16T13:26:52Z",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "not a date",
+			expiresAt:      "tomorrow",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			if tc.expectedStatus == http.StatusOK {
+				metadataStorage.EXPECT().GetBucketByID(
+					gomock.Any(), "default", gomock.Any(),
+				).Return(controller.BucketMetadata{ //nolint: exhaustruct
+					ID:            "default",
+					MaxUploadFile: 100,
+				}, nil)
+
+				contentStorage.EXPECT().CreateMultipartUpload(
+					gomock.Any(), gomock.Any(), "text/plain",
+				).Return("upload-id", nil)
+
+				metadataStorage.EXPECT().InitializeFile(
+					gomock.Any(), gomock.Any(), "file.txt", int64(5), "default", "text/plain",
+					gomock.Any(), int64(5), int64(1), "upload-id", tc.expectedExpiresAt,
+					gomock.Any(),
+				).Return(nil)
+
+				metadataStorage.EXPECT().GetFileByID(
+					gomock.Any(), gomock.Any(), gomock.Any(),
+				).Return(controller.FileMetadata{}, nil) //nolint: exhaustruct
+			}
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(),
+				"POST",
+				"/v1/files/multipart",
+				strings.NewReader(fmt.Sprintf(
+					`{"files":[{"size":5,"chunkSize":5,"fileName":"file.txt","contentType":"text/plain","expiresAt":%q}]}`,
+					tc.expiresAt,
+				)),
+			)
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+		})
+	}
+}
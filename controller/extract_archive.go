@@ -0,0 +1,195 @@
+package controller
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// maximum number of entries in an archive extracted on upload
+	maxExtractEntries = 1000
+	// maximum total uncompressed size of an archive extracted on upload
+	maxExtractSize = 1 << 30
+	// maximum uncompressed size of a single entry of an archive extracted on upload
+	maxExtractEntrySize = 100 << 20
+)
+
+// validArchiveEntryName rejects names that could escape the prefix the entries are
+// extracted to once used as paths, i.e. zip-slip.
+func validArchiveEntryName(name string) bool {
+	if name == "" || strings.ContainsAny(name, "\\\x00") || path.IsAbs(name) {
+		return false
+	}
+
+	for _, elem := range strings.Split(name, "/") {
+		if elem == ".." {
+			return false
+		}
+	}
+
+	return path.Clean(name) == name
+}
+
+// tempFile is removed once it's closed.
+type tempFile struct {
+	*os.File
+}
+
+func (f tempFile) Close() error {
+	return errors.Join(f.File.Close(), os.Remove(f.Name()))
+}
+
+// openZipEntry decompresses the entry to a temporary file so it doesn't have to be held
+// in memory, making sure it isn't bigger than what its header declares.
+func openZipEntry(f *zip.File) func() (multipart.File, error) {
+	return func() (multipart.File, error) {
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("problem opening archive entry %s: %w", f.Name, err)
+		}
+		defer rc.Close()
+
+		tmp, err := os.CreateTemp("", "hasura-storage-extract-")
+		if err != nil {
+			return nil, fmt.Errorf("problem creating temporary file: %w", err)
+		}
+		file := tempFile{tmp}
+
+		size := int64(f.UncompressedSize64)
+		n, err := io.Copy(file, io.LimitReader(rc, size+1))
+		if err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("problem reading archive entry %s: %w", f.Name, err)
+		}
+		if n != size {
+			_ = file.Close()
+			return nil, fmt.Errorf( //nolint: goerr113
+				"archive entry %s doesn't match its declared size", f.Name,
+			)
+		}
+
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("problem rewinding archive entry %s: %w", f.Name, err)
+		}
+
+		return file, nil
+	}
+}
+
+// extractArchive returns a file for each regular file in the zip archive. Entries are
+// decompressed when they are processed and the returned closer releases the archive.
+func extractArchive(archive fileData) ([]fileData, io.Closer, *APIError) {
+	content, err := archive.header.Open()
+	if err != nil {
+		return nil, nil, InternalServerError(
+			fmt.Errorf("problem opening file %s: %w", archive.Name, err),
+		)
+	}
+
+	r, err := zip.NewReader(content, archive.header.Size)
+	if err != nil {
+		_ = content.Close()
+		return nil, nil, BadDataError(
+			fmt.Errorf("problem reading archive %s: %w", archive.Name, err),
+			"file "+archive.Name+" is not a valid zip archive",
+		)
+	}
+
+	if len(r.File) > maxExtractEntries {
+		_ = content.Close()
+		msg := fmt.Sprintf("archives can't have more than %d entries", maxExtractEntries)
+		return nil, nil, BadDataError(errors.New(msg), msg) //nolint: goerr113
+	}
+
+	var total uint64
+	files := make([]fileData, 0, len(r.File))
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		if !validArchiveEntryName(f.Name) || !f.Mode().IsRegular() {
+			_ = content.Close()
+			msg := fmt.Sprintf("archive entry %q is not allowed", f.Name)
+			return nil, nil, BadDataError(errors.New(msg), msg) //nolint: goerr113
+		}
+
+		if f.UncompressedSize64 > maxExtractEntrySize {
+			_ = content.Close()
+			msg := fmt.Sprintf(
+				"archive entry %q can't be bigger than %d bytes uncompressed", f.Name, maxExtractEntrySize,
+			)
+			return nil, nil, BadDataError(errors.New(msg), msg) //nolint: goerr113
+		}
+
+		total += f.UncompressedSize64
+		if total > maxExtractSize {
+			_ = content.Close()
+			msg := fmt.Sprintf("archives can't be bigger than %d bytes uncompressed", maxExtractSize)
+			return nil, nil, BadDataError(errors.New(msg), msg) //nolint: goerr113
+		}
+
+		files = append(files, fileData{
+			Name:      f.Name,
+			ID:        uuid.New().String(),
+			Metadata:  archive.Metadata,
+			ExpiresAt: archive.ExpiresAt,
+			header: &multipart.FileHeader{ //nolint: exhaustruct
+				Filename: path.Base(f.Name),
+				Header:   make(textproto.MIMEHeader),
+				Size:     int64(f.UncompressedSize64),
+			},
+			open: openZipEntry(f),
+		})
+	}
+
+	return files, content, nil
+}
+
+// processArchive uploads every entry of the archive as its own file. Entries are stored
+// under a prefix named after the id of the archive, which is generated unless specified.
+// Entries are processed in order and the ones uploaded before an entry fails are kept,
+// they are returned along with the error.
+func (ctrl *Controller) processArchive(
+	ctx context.Context,
+	archive fileData,
+	bucket BucketMetadata,
+	objectPrefix string,
+	headers http.Header,
+) ([]FileMetadata, *APIError) {
+	files, closer, apiErr := extractArchive(archive)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	defer closer.Close()
+
+	prefix, err := url.JoinPath(objectPrefix, archive.ID)
+	if err != nil {
+		return nil, InternalServerError(fmt.Errorf("problem joining path: %w", err))
+	}
+
+	filesMetadata := make([]FileMetadata, 0, len(files))
+	for _, file := range files {
+		metadata, apiErr := ctrl.processFile(ctx, file, bucket, prefix, headers)
+		if apiErr != nil {
+			return filesMetadata, apiErr.ExtendError("problem extracting " + file.Name)
+		}
+
+		filesMetadata = append(filesMetadata, metadata)
+	}
+
+	return filesMetadata, nil
+}
@@ -0,0 +1,257 @@
+package controller_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+)
+
+type zipEntry struct {
+	name     string
+	contents string
+}
+
+func createZip(t *testing.T, entries ...zipEntry) string {
+	t.Helper()
+
+	b := &bytes.Buffer{}
+	w := zip.NewWriter(b)
+	for _, e := range entries {
+		f, err := w.Create(e.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(f, e.contents); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return b.String()
+}
+
+func createExtractForm(t *testing.T, archive string) (io.Reader, string) {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	for k, v := range map[string]string{
+		"bucket-id":     "blah",
+		"object-prefix": "datasets",
+		"extract":       "true",
+		"metadata[]":    `{"id":"9c3ab1f3-0a53-4f2f-9b0b-2d1b8c6a4f5e","metadata":{"customer":"acme"}}`,
+	} {
+		if err := writer.WriteField(k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", `form-data; name="file[]"; filename="dataset.zip"`)
+	h.Set("Content-Type", "application/zip")
+	part, err := writer.CreatePart(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.WriteString(part, archive); err != nil {
+		t.Fatal(err)
+	}
+
+	writer.Close()
+
+	return body, writer.FormDataContentType()
+}
+
+func TestUploadFileExtract(t *testing.T) { //nolint: maintidx
+	t.Parallel()
+
+	const prefix = "datasets/9c3ab1f3-0a53-4f2f-9b0b-2d1b8c6a4f5e/"
+
+	cases := []struct {
+		name           string
+		archive        func(t *testing.T) string
+		expectedStatus int
+		expectedFiles  []string
+	}{
+		{
+			name: "success",
+			archive: func(t *testing.T) string {
+				t.Helper()
+				return createZip(
+					t,
+					zipEntry{"data/", ""},
+					zipEntry{"data/a.csv", "a,b\n1,2\n"},
+					zipEntry{"readme.txt", "hello"},
+				)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedFiles:  []string{"data/a.csv", "readme.txt"},
+		},
+		{
+			name: "zip slip",
+			archive: func(t *testing.T) string {
+				t.Helper()
+				return createZip(t, zipEntry{"../../etc/passwd", "root"})
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedFiles:  nil,
+		},
+		{
+			name: "entry too big",
+			archive: func(t *testing.T) string {
+				t.Helper()
+				return createZip(
+					t,
+					zipEntry{"readme.txt", "hello"},
+					zipEntry{"data.csv", strings.Repeat("a,b\n", 100)},
+				)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedFiles:  []string{"readme.txt"},
+		},
+		{
+			name: "not an archive",
+			archive: func(t *testing.T) string {
+				t.Helper()
+				return "just some text"
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedFiles:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+			av := mock.NewMockAntivirus(c)
+
+			metadataStorage.EXPECT().GetBucketByID(
+				gomock.Any(), "blah", gomock.Any(),
+			).Return(controller.BucketMetadata{ //nolint: exhaustruct
+				ID:            "blah",
+				MaxUploadFile: 100,
+			}, nil)
+
+			n := len(tc.expectedFiles)
+			metadataStorage.EXPECT().InitializeFile(
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "blah", gomock.Any(),
+				gomock.Any(), gomock.Any(), int64(1), "", "", gomock.Any(),
+			).Return(nil).Times(n)
+			av.EXPECT().ScanReader(gomock.Any()).Return(nil).Times(n)
+			contentStorage.EXPECT().PutFile(
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+			).DoAndReturn(func(
+				_ context.Context, _ io.ReadSeeker, objectKey, _ string,
+			) (string, *controller.APIError) {
+				if !strings.HasPrefix(objectKey, prefix) {
+					t.Errorf("object key %s not under %s", objectKey, prefix)
+				}
+				return "some-etag", nil
+			}).Times(n)
+			metadataStorage.EXPECT().PopulateMetadata(
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "blah", "some-etag",
+				true, gomock.Any(), gomock.Any(), gomock.Any(), int64(1), "", "",
+				"",
+				map[string]any{"customer": "acme"}, gomock.Any(), gomock.Any(),
+			).DoAndReturn(func(
+				_ context.Context,
+				id, name string,
+				size int64,
+				bucketID, etag string,
+				isUploaded bool,
+				mimeType, objectKey string,
+				_, _ int64,
+				_, _, _ string,
+				md map[string]any,
+				_ []controller.WebhookEvent,
+				_ http.Header,
+			) (controller.FileMetadata, *controller.APIError) {
+				return controller.FileMetadata{ //nolint: exhaustruct
+					ID:         id,
+					Name:       name,
+					Size:       size,
+					BucketID:   bucketID,
+					ETag:       etag,
+					IsUploaded: isUploaded,
+					MimeType:   mimeType,
+					ObjectKey:  objectKey,
+					Metadata:   md,
+				}, nil
+			}).Times(n)
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				av,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+			body, contentType := createExtractForm(t, tc.archive(t))
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(context.Background(), "POST", "/v1/files/", body)
+			req.Header.Set("Content-Type", contentType)
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+
+			if tc.expectedFiles == nil {
+				return
+			}
+
+			var resp struct {
+				Data []controller.FileMetadata `json:"data"`
+			}
+			if err := json.Unmarshal(responseRecorder.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+
+			names := make([]string, len(resp.Data))
+			for i, f := range resp.Data {
+				names[i] = f.Name
+				if f.ObjectKey != fmt.Sprintf("%s%s", prefix, f.ID) {
+					t.Errorf("unexpected object key %s", f.ObjectKey)
+				}
+			}
+			assert(t, tc.expectedFiles, names)
+		})
+	}
+}
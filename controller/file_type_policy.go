@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+// sniffContentType detects the content type from the content itself, the reader is
+// rewound afterwards.
+func sniffContentType(content io.ReadSeeker) (*mimetype.MIME, *APIError) {
+	mt, err := mimetype.DetectReader(content)
+	if err != nil {
+		return nil, InternalServerError(fmt.Errorf("problem sniffing content type: %w", err))
+	}
+
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return nil, InternalServerError(
+			fmt.Errorf("problem going to the beginning of the content: %w", err),
+		)
+	}
+
+	return mt, nil
+}
+
+// sniffLength is how much of an object is downloaded to detect its type, it matches the
+// default read limit of mimetype.
+const sniffLength = 3072
+
+// sniffObject detects the content type of an object that reached the storage backend
+// without going through hasura-storage, only its beginning is downloaded.
+func (ctrl *Controller) sniffObject(
+	ctx context.Context, objectKey string, size int64,
+) (*mimetype.MIME, *APIError) {
+	if size == 0 {
+		return mimetype.Detect(nil), nil
+	}
+
+	download, apiErr := ctrl.contentStorage.GetFile(
+		ctx, objectKey, http.Header{"Range": []string{fmt.Sprintf("bytes=0-%d", sniffLength-1)}},
+	)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	defer download.Body.Close()
+
+	head, err := io.ReadAll(io.LimitReader(download.Body, sniffLength))
+	if err != nil {
+		return nil, InternalServerError(fmt.Errorf("problem reading object %s: %w", objectKey, err))
+	}
+
+	return mimetype.Detect(head), nil
+}
+
+func mediaType(contentType string) string {
+	mt, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(mt))
+}
+
+// mimeTypeMatches supports exact matches and wildcards like image/* or */*.
+func mimeTypeMatches(mimeType string, patterns []string) bool {
+	mimeType = mediaType(mimeType)
+	for _, p := range patterns {
+		p = mediaType(p)
+		if p == mimeType || p == "*/*" {
+			return true
+		}
+
+		if prefix, ok := strings.CutSuffix(p, "/*"); ok && strings.HasPrefix(mimeType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func fileExtension(filename string) string {
+	return strings.ToLower(strings.TrimPrefix(path.Ext(filename), "."))
+}
+
+func extensionMatches(ext string, extensions []string) bool {
+	for _, e := range extensions {
+		if strings.ToLower(strings.TrimPrefix(e, ".")) == ext {
+			return true
+		}
+	}
+	return false
+}
+
+// checkFileType enforces the allow and deny lists of the bucket. Deny lists take
+// precedence and empty allow lists allow everything.
+func checkFileType(bucket BucketMetadata, filename, mimeType string) *APIError {
+	if len(bucket.DeniedMimeTypes) > 0 && mimeTypeMatches(mimeType, bucket.DeniedMimeTypes) ||
+		len(bucket.AllowedMimeTypes) > 0 && !mimeTypeMatches(mimeType, bucket.AllowedMimeTypes) {
+		return FileTypeNotAllowedError(filename, mediaType(mimeType), bucket)
+	}
+
+	ext := fileExtension(filename)
+	if len(bucket.DeniedExtensions) > 0 && extensionMatches(ext, bucket.DeniedExtensions) ||
+		len(bucket.AllowedExtensions) > 0 && !extensionMatches(ext, bucket.AllowedExtensions) {
+		return FileTypeNotAllowedError(filename, mediaType(mimeType), bucket)
+	}
+
+	return nil
+}
+
+// checkContentType enforces the policy on the detected type, which is what the content
+// is, and on the declared one, which is what gets stored and served.
+func checkContentType(bucket BucketMetadata, filename, contentType, detectedType string) *APIError {
+	if apiErr := checkFileType(bucket, filename, detectedType); apiErr != nil {
+		return apiErr
+	}
+
+	if contentType == "" || mediaType(contentType) == mediaType(detectedType) {
+		return nil
+	}
+
+	return checkFileType(bucket, filename, contentType)
+}
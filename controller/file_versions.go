@@ -0,0 +1,260 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// versionsPrefix is where previous contents of files are stored. Version objects end
+// with the id of their file so they are attributed to it when looking for orphans.
+const versionsPrefix = ".versions"
+
+type ListFileVersionsResponse struct {
+	Versions []FileVersion `json:"versions"`
+}
+
+// preserveFileVersion keeps the current content of the file as a version before it's
+// replaced.
+func (ctrl *Controller) preserveFileVersion(
+	ctx *gin.Context, fileMetadata FileMetadata,
+) *APIError {
+	versionID := uuid.New().String()
+	objectKey := path.Join(versionsPrefix, versionID, FileObjectKey(fileMetadata))
+
+	if _, apiErr := ctrl.contentStorage.CopyFile(
+		ctx, FileObjectKey(fileMetadata), objectKey, fileMetadata.Size,
+	); apiErr != nil {
+		return apiErr.ExtendError("problem copying file version in storage")
+	}
+
+	if _, apiErr := ctrl.metadataStorage.InsertFileVersion(
+		ctx,
+		FileVersion{ //nolint: exhaustruct
+			ID:               versionID,
+			FileID:           fileMetadata.ID,
+			Name:             fileMetadata.Name,
+			Size:             fileMetadata.Size,
+			MimeType:         fileMetadata.MimeType,
+			ETag:             fileMetadata.ETag,
+			ObjectKey:        objectKey,
+			UploadedByUserID: fileMetadata.UploadedByUserID,
+			Metadata:         fileMetadata.Metadata,
+		},
+		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}},
+	); apiErr != nil {
+		_ = ctrl.contentStorage.DeleteFile(ctx, objectKey)
+		return apiErr.ExtendError("problem inserting file version")
+	}
+
+	return nil
+}
+
+// getFileVersion returns the version of the file, versions are only readable by
+// users who can read the file so they are fetched as admin.
+func (ctrl *Controller) getFileVersion(
+	ctx *gin.Context, fileMetadata FileMetadata, versionID string,
+) (FileVersion, *APIError) {
+	if _, err := uuid.Parse(versionID); err != nil {
+		return FileVersion{}, ErrFileVersionNotFound
+	}
+
+	version, apiErr := ctrl.metadataStorage.GetFileVersionByID(
+		ctx,
+		versionID,
+		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}},
+	)
+	if apiErr != nil {
+		return FileVersion{}, apiErr
+	}
+
+	if version.FileID != fileMetadata.ID {
+		return FileVersion{}, ErrFileVersionNotFound
+	}
+
+	return version, nil
+}
+
+// fileVersionMetadata returns the metadata of the file as it was when the version was
+// replaced, the version is dated when that happened.
+func fileVersionMetadata(fileMetadata FileMetadata, version FileVersion) FileMetadata {
+	fileMetadata.Name = version.Name
+	fileMetadata.Size = version.Size
+	fileMetadata.MimeType = version.MimeType
+	fileMetadata.ETag = version.ETag
+	fileMetadata.ObjectKey = version.ObjectKey
+	fileMetadata.UploadedByUserID = version.UploadedByUserID
+	fileMetadata.Metadata = version.Metadata
+	fileMetadata.UpdatedAt = version.CreatedAt
+
+	return fileMetadata
+}
+
+func (ctrl *Controller) listFileVersions(ctx *gin.Context) (ListFileVersionsResponse, *APIError) {
+	fileMetadata, _, apiErr := ctrl.getFileMetadata(
+		ctx.Request.Context(), ctx.Param("id"), false, ctx.Request.Header,
+	)
+	if apiErr != nil {
+		return ListFileVersionsResponse{}, apiErr
+	}
+
+	versions, apiErr := ctrl.metadataStorage.ListFileVersions(
+		ctx,
+		fileMetadata.ID,
+		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}},
+	)
+	if apiErr != nil {
+		return ListFileVersionsResponse{}, apiErr
+	}
+
+	return ListFileVersionsResponse{Versions: versions}, nil
+}
+
+func (ctrl *Controller) ListFileVersions(ctx *gin.Context) {
+	res, apiErr := ctrl.listFileVersions(ctx)
+	if apiErr != nil {
+		_ = ctx.Error(fmt.Errorf("problem processing request: %w", apiErr))
+
+		ctx.JSON(apiErr.statusCode, CommonResponse{
+			Code:    apiErr.statusCode,
+			Message: apiErr.PublicResponse().Message,
+		})
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, CommonResponse{http.StatusOK, "ok", res})
+}
+
+func (ctrl *Controller) restoreFileVersion(ctx *gin.Context) (FileMetadata, *APIError) { //nolint: funlen
+	fileMetadata, bucket, apiErr := ctrl.getFileMetadata(
+		ctx.Request.Context(), ctx.Param("id"), false, ctx.Request.Header,
+	)
+	if apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	condition, apiErr := checkWritePreconditions(ctx, fileMetadata)
+	if apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	if apiErr := checkFileLock(fileMetadata); apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	version, apiErr := ctrl.getFileVersion(ctx, fileMetadata, ctx.Param("versionId"))
+	if apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	// the bucket policy may have changed since the version was stored
+	if apiErr := checkSize(
+		version.Name, version.Size, bucket.MinUploadFile, bucket.MaxUploadFile,
+	); apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	if apiErr := checkFileType(bucket, version.Name, version.MimeType); apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	hookReq, apiErr := ctrl.checkUpload(ctx, UploadHookRequest{ //nolint: exhaustruct
+		Operation: UploadOperationUpdate,
+		FileID:    fileMetadata.ID,
+		BucketID:  fileMetadata.BucketID,
+		Name:      version.Name,
+		Size:      version.Size,
+		MimeType:  version.MimeType,
+		Metadata:  version.Metadata,
+	}, ctx.Request.Header)
+	if apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	if delta := version.Size - fileMetadata.Size; delta > 0 {
+		if apiErr := ctrl.checkQuota(
+			ctx, ctx.Request.Header, fileMetadata.BucketID, delta, 0,
+		); apiErr != nil {
+			return FileMetadata{}, apiErr
+		}
+	}
+
+	// restoring is an update so it requires the same permissions
+	if apiErr := ctrl.metadataStorage.SetIsUploaded(
+		ctx, fileMetadata.ID, false, condition, ctx.Request.Header,
+	); apiErr != nil {
+		return FileMetadata{}, apiErr.ExtendError(
+			"problem flagging file as pending upload " + fileMetadata.ID,
+		)
+	}
+
+	if bucket.VersioningEnabled && fileMetadata.IsUploaded {
+		if apiErr := ctrl.preserveFileVersion(ctx, fileMetadata); apiErr != nil {
+			_ = ctrl.metadataStorage.SetIsUploaded(
+				ctx, fileMetadata.ID, true, FileCondition{}, ctx.Request.Header,
+			)
+			return FileMetadata{}, apiErr
+		}
+	}
+
+	objectKey := FileObjectKey(fileMetadata)
+	etag, apiErr := ctrl.contentStorage.CopyFile(ctx, version.ObjectKey, objectKey, version.Size)
+	if apiErr != nil {
+		_ = ctrl.metadataStorage.SetIsUploaded(
+			ctx, fileMetadata.ID, true, FileCondition{}, ctx.Request.Header,
+		)
+		return FileMetadata{}, apiErr.ExtendError("problem restoring file version in storage")
+	}
+
+	newMetadata, apiErr := ctrl.metadataStorage.PopulateMetadata(
+		ctx,
+		fileMetadata.ID,
+		version.Name,
+		version.Size,
+		fileMetadata.BucketID,
+		etag,
+		true,
+		version.MimeType,
+		objectKey,
+		version.Size,
+		1,
+		"",
+		"",
+		"",
+		hookReq.Metadata,
+		nil,
+		ctx.Request.Header,
+	)
+	if apiErr != nil {
+		return FileMetadata{}, apiErr.ExtendError(
+			"problem populating file metadata for file " + fileMetadata.ID,
+		)
+	}
+
+	ctrl.lockObject(ctx, newMetadata, bucket)
+
+	ctx.Set("FileChanged", fileMetadata.ID)
+	ctrl.notify(ctx, Event{Type: EventFileUpdated, BucketID: newMetadata.BucketID, Data: newMetadata})
+
+	return newMetadata, nil
+}
+
+func (ctrl *Controller) RestoreFileVersion(ctx *gin.Context) {
+	metadata, apiErr := ctrl.restoreFileVersion(ctx)
+	if apiErr != nil {
+		_ = ctx.Error(fmt.Errorf("problem processing request: %w", apiErr))
+
+		ctx.JSON(apiErr.statusCode, CommonResponse{
+			Code:    apiErr.statusCode,
+			Message: apiErr.PublicResponse().Message,
+		})
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, CommonResponse{http.StatusOK, "ok", metadata})
+}
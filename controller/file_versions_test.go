@@ -0,0 +1,342 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+)
+
+const (
+	versionedFileID = "55af1e60-0f28-454e-885e-ea6aab2bb288"
+	fileVersionID   = "9b7e7c1a-3b3c-4d59-8f0e-5ac2b1d8e0c4"
+)
+
+func versionedFile() controller.FileMetadata {
+	return controller.FileMetadata{ //nolint: exhaustruct
+		ID:         versionedFileID,
+		Name:       "report.pdf",
+		Size:       1024,
+		BucketID:   "default",
+		ETag:       `"current-etag"`,
+		UpdatedAt:  "2021-12-16T13:26:52.082485+00:00",
+		IsUploaded: true,
+		MimeType:   "application/pdf",
+		ObjectKey:  "reports/" + versionedFileID,
+	}
+}
+
+func fileVersion() controller.FileVersion {
+	return controller.FileVersion{ //nolint: exhaustruct
+		ID:        fileVersionID,
+		FileID:    versionedFileID,
+		Name:      "report-draft.pdf",
+		Size:      512,
+		MimeType:  "application/pdf",
+		ETag:      `"old-etag"`,
+		ObjectKey: ".versions/" + fileVersionID + "/reports/" + versionedFileID,
+		CreatedAt: "2021-12-15T13:26:52.082485+00:00",
+	}
+}
+
+func TestListFileVersions(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	metadataStorage := mock.NewMockMetadataStorage(c)
+	contentStorage := mock.NewMockContentStorage(c)
+
+	metadataStorage.EXPECT().GetFileByID(
+		gomock.Any(), versionedFileID, gomock.Any(),
+	).Return(versionedFile(), nil)
+	metadataStorage.EXPECT().GetBucketByID(
+		gomock.Any(), "default", gomock.Any(),
+	).Return(controller.BucketMetadata{ID: "default"}, nil) //nolint: exhaustruct
+	metadataStorage.EXPECT().ListFileVersions(
+		gomock.Any(), versionedFileID, gomock.Any(),
+	).Return([]controller.FileVersion{fileVersion()}, nil)
+
+	ctrl := controller.New(
+		"http://asd",
+		"/v1",
+		auth.NewAdminSecrets("asdasd"),
+		metadataStorage,
+		contentStorage,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		logger,
+	)
+
+	router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+	responseRecorder := httptest.NewRecorder()
+
+	req, _ := http.NewRequestWithContext(
+		context.Background(), "GET", "/v1/files/"+versionedFileID+"/versions", nil,
+	)
+
+	router.ServeHTTP(responseRecorder, req)
+
+	assert(t, http.StatusOK, responseRecorder.Code)
+
+	resp := struct {
+		Data controller.ListFileVersionsResponse `json:"data"`
+	}{}
+	if err := json.Unmarshal(responseRecorder.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	// object keys are internal so they aren't returned
+	expected := fileVersion()
+	expected.ObjectKey = ""
+	assert(t, controller.ListFileVersionsResponse{Versions: []controller.FileVersion{expected}}, resp.Data)
+}
+
+func TestGetFileVersion(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name           string
+		version        controller.FileVersion
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			version:        fileVersion(),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "version of another file",
+			version: func() controller.FileVersion {
+				v := fileVersion()
+				v.FileID = "8c4b6e8e-2f3a-4ea0-a9a6-6d16ea6b1fc1"
+				return v
+			}(),
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			metadataStorage.EXPECT().GetFileByID(
+				gomock.Any(), versionedFileID, gomock.Any(),
+			).Return(versionedFile(), nil)
+			metadataStorage.EXPECT().GetBucketByID(
+				gomock.Any(), "default", gomock.Any(),
+			).Return(controller.BucketMetadata{ //nolint: exhaustruct
+				ID:           "default",
+				CacheControl: "max-age=3600",
+			}, nil)
+			metadataStorage.EXPECT().GetFileVersionByID(
+				gomock.Any(), fileVersionID, gomock.Any(),
+			).Return(tc.version, nil)
+
+			if tc.expectedStatus == http.StatusOK {
+				contentStorage.EXPECT().GetFile(
+					gomock.Any(), tc.version.ObjectKey, gomock.Any(),
+				).Return(&controller.File{
+					StatusCode:    http.StatusOK,
+					Etag:          `"old-etag"`,
+					Body:          io.NopCloser(strings.NewReader("draft")),
+					ContentLength: 5,
+					ExtraHeaders:  make(http.Header),
+				}, nil)
+			}
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(),
+				"GET",
+				"/v1/files/"+versionedFileID+"?version="+fileVersionID,
+				nil,
+			)
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+			if tc.expectedStatus == http.StatusOK {
+				assert(t, `"old-etag"`, responseRecorder.Header().Get("Etag"))
+				assert(
+					t,
+					`inline; filename="report-draft.pdf"`,
+					responseRecorder.Header().Get("Content-Disposition"),
+				)
+				assert(t, "draft", responseRecorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestRestoreFileVersion(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name              string
+		versioningEnabled bool
+	}{
+		{
+			name:              "versioning enabled",
+			versioningEnabled: true,
+		},
+		{
+			name:              "versioning disabled",
+			versioningEnabled: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			file := versionedFile()
+			version := fileVersion()
+
+			metadataStorage.EXPECT().GetFileByID(
+				gomock.Any(), versionedFileID, gomock.Any(),
+			).Return(file, nil)
+			metadataStorage.EXPECT().GetBucketByID(
+				gomock.Any(), "default", gomock.Any(),
+			).Return(controller.BucketMetadata{ //nolint: exhaustruct
+				ID:                "default",
+				MaxUploadFile:     2048,
+				VersioningEnabled: tc.versioningEnabled,
+			}, nil)
+			metadataStorage.EXPECT().GetFileVersionByID(
+				gomock.Any(), fileVersionID, gomock.Any(),
+			).Return(version, nil)
+
+			calls := []any{
+				metadataStorage.EXPECT().SetIsUploaded(
+					gomock.Any(), versionedFileID, false, controller.FileCondition{}, gomock.Any(),
+				).Return(nil),
+			}
+
+			if tc.versioningEnabled {
+				calls = append(calls,
+					contentStorage.EXPECT().CopyFile(
+						gomock.Any(), file.ObjectKey, gomock.Any(), file.Size,
+					).DoAndReturn(func(
+						_ context.Context, _, dstPath string, _ int64,
+					) (string, *controller.APIError) {
+						if !strings.HasPrefix(dstPath, ".versions/") ||
+							!strings.HasSuffix(dstPath, "/"+file.ObjectKey) {
+							t.Errorf("unexpected version object key %s", dstPath)
+						}
+						return `"current-etag"`, nil
+					}),
+					metadataStorage.EXPECT().InsertFileVersion(
+						gomock.Any(), gomock.Any(), gomock.Any(),
+					).DoAndReturn(func(
+						_ context.Context, v controller.FileVersion, _ http.Header,
+					) (controller.FileVersion, *controller.APIError) {
+						assert(t, file.ETag, v.ETag)
+						assert(t, file.Name, v.Name)
+						return v, nil
+					}),
+				)
+			}
+
+			calls = append(calls,
+				contentStorage.EXPECT().CopyFile(
+					gomock.Any(), version.ObjectKey, file.ObjectKey, version.Size,
+				).Return(`"old-etag"`, nil),
+				metadataStorage.EXPECT().PopulateMetadata(
+					gomock.Any(),
+					versionedFileID, "report-draft.pdf", int64(512), "default", `"old-etag"`, true,
+					"application/pdf", file.ObjectKey, int64(512), int64(1), "", "", "", nil,
+					gomock.Any(),
+					gomock.Any(),
+				).Return(controller.FileMetadata{ //nolint: exhaustruct
+					ID:       versionedFileID,
+					Name:     "report-draft.pdf",
+					BucketID: "default",
+					ETag:     `"old-etag"`,
+				}, nil),
+			)
+			gomock.InOrder(calls...)
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(),
+				"POST",
+				"/v1/files/"+versionedFileID+"/versions/"+fileVersionID+"/restore",
+				nil,
+			)
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, http.StatusOK, responseRecorder.Code)
+		})
+	}
+}
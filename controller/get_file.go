@@ -8,7 +8,6 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/nhost/hasura-storage/image"
@@ -126,76 +125,216 @@ func (ctrl *Controller) manipulateImage(
 
 type getFileFunc func() (*File, *APIError)
 
-func (ctrl *Controller) processFileToDownload( //nolint: funlen
+// storageHeaders returns the request headers that are passed to the storage backend.
+// Ranges are requested explicitly when needed and conditionals are removed if the image
+// is manipulated as they apply to the output.
+func storageHeaders(headers http.Header, opts image.Options) http.Header {
+	h := headers.Clone()
+	h.Del("Range")
+	h.Del("If-Range")
+
+	if !opts.IsEmpty() {
+		for _, k := range []string{
+			"If-Match", "If-None-Match", "If-Modified-Since", "If-Unmodified-Since",
+		} {
+			h.Del(k)
+		}
+	}
+
+	return h
+}
+
+// downloadWithHeaders calls downloadFunc with the given request headers, restoring the
+// original ones afterwards.
+func downloadWithHeaders(
+	ctx *gin.Context, downloadFunc getFileFunc, headers http.Header,
+) (*File, *APIError) {
+	original := ctx.Request.Header
+	ctx.Request.Header = headers
+	defer func() { ctx.Request.Header = original }()
+
+	return downloadFunc()
+}
+
+// downloadFile serves the original file, ranges are requested to the storage backend.
+func downloadFile(
 	ctx *gin.Context,
 	downloadFunc getFileFunc,
 	fileMetadata FileMetadata,
 	cacheControl string,
-	infoHeaders *getFileInformationHeaders,
+	updateAt string,
 ) (*FileResponse, *APIError) {
-	opts, apiErr := getImageManipulationOptions(ctx, fileMetadata.MimeType)
+	headers := storageHeaders(ctx.Request.Header, image.Options{}) //nolint: exhaustruct
+
+	ranges, err := requestedRanges(ctx.Request.Header, fileMetadata.Size, fileMetadata.ETag, updateAt)
+	if err != nil {
+		return rangeNotSatisfiable(fileMetadata, fileMetadata.ETag, cacheControl, updateAt, fileMetadata.Size), nil
+	}
+
+	if len(ranges) == 0 {
+		download, apiErr := downloadWithHeaders(ctx, downloadFunc, headers)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+
+		etag := download.Etag
+		if etag == "" {
+			etag = fileMetadata.ETag
+		}
+
+		return NewFileResponse(
+			fileMetadata.ID,
+			fileMetadata.MimeType,
+			download.ContentLength,
+			etag,
+			cacheControl,
+			updateAt,
+			download.StatusCode,
+			download.Body,
+			fileMetadata.Name,
+			download.ExtraHeaders,
+		), nil
+	}
+
+	return rangesResponse(
+		fileMetadata, ranges, fileMetadata.Size, fileMetadata.ETag, cacheControl, updateAt,
+		func(r byteRange) (*File, *APIError) {
+			return downloadRange(ctx, downloadFunc, headers, r)
+		},
+	)
+}
+
+// downloadManipulatedImage serves the image after applying opts, ranges apply to the output.
+func (ctrl *Controller) downloadManipulatedImage(
+	ctx *gin.Context,
+	downloadFunc getFileFunc,
+	fileMetadata FileMetadata,
+	cacheControl string,
+	updateAt string,
+	opts image.Options,
+) (*FileResponse, *APIError) {
+	download, apiErr := downloadWithHeaders(
+		ctx, downloadFunc, storageHeaders(ctx.Request.Header, opts),
+	)
 	if apiErr != nil {
 		return nil, apiErr
 	}
 
-	// we remove this header if image manipulation options are specified
-	// because we pass them as is to the storage backend which means
-	// we'd get a partial file prior to performing the image manipulation
-	rangeHeader := ctx.Request.Header.Get("Range")
-	if !opts.IsEmpty() {
-		ctx.Request.Header.Set("Range", "")
+	body, contentLength, etag, apiErr := ctrl.manipulateImage(
+		download.Body, uint64(download.ContentLength), opts,
+	)
+	if apiErr != nil {
+		return nil, apiErr
 	}
-	download, apiErr := downloadFunc()
+
+	ranges, err := requestedRanges(ctx.Request.Header, contentLength, etag, updateAt)
+	if err != nil {
+		body.Close()
+		return rangeNotSatisfiable(fileMetadata, etag, cacheControl, updateAt, contentLength), nil
+	}
+
+	if len(ranges) == 0 {
+		return NewFileResponse(
+			fileMetadata.ID,
+			fileMetadata.MimeType,
+			contentLength,
+			etag,
+			cacheControl,
+			updateAt,
+			http.StatusOK,
+			body,
+			fileMetadata.Name,
+			make(http.Header),
+		), nil
+	}
+
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, InternalServerError(fmt.Errorf("problem reading image: %w", err))
+	}
+
+	return rangesResponse(
+		fileMetadata, ranges, contentLength, etag, cacheControl, updateAt,
+		func(r byteRange) (*File, *APIError) {
+			return &File{
+				ContentType:   fileMetadata.MimeType,
+				ContentLength: r.length,
+				Etag:          etag,
+				StatusCode:    http.StatusPartialContent,
+				Body:          io.NopCloser(bytes.NewReader(data[r.start : r.start+r.length])),
+				ExtraHeaders:  make(http.Header),
+			}, nil
+		},
+	)
+}
+
+func (ctrl *Controller) processFileToDownload(
+	ctx *gin.Context,
+	downloadFunc getFileFunc,
+	fileMetadata FileMetadata,
+	cacheControl string,
+	infoHeaders *getFileInformationHeaders,
+) (*FileResponse, *APIError) {
+	opts, apiErr := getImageManipulationOptions(ctx, fileMetadata.MimeType)
 	if apiErr != nil {
 		return nil, apiErr
 	}
-	ctx.Request.Header.Set("Range", rangeHeader)
 
+	// manipulated images only change when the original does so they keep its date
 	updateAt, apiErr := timeFromRFC3339ToRFC1123(fileMetadata.UpdatedAt)
 	if apiErr != nil {
 		return nil, apiErr
 	}
 
-	body := download.Body
-	contentLength := download.ContentLength
-	etag := download.Etag
-
-	if !opts.IsEmpty() {
-		defer body.Close()
-
-		body, contentLength, etag, apiErr = ctrl.manipulateImage(body, uint64(contentLength), opts)
+	// the metadata is enough to evaluate the conditionals unless the image is manipulated,
+	// in which case the etag depends on the output
+	if infoHeaders != nil && opts.IsEmpty() {
+		statusCode, apiErr := checkConditionals(
+			fileMetadata.ETag, updateAt, infoHeaders, http.StatusOK,
+		)
 		if apiErr != nil {
 			return nil, apiErr
 		}
-
-		updateAt = time.Now().Format(time.RFC3339)
-
-		if _, ok := download.ExtraHeaders["Content-Range"]; ok {
-			download.ExtraHeaders["Content-Range"] = []string{
-				fmt.Sprintf("bytes 0-%d/%d", contentLength-1, contentLength),
-			}
+		if statusCode != http.StatusOK {
+			return NewFileResponse(
+				fileMetadata.ID,
+				fileMetadata.MimeType,
+				fileMetadata.Size,
+				fileMetadata.ETag,
+				cacheControl,
+				updateAt,
+				statusCode,
+				http.NoBody,
+				fileMetadata.Name,
+				make(http.Header),
+			), nil
 		}
 	}
 
-	statusCode := download.StatusCode
+	var response *FileResponse
+	if opts.IsEmpty() {
+		response, apiErr = downloadFile(ctx, downloadFunc, fileMetadata, cacheControl, updateAt)
+	} else {
+		response, apiErr = ctrl.downloadManipulatedImage(
+			ctx, downloadFunc, fileMetadata, cacheControl, updateAt, opts,
+		)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
 	if infoHeaders != nil {
-		statusCode, apiErr = checkConditionals(etag, updateAt, infoHeaders, download.StatusCode)
+		response.statusCode, apiErr = checkConditionals(
+			response.etag, updateAt, infoHeaders, response.statusCode,
+		)
 		if apiErr != nil {
+			response.body.Close()
 			return nil, apiErr
 		}
 	}
-	return NewFileResponse(
-		fileMetadata.ID,
-		fileMetadata.MimeType,
-		contentLength,
-		etag,
-		cacheControl,
-		updateAt,
-		statusCode,
-		body,
-		fileMetadata.Name,
-		download.ExtraHeaders,
-	), nil
+
+	return response, nil
 }
 
 func (ctrl *Controller) getFileProcess(ctx *gin.Context) (*FileResponse, *APIError) {
@@ -211,11 +350,27 @@ func (ctrl *Controller) getFileProcess(ctx *gin.Context) (*FileResponse, *APIErr
 		return nil, apiErr
 	}
 
+	if versionID := ctx.Query("version"); versionID != "" {
+		version, apiErr := ctrl.getFileVersion(ctx, fileMetadata, versionID)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		fileMetadata = fileVersionMetadata(fileMetadata, version)
+	}
+
 	filePath := fileMetadata.ID
 	if len(fileMetadata.ObjectKey) > 0 {
 		filePath = fileMetadata.ObjectKey
 	}
 
+	redirect, apiErr := redirectDownload(ctx, fileMetadata, bucketMetadata)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if redirect {
+		return ctrl.redirectResponse(ctx, fileMetadata, bucketMetadata, filePath)
+	}
+
 	downloadFunc := func() (*File, *APIError) {
 		return ctrl.contentStorage.GetFile(ctx, filePath, ctx.Request.Header)
 	}
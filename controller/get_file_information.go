@@ -22,6 +22,20 @@ func (ctrl *Controller) getFileMetadata(
 		return FileMetadata{}, BucketMetadata{}, apiErr
 	}
 
+	if apiErr := checkAPIKeyBucket(headers, fileMetadata.BucketID); apiErr != nil {
+		return FileMetadata{}, BucketMetadata{}, apiErr
+	}
+
+	// files in the trash can only be restored
+	if fileMetadata.DeletedAt != "" {
+		return FileMetadata{}, BucketMetadata{}, ErrFileNotFound
+	}
+
+	// expired files are gone even if they haven't been deleted yet
+	if fileMetadata.expired(time.Now()) {
+		return FileMetadata{}, BucketMetadata{}, ErrFileExpired
+	}
+
 	if checkIsUploaded && !fileMetadata.IsUploaded {
 		msg := "file is not uploaded"
 		return FileMetadata{}, BucketMetadata{},
@@ -31,7 +45,7 @@ func (ctrl *Controller) getFileMetadata(
 	bucketMetadata, apiErr := ctrl.metadataStorage.GetBucketByID(
 		ctx,
 		fileMetadata.BucketID,
-		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret}},
+		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}},
 	)
 	if apiErr != nil {
 		return FileMetadata{}, BucketMetadata{}, apiErr
@@ -9,6 +9,7 @@ import (
 	"github.com/nhost/hasura-storage/controller"
 	"github.com/nhost/hasura-storage/controller/mock"
 	"github.com/nhost/hasura-storage/image"
+	"github.com/nhost/hasura-storage/middleware/auth"
 	"github.com/sirupsen/logrus"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -142,11 +143,14 @@ func TestGetFileInfo(t *testing.T) {
 			ctrl := controller.New(
 				"http://asd",
 				"/v1",
-				"asdasd",
+				auth.NewAdminSecrets("asdasd"),
 				metadataStorage,
 				contentStorage,
 				image.NewTransformer(),
 				nil,
+				nil,
+				nil,
+				nil,
 				logger,
 			)
 
@@ -62,6 +62,10 @@ func (ctrl *Controller) getFileMetadataByETag(
 		return FileMetadata{}, BadDataError(errors.New(errMsg), errMsg)
 	}
 
+	if apiErr := checkAPIKeyBucket(headers, fileMetadatas[0].BucketID); apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
 	return fileMetadatas[0], nil
 }
 
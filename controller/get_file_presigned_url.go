@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -16,18 +17,56 @@ type GetFilePresignedURLResponse struct {
 
 type GetFilePresignedURLRequest struct {
 	FileID string
+	// Params holds the image manipulation options and disposition that will be
+	// covered by the signature when URLs are signed by the service
+	Params url.Values
 }
 
-func (ctrl *Controller) getFilePresignedURLParse(ctx *gin.Context) GetFilePresignedURLRequest {
+func (ctrl *Controller) getFilePresignedURLParse(
+	ctx *gin.Context,
+) (GetFilePresignedURLRequest, *APIError) {
+	params := make(url.Values)
+	for _, p := range signedURLParams {
+		if v := ctx.Query(p); v != "" {
+			params.Set(p, v)
+		}
+	}
+
+	switch params.Get(signedURLDisposition) {
+	case "", "inline", "attachment":
+	default:
+		msg := "disposition must be inline or attachment"
+		return GetFilePresignedURLRequest{}, BadDataError(errors.New(msg), msg) //nolint: goerr113
+	}
+
 	return GetFilePresignedURLRequest{
 		FileID: ctx.Param("id"),
-	}
+		Params: params,
+	}, nil
+}
+
+func (ctrl *Controller) signedURL(
+	fileMetadata FileMetadata, bucketMetadata BucketMetadata, params url.Values,
+) string {
+	query := ctrl.urlSigner.Sign(
+		fileMetadata.ID,
+		time.Now().Add(time.Duration(bucketMetadata.DownloadExpiration)*time.Second),
+		params,
+	)
+
+	return fmt.Sprintf(
+		"%s%s/files/%s/presignedurl/content?%s",
+		ctrl.publicURL, ctrl.apiRootPrefix, fileMetadata.ID, query.Encode(),
+	)
 }
 
 func (ctrl *Controller) getFilePresignedURL(
 	ctx *gin.Context,
 ) (GetFilePresignedURLResponse, *APIError) {
-	req := ctrl.getFilePresignedURLParse(ctx)
+	req, apiErr := ctrl.getFilePresignedURLParse(ctx)
+	if apiErr != nil {
+		return GetFilePresignedURLResponse{}, apiErr
+	}
 
 	fileMetadata, bucketMetadata, apiErr := ctrl.getFileMetadata(
 		ctx.Request.Context(), req.FileID, true, ctx.Request.Header,
@@ -43,6 +82,13 @@ func (ctrl *Controller) getFilePresignedURL(
 		return GetFilePresignedURLResponse{}, ForbiddenError(err, err.Error())
 	}
 
+	if ctrl.urlSigner != nil {
+		return GetFilePresignedURLResponse{
+			ctrl.signedURL(fileMetadata, bucketMetadata, req.Params),
+			bucketMetadata.DownloadExpiration,
+		}, nil
+	}
+
 	objectKey := fileMetadata.ObjectKey
 	if objectKey == "" {
 		objectKey = fileMetadata.ID
@@ -10,6 +10,7 @@ import (
 
 	"github.com/nhost/hasura-storage/controller"
 	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
 	"github.com/sirupsen/logrus"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -96,11 +97,14 @@ func TestGetFilePresignedURL(t *testing.T) {
 			ctrl := controller.New(
 				"http://asd",
 				"/v1",
-				"asdasd",
+				auth.NewAdminSecrets("asdasd"),
 				metadataStorage,
 				contentStorage,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
 				logger,
 			)
 
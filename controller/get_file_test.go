@@ -2,14 +2,20 @@ package controller_test
 
 import (
 	"context"
+	"errors"
 	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/nhost/hasura-storage/controller"
 	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
 	"github.com/sirupsen/logrus"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -65,29 +71,35 @@ func TestGetFile(t *testing.T) {
 				UploadExpiration:     600,
 			}, nil)
 
-			contentStorage.EXPECT().GetFile(
-				gomock.Any(),
-				"55af1e60-0f28-454e-885e-ea6aab2bb288",
-				gomock.Any(),
-			).Return(
-				&controller.File{
-					StatusCode:    200,
-					Etag:          `"55af1e60-0f28-454e-885e-ea6aab2bb288"`,
-					Body:          io.NopCloser(strings.NewReader("Hello, world!")),
-					ContentLength: 64,
-					ExtraHeaders:  make(http.Header),
-				},
-				nil,
-			)
+			// failed conditionals are answered from the metadata
+			if tc.expectedStatus == http.StatusOK {
+				contentStorage.EXPECT().GetFile(
+					gomock.Any(),
+					"55af1e60-0f28-454e-885e-ea6aab2bb288",
+					gomock.Any(),
+				).Return(
+					&controller.File{
+						StatusCode:    200,
+						Etag:          `"55af1e60-0f28-454e-885e-ea6aab2bb288"`,
+						Body:          io.NopCloser(strings.NewReader("Hello, world!")),
+						ContentLength: 64,
+						ExtraHeaders:  make(http.Header),
+					},
+					nil,
+				)
+			}
 
 			ctrl := controller.New(
 				"http://asd",
 				"/v1",
-				"asdasd",
+				auth.NewAdminSecrets("asdasd"),
 				metadataStorage,
 				contentStorage,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
 				logger,
 			)
 
@@ -151,3 +163,534 @@ func TestGetFile(t *testing.T) {
 		})
 	}
 }
+
+func TestGetFileActiveContent(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name                string
+		mimeType            string
+		expectedDisposition string
+		expectedCSP         string
+		expectedNoSniff     string
+	}{
+		{
+			name:                "image",
+			mimeType:            "image/png",
+			expectedDisposition: `inline; filename="my-file"`,
+		},
+		{
+			name:                "svg",
+			mimeType:            "image/svg+xml",
+			expectedDisposition: `attachment; filename="my-file"`,
+			expectedCSP:         "default-src 'none'; style-src 'unsafe-inline'; sandbox",
+			expectedNoSniff:     "nosniff",
+		},
+		{
+			name:                "html",
+			mimeType:            "text/html; charset=utf-8",
+			expectedDisposition: `attachment; filename="my-file"`,
+			expectedCSP:         "default-src 'none'; style-src 'unsafe-inline'; sandbox",
+			expectedNoSniff:     "nosniff",
+		},
+		{
+			name:                "xml",
+			mimeType:            "application/rss+xml",
+			expectedDisposition: `attachment; filename="my-file"`,
+			expectedCSP:         "default-src 'none'; style-src 'unsafe-inline'; sandbox",
+			expectedNoSniff:     "nosniff",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			metadataStorage.EXPECT().GetFileByID(
+				gomock.Any(), "55af1e60-0f28-454e-885e-ea6aab2bb288", gomock.Any(),
+			).Return(controller.FileMetadata{ //nolint: exhaustruct
+				ID:         "55af1e60-0f28-454e-885e-ea6aab2bb288",
+				Name:       "my-file",
+				Size:       64,
+				BucketID:   "default",
+				ETag:       "\"55af1e60-0f28-454e-885e-ea6aab2bb288\"",
+				CreatedAt:  "2021-12-27T09:58:11Z",
+				UpdatedAt:  "2021-12-27T09:58:11Z",
+				IsUploaded: true,
+				MimeType:   tc.mimeType,
+				ObjectKey:  "55af1e60-0f28-454e-885e-ea6aab2bb288",
+			}, nil)
+
+			metadataStorage.EXPECT().GetBucketByID(
+				gomock.Any(), "default", gomock.Any(),
+			).Return(controller.BucketMetadata{ //nolint: exhaustruct
+				ID:           "default",
+				CacheControl: "max-age=3600",
+			}, nil)
+
+			contentStorage.EXPECT().GetFile(
+				gomock.Any(), "55af1e60-0f28-454e-885e-ea6aab2bb288", gomock.Any(),
+			).Return(
+				&controller.File{
+					StatusCode:    200,
+					Etag:          `"55af1e60-0f28-454e-885e-ea6aab2bb288"`,
+					Body:          io.NopCloser(strings.NewReader("Hello, world!")),
+					ContentLength: 64,
+					ExtraHeaders:  make(http.Header),
+				},
+				nil,
+			)
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(),
+				"GET",
+				"/v1/files/55af1e60-0f28-454e-885e-ea6aab2bb288",
+				nil,
+			)
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, http.StatusOK, responseRecorder.Code)
+			assert(t, tc.expectedDisposition, responseRecorder.Header().Get("Content-Disposition"))
+			assert(t, tc.expectedCSP, responseRecorder.Header().Get("Content-Security-Policy"))
+			assert(t, tc.expectedNoSniff, responseRecorder.Header().Get("X-Content-Type-Options"))
+		})
+	}
+}
+
+func TestGetFileRedirect(t *testing.T) {
+	t.Parallel()
+
+	const fileID = "55af1e60-0f28-454e-885e-ea6aab2bb288"
+
+	cases := []struct {
+		name              string
+		query             string
+		redirectDownloads bool
+		expectedStatus    int
+	}{
+		{
+			name:              "bucket",
+			redirectDownloads: true,
+			expectedStatus:    http.StatusFound,
+		},
+		{
+			name:           "request",
+			query:          "?redirect=true",
+			expectedStatus: http.StatusFound,
+		},
+		{
+			name:              "request opts out",
+			query:             "?redirect=false",
+			redirectDownloads: true,
+			expectedStatus:    http.StatusOK,
+		},
+		{
+			name:           "invalid",
+			query:          "?redirect=maybe",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			metadataStorage.EXPECT().GetFileByID(
+				gomock.Any(), fileID, gomock.Any(),
+			).Return(controller.FileMetadata{ //nolint: exhaustruct
+				ID:         fileID,
+				Name:       "my-file.png",
+				Size:       13,
+				BucketID:   "default",
+				ETag:       `"55af1e60-0f28-454e-885e-ea6aab2bb288"`,
+				CreatedAt:  "2021-12-27T09:58:11Z",
+				UpdatedAt:  "2021-12-27T09:58:11Z",
+				IsUploaded: true,
+				MimeType:   "image/png",
+			}, nil)
+
+			metadataStorage.EXPECT().GetBucketByID(
+				gomock.Any(), "default", gomock.Any(),
+			).Return(controller.BucketMetadata{ //nolint: exhaustruct
+				ID:                 "default",
+				DownloadExpiration: 30,
+				CacheControl:       "max-age=3600",
+				RedirectDownloads:  tc.redirectDownloads,
+			}, nil)
+
+			switch tc.expectedStatus {
+			case http.StatusFound:
+				contentStorage.EXPECT().CreateGetObjectRedirectURL(
+					gomock.Any(), fileID, `inline; filename="my-file.png"`, 30*time.Second,
+				).Return("https://s3.example.com/default/"+fileID+"?X-Amz-Signature=asd", nil)
+			case http.StatusOK:
+				contentStorage.EXPECT().GetFile(gomock.Any(), fileID, gomock.Any()).Return(
+					&controller.File{
+						StatusCode:    http.StatusOK,
+						Etag:          `"55af1e60-0f28-454e-885e-ea6aab2bb288"`,
+						Body:          io.NopCloser(strings.NewReader("Hello, world!")),
+						ContentLength: 13,
+						ExtraHeaders:  make(http.Header),
+					}, nil,
+				)
+			}
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(), "GET", "/v1/files/"+fileID+tc.query, nil,
+			)
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+
+			if tc.expectedStatus == http.StatusFound {
+				assert(
+					t,
+					"https://s3.example.com/default/"+fileID+"?X-Amz-Signature=asd",
+					responseRecorder.Header().Get("Location"),
+				)
+				assert(t, "no-store", responseRecorder.Header().Get("Cache-Control"))
+			}
+		})
+	}
+}
+
+func TestGetFileIfRange(t *testing.T) {
+	t.Parallel()
+
+	const fileID = "55af1e60-0f28-454e-885e-ea6aab2bb288"
+
+	cases := []struct {
+		name          string
+		ifRange       string
+		expectedRange string
+	}{
+		{
+			name:          "no If-Range",
+			expectedRange: "bytes=0-4",
+		},
+		{
+			name:          "etag matches",
+			ifRange:       `"55af1e60-0f28-454e-885e-ea6aab2bb288"`,
+			expectedRange: "bytes=0-4",
+		},
+		{
+			name:          "etag doesn't match",
+			ifRange:       `"blah"`,
+			expectedRange: "",
+		},
+		{
+			name:          "date matches",
+			ifRange:       "Mon, 27 Dec 2021 09:58:11 UTC",
+			expectedRange: "bytes=0-4",
+		},
+		{
+			name:          "date doesn't match",
+			ifRange:       "Wed, 15 Jan 2020 10:00:00 UTC",
+			expectedRange: "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			metadataStorage.EXPECT().GetFileByID(
+				gomock.Any(), fileID, gomock.Any(),
+			).Return(controller.FileMetadata{ //nolint: exhaustruct
+				ID:         fileID,
+				Name:       "my-file.txt",
+				Size:       13,
+				BucketID:   "default",
+				ETag:       `"55af1e60-0f28-454e-885e-ea6aab2bb288"`,
+				CreatedAt:  "2021-12-27T09:58:11Z",
+				UpdatedAt:  "2021-12-27T09:58:11Z",
+				IsUploaded: true,
+				MimeType:   "text/plain; charset=utf-8",
+			}, nil)
+
+			metadataStorage.EXPECT().GetBucketByID(
+				gomock.Any(), "default", gomock.Any(),
+			).Return(controller.BucketMetadata{ //nolint: exhaustruct
+				ID:           "default",
+				CacheControl: "max-age=3600",
+			}, nil)
+
+			contentStorage.EXPECT().GetFile(gomock.Any(), fileID, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ string, headers http.Header) (*controller.File, *controller.APIError) {
+					assert(t, tc.expectedRange, headers.Get("Range"))
+					return &controller.File{
+						StatusCode:    http.StatusOK,
+						Etag:          `"55af1e60-0f28-454e-885e-ea6aab2bb288"`,
+						Body:          io.NopCloser(strings.NewReader("Hello, world!")),
+						ContentLength: 13,
+						ExtraHeaders:  make(http.Header),
+					}, nil
+				},
+			)
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(), "GET", "/v1/files/"+fileID, nil,
+			)
+			req.Header.Set("Range", "bytes=0-4")
+			if tc.ifRange != "" {
+				req.Header.Set("If-Range", tc.ifRange)
+			}
+
+			router.ServeHTTP(responseRecorder, req)
+
+			if tc.expectedRange == "" {
+				assert(t, http.StatusOK, responseRecorder.Code)
+				assert(t, "Hello, world!", responseRecorder.Body.String())
+			} else {
+				assert(t, http.StatusPartialContent, responseRecorder.Code)
+				assert(t, "Hello", responseRecorder.Body.String())
+				assert(t, "bytes 0-4/13", responseRecorder.Header().Get("Content-Range"))
+			}
+		})
+	}
+}
+
+func TestGetFileRanges(t *testing.T) {
+	t.Parallel()
+
+	const fileID = "55af1e60-0f28-454e-885e-ea6aab2bb288"
+
+	content := "Hello, world!"
+
+	cases := []struct {
+		name           string
+		rangeHeader    string
+		expectedStatus int
+		expectedRange  string
+		expectedParts  []string
+	}{
+		{
+			name:           "single",
+			rangeHeader:    "bytes=7-",
+			expectedStatus: http.StatusPartialContent,
+			expectedRange:  "bytes 7-12/13",
+			expectedParts:  []string{"world!"},
+		},
+		{
+			name:           "suffix",
+			rangeHeader:    "bytes=-6",
+			expectedStatus: http.StatusPartialContent,
+			expectedRange:  "bytes 7-12/13",
+			expectedParts:  []string{"world!"},
+		},
+		{
+			name:           "multiple",
+			rangeHeader:    "bytes=0-4, 7-11",
+			expectedStatus: http.StatusPartialContent,
+			expectedParts:  []string{"Hello", "world"},
+		},
+		{
+			name:           "unsatisfiable",
+			rangeHeader:    "bytes=20-30",
+			expectedStatus: http.StatusRequestedRangeNotSatisfiable,
+			expectedRange:  "bytes */13",
+		},
+		{
+			name:           "invalid",
+			rangeHeader:    "bytes=5-1",
+			expectedStatus: http.StatusOK,
+			expectedParts:  []string{content},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			metadataStorage.EXPECT().GetFileByID(
+				gomock.Any(), fileID, gomock.Any(),
+			).Return(controller.FileMetadata{ //nolint: exhaustruct
+				ID:         fileID,
+				Name:       "my-file.txt",
+				Size:       int64(len(content)),
+				BucketID:   "default",
+				ETag:       `"55af1e60-0f28-454e-885e-ea6aab2bb288"`,
+				CreatedAt:  "2021-12-27T09:58:11Z",
+				UpdatedAt:  "2021-12-27T09:58:11Z",
+				IsUploaded: true,
+				MimeType:   "text/plain; charset=utf-8",
+			}, nil)
+
+			metadataStorage.EXPECT().GetBucketByID(
+				gomock.Any(), "default", gomock.Any(),
+			).Return(controller.BucketMetadata{ //nolint: exhaustruct
+				ID:           "default",
+				CacheControl: "max-age=3600",
+			}, nil)
+
+			// the mock ignores the range like some storage backends do
+			contentStorage.EXPECT().GetFile(gomock.Any(), fileID, gomock.Any()).DoAndReturn(
+				func(context.Context, string, http.Header) (*controller.File, *controller.APIError) {
+					return &controller.File{
+						StatusCode:    http.StatusOK,
+						Etag:          `"55af1e60-0f28-454e-885e-ea6aab2bb288"`,
+						Body:          io.NopCloser(strings.NewReader(content)),
+						ContentLength: int64(len(content)),
+						ExtraHeaders:  make(http.Header),
+					}, nil
+				},
+			).Times(len(tc.expectedParts))
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(), "GET", "/v1/files/"+fileID, nil,
+			)
+			req.Header.Set("Range", tc.rangeHeader)
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+			assert(t, tc.expectedRange, responseRecorder.Header().Get("Content-Range"))
+			assert(
+				t,
+				strconv.Itoa(responseRecorder.Body.Len()),
+				responseRecorder.Header().Get("Content-Length"),
+			)
+
+			if len(tc.expectedParts) < 2 {
+				assert(t, strings.Join(tc.expectedParts, ""), responseRecorder.Body.String())
+				return
+			}
+
+			mediaType, params, err := mime.ParseMediaType(responseRecorder.Header().Get("Content-Type"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert(t, "multipart/byteranges", mediaType)
+
+			reader := multipart.NewReader(responseRecorder.Body, params["boundary"])
+			var parts []string
+			for {
+				part, err := reader.NextPart()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				assert(t, "text/plain; charset=utf-8", part.Header.Get("Content-Type"))
+
+				b, err := io.ReadAll(part)
+				if err != nil {
+					t.Fatal(err)
+				}
+				parts = append(parts, string(b))
+			}
+			assert(t, tc.expectedParts, parts)
+		})
+	}
+}
@@ -21,6 +21,9 @@ type GetFileWithPresignedURLRequest struct {
 	signature string
 	headers   getFileInformationHeaders
 	Expires   int
+	// signed is true when the URL was signed by the service instead of the storage backend
+	signed      bool
+	disposition string
 }
 
 type File struct {
@@ -64,6 +67,10 @@ func (ctrl *Controller) getFileWithPresignedURLParse(
 			InternalServerError(fmt.Errorf("problem parsing request headers: %w", err))
 	}
 
+	if isSignedURL(ctx.Request.URL.Query()) {
+		return ctrl.getFileWithSignedURLParse(ctx, headers)
+	}
+
 	expires, apiErr := expiresIn(ctx.Request.URL.Query())
 	if apiErr != nil {
 		return GetFileWithPresignedURLRequest{}, apiErr //nolint: exhaustruct
@@ -86,6 +93,33 @@ func (ctrl *Controller) getFileWithPresignedURLParse(
 	}, nil
 }
 
+func (ctrl *Controller) getFileWithSignedURLParse(
+	ctx *gin.Context, headers getFileInformationHeaders,
+) (GetFileWithPresignedURLRequest, *APIError) {
+	if ctrl.urlSigner == nil {
+		return GetFileWithPresignedURLRequest{}, ForbiddenError( //nolint: exhaustruct
+			ErrSignedURLInvalid, "signed URLs are not enabled",
+		)
+	}
+
+	expires, err := ctrl.urlSigner.Verify(ctx.Param("id"), ctx.Request.URL.Query(), time.Now())
+	switch {
+	case errors.Is(err, ErrSignedURLExpired):
+		return GetFileWithPresignedURLRequest{}, BadDataError(err, err.Error()) //nolint: exhaustruct
+	case err != nil:
+		return GetFileWithPresignedURLRequest{}, ForbiddenError(err, err.Error()) //nolint: exhaustruct
+	}
+
+	return GetFileWithPresignedURLRequest{
+		fileID:      ctx.Param("id"),
+		signature:   "",
+		headers:     headers,
+		Expires:     int(expires.Seconds()),
+		signed:      true,
+		disposition: ctx.Query(signedURLDisposition),
+	}, nil
+}
+
 func (ctrl *Controller) getFileWithPresignedURL(ctx *gin.Context) (*FileResponse, *APIError) {
 	req, apiErr := ctrl.getFileWithPresignedURLParse(ctx)
 	if apiErr != nil {
@@ -96,7 +130,7 @@ func (ctrl *Controller) getFileWithPresignedURL(ctx *gin.Context) (*FileResponse
 		ctx.Request.Context(),
 		req.fileID,
 		true,
-		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret}},
+		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}},
 	)
 	if apiErr != nil {
 		return nil, apiErr
@@ -108,6 +142,9 @@ func (ctrl *Controller) getFileWithPresignedURL(ctx *gin.Context) (*FileResponse
 	}
 
 	downloadFunc := func() (*File, *APIError) {
+		if req.signed {
+			return ctrl.contentStorage.GetFile(ctx, objectKey, ctx.Request.Header)
+		}
 		return ctrl.contentStorage.GetFileWithPresignedURL(
 			ctx.Request.Context(),
 			objectKey,
@@ -116,13 +153,20 @@ func (ctrl *Controller) getFileWithPresignedURL(ctx *gin.Context) (*FileResponse
 		)
 	}
 
-	return ctrl.processFileToDownload(
+	response, apiErr := ctrl.processFileToDownload(
 		ctx,
 		downloadFunc,
 		fileMetadata,
 		fmt.Sprintf("max-age=%d", req.Expires),
 		nil,
 	)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	response.disposition = req.disposition
+
+	return response, nil
 }
 
 func (ctrl *Controller) GetFileWithPresignedURL(ctx *gin.Context) {
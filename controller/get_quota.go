@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GetQuotaResponse struct {
+	UserID string       `json:"userId"`
+	Usage  []Usage      `json:"usage"`
+	Quotas []QuotaUsage `json:"quotas"`
+}
+
+func (ctrl *Controller) getQuota(ctx *gin.Context) (GetQuotaResponse, *APIError) {
+	userID, role := ctrl.sessionVariables(ctx, ctx.Request.Header)
+	if userID == "" {
+		return GetQuotaResponse{}, NewAPIError(
+			http.StatusUnauthorized,
+			"a user session is required",
+			errors.New("no user id in the session"), //nolint: goerr113
+			nil,
+		)
+	}
+
+	quotas, usage, apiErr := ctrl.getQuotaUsage(ctx.Request.Context(), userID, role, "")
+	if apiErr != nil {
+		return GetQuotaResponse{}, apiErr
+	}
+
+	return GetQuotaResponse{
+		UserID: userID,
+		Usage:  usage,
+		Quotas: quotas,
+	}, nil
+}
+
+func (ctrl *Controller) GetQuota(ctx *gin.Context) {
+	res, apiErr := ctrl.getQuota(ctx)
+	if apiErr != nil {
+		_ = ctx.Error(fmt.Errorf("problem processing request: %w", apiErr))
+
+		ctx.JSON(apiErr.statusCode, CommonResponse{
+			Code:    apiErr.statusCode,
+			Message: apiErr.PublicResponse().Message,
+		})
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, CommonResponse{http.StatusOK, "ok", res})
+}
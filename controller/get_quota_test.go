@@ -0,0 +1,148 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestGetQuota(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name           string
+		headers        http.Header
+		expectedStatus int
+		expected       *controller.GetQuotaResponse
+	}{
+		{
+			name: "success",
+			headers: http.Header{
+				"Authorization": []string{bearer(t, "ab5ba58e-932a-40dc-87e8-733998794ec2", "user")},
+			},
+			expectedStatus: http.StatusOK,
+			expected: &controller.GetQuotaResponse{
+				UserID: "ab5ba58e-932a-40dc-87e8-733998794ec2",
+				Usage: []controller.Usage{
+					{BucketID: "default", Bytes: 100, Files: 2},
+					{BucketID: "images", Bytes: 50, Files: 1},
+				},
+				Quotas: []controller.QuotaUsage{
+					{
+						Quota: controller.Quota{ //nolint: exhaustruct
+							ID:       "a",
+							Role:     "user",
+							MaxBytes: 1000,
+						},
+						UsedBytes: 150,
+						UsedFiles: 3,
+					},
+					{
+						Quota: controller.Quota{ //nolint: exhaustruct
+							ID:       "b",
+							UserID:   "ab5ba58e-932a-40dc-87e8-733998794ec2",
+							BucketID: "images",
+							MaxFiles: 10,
+						},
+						UsedBytes: 50,
+						UsedFiles: 1,
+					},
+				},
+			},
+		},
+		{
+			name:           "no user",
+			headers:        http.Header{},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "session headers without a token",
+			headers: http.Header{
+				"X-Hasura-User-Id": []string{"ab5ba58e-932a-40dc-87e8-733998794ec2"},
+				"X-Hasura-Role":    []string{"user"},
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			if tc.expected != nil {
+				quotas := make([]controller.Quota, len(tc.expected.Quotas))
+				for i, q := range tc.expected.Quotas {
+					quotas[i] = q.Quota
+				}
+
+				metadataStorage.EXPECT().GetQuotas(
+					gomock.Any(), tc.expected.UserID, "user", "", gomock.Any(),
+				).Return(quotas, nil)
+
+				metadataStorage.EXPECT().GetUsage(
+					gomock.Any(), tc.expected.UserID, gomock.Any(),
+				).Return(tc.expected.Usage, nil)
+			}
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(
+				nil, "/v1", []string{"*"}, false, ginLogger(logger), verifyJWT(t),
+			)
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(context.Background(), "GET", "/v1/quota", nil)
+			req.Header = tc.headers
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+
+			if tc.expected == nil {
+				return
+			}
+
+			var resp struct {
+				Data controller.GetQuotaResponse `json:"data"`
+			}
+			if err := json.Unmarshal(responseRecorder.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(*tc.expected, resp.Data); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
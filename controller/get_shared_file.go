@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const sharePasswordHeader = "X-Nhost-Share-Password"
+
+func (share Share) expired(now time.Time) bool {
+	if share.ExpiresAt == "" {
+		return false
+	}
+	expiresAt, err := time.Parse(time.RFC3339, share.ExpiresAt)
+	if err != nil {
+		return true
+	}
+	return now.After(expiresAt)
+}
+
+func checkSharePassword(ctx *gin.Context, share Share) *APIError {
+	if share.PasswordHash == "" {
+		return nil
+	}
+
+	// the password isn't accepted in the query string so it doesn't end up in logs,
+	// browser history or referer headers
+	password := ctx.Request.Header.Get(sharePasswordHeader)
+
+	if err := bcrypt.CompareHashAndPassword([]byte(share.PasswordHash), []byte(password)); err != nil {
+		return NewAPIError(http.StatusUnauthorized, "invalid password", err, nil)
+	}
+
+	return nil
+}
+
+func shareDownloadLimitError() *APIError {
+	msg := "download limit reached"
+	return ForbiddenError(errors.New(msg), msg) //nolint: goerr113
+}
+
+func checkShareTransformations(ctx *gin.Context, share Share) *APIError {
+	for _, t := range shareTransformations {
+		if _, ok := ctx.GetQuery(t); ok && !slices.Contains(share.AllowedTransformations, t) {
+			msg := fmt.Sprintf("transformation %s not allowed for this share", t)
+			return ForbiddenError(errors.New(msg), msg) //nolint: goerr113
+		}
+	}
+	return nil
+}
+
+func (ctrl *Controller) getSharedFileProcess(ctx *gin.Context) (*FileResponse, *APIError) {
+	var headers getFileInformationHeaders
+	if err := ctx.ShouldBindHeader(&headers); err != nil {
+		return nil, InternalServerError(fmt.Errorf("problem parsing request headers: %w", err))
+	}
+
+	adminHeaders := http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}}
+
+	share, apiErr := ctrl.metadataStorage.GetShareByTokenHash(
+		ctx.Request.Context(), hashShareToken(ctx.Param("token")), adminHeaders,
+	)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	if share.expired(time.Now()) {
+		msg := "share expired"
+		return nil, ForbiddenError(errors.New(msg), msg) //nolint: goerr113
+	}
+
+	if apiErr := checkSharePassword(ctx, share); apiErr != nil {
+		return nil, apiErr
+	}
+
+	if apiErr := checkShareTransformations(ctx, share); apiErr != nil {
+		return nil, apiErr
+	}
+
+	if share.MaxDownloads > 0 && share.DownloadCount >= share.MaxDownloads {
+		return nil, shareDownloadLimitError()
+	}
+
+	fileMetadata, _, apiErr := ctrl.getFileMetadata(
+		ctx.Request.Context(), share.FileID, true, adminHeaders,
+	)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	filePath := fileMetadata.ID
+	if len(fileMetadata.ObjectKey) > 0 {
+		filePath = fileMetadata.ObjectKey
+	}
+
+	downloadFunc := func() (*File, *APIError) {
+		return ctrl.contentStorage.GetFile(ctx, filePath, ctx.Request.Header)
+	}
+
+	// shares can be revoked or limited so they shouldn't be cached
+	response, apiErr := ctrl.processFileToDownload(
+		ctx, downloadFunc, fileMetadata, "no-store", &headers)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	// only full downloads are counted, ranges and revalidations of a file already
+	// downloaded don't use up the limit
+	if response.statusCode == http.StatusOK {
+		ok, apiErr := ctrl.metadataStorage.IncrementShareDownloads(
+			ctx.Request.Context(), share.ID, share.MaxDownloads, adminHeaders,
+		)
+		if apiErr != nil {
+			response.body.Close()
+			return nil, apiErr
+		}
+		if !ok {
+			response.body.Close()
+			return nil, shareDownloadLimitError()
+		}
+
+		response.headers.Add(
+			"Content-Disposition",
+			fmt.Sprintf(`inline; filename="%s"`, url.QueryEscape(fileMetadata.Name)),
+		)
+	}
+
+	return response, nil
+}
+
+func (ctrl *Controller) GetSharedFile(ctx *gin.Context) {
+	response, apiErr := ctrl.getSharedFileProcess(ctx)
+	if apiErr != nil {
+		_ = ctx.Error(apiErr)
+
+		ctx.JSON(apiErr.statusCode, CommonResponse{
+			Code:    apiErr.statusCode,
+			Message: apiErr.PublicResponse().Message,
+		})
+
+		return
+	}
+
+	defer response.body.Close()
+
+	response.Write(ctx)
+}
@@ -0,0 +1,228 @@
+package controller_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestGetSharedFile(t *testing.T) {
+	t.Parallel()
+
+	const fileID = "55af1e60-0f28-454e-885e-ea6aab2bb288"
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte("hunter2"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	share := controller.Share{ //nolint: exhaustruct
+		ID:                     "8a9c3d6b-55a4-4b0a-bc5c-3f1e7f3c2a10",
+		FileID:                 fileID,
+		MaxDownloads:           5,
+		AllowedTransformations: []string{"w"},
+	}
+
+	withExpiry := share
+	withExpiry.ExpiresAt = time.Now().Add(-time.Minute).Format(time.RFC3339)
+
+	withPassword := share
+	withPassword.PasswordHash = string(passwordHash)
+
+	exhausted := share
+	exhausted.DownloadCount = 5
+
+	cases := []struct {
+		name           string
+		query          string
+		headers        http.Header
+		share          controller.Share
+		shareErr       *controller.APIError
+		fileStatus     int
+		counted        bool
+		limitReached   bool
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			share:          share,
+			fileStatus:     http.StatusOK,
+			counted:        true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown token",
+			shareErr:       controller.ErrShareNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "expired",
+			share:          withExpiry,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "missing password",
+			share:          withPassword,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "password",
+			share:          withPassword,
+			headers:        http.Header{"X-Nhost-Share-Password": []string{"hunter2"}},
+			fileStatus:     http.StatusOK,
+			counted:        true,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "password in query",
+			share:          withPassword,
+			query:          "?password=hunter2",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "range",
+			share:          share,
+			headers:        http.Header{"Range": []string{"bytes=0-4"}},
+			fileStatus:     http.StatusPartialContent,
+			expectedStatus: http.StatusPartialContent,
+		},
+		{
+			name:  "not modified",
+			share: share,
+			headers: http.Header{
+				"If-None-Match": []string{`"55af1e60-0f28-454e-885e-ea6aab2bb288"`},
+			},
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			name:           "transformation not allowed",
+			share:          share,
+			query:          "?q=50",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "download limit reached",
+			share:          share,
+			fileStatus:     http.StatusOK,
+			counted:        true,
+			limitReached:   true,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "download limit already reached",
+			share:          exhausted,
+			headers:        http.Header{"Range": []string{"bytes=0-4"}},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			metadataStorage.EXPECT().GetShareByTokenHash(
+				gomock.Any(), gomock.Any(), gomock.Any(),
+			).Return(tc.share, tc.shareErr)
+
+			if tc.fileStatus != 0 || tc.expectedStatus == http.StatusNotModified {
+				metadataStorage.EXPECT().GetFileByID(
+					gomock.Any(), fileID, gomock.Any(),
+				).Return(controller.FileMetadata{ //nolint: exhaustruct
+					ID:         fileID,
+					Name:       "my-file.txt",
+					Size:       13,
+					BucketID:   "default",
+					ETag:       `"55af1e60-0f28-454e-885e-ea6aab2bb288"`,
+					CreatedAt:  "2021-12-27T09:58:11Z",
+					UpdatedAt:  "2021-12-27T09:58:11Z",
+					IsUploaded: true,
+					MimeType:   "text/plain; charset=utf-8",
+				}, nil)
+
+				metadataStorage.EXPECT().GetBucketByID(
+					gomock.Any(), "default", gomock.Any(),
+				).Return(controller.BucketMetadata{ //nolint: exhaustruct
+					ID:           "default",
+					CacheControl: "max-age=3600",
+				}, nil)
+			}
+
+			if tc.counted {
+				metadataStorage.EXPECT().IncrementShareDownloads(
+					gomock.Any(), share.ID, int64(5), gomock.Any(),
+				).Return(!tc.limitReached, nil)
+			}
+
+			if tc.fileStatus != 0 {
+				content := "Hello, world!"
+				if tc.fileStatus == http.StatusPartialContent {
+					content = "Hello"
+				}
+
+				contentStorage.EXPECT().GetFile(gomock.Any(), fileID, gomock.Any()).Return(
+					&controller.File{
+						StatusCode:    tc.fileStatus,
+						Etag:          `"55af1e60-0f28-454e-885e-ea6aab2bb288"`,
+						Body:          io.NopCloser(strings.NewReader(content)),
+						ContentLength: int64(len(content)),
+						ExtraHeaders:  make(http.Header),
+					}, nil,
+				)
+			}
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(), "GET", "/v1/shares/some-token"+tc.query, nil,
+			)
+			if tc.headers != nil {
+				req.Header = tc.headers
+			}
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				assert(t, "Hello, world!", responseRecorder.Body.String())
+				assert(t, "no-store", responseRecorder.Header().Get("Cache-Control"))
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (ctrl *Controller) GetVirus(ctx *gin.Context) {
+	virus, apiErr := ctrl.metadataStorage.GetVirusByID(
+		ctx.Request.Context(),
+		ctx.Param("id"),
+		ctx.Request.Header,
+	)
+	if apiErr != nil {
+		_ = ctx.Error(fmt.Errorf("problem processing request: %w", apiErr))
+
+		ctx.JSON(apiErr.statusCode, CommonResponse{
+			Code:    apiErr.statusCode,
+			Message: apiErr.PublicResponse().Message,
+		})
+
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		CommonResponse{
+			http.StatusOK,
+			"ok",
+			virus,
+		},
+	)
+}
@@ -1,7 +1,10 @@
 package controller
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"path"
 
@@ -12,9 +15,26 @@ type ListBrokenMetadataResponse struct {
 	Metadata []FileSummary `json:"metadata"`
 }
 
+// parseFileFilter reads the optional filter sent in the body of the ops requests.
+func parseFileFilter(ctx *gin.Context) (FileFilter, *APIError) {
+	var filter FileFilter
+	if err := json.NewDecoder(ctx.Request.Body).Decode(&filter); err != nil &&
+		!errors.Is(err, io.EOF) {
+		return FileFilter{}, BadDataError(err, "couldn't decode filter")
+	}
+
+	return filter, nil
+}
+
 func (ctrl *Controller) listBrokenMetadata(ctx *gin.Context) ([]FileSummary, *APIError) {
+	filter, apiErr := parseFileFilter(ctx)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
 	filesInHasura, apiErr := ctrl.metadataStorage.ListFiles(
 		ctx.Request.Context(),
+		filter,
 		ctx.Request.Header,
 	)
 	if apiErr != nil {
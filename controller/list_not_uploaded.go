@@ -8,8 +8,14 @@ import (
 )
 
 func (ctrl *Controller) listNotUploaded(ctx *gin.Context) ([]FileSummary, *APIError) {
+	filter, apiErr := parseFileFilter(ctx)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
 	filesInHasura, apiErr := ctrl.metadataStorage.ListFiles(
 		ctx.Request.Context(),
+		filter,
 		ctx.Request.Header,
 	)
 	if apiErr != nil {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/nhost/hasura-storage/controller"
 	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
 	"github.com/sirupsen/logrus"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -49,7 +50,7 @@ func TestListNotUploaded(t *testing.T) {
 			contentStorage := mock.NewMockContentStorage(c)
 
 			metadataStorage.EXPECT().ListFiles(
-				gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), gomock.Any(),
 			).Return(
 				[]controller.FileSummary{
 					{
@@ -82,11 +83,14 @@ func TestListNotUploaded(t *testing.T) {
 			ctrl := controller.New(
 				"http://asd",
 				"/v1",
-				"asdasd",
+				auth.NewAdminSecrets("asdasd"),
 				metadataStorage,
 				contentStorage,
 				nil,
 				nil,
+				nil,
+				nil,
+				nil,
 				logger,
 			)
 
@@ -13,8 +13,11 @@ type ListOrphansResponse struct {
 }
 
 func (ctrl *Controller) listOrphans(ctx *gin.Context) ([]string, *APIError) {
+	// orphans have no metadata so they can't be filtered, all the files are needed to
+	// find them
 	filesInHasura, apiErr := ctrl.metadataStorage.ListFiles(
 		ctx.Request.Context(),
+		FileFilter{}, //nolint: exhaustruct
 		ctx.Request.Header,
 	)
 	if apiErr != nil {
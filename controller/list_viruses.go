@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ListVirusesResponse struct {
+	Viruses []VirusMetadata `json:"viruses"`
+}
+
+func parseVirusFilter(ctx *gin.Context) (VirusFilter, *APIError) {
+	var filter VirusFilter
+	if err := json.NewDecoder(ctx.Request.Body).Decode(&filter); err != nil &&
+		!errors.Is(err, io.EOF) {
+		return VirusFilter{}, BadDataError(err, "couldn't decode filter")
+	}
+
+	for _, date := range []string{filter.CreatedAfter, filter.CreatedBefore} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, date); err != nil {
+			return VirusFilter{}, ErrWrongDate
+		}
+	}
+
+	return filter, nil
+}
+
+func (ctrl *Controller) listViruses(ctx *gin.Context) ([]VirusMetadata, *APIError) {
+	filter, apiErr := parseVirusFilter(ctx)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	viruses, apiErr := ctrl.metadataStorage.ListViruses(
+		ctx.Request.Context(),
+		filter,
+		ctx.Request.Header,
+	)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	// user sessions are only returned when requesting a single finding
+	for i := range viruses {
+		viruses[i].UserSession = nil
+	}
+
+	return viruses, nil
+}
+
+func (ctrl *Controller) ListViruses(ctx *gin.Context) {
+	viruses, apiErr := ctrl.listViruses(ctx)
+	if apiErr != nil {
+		_ = ctx.Error(fmt.Errorf("problem processing request: %w", apiErr))
+
+		ctx.JSON(apiErr.statusCode, CommonResponse{
+			Code:    apiErr.statusCode,
+			Message: apiErr.PublicResponse().Message,
+		})
+
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		CommonResponse{
+			http.StatusOK,
+			"ok",
+			ListVirusesResponse{
+				viruses,
+			},
+		},
+	)
+}
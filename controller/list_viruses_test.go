@@ -0,0 +1,159 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func ptr[T any](x T) *T {
+	return &x
+}
+
+func TestListViruses(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name           string
+		body           string
+		filter         *controller.VirusFilter
+		expectedStatus int
+		expected       controller.ListVirusesResponse
+	}{
+		{
+			name:   "no filter",
+			body:   "",
+			filter: &controller.VirusFilter{},
+			expected: controller.ListVirusesResponse{
+				Viruses: []controller.VirusMetadata{
+					{
+						ID:        "0ac4cb2c-64ec-4ae6-a5a4-6bd0f3e0a1bd",
+						FileID:    "55af1e60-0f28-454e-885e-ea6aab2bb288",
+						Filename:  "eicar.txt",
+						BucketID:  "default",
+						Virus:     "Eicar-Signature",
+						CreatedAt: "2024-01-02T03:04:05Z",
+						UpdatedAt: "2024-01-02T03:04:05Z",
+					},
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "with filter",
+			body: `{"bucketId":"default","userId":"d7d0a8c3-5d3b-4d0e-8a4c-5d4f0c1f7b3a","virus":"eicar","createdAfter":"2024-01-01T00:00:00Z","falsePositive":false}`, //nolint: lll
+			filter: &controller.VirusFilter{
+				BucketID:      "default",
+				UserID:        "d7d0a8c3-5d3b-4d0e-8a4c-5d4f0c1f7b3a",
+				Virus:         "eicar",
+				CreatedAfter:  "2024-01-01T00:00:00Z",
+				CreatedBefore: "",
+				FalsePositive: ptr(false),
+			},
+			expected: controller.ListVirusesResponse{
+				Viruses: []controller.VirusMetadata{
+					{
+						ID:        "0ac4cb2c-64ec-4ae6-a5a4-6bd0f3e0a1bd",
+						FileID:    "55af1e60-0f28-454e-885e-ea6aab2bb288",
+						Filename:  "eicar.txt",
+						BucketID:  "default",
+						Virus:     "Eicar-Signature",
+						CreatedAt: "2024-01-02T03:04:05Z",
+						UpdatedAt: "2024-01-02T03:04:05Z",
+					},
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "wrong date",
+			body:           `{"createdBefore":"yesterday"}`,
+			filter:         nil,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			if tc.filter != nil {
+				metadataStorage.EXPECT().ListViruses(
+					gomock.Any(), *tc.filter, gomock.Any(),
+				).Return(
+					[]controller.VirusMetadata{
+						{
+							ID:          "0ac4cb2c-64ec-4ae6-a5a4-6bd0f3e0a1bd",
+							FileID:      "55af1e60-0f28-454e-885e-ea6aab2bb288",
+							Filename:    "eicar.txt",
+							BucketID:    "default",
+							Virus:       "Eicar-Signature",
+							UserSession: map[string]any{"hasura_headers": map[string]any{}},
+							CreatedAt:   "2024-01-02T03:04:05Z",
+							UpdatedAt:   "2024-01-02T03:04:05Z",
+						},
+					}, nil,
+				)
+			}
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(),
+				"POST",
+				"/v1/ops/list-viruses",
+				strings.NewReader(tc.body),
+			)
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+
+			resp := struct {
+				Data controller.ListVirusesResponse `json:"data"`
+			}{}
+			if err := json.Unmarshal(responseRecorder.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			assert(t, tc.expected, resp.Data)
+		})
+	}
+}
@@ -7,13 +7,17 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/middleware/auth"
 	"github.com/sirupsen/logrus"
 	gomock "go.uber.org/mock/gomock"
 )
 
+const jwtKey = "a-very-long-secret-used-only-for-testing-purposes"
+
 type readerMatcher struct {
 	v string
 }
@@ -95,6 +99,38 @@ func ginLogger(logger *logrus.Logger) gin.HandlerFunc {
 	}
 }
 
+// verifyJWT verifies access tokens the same way the service does so handlers get a
+// verified session.
+func verifyJWT(t *testing.T) gin.HandlerFunc {
+	t.Helper()
+
+	verifier, err := auth.NewJWTVerifier(`{"type":"HS256","key":"` + jwtKey + `"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return auth.VerifyJWT(verifier, auth.NewAdminSecrets("asdasd"))
+}
+
+// bearer returns the value of the Authorization header for an access token of the user.
+func bearer(t *testing.T, userID, role string) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"https://hasura.io/jwt/claims": map[string]any{
+			"x-hasura-allowed-roles": []any{role},
+			"x-hasura-default-role":  role,
+			"x-hasura-user-id":       userID,
+		},
+	}).SignedString([]byte(jwtKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return "Bearer " + token
+}
+
 func assert(t *testing.T, got, wanted interface{}, opts ...cmp.Option) {
 	t.Helper()
 
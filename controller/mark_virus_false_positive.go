@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (ctrl *Controller) markVirusFalsePositive(ctx *gin.Context) (VirusMetadata, *APIError) {
+	virus, apiErr := ctrl.metadataStorage.GetVirusByID(
+		ctx.Request.Context(),
+		ctx.Param("id"),
+		ctx.Request.Header,
+	)
+	if apiErr != nil {
+		return VirusMetadata{}, apiErr
+	}
+
+	fileMetadata, apiErr := ctrl.metadataStorage.GetFileByID(
+		ctx.Request.Context(),
+		virus.FileID,
+		ctx.Request.Header,
+	)
+	if apiErr != nil {
+		return VirusMetadata{}, apiErr
+	}
+
+	// findings recorded before infected content was quarantined have nothing to release
+	if virus.Quarantine == nil {
+		errMsg := "file content wasn't kept, it needs to be uploaded again"
+		return VirusMetadata{}, NewAPIError(http.StatusConflict, errMsg, errors.New(errMsg), nil)
+	}
+
+	// the finding is flagged first as that's where the permissions of the caller are
+	// checked, the flag is reset if the file can't be released so it can be retried
+	if apiErr := ctrl.metadataStorage.SetVirusFalsePositive(
+		ctx.Request.Context(),
+		virus.ID,
+		true,
+		ctx.Request.Header,
+	); apiErr != nil {
+		return VirusMetadata{}, apiErr
+	}
+
+	if _, apiErr := ctrl.releaseQuarantine(ctx, fileMetadata, *virus.Quarantine); apiErr != nil {
+		if err := ctrl.metadataStorage.SetVirusFalsePositive(
+			ctx.Request.Context(),
+			virus.ID,
+			false,
+			http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}},
+		); err != nil {
+			ctrl.logger.WithError(err).Error("problem resetting false positive of virus " + virus.ID)
+		}
+		return VirusMetadata{}, apiErr.ExtendError("problem releasing quarantined file")
+	}
+
+	ctx.Set("FileChanged", fileMetadata.ID)
+
+	virus.FalsePositive = true
+	return virus, nil
+}
+
+func (ctrl *Controller) MarkVirusFalsePositive(ctx *gin.Context) {
+	virus, apiErr := ctrl.markVirusFalsePositive(ctx)
+	if apiErr != nil {
+		_ = ctx.Error(fmt.Errorf("problem processing request: %w", apiErr))
+
+		ctx.JSON(apiErr.statusCode, CommonResponse{
+			Code:    apiErr.statusCode,
+			Message: apiErr.PublicResponse().Message,
+		})
+
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		CommonResponse{
+			http.StatusOK,
+			"ok",
+			virus,
+		},
+	)
+}
@@ -0,0 +1,173 @@
+package controller_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+)
+
+const quarantineKey = ".quarantine/0ac4cb2c-64ec-4ae6-a5a4-6bd0f3e0a1bd/55af1e60-0f28-454e-885e-ea6aab2bb288"
+
+func TestMarkVirusFalsePositive(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name           string
+		quarantine     *controller.QuarantinedFile
+		copyErr        *controller.APIError
+		expectedStatus int
+	}{
+		{
+			name: "success",
+			quarantine: &controller.QuarantinedFile{ //nolint: exhaustruct
+				ObjectKey: quarantineKey,
+				Name:      "eicar.txt",
+				Size:      68,
+				MimeType:  "text/plain",
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "release fails",
+			quarantine: &controller.QuarantinedFile{ //nolint: exhaustruct
+				ObjectKey: quarantineKey,
+				Name:      "eicar.txt",
+				Size:      68,
+				MimeType:  "text/plain",
+			},
+			copyErr:        controller.InternalServerError(errors.New("some error")), //nolint: goerr113
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "content not kept",
+			quarantine:     nil,
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			metadataStorage.EXPECT().GetVirusByID(
+				gomock.Any(), "0ac4cb2c-64ec-4ae6-a5a4-6bd0f3e0a1bd", gomock.Any(),
+			).Return(
+				controller.VirusMetadata{
+					ID:         "0ac4cb2c-64ec-4ae6-a5a4-6bd0f3e0a1bd",
+					FileID:     "55af1e60-0f28-454e-885e-ea6aab2bb288",
+					Filename:   "eicar.txt",
+					BucketID:   "default",
+					Virus:      "Eicar-Signature",
+					Quarantine: tc.quarantine,
+				}, nil,
+			)
+
+			metadataStorage.EXPECT().GetFileByID(
+				gomock.Any(), "55af1e60-0f28-454e-885e-ea6aab2bb288", gomock.Any(),
+			).Return(
+				controller.FileMetadata{
+					ID:       "55af1e60-0f28-454e-885e-ea6aab2bb288",
+					Name:     "eicar.txt",
+					BucketID: "default",
+				}, nil,
+			)
+
+			if tc.quarantine != nil {
+				metadataStorage.EXPECT().SetVirusFalsePositive(
+					gomock.Any(), "0ac4cb2c-64ec-4ae6-a5a4-6bd0f3e0a1bd", true, gomock.Any(),
+				).Return(nil)
+
+				metadataStorage.EXPECT().GetBucketByID(
+					gomock.Any(), "default", gomock.Any(),
+				).Return(controller.BucketMetadata{ID: "default"}, nil) //nolint: exhaustruct
+
+				// the file gets the quarantined content and the quarantined copy is removed
+				contentStorage.EXPECT().CopyFile(
+					gomock.Any(), quarantineKey, "55af1e60-0f28-454e-885e-ea6aab2bb288", int64(68),
+				).Return("\"some-etag\"", tc.copyErr)
+			}
+
+			if tc.copyErr != nil {
+				// the finding can be marked again once the file can be released
+				metadataStorage.EXPECT().SetVirusFalsePositive(
+					gomock.Any(), "0ac4cb2c-64ec-4ae6-a5a4-6bd0f3e0a1bd", false, gomock.Any(),
+				).Return(nil)
+			}
+
+			if tc.quarantine != nil && tc.copyErr == nil {
+				metadataStorage.EXPECT().PopulateMetadata(
+					gomock.Any(),
+					"55af1e60-0f28-454e-885e-ea6aab2bb288",
+					"eicar.txt",
+					int64(68),
+					"default",
+					"\"some-etag\"",
+					true,
+					"text/plain",
+					"55af1e60-0f28-454e-885e-ea6aab2bb288",
+					int64(68),
+					int64(1),
+					"",
+					"",
+					"",
+					gomock.Any(),
+					gomock.Any(),
+					gomock.Any(),
+				).Return(controller.FileMetadata{ //nolint: exhaustruct
+					ID:         "55af1e60-0f28-454e-885e-ea6aab2bb288",
+					Name:       "eicar.txt",
+					BucketID:   "default",
+					IsUploaded: true,
+				}, nil)
+
+				contentStorage.EXPECT().DeleteFile(gomock.Any(), quarantineKey).Return(nil)
+			}
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(),
+				"POST",
+				"/v1/ops/viruses/0ac4cb2c-64ec-4ae6-a5a4-6bd0f3e0a1bd/false-positive",
+				nil,
+			)
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+		})
+	}
+}
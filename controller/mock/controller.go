@@ -45,17 +45,60 @@ func (m *MockMetadataStorage) EXPECT() *MockMetadataStorageMockRecorder {
 }
 
 // DeleteFileByID mocks base method.
-func (m *MockMetadataStorage) DeleteFileByID(ctx context.Context, fileID string, headers http.Header) *controller.APIError {
+func (m *MockMetadataStorage) DeleteFileByID(ctx context.Context, fileID string, condition controller.FileCondition, events []controller.WebhookEvent, headers http.Header) *controller.APIError {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteFileByID", ctx, fileID, headers)
+	ret := m.ctrl.Call(m, "DeleteFileByID", ctx, fileID, condition, events, headers)
 	ret0, _ := ret[0].(*controller.APIError)
 	return ret0
 }
 
 // DeleteFileByID indicates an expected call of DeleteFileByID.
-func (mr *MockMetadataStorageMockRecorder) DeleteFileByID(ctx, fileID, headers interface{}) *gomock.Call {
+func (mr *MockMetadataStorageMockRecorder) DeleteFileByID(ctx, fileID, condition, events, headers interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFileByID", reflect.TypeOf((*MockMetadataStorage)(nil).DeleteFileByID), ctx, fileID, headers)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFileByID", reflect.TypeOf((*MockMetadataStorage)(nil).DeleteFileByID), ctx, fileID, condition, events, headers)
+}
+
+// DeleteShare mocks base method.
+func (m *MockMetadataStorage) DeleteShare(ctx context.Context, id, fileID, createdByUserID string, headers http.Header) *controller.APIError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShare", ctx, id, fileID, createdByUserID, headers)
+	ret0, _ := ret[0].(*controller.APIError)
+	return ret0
+}
+
+// DeleteShare indicates an expected call of DeleteShare.
+func (mr *MockMetadataStorageMockRecorder) DeleteShare(ctx, id, fileID, createdByUserID, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShare", reflect.TypeOf((*MockMetadataStorage)(nil).DeleteShare), ctx, id, fileID, createdByUserID, headers)
+}
+
+// DeleteVirusesByFileID mocks base method.
+func (m *MockMetadataStorage) DeleteVirusesByFileID(ctx context.Context, fileID string, headers http.Header) *controller.APIError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVirusesByFileID", ctx, fileID, headers)
+	ret0, _ := ret[0].(*controller.APIError)
+	return ret0
+}
+
+// DeleteVirusesByFileID indicates an expected call of DeleteVirusesByFileID.
+func (mr *MockMetadataStorageMockRecorder) DeleteVirusesByFileID(ctx, fileID, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVirusesByFileID", reflect.TypeOf((*MockMetadataStorage)(nil).DeleteVirusesByFileID), ctx, fileID, headers)
+}
+
+// GetAPIKeyByHash mocks base method.
+func (m *MockMetadataStorage) GetAPIKeyByHash(ctx context.Context, keyHash string, headers http.Header) (controller.APIKey, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeyByHash", ctx, keyHash, headers)
+	ret0, _ := ret[0].(controller.APIKey)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// GetAPIKeyByHash indicates an expected call of GetAPIKeyByHash.
+func (mr *MockMetadataStorageMockRecorder) GetAPIKeyByHash(ctx, keyHash, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyByHash", reflect.TypeOf((*MockMetadataStorage)(nil).GetAPIKeyByHash), ctx, keyHash, headers)
 }
 
 // GetBucketByID mocks base method.
@@ -88,6 +131,21 @@ func (mr *MockMetadataStorageMockRecorder) GetFileByID(ctx, id, headers interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileByID", reflect.TypeOf((*MockMetadataStorage)(nil).GetFileByID), ctx, id, headers)
 }
 
+// GetFileVersionByID mocks base method.
+func (m *MockMetadataStorage) GetFileVersionByID(ctx context.Context, id string, headers http.Header) (controller.FileVersion, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileVersionByID", ctx, id, headers)
+	ret0, _ := ret[0].(controller.FileVersion)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// GetFileVersionByID indicates an expected call of GetFileVersionByID.
+func (mr *MockMetadataStorageMockRecorder) GetFileVersionByID(ctx, id, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileVersionByID", reflect.TypeOf((*MockMetadataStorage)(nil).GetFileVersionByID), ctx, id, headers)
+}
+
 // GetFilesByETag mocks base method.
 func (m *MockMetadataStorage) GetFilesByETag(ctx context.Context, etag string, headers http.Header) ([]controller.FileMetadata, *controller.APIError) {
 	m.ctrl.T.Helper()
@@ -103,76 +161,314 @@ func (mr *MockMetadataStorageMockRecorder) GetFilesByETag(ctx, etag, headers int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilesByETag", reflect.TypeOf((*MockMetadataStorage)(nil).GetFilesByETag), ctx, etag, headers)
 }
 
+// GetQuotas mocks base method.
+func (m *MockMetadataStorage) GetQuotas(ctx context.Context, userID, role, bucketID string, headers http.Header) ([]controller.Quota, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuotas", ctx, userID, role, bucketID, headers)
+	ret0, _ := ret[0].([]controller.Quota)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// GetQuotas indicates an expected call of GetQuotas.
+func (mr *MockMetadataStorageMockRecorder) GetQuotas(ctx, userID, role, bucketID, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuotas", reflect.TypeOf((*MockMetadataStorage)(nil).GetQuotas), ctx, userID, role, bucketID, headers)
+}
+
+// GetShareByTokenHash mocks base method.
+func (m *MockMetadataStorage) GetShareByTokenHash(ctx context.Context, tokenHash string, headers http.Header) (controller.Share, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShareByTokenHash", ctx, tokenHash, headers)
+	ret0, _ := ret[0].(controller.Share)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// GetShareByTokenHash indicates an expected call of GetShareByTokenHash.
+func (mr *MockMetadataStorageMockRecorder) GetShareByTokenHash(ctx, tokenHash, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShareByTokenHash", reflect.TypeOf((*MockMetadataStorage)(nil).GetShareByTokenHash), ctx, tokenHash, headers)
+}
+
+// GetUsage mocks base method.
+func (m *MockMetadataStorage) GetUsage(ctx context.Context, userID string, headers http.Header) ([]controller.Usage, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsage", ctx, userID, headers)
+	ret0, _ := ret[0].([]controller.Usage)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// GetUsage indicates an expected call of GetUsage.
+func (mr *MockMetadataStorageMockRecorder) GetUsage(ctx, userID, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockMetadataStorage)(nil).GetUsage), ctx, userID, headers)
+}
+
+// GetVirusByID mocks base method.
+func (m *MockMetadataStorage) GetVirusByID(ctx context.Context, id string, headers http.Header) (controller.VirusMetadata, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVirusByID", ctx, id, headers)
+	ret0, _ := ret[0].(controller.VirusMetadata)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// GetVirusByID indicates an expected call of GetVirusByID.
+func (mr *MockMetadataStorageMockRecorder) GetVirusByID(ctx, id, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVirusByID", reflect.TypeOf((*MockMetadataStorage)(nil).GetVirusByID), ctx, id, headers)
+}
+
+// IncrementShareDownloads mocks base method.
+func (m *MockMetadataStorage) IncrementShareDownloads(ctx context.Context, id string, maxDownloads int64, headers http.Header) (bool, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementShareDownloads", ctx, id, maxDownloads, headers)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// IncrementShareDownloads indicates an expected call of IncrementShareDownloads.
+func (mr *MockMetadataStorageMockRecorder) IncrementShareDownloads(ctx, id, maxDownloads, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementShareDownloads", reflect.TypeOf((*MockMetadataStorage)(nil).IncrementShareDownloads), ctx, id, maxDownloads, headers)
+}
+
 // InitializeFile mocks base method.
-func (m *MockMetadataStorage) InitializeFile(ctx context.Context, id, name string, size int64, bucketID, mimeType, objectKey string, chunkSize, chunkCount int64, uploadId string, headers http.Header) *controller.APIError {
+func (m *MockMetadataStorage) InitializeFile(ctx context.Context, id, name string, size int64, bucketID, mimeType, objectKey string, chunkSize, chunkCount int64, uploadId, expiresAt string, headers http.Header) *controller.APIError {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InitializeFile", ctx, id, name, size, bucketID, mimeType, objectKey, chunkSize, chunkCount, uploadId, headers)
+	ret := m.ctrl.Call(m, "InitializeFile", ctx, id, name, size, bucketID, mimeType, objectKey, chunkSize, chunkCount, uploadId, expiresAt, headers)
 	ret0, _ := ret[0].(*controller.APIError)
 	return ret0
 }
 
 // InitializeFile indicates an expected call of InitializeFile.
-func (mr *MockMetadataStorageMockRecorder) InitializeFile(ctx, id, name, size, bucketID, mimeType, objectKey, chunkSize, chunkCount, uploadId, headers interface{}) *gomock.Call {
+func (mr *MockMetadataStorageMockRecorder) InitializeFile(ctx, id, name, size, bucketID, mimeType, objectKey, chunkSize, chunkCount, uploadId, expiresAt, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitializeFile", reflect.TypeOf((*MockMetadataStorage)(nil).InitializeFile), ctx, id, name, size, bucketID, mimeType, objectKey, chunkSize, chunkCount, uploadId, expiresAt, headers)
+}
+
+// InsertFileVersion mocks base method.
+func (m *MockMetadataStorage) InsertFileVersion(ctx context.Context, version controller.FileVersion, headers http.Header) (controller.FileVersion, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertFileVersion", ctx, version, headers)
+	ret0, _ := ret[0].(controller.FileVersion)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// InsertFileVersion indicates an expected call of InsertFileVersion.
+func (mr *MockMetadataStorageMockRecorder) InsertFileVersion(ctx, version, headers interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitializeFile", reflect.TypeOf((*MockMetadataStorage)(nil).InitializeFile), ctx, id, name, size, bucketID, mimeType, objectKey, chunkSize, chunkCount, uploadId, headers)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertFileVersion", reflect.TypeOf((*MockMetadataStorage)(nil).InsertFileVersion), ctx, version, headers)
+}
+
+// InsertShare mocks base method.
+func (m *MockMetadataStorage) InsertShare(ctx context.Context, share controller.Share, tokenHash string, headers http.Header) (controller.Share, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertShare", ctx, share, tokenHash, headers)
+	ret0, _ := ret[0].(controller.Share)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// InsertShare indicates an expected call of InsertShare.
+func (mr *MockMetadataStorageMockRecorder) InsertShare(ctx, share, tokenHash, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertShare", reflect.TypeOf((*MockMetadataStorage)(nil).InsertShare), ctx, share, tokenHash, headers)
 }
 
 // InsertVirus mocks base method.
-func (m *MockMetadataStorage) InsertVirus(ctx context.Context, fileID, filename, virus string, userSession map[string]any, headers http.Header) *controller.APIError {
+func (m *MockMetadataStorage) InsertVirus(ctx context.Context, virus controller.VirusMetadata, events []controller.WebhookEvent, headers http.Header) *controller.APIError {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertVirus", ctx, fileID, filename, virus, userSession, headers)
+	ret := m.ctrl.Call(m, "InsertVirus", ctx, virus, events, headers)
 	ret0, _ := ret[0].(*controller.APIError)
 	return ret0
 }
 
 // InsertVirus indicates an expected call of InsertVirus.
-func (mr *MockMetadataStorageMockRecorder) InsertVirus(ctx, fileID, filename, virus, userSession, headers interface{}) *gomock.Call {
+func (mr *MockMetadataStorageMockRecorder) InsertVirus(ctx, virus, events, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertVirus", reflect.TypeOf((*MockMetadataStorage)(nil).InsertVirus), ctx, virus, events, headers)
+}
+
+// ListFileVersions mocks base method.
+func (m *MockMetadataStorage) ListFileVersions(ctx context.Context, fileID string, headers http.Header) ([]controller.FileVersion, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFileVersions", ctx, fileID, headers)
+	ret0, _ := ret[0].([]controller.FileVersion)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// ListFileVersions indicates an expected call of ListFileVersions.
+func (mr *MockMetadataStorageMockRecorder) ListFileVersions(ctx, fileID, headers interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertVirus", reflect.TypeOf((*MockMetadataStorage)(nil).InsertVirus), ctx, fileID, filename, virus, userSession, headers)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFileVersions", reflect.TypeOf((*MockMetadataStorage)(nil).ListFileVersions), ctx, fileID, headers)
 }
 
 // ListFiles mocks base method.
-func (m *MockMetadataStorage) ListFiles(ctx context.Context, headers http.Header) ([]controller.FileSummary, *controller.APIError) {
+func (m *MockMetadataStorage) ListFiles(ctx context.Context, filter controller.FileFilter, headers http.Header) ([]controller.FileSummary, *controller.APIError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListFiles", ctx, headers)
+	ret := m.ctrl.Call(m, "ListFiles", ctx, filter, headers)
 	ret0, _ := ret[0].([]controller.FileSummary)
 	ret1, _ := ret[1].(*controller.APIError)
 	return ret0, ret1
 }
 
 // ListFiles indicates an expected call of ListFiles.
-func (mr *MockMetadataStorageMockRecorder) ListFiles(ctx, headers interface{}) *gomock.Call {
+func (mr *MockMetadataStorageMockRecorder) ListFiles(ctx, filter, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFiles", reflect.TypeOf((*MockMetadataStorage)(nil).ListFiles), ctx, filter, headers)
+}
+
+// ListTrashedFiles mocks base method.
+func (m *MockMetadataStorage) ListTrashedFiles(ctx context.Context, filter controller.FileFilter, headers http.Header) ([]controller.FileMetadata, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrashedFiles", ctx, filter, headers)
+	ret0, _ := ret[0].([]controller.FileMetadata)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// ListTrashedFiles indicates an expected call of ListTrashedFiles.
+func (mr *MockMetadataStorageMockRecorder) ListTrashedFiles(ctx, filter, headers interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFiles", reflect.TypeOf((*MockMetadataStorage)(nil).ListFiles), ctx, headers)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrashedFiles", reflect.TypeOf((*MockMetadataStorage)(nil).ListTrashedFiles), ctx, filter, headers)
+}
+
+// ListViruses mocks base method.
+func (m *MockMetadataStorage) ListViruses(ctx context.Context, filter controller.VirusFilter, headers http.Header) ([]controller.VirusMetadata, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListViruses", ctx, filter, headers)
+	ret0, _ := ret[0].([]controller.VirusMetadata)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// ListViruses indicates an expected call of ListViruses.
+func (mr *MockMetadataStorageMockRecorder) ListViruses(ctx, filter, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListViruses", reflect.TypeOf((*MockMetadataStorage)(nil).ListViruses), ctx, filter, headers)
 }
 
 // PopulateMetadata mocks base method.
-func (m *MockMetadataStorage) PopulateMetadata(ctx context.Context, id, name string, size int64, bucketID, etag string, IsUploaded bool, mimeType, objectKey string, chunkSize, chunkCount int64, uploadId string, metadata map[string]any, headers http.Header) (controller.FileMetadata, *controller.APIError) {
+func (m *MockMetadataStorage) PopulateMetadata(ctx context.Context, id, name string, size int64, bucketID, etag string, IsUploaded bool, mimeType, objectKey string, chunkSize, chunkCount int64, uploadId, uploadedByUserID, uploadedByRole string, metadata map[string]any, events []controller.WebhookEvent, headers http.Header) (controller.FileMetadata, *controller.APIError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PopulateMetadata", ctx, id, name, size, bucketID, etag, IsUploaded, mimeType, objectKey, chunkSize, chunkCount, uploadId, metadata, headers)
+	ret := m.ctrl.Call(m, "PopulateMetadata", ctx, id, name, size, bucketID, etag, IsUploaded, mimeType, objectKey, chunkSize, chunkCount, uploadId, uploadedByUserID, uploadedByRole, metadata, events, headers)
 	ret0, _ := ret[0].(controller.FileMetadata)
 	ret1, _ := ret[1].(*controller.APIError)
 	return ret0, ret1
 }
 
 // PopulateMetadata indicates an expected call of PopulateMetadata.
-func (mr *MockMetadataStorageMockRecorder) PopulateMetadata(ctx, id, name, size, bucketID, etag, IsUploaded, mimeType, objectKey, chunkSize, chunkCount, uploadId, metadata, headers interface{}) *gomock.Call {
+func (mr *MockMetadataStorageMockRecorder) PopulateMetadata(ctx, id, name, size, bucketID, etag, IsUploaded, mimeType, objectKey, chunkSize, chunkCount, uploadId, uploadedByUserID, uploadedByRole, metadata, events, headers interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PopulateMetadata", reflect.TypeOf((*MockMetadataStorage)(nil).PopulateMetadata), ctx, id, name, size, bucketID, etag, IsUploaded, mimeType, objectKey, chunkSize, chunkCount, uploadId, metadata, headers)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PopulateMetadata", reflect.TypeOf((*MockMetadataStorage)(nil).PopulateMetadata), ctx, id, name, size, bucketID, etag, IsUploaded, mimeType, objectKey, chunkSize, chunkCount, uploadId, uploadedByUserID, uploadedByRole, metadata, events, headers)
+}
+
+// RestoreFile mocks base method.
+func (m *MockMetadataStorage) RestoreFile(ctx context.Context, fileID string, events []controller.WebhookEvent, headers http.Header) (controller.FileMetadata, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreFile", ctx, fileID, events, headers)
+	ret0, _ := ret[0].(controller.FileMetadata)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// RestoreFile indicates an expected call of RestoreFile.
+func (mr *MockMetadataStorageMockRecorder) RestoreFile(ctx, fileID, events, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreFile", reflect.TypeOf((*MockMetadataStorage)(nil).RestoreFile), ctx, fileID, events, headers)
+}
+
+// SetAPIKeyLastUsed mocks base method.
+func (m *MockMetadataStorage) SetAPIKeyLastUsed(ctx context.Context, id string, headers http.Header) *controller.APIError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAPIKeyLastUsed", ctx, id, headers)
+	ret0, _ := ret[0].(*controller.APIError)
+	return ret0
+}
+
+// SetAPIKeyLastUsed indicates an expected call of SetAPIKeyLastUsed.
+func (mr *MockMetadataStorageMockRecorder) SetAPIKeyLastUsed(ctx, id, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAPIKeyLastUsed", reflect.TypeOf((*MockMetadataStorage)(nil).SetAPIKeyLastUsed), ctx, id, headers)
 }
 
 // SetIsUploaded mocks base method.
-func (m *MockMetadataStorage) SetIsUploaded(ctx context.Context, fileID string, isUploaded bool, headers http.Header) *controller.APIError {
+func (m *MockMetadataStorage) SetIsUploaded(ctx context.Context, fileID string, isUploaded bool, condition controller.FileCondition, headers http.Header) *controller.APIError {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetIsUploaded", ctx, fileID, isUploaded, headers)
+	ret := m.ctrl.Call(m, "SetIsUploaded", ctx, fileID, isUploaded, condition, headers)
 	ret0, _ := ret[0].(*controller.APIError)
 	return ret0
 }
 
 // SetIsUploaded indicates an expected call of SetIsUploaded.
-func (mr *MockMetadataStorageMockRecorder) SetIsUploaded(ctx, fileID, isUploaded, headers interface{}) *gomock.Call {
+func (mr *MockMetadataStorageMockRecorder) SetIsUploaded(ctx, fileID, isUploaded, condition, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIsUploaded", reflect.TypeOf((*MockMetadataStorage)(nil).SetIsUploaded), ctx, fileID, isUploaded, condition, headers)
+}
+
+// SetLegalHold mocks base method.
+func (m *MockMetadataStorage) SetLegalHold(ctx context.Context, fileID string, legalHold bool, event controller.FileAuditEvent, headers http.Header) (controller.FileMetadata, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLegalHold", ctx, fileID, legalHold, event, headers)
+	ret0, _ := ret[0].(controller.FileMetadata)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// SetLegalHold indicates an expected call of SetLegalHold.
+func (mr *MockMetadataStorageMockRecorder) SetLegalHold(ctx, fileID, legalHold, event, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLegalHold", reflect.TypeOf((*MockMetadataStorage)(nil).SetLegalHold), ctx, fileID, legalHold, event, headers)
+}
+
+// SetVirusFalsePositive mocks base method.
+func (m *MockMetadataStorage) SetVirusFalsePositive(ctx context.Context, id string, falsePositive bool, headers http.Header) *controller.APIError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVirusFalsePositive", ctx, id, falsePositive, headers)
+	ret0, _ := ret[0].(*controller.APIError)
+	return ret0
+}
+
+// SetVirusFalsePositive indicates an expected call of SetVirusFalsePositive.
+func (mr *MockMetadataStorageMockRecorder) SetVirusFalsePositive(ctx, id, falsePositive, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVirusFalsePositive", reflect.TypeOf((*MockMetadataStorage)(nil).SetVirusFalsePositive), ctx, id, falsePositive, headers)
+}
+
+// TrashFile mocks base method.
+func (m *MockMetadataStorage) TrashFile(ctx context.Context, fileID, deletedByUserID string, condition controller.FileCondition, headers http.Header) (controller.FileMetadata, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrashFile", ctx, fileID, deletedByUserID, condition, headers)
+	ret0, _ := ret[0].(controller.FileMetadata)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// TrashFile indicates an expected call of TrashFile.
+func (mr *MockMetadataStorageMockRecorder) TrashFile(ctx, fileID, deletedByUserID, condition, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrashFile", reflect.TypeOf((*MockMetadataStorage)(nil).TrashFile), ctx, fileID, deletedByUserID, condition, headers)
+}
+
+// UpdateFileMetadata mocks base method.
+func (m *MockMetadataStorage) UpdateFileMetadata(ctx context.Context, fileID, name, bucketID string, metadata map[string]any, condition controller.FileCondition, headers http.Header) (controller.FileMetadata, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFileMetadata", ctx, fileID, name, bucketID, metadata, condition, headers)
+	ret0, _ := ret[0].(controller.FileMetadata)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// UpdateFileMetadata indicates an expected call of UpdateFileMetadata.
+func (mr *MockMetadataStorageMockRecorder) UpdateFileMetadata(ctx, fileID, name, bucketID, metadata, condition, headers interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIsUploaded", reflect.TypeOf((*MockMetadataStorage)(nil).SetIsUploaded), ctx, fileID, isUploaded, headers)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFileMetadata", reflect.TypeOf((*MockMetadataStorage)(nil).UpdateFileMetadata), ctx, fileID, name, bucketID, metadata, condition, headers)
 }
 
 // MockContentStorage is a mock of ContentStorage interface.
@@ -227,6 +523,21 @@ func (mr *MockContentStorageMockRecorder) CompleteMultipartUpload(ctx, filepath,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteMultipartUpload", reflect.TypeOf((*MockContentStorage)(nil).CompleteMultipartUpload), ctx, filepath, uploadId)
 }
 
+// CopyFile mocks base method.
+func (m *MockContentStorage) CopyFile(ctx context.Context, srcPath, dstPath string, size int64) (string, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyFile", ctx, srcPath, dstPath, size)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// CopyFile indicates an expected call of CopyFile.
+func (mr *MockContentStorageMockRecorder) CopyFile(ctx, srcPath, dstPath, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyFile", reflect.TypeOf((*MockContentStorage)(nil).CopyFile), ctx, srcPath, dstPath, size)
+}
+
 // CreateGetObjectPresignedURL mocks base method.
 func (m *MockContentStorage) CreateGetObjectPresignedURL(ctx context.Context, filepath string, expire time.Duration) (string, *controller.APIError) {
 	m.ctrl.T.Helper()
@@ -242,6 +553,21 @@ func (mr *MockContentStorageMockRecorder) CreateGetObjectPresignedURL(ctx, filep
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGetObjectPresignedURL", reflect.TypeOf((*MockContentStorage)(nil).CreateGetObjectPresignedURL), ctx, filepath, expire)
 }
 
+// CreateGetObjectRedirectURL mocks base method.
+func (m *MockContentStorage) CreateGetObjectRedirectURL(ctx context.Context, filepath, contentDisposition string, expire time.Duration) (string, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGetObjectRedirectURL", ctx, filepath, contentDisposition, expire)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// CreateGetObjectRedirectURL indicates an expected call of CreateGetObjectRedirectURL.
+func (mr *MockContentStorageMockRecorder) CreateGetObjectRedirectURL(ctx, filepath, contentDisposition, expire interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGetObjectRedirectURL", reflect.TypeOf((*MockContentStorage)(nil).CreateGetObjectRedirectURL), ctx, filepath, contentDisposition, expire)
+}
+
 // CreateMultipartUpload mocks base method.
 func (m *MockContentStorage) CreateMultipartUpload(ctx context.Context, filepath, contentType string) (string, *controller.APIError) {
 	m.ctrl.T.Helper()
@@ -391,6 +717,34 @@ func (mr *MockContentStorageMockRecorder) PutFileWithPresignedURL(ctx, filepath,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutFileWithPresignedURL", reflect.TypeOf((*MockContentStorage)(nil).PutFileWithPresignedURL), ctx, filepath, signature, headers)
 }
 
+// SetObjectLegalHold mocks base method.
+func (m *MockContentStorage) SetObjectLegalHold(ctx context.Context, filepath string, legalHold bool) *controller.APIError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetObjectLegalHold", ctx, filepath, legalHold)
+	ret0, _ := ret[0].(*controller.APIError)
+	return ret0
+}
+
+// SetObjectLegalHold indicates an expected call of SetObjectLegalHold.
+func (mr *MockContentStorageMockRecorder) SetObjectLegalHold(ctx, filepath, legalHold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetObjectLegalHold", reflect.TypeOf((*MockContentStorage)(nil).SetObjectLegalHold), ctx, filepath, legalHold)
+}
+
+// SetObjectRetention mocks base method.
+func (m *MockContentStorage) SetObjectRetention(ctx context.Context, filepath, mode string, retainUntil time.Time) *controller.APIError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetObjectRetention", ctx, filepath, mode, retainUntil)
+	ret0, _ := ret[0].(*controller.APIError)
+	return ret0
+}
+
+// SetObjectRetention indicates an expected call of SetObjectRetention.
+func (mr *MockContentStorageMockRecorder) SetObjectRetention(ctx, filepath, mode, retainUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetObjectRetention", reflect.TypeOf((*MockContentStorage)(nil).SetObjectRetention), ctx, filepath, mode, retainUntil)
+}
+
 // UploadPart mocks base method.
 func (m *MockContentStorage) UploadPart(ctx context.Context, filepath, uploadId string, partNumber int32, body io.ReadSeeker) (string, *controller.APIError) {
 	m.ctrl.T.Helper()
@@ -442,3 +796,78 @@ func (mr *MockAntivirusMockRecorder) ScanReader(r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanReader", reflect.TypeOf((*MockAntivirus)(nil).ScanReader), r)
 }
+
+// MockAdminSecret is a mock of AdminSecret interface.
+type MockAdminSecret struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminSecretMockRecorder
+}
+
+// MockAdminSecretMockRecorder is the mock recorder for MockAdminSecret.
+type MockAdminSecretMockRecorder struct {
+	mock *MockAdminSecret
+}
+
+// NewMockAdminSecret creates a new mock instance.
+func NewMockAdminSecret(ctrl *gomock.Controller) *MockAdminSecret {
+	mock := &MockAdminSecret{ctrl: ctrl}
+	mock.recorder = &MockAdminSecretMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminSecret) EXPECT() *MockAdminSecretMockRecorder {
+	return m.recorder
+}
+
+// Primary mocks base method.
+func (m *MockAdminSecret) Primary() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Primary")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Primary indicates an expected call of Primary.
+func (mr *MockAdminSecretMockRecorder) Primary() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Primary", reflect.TypeOf((*MockAdminSecret)(nil).Primary))
+}
+
+// MockUploadHook is a mock of UploadHook interface.
+type MockUploadHook struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadHookMockRecorder
+}
+
+// MockUploadHookMockRecorder is the mock recorder for MockUploadHook.
+type MockUploadHookMockRecorder struct {
+	mock *MockUploadHook
+}
+
+// NewMockUploadHook creates a new mock instance.
+func NewMockUploadHook(ctrl *gomock.Controller) *MockUploadHook {
+	mock := &MockUploadHook{ctrl: ctrl}
+	mock.recorder = &MockUploadHookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadHook) EXPECT() *MockUploadHookMockRecorder {
+	return m.recorder
+}
+
+// CheckUpload mocks base method.
+func (m *MockUploadHook) CheckUpload(ctx context.Context, req controller.UploadHookRequest) (controller.UploadHookResponse, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckUpload", ctx, req)
+	ret0, _ := ret[0].(controller.UploadHookResponse)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// CheckUpload indicates an expected call of CheckUpload.
+func (mr *MockUploadHookMockRecorder) CheckUpload(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUpload", reflect.TypeOf((*MockUploadHook)(nil).CheckUpload), ctx, req)
+}
@@ -0,0 +1,187 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PatchFileRequest struct {
+	Name     *string `json:"name"`
+	BucketID *string `json:"bucketId"`
+	// Metadata is applied to the current metadata as a JSON merge patch (RFC 7396)
+	Metadata json.RawMessage `json:"metadata"`
+}
+
+// mergePatch applies a JSON merge patch (RFC 7396) to target.
+func mergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(map[string]any)
+	merged := make(map[string]any, len(t)+len(p))
+	if ok {
+		for k, v := range t {
+			merged[k] = v
+		}
+	}
+
+	for k, v := range p {
+		if v == nil {
+			delete(merged, k)
+			continue
+		}
+		merged[k] = mergePatch(merged[k], v)
+	}
+
+	return merged
+}
+
+func parsePatchFileRequest(ctx *gin.Context) (PatchFileRequest, *APIError) {
+	var req PatchFileRequest
+	if err := json.NewDecoder(ctx.Request.Body).Decode(&req); err != nil {
+		return PatchFileRequest{}, BadDataError(err, "couldn't decode request")
+	}
+
+	if req.Name == nil && req.BucketID == nil && req.Metadata == nil {
+		msg := "at least one of name, bucketId or metadata is required"
+		return PatchFileRequest{}, BadDataError(errors.New(msg), msg) //nolint: goerr113
+	}
+
+	if req.Name != nil && *req.Name == "" || req.BucketID != nil && *req.BucketID == "" {
+		msg := "name and bucketId can't be empty"
+		return PatchFileRequest{}, BadDataError(errors.New(msg), msg) //nolint: goerr113
+	}
+
+	return req, nil
+}
+
+// patchedMetadata returns the metadata of the file after applying the patch, a null
+// patch removes all the metadata.
+func patchedMetadata(current map[string]any, patch json.RawMessage) (map[string]any, *APIError) {
+	if patch == nil {
+		return current, nil
+	}
+
+	var p any
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, BadDataError(err, "couldn't decode metadata")
+	}
+
+	if p == nil {
+		return map[string]any{}, nil
+	}
+
+	metadata, ok := mergePatch(current, p).(map[string]any)
+	if !ok {
+		msg := "metadata must be an object"
+		return nil, BadDataError(errors.New(msg), msg) //nolint: goerr113
+	}
+
+	return metadata, nil
+}
+
+func (ctrl *Controller) patchFile(ctx *gin.Context) (FileMetadata, *APIError) {
+	req, apiErr := parsePatchFileRequest(ctx)
+	if apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	fileMetadata, bucket, apiErr := ctrl.getFileMetadata(
+		ctx.Request.Context(), ctx.Param("id"), false, ctx.Request.Header,
+	)
+	if apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	condition, apiErr := checkWritePreconditions(ctx, fileMetadata)
+	if apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	name := fileMetadata.Name
+	if req.Name != nil {
+		name = *req.Name
+	}
+
+	if req.BucketID != nil && *req.BucketID != fileMetadata.BucketID {
+		// changing the bucket is a move
+		if apiErr := checkFileLock(fileMetadata); apiErr != nil {
+			return FileMetadata{}, apiErr
+		}
+
+		if apiErr := checkAPIKeyBucket(ctx.Request.Header, *req.BucketID); apiErr != nil {
+			return FileMetadata{}, apiErr
+		}
+
+		bucket, apiErr = ctrl.metadataStorage.GetBucketByID(
+			ctx,
+			*req.BucketID,
+			http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}},
+		)
+		if apiErr != nil {
+			return FileMetadata{}, apiErr
+		}
+
+		if apiErr := checkSize(
+			name, fileMetadata.Size, bucket.MinUploadFile, bucket.MaxUploadFile,
+		); apiErr != nil {
+			return FileMetadata{}, apiErr
+		}
+
+		if apiErr := ctrl.checkQuota(
+			ctx, ctx.Request.Header, bucket.ID, fileMetadata.Size, 1,
+		); apiErr != nil {
+			return FileMetadata{}, apiErr
+		}
+	}
+
+	// the file type policy may restrict extensions so renamed files are checked as well
+	headersChanged := name != fileMetadata.Name || bucket.ID != fileMetadata.BucketID
+	if headersChanged {
+		if apiErr := checkFileType(bucket, name, fileMetadata.MimeType); apiErr != nil {
+			return FileMetadata{}, apiErr
+		}
+	}
+
+	metadata, apiErr := patchedMetadata(fileMetadata.Metadata, req.Metadata)
+	if apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	newMetadata, apiErr := ctrl.metadataStorage.UpdateFileMetadata(
+		ctx, fileMetadata.ID, name, bucket.ID, metadata, condition, ctx.Request.Header,
+	)
+	if apiErr != nil {
+		return FileMetadata{}, apiErr.ExtendError("problem updating metadata of file " + fileMetadata.ID)
+	}
+
+	// the metadata isn't part of the response headers so it doesn't need to be purged
+	if headersChanged {
+		ctx.Set("FileChanged", fileMetadata.ID)
+	}
+	ctrl.notify(ctx, Event{Type: EventFileUpdated, BucketID: newMetadata.BucketID, Data: newMetadata})
+
+	return newMetadata, nil
+}
+
+func (ctrl *Controller) PatchFile(ctx *gin.Context) {
+	metadata, apiErr := ctrl.patchFile(ctx)
+	if apiErr != nil {
+		_ = ctx.Error(fmt.Errorf("problem processing request: %w", apiErr))
+
+		ctx.JSON(apiErr.statusCode, CommonResponse{
+			Code:    apiErr.statusCode,
+			Message: apiErr.PublicResponse().Message,
+		})
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, CommonResponse{http.StatusOK, "ok", metadata})
+}
@@ -0,0 +1,157 @@
+package controller_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestPatchFile(t *testing.T) {
+	t.Parallel()
+
+	const fileID = "55af1e60-0f28-454e-885e-ea6aab2bb288"
+
+	cases := []struct {
+		name             string
+		body             string
+		expectedStatus   int
+		expectedName     string
+		expectedMetadata map[string]any
+		expectedPurge    bool
+	}{
+		{
+			name:           "merge patch metadata",
+			body:           `{"metadata":{"nested":{"y":null,"z":3},"drop":null,"b":"new"}}`,
+			expectedStatus: http.StatusOK,
+			expectedName:   "report.pdf",
+			expectedMetadata: map[string]any{
+				"a":      float64(1),
+				"nested": map[string]any{"x": float64(1), "z": float64(3)},
+				"b":      "new",
+			},
+			expectedPurge: false,
+		},
+		{
+			name:             "clear metadata",
+			body:             `{"metadata":null}`,
+			expectedStatus:   http.StatusOK,
+			expectedName:     "report.pdf",
+			expectedMetadata: map[string]any{},
+			expectedPurge:    false,
+		},
+		{
+			name:           "rename",
+			body:           `{"name":"report-2021.pdf"}`,
+			expectedStatus: http.StatusOK,
+			expectedName:   "report-2021.pdf",
+			expectedMetadata: map[string]any{
+				"a":      float64(1),
+				"nested": map[string]any{"x": float64(1), "y": float64(2)},
+				"drop":   true,
+			},
+			expectedPurge: true,
+		},
+		{
+			name:           "metadata not an object",
+			body:           `{"metadata":[1,2]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "nothing to update",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			if tc.body != `{}` {
+				metadataStorage.EXPECT().GetFileByID(
+					gomock.Any(), fileID, gomock.Any(),
+				).Return(controller.FileMetadata{ //nolint: exhaustruct
+					ID:         fileID,
+					Name:       "report.pdf",
+					Size:       1024,
+					BucketID:   "default",
+					IsUploaded: true,
+					MimeType:   "application/pdf",
+					Metadata: map[string]any{
+						"a":      float64(1),
+						"nested": map[string]any{"x": float64(1), "y": float64(2)},
+						"drop":   true,
+					},
+				}, nil)
+
+				metadataStorage.EXPECT().GetBucketByID(
+					gomock.Any(), "default", gomock.Any(),
+				).Return(controller.BucketMetadata{ID: "default"}, nil) //nolint: exhaustruct
+			}
+
+			if tc.expectedStatus == http.StatusOK {
+				metadataStorage.EXPECT().UpdateFileMetadata(
+					gomock.Any(), fileID, tc.expectedName, "default", tc.expectedMetadata,
+					controller.FileCondition{}, gomock.Any(),
+				).Return(controller.FileMetadata{ //nolint: exhaustruct
+					ID:       fileID,
+					Name:     tc.expectedName,
+					BucketID: "default",
+					Metadata: tc.expectedMetadata,
+				}, nil)
+			}
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			purged := false
+			router, _ := ctrl.SetupRouter(
+				nil, "/v1", []string{"*"}, false, ginLogger(logger),
+				func(ctx *gin.Context) {
+					ctx.Next()
+					_, purged = ctx.Get("FileChanged")
+				},
+			)
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(), "PATCH", "/v1/files/"+fileID, strings.NewReader(tc.body),
+			)
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+			assert(t, tc.expectedPurge, purged)
+		})
+	}
+}
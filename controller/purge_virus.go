@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (ctrl *Controller) purgeVirus(ctx *gin.Context) (VirusMetadata, *APIError) {
+	virus, apiErr := ctrl.metadataStorage.GetVirusByID(
+		ctx.Request.Context(),
+		ctx.Param("id"),
+		ctx.Request.Header,
+	)
+	if apiErr != nil {
+		return VirusMetadata{}, apiErr
+	}
+
+	fileMetadata, apiErr := ctrl.metadataStorage.GetFileByID(
+		ctx.Request.Context(),
+		virus.FileID,
+		ctx.Request.Header,
+	)
+	if apiErr != nil {
+		return VirusMetadata{}, apiErr
+	}
+
+	// findings reference the file so they need to go first
+	if apiErr := ctrl.metadataStorage.DeleteVirusesByFileID(
+		ctx.Request.Context(),
+		fileMetadata.ID,
+		ctx.Request.Header,
+	); apiErr != nil {
+		return VirusMetadata{}, apiErr
+	}
+
+	if apiErr := ctrl.metadataStorage.DeleteFileByID(
+		ctx.Request.Context(),
+		fileMetadata.ID,
+		FileCondition{},
+		ctrl.events(ctx, Event{Type: EventFileDeleted, BucketID: fileMetadata.BucketID, Data: fileMetadata}),
+		ctx.Request.Header,
+	); apiErr != nil {
+		return VirusMetadata{}, apiErr
+	}
+
+	objectKey := fileMetadata.ObjectKey
+	if objectKey == "" {
+		objectKey = fileMetadata.ID
+	}
+
+	if apiErr := ctrl.contentStorage.DeleteFile(ctx, objectKey); apiErr != nil {
+		return VirusMetadata{}, apiErr.ExtendError("problem deleting file content")
+	}
+
+	// the content quarantined by other findings of the file is left for delete-orphans
+	if virus.Quarantine != nil {
+		if apiErr := ctrl.contentStorage.DeleteFile(ctx, virus.Quarantine.ObjectKey); apiErr != nil {
+			return VirusMetadata{}, apiErr.ExtendError("problem deleting quarantined content")
+		}
+	}
+
+	ctx.Set("FileChanged", fileMetadata.ID)
+
+	return virus, nil
+}
+
+func (ctrl *Controller) PurgeVirus(ctx *gin.Context) {
+	virus, apiErr := ctrl.purgeVirus(ctx)
+	if apiErr != nil {
+		_ = ctx.Error(fmt.Errorf("problem processing request: %w", apiErr))
+
+		ctx.JSON(apiErr.statusCode, CommonResponse{
+			Code:    apiErr.statusCode,
+			Message: apiErr.PublicResponse().Message,
+		})
+
+		return
+	}
+
+	ctx.JSON(
+		http.StatusOK,
+		CommonResponse{
+			http.StatusOK,
+			"ok",
+			virus,
+		},
+	)
+}
@@ -0,0 +1,119 @@
+package controller_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestPurgeVirus(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name           string
+		virusErr       *controller.APIError
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "virus not found",
+			virusErr:       controller.ErrVirusNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			metadataStorage.EXPECT().GetVirusByID(
+				gomock.Any(), "0ac4cb2c-64ec-4ae6-a5a4-6bd0f3e0a1bd", gomock.Any(),
+			).Return(
+				controller.VirusMetadata{
+					ID:       "0ac4cb2c-64ec-4ae6-a5a4-6bd0f3e0a1bd",
+					FileID:   "55af1e60-0f28-454e-885e-ea6aab2bb288",
+					Filename: "eicar.txt",
+					BucketID: "default",
+					Virus:    "Eicar-Signature",
+				}, tc.virusErr,
+			)
+
+			if tc.virusErr == nil {
+				metadataStorage.EXPECT().GetFileByID(
+					gomock.Any(), "55af1e60-0f28-454e-885e-ea6aab2bb288", gomock.Any(),
+				).Return(
+					controller.FileMetadata{
+						ID:        "55af1e60-0f28-454e-885e-ea6aab2bb288",
+						Name:      "eicar.txt",
+						BucketID:  "default",
+						ETag:      "\"some-etag\"",
+						ObjectKey: "quarantine/55af1e60-0f28-454e-885e-ea6aab2bb288",
+					}, nil,
+				)
+
+				gomock.InOrder(
+					metadataStorage.EXPECT().DeleteVirusesByFileID(
+						gomock.Any(), "55af1e60-0f28-454e-885e-ea6aab2bb288", gomock.Any(),
+					).Return(nil),
+					metadataStorage.EXPECT().DeleteFileByID(
+						gomock.Any(), "55af1e60-0f28-454e-885e-ea6aab2bb288", controller.FileCondition{},
+						gomock.Any(),
+						gomock.Any(),
+					).Return(nil),
+				)
+
+				contentStorage.EXPECT().DeleteFile(
+					gomock.Any(), "quarantine/55af1e60-0f28-454e-885e-ea6aab2bb288",
+				).Return(nil)
+			}
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(),
+				"POST",
+				"/v1/ops/viruses/0ac4cb2c-64ec-4ae6-a5a4-6bd0f3e0a1bd/purge",
+				nil,
+			)
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+		})
+	}
+}
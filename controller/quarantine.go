@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/gin-gonic/gin"
+)
+
+// quarantinePrefix is where the content rejected by the antivirus is stored. As with
+// versions, quarantined objects end with the object key of their file so they are
+// attributed to it when looking for orphans.
+const quarantinePrefix = ".quarantine"
+
+// quarantineFile keeps the content flagged by the antivirus so it can be released if the
+// finding is marked as a false positive. Problems are only logged as the file is
+// rejected either way.
+func (ctrl *Controller) quarantineFile(
+	ctx context.Context,
+	findingID string,
+	fileContent io.ReadSeeker,
+	file fileData,
+	contentType, objectKey string,
+) *QuarantinedFile {
+	quarantineKey := path.Join(quarantinePrefix, findingID, objectKey)
+
+	if _, apiErr := ctrl.contentStorage.PutFile(
+		ctx, fileContent, quarantineKey, contentType,
+	); apiErr != nil {
+		ctrl.logger.WithError(apiErr).Error("problem quarantining file " + file.ID)
+		return nil
+	}
+
+	return &QuarantinedFile{
+		ObjectKey: quarantineKey,
+		Name:      file.Name,
+		Size:      file.header.Size,
+		MimeType:  contentType,
+		Metadata:  file.Metadata,
+	}
+}
+
+// releaseQuarantine makes the quarantined content the content of the file, as if it
+// had never been rejected. Files that were already uploaded are replaced the same way an
+// update would do it.
+func (ctrl *Controller) releaseQuarantine(
+	ctx *gin.Context, fileMetadata FileMetadata, quarantine QuarantinedFile,
+) (FileMetadata, *APIError) {
+	adminHeaders := http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}}
+
+	bucket, apiErr := ctrl.metadataStorage.GetBucketByID(ctx, fileMetadata.BucketID, adminHeaders)
+	if apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	if fileMetadata.IsUploaded {
+		if apiErr := checkFileLock(fileMetadata); apiErr != nil {
+			return FileMetadata{}, apiErr
+		}
+
+		if bucket.VersioningEnabled {
+			if apiErr := ctrl.preserveFileVersion(ctx, fileMetadata); apiErr != nil {
+				return FileMetadata{}, apiErr
+			}
+		}
+	}
+
+	objectKey := FileObjectKey(fileMetadata)
+
+	etag, apiErr := ctrl.contentStorage.CopyFile(ctx, quarantine.ObjectKey, objectKey, quarantine.Size)
+	if apiErr != nil {
+		return FileMetadata{}, apiErr.ExtendError("problem copying quarantined file")
+	}
+
+	fileMetadata, apiErr = ctrl.metadataStorage.PopulateMetadata(
+		ctx,
+		fileMetadata.ID, quarantine.Name, quarantine.Size, fileMetadata.BucketID, etag, true, quarantine.MimeType, objectKey, quarantine.Size, 1, "", "", "", quarantine.Metadata,
+		nil,
+		adminHeaders,
+	)
+	if apiErr != nil {
+		return FileMetadata{}, apiErr.ExtendError("problem populating file metadata for file " + quarantine.Name)
+	}
+
+	ctrl.lockObject(ctx, fileMetadata, bucket)
+
+	if apiErr := ctrl.contentStorage.DeleteFile(ctx, quarantine.ObjectKey); apiErr != nil {
+		ctrl.logger.WithError(apiErr).Error("problem deleting quarantined file " + fileMetadata.ID)
+	}
+
+	return fileMetadata, nil
+}
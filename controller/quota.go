@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+)
+
+type QuotaUsage struct {
+	Quota
+	UsedBytes int64 `json:"usedBytes"`
+	UsedFiles int64 `json:"usedFiles"`
+}
+
+// quotaUsage adds up the usage the quota applies to, quotas without a bucket apply to
+// all of them.
+func quotaUsage(quota Quota, usage []Usage) QuotaUsage {
+	res := QuotaUsage{Quota: quota, UsedBytes: 0, UsedFiles: 0}
+	for _, u := range usage {
+		if quota.BucketID == "" || quota.BucketID == u.BucketID {
+			res.UsedBytes += u.Bytes
+			res.UsedFiles += u.Files
+		}
+	}
+	return res
+}
+
+// getQuotaUsage returns the quotas that apply to the user and how much of them is used.
+// Quotas and usage aren't exposed to users so they are queried as admin.
+func (ctrl *Controller) getQuotaUsage(
+	ctx context.Context, userID, role, bucketID string,
+) ([]QuotaUsage, []Usage, *APIError) {
+	adminHeaders := http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}}
+
+	quotas, apiErr := ctrl.metadataStorage.GetQuotas(ctx, userID, role, bucketID, adminHeaders)
+	if apiErr != nil {
+		return nil, nil, apiErr
+	}
+
+	usage, apiErr := ctrl.metadataStorage.GetUsage(ctx, userID, adminHeaders)
+	if apiErr != nil {
+		return nil, nil, apiErr
+	}
+
+	res := make([]QuotaUsage, len(quotas))
+	for i, quota := range quotas {
+		res[i] = quotaUsage(quota, usage)
+	}
+
+	return res, usage, nil
+}
+
+// checkQuota verifies storing size more bytes and files more files in the bucket
+// doesn't exceed any of the quotas of the caller. Anonymous uploads aren't accounted
+// for so they aren't limited either.
+func (ctrl *Controller) checkQuota(
+	ctx context.Context, headers http.Header, bucketID string, size, files int64,
+) *APIError {
+	userID, role := ctrl.sessionVariables(ctx, headers)
+	if userID == "" {
+		return nil
+	}
+
+	quotas, _, apiErr := ctrl.getQuotaUsage(ctx, userID, role, bucketID)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	for _, q := range quotas {
+		if q.BucketID != "" && q.BucketID != bucketID {
+			continue
+		}
+
+		if q.MaxBytes > 0 && q.UsedBytes+size > q.MaxBytes ||
+			q.MaxFiles > 0 && q.UsedFiles+files > q.MaxFiles {
+			return QuotaExceededError(q.Quota, q.UsedBytes, q.UsedFiles)
+		}
+	}
+
+	return nil
+}
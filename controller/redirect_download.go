@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// redirectDownload returns true if the client should be redirected to the storage backend
+// instead of streaming the file through the service. The `redirect` query parameter takes
+// precedence over the bucket configuration. Image manipulation requires the file to go
+// through the service so those requests are never redirected.
+func redirectDownload(
+	ctx *gin.Context, fileMetadata FileMetadata, bucketMetadata BucketMetadata,
+) (bool, *APIError) {
+	redirect := bucketMetadata.RedirectDownloads
+	if s, ok := ctx.GetQuery("redirect"); ok {
+		var err error
+		redirect, err = strconv.ParseBool(s)
+		if err != nil {
+			return false, BadDataError(err, "query parameter redirect must be a boolean")
+		}
+	}
+
+	if !redirect {
+		return false, nil
+	}
+
+	opts, apiErr := getImageManipulationOptions(ctx, fileMetadata.MimeType)
+	if apiErr != nil {
+		return false, apiErr
+	}
+
+	return opts.IsEmpty(), nil
+}
+
+func (ctrl *Controller) redirectResponse(
+	ctx *gin.Context, fileMetadata FileMetadata, bucketMetadata BucketMetadata, filePath string,
+) (*FileResponse, *APIError) {
+	disposition := "inline"
+	if isActiveContent(fileMetadata.MimeType) {
+		disposition = "attachment"
+	}
+
+	location, apiErr := ctrl.contentStorage.CreateGetObjectRedirectURL(
+		ctx.Request.Context(),
+		filePath,
+		fmt.Sprintf(`%s; filename="%s"`, disposition, url.QueryEscape(fileMetadata.Name)),
+		time.Duration(bucketMetadata.DownloadExpiration)*time.Second,
+	)
+	if apiErr != nil {
+		return nil, apiErr.ExtendError("problem creating redirect URL for file " + fileMetadata.Name)
+	}
+
+	return &FileResponse{ //nolint: exhaustruct
+		fileID:      fileMetadata.ID,
+		statusCode:  http.StatusFound,
+		body:        http.NoBody,
+		redirectURL: location,
+	}, nil
+}
@@ -31,6 +31,13 @@ type FileResponse struct {
 	name                        string
 	headers                     http.Header
 	disableSurrageControlHeader bool
+	// disposition overrides the default inline disposition, active content is always
+	// served as an attachment
+	disposition string
+	// redirectURL is set when the client is sent to the storage backend instead
+	redirectURL string
+	// partsContentType is the content type of the file in multipart/byteranges responses
+	partsContentType string
 }
 
 func NewFileResponse(
@@ -60,6 +67,13 @@ func NewFileResponse(
 }
 
 func (r *FileResponse) Write(ctx *gin.Context) {
+	if r.redirectURL != "" {
+		// the location expires shortly so the redirect can't be cached
+		ctx.Header("Cache-Control", "no-store")
+		ctx.Redirect(r.statusCode, r.redirectURL)
+		return
+	}
+
 	ctx.Writer.WriteHeader(r.statusCode)
 
 	for k, v := range r.headers {
@@ -84,8 +98,18 @@ func (r *FileResponse) Write(ctx *gin.Context) {
 
 	if r.body != nil &&
 		(r.statusCode == http.StatusOK || r.statusCode == http.StatusPartialContent) {
+		// content that can run scripts is never rendered from our domain
+		disposition := "inline"
+		if r.disposition != "" {
+			disposition = r.disposition
+		}
+		if isActiveContent(r.contentType) || isActiveContent(r.partsContentType) {
+			disposition = "attachment"
+			ctx.Header("Content-Security-Policy", activeContentSecurityPolicy)
+			ctx.Header("X-Content-Type-Options", "nosniff")
+		}
 		ctx.Writer.Header().
-			Set("Content-Disposition", fmt.Sprintf(`inline; filename="%s"`, url.QueryEscape(r.name)))
+			Set("Content-Disposition", fmt.Sprintf(`%s; filename="%s"`, disposition, url.QueryEscape(r.name)))
 
 		_, err := io.Copy(ctx.Writer, r.body)
 		if err != nil {
@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	FileAuditActionLegalHoldSet     = "legal_hold.set"
+	FileAuditActionLegalHoldCleared = "legal_hold.cleared"
+)
+
+type LegalHoldRequest struct {
+	Reason string `json:"reason"`
+}
+
+// checkFileLock rejects changes to files under legal hold or whose retention period
+// isn't over.
+func checkFileLock(fileMetadata FileMetadata) *APIError {
+	if fileMetadata.LegalHold {
+		return ErrFileLegalHold
+	}
+
+	if fileMetadata.RetainUntil == "" {
+		return nil
+	}
+
+	retainUntil, err := time.Parse(time.RFC3339, fileMetadata.RetainUntil)
+	if err != nil {
+		// better safe than sorry
+		return ForbiddenError(
+			fmt.Errorf("problem parsing retention date of file %s: %w", fileMetadata.ID, err),
+			"file is retained",
+		)
+	}
+
+	if time.Now().Before(retainUntil) {
+		msg := "file is retained until " + retainUntil.UTC().Format(time.RFC3339)
+		return ForbiddenError(fmt.Errorf("file %s: %s", fileMetadata.ID, msg), msg) //nolint: goerr113
+	}
+
+	return nil
+}
+
+// lockObject applies the retention of the file, set when the content is uploaded to a
+// bucket with retention_days, to the object in the storage backend. The metadata is
+// already stored by then and keeps protecting the file so errors are only logged.
+func (ctrl *Controller) lockObject(
+	ctx context.Context, fileMetadata FileMetadata, bucket BucketMetadata,
+) {
+	if fileMetadata.RetainUntil == "" {
+		return
+	}
+
+	retainUntil, err := time.Parse(time.RFC3339, fileMetadata.RetainUntil)
+	if err != nil {
+		ctrl.logger.WithError(err).Error("problem parsing retention date of file " + fileMetadata.ID)
+		return
+	}
+
+	if apiErr := ctrl.contentStorage.SetObjectRetention(
+		ctx, FileObjectKey(fileMetadata), bucket.RetentionMode, retainUntil,
+	); apiErr != nil {
+		ctrl.logger.WithError(apiErr).Error("problem locking object of file " + fileMetadata.ID)
+	}
+}
+
+func (ctrl *Controller) setLegalHold(ctx *gin.Context, legalHold bool) (FileMetadata, *APIError) {
+	if !ctrl.isAdmin(ctx.Request.Header) {
+		return FileMetadata{}, ForbiddenError(
+			errors.New("legal holds can only be managed by admins"), //nolint: goerr113
+			"you are not authorized to manage legal holds",
+		)
+	}
+
+	var req LegalHoldRequest
+	if err := json.NewDecoder(ctx.Request.Body).Decode(&req); err != nil &&
+		!errors.Is(err, io.EOF) {
+		return FileMetadata{}, BadDataError(err, "couldn't decode request")
+	}
+
+	fileMetadata, apiErr := ctrl.metadataStorage.GetFileByID(
+		ctx.Request.Context(), ctx.Param("id"), ctx.Request.Header,
+	)
+	if apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	action := FileAuditActionLegalHoldCleared
+	if legalHold {
+		action = FileAuditActionLegalHoldSet
+
+		// the object is locked first so a file can't be deleted after being reported
+		// as held
+		if apiErr := ctrl.contentStorage.SetObjectLegalHold(
+			ctx.Request.Context(), FileObjectKey(fileMetadata), true,
+		); apiErr != nil {
+			return FileMetadata{}, apiErr.ExtendError("problem setting legal hold of object")
+		}
+	}
+
+	userID, _ := ctrl.sessionVariables(ctx, ctx.Request.Header)
+	fileMetadata, apiErr = ctrl.metadataStorage.SetLegalHold(
+		ctx.Request.Context(),
+		fileMetadata.ID,
+		legalHold,
+		FileAuditEvent{
+			FileID:   fileMetadata.ID,
+			Action:   action,
+			Reason:   req.Reason,
+			UserID:   userID,
+			APIKeyID: ctx.Request.Header.Get(apiKeyIDHeader),
+		},
+		ctx.Request.Header,
+	)
+	if apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	if !legalHold {
+		if apiErr := ctrl.contentStorage.SetObjectLegalHold(
+			ctx.Request.Context(), FileObjectKey(fileMetadata), false,
+		); apiErr != nil {
+			return FileMetadata{}, apiErr.ExtendError("problem clearing legal hold of object")
+		}
+	}
+
+	return fileMetadata, nil
+}
+
+func (ctrl *Controller) respondLegalHold(ctx *gin.Context, legalHold bool) {
+	metadata, apiErr := ctrl.setLegalHold(ctx, legalHold)
+	if apiErr != nil {
+		_ = ctx.Error(fmt.Errorf("problem processing request: %w", apiErr))
+
+		ctx.JSON(apiErr.statusCode, CommonResponse{
+			Code:    apiErr.statusCode,
+			Message: apiErr.PublicResponse().Message,
+		})
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, CommonResponse{http.StatusOK, "ok", metadata})
+}
+
+func (ctrl *Controller) SetLegalHold(ctx *gin.Context) {
+	ctrl.respondLegalHold(ctx, true)
+}
+
+func (ctrl *Controller) ClearLegalHold(ctx *gin.Context) {
+	ctrl.respondLegalHold(ctx, false)
+}
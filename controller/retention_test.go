@@ -0,0 +1,242 @@
+package controller_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+)
+
+const lockedFileID = "55af1e60-0f28-454e-885e-ea6aab2bb288"
+
+func lockedFile() controller.FileMetadata {
+	return controller.FileMetadata{ //nolint: exhaustruct
+		ID:         lockedFileID,
+		Name:       "contract.pdf",
+		Size:       1024,
+		BucketID:   "default",
+		ETag:       `"some-etag"`,
+		IsUploaded: true,
+		MimeType:   "application/pdf",
+	}
+}
+
+func TestDeleteLockedFile(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name           string
+		file           func() controller.FileMetadata
+		expectedStatus int
+	}{
+		{
+			name: "legal hold",
+			file: func() controller.FileMetadata {
+				f := lockedFile()
+				f.LegalHold = true
+				return f
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "retained",
+			file: func() controller.FileMetadata {
+				f := lockedFile()
+				f.RetainUntil = time.Now().Add(24 * time.Hour).Format(time.RFC3339)
+				return f
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name: "retention expired",
+			file: func() controller.FileMetadata {
+				f := lockedFile()
+				f.RetainUntil = time.Now().Add(-24 * time.Hour).Format(time.RFC3339)
+				return f
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			metadataStorage.EXPECT().GetFileByID(
+				gomock.Any(), lockedFileID, gomock.Any(),
+			).Return(tc.file(), nil)
+			if tc.expectedStatus == http.StatusNoContent {
+				metadataStorage.EXPECT().GetBucketByID(
+					gomock.Any(), "default", gomock.Any(),
+				).Return(controller.BucketMetadata{ID: "default"}, nil) //nolint: exhaustruct
+				metadataStorage.EXPECT().ListFileVersions(gomock.Any(), lockedFileID, gomock.Any()).Return(nil, nil)
+				metadataStorage.EXPECT().DeleteFileByID(
+					gomock.Any(), lockedFileID, controller.FileCondition{}, gomock.Any(), gomock.Any(),
+				).Return(nil)
+				contentStorage.EXPECT().DeleteFile(
+					gomock.Any(), lockedFileID,
+				).Return(nil)
+			}
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(), "DELETE", "/v1/files/"+lockedFileID, nil,
+			)
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+		})
+	}
+}
+
+func TestSetLegalHold(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name           string
+		method         string
+		headers        http.Header
+		expectedAction string
+		expectedStatus int
+	}{
+		{
+			name:   "set",
+			method: "PUT",
+			headers: http.Header{
+				"X-Hasura-Admin-Secret": []string{"asdasd"},
+			},
+			expectedAction: controller.FileAuditActionLegalHoldSet,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "clear",
+			method: "DELETE",
+			headers: http.Header{
+				"X-Hasura-Admin-Secret": []string{"asdasd"},
+			},
+			expectedAction: controller.FileAuditActionLegalHoldCleared,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "not an admin",
+			method: "PUT",
+			headers: http.Header{
+				"X-Hasura-User-Id": []string{"ab5ba58e-932a-40dc-87e8-733998794ec2"},
+				"X-Hasura-Role":    []string{"user"},
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			if tc.expectedStatus == http.StatusOK {
+				legalHold := tc.expectedAction == controller.FileAuditActionLegalHoldSet
+
+				held := lockedFile()
+				held.LegalHold = legalHold
+
+				getFile := metadataStorage.EXPECT().GetFileByID(
+					gomock.Any(), lockedFileID, gomock.Any(),
+				).Return(lockedFile(), nil)
+				setObject := contentStorage.EXPECT().SetObjectLegalHold(
+					gomock.Any(), lockedFileID, legalHold,
+				).Return(nil)
+				setMetadata := metadataStorage.EXPECT().SetLegalHold(
+					gomock.Any(),
+					lockedFileID,
+					legalHold,
+					controller.FileAuditEvent{ //nolint: exhaustruct
+						FileID: lockedFileID,
+						Action: tc.expectedAction,
+						Reason: "litigation",
+					},
+					gomock.Any(),
+				).Return(held, nil)
+
+				// the object is held before the metadata and released after it
+				if legalHold {
+					gomock.InOrder(getFile, setObject, setMetadata)
+				} else {
+					gomock.InOrder(getFile, setMetadata, setObject)
+				}
+			}
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(),
+				tc.method,
+				"/v1/ops/files/"+lockedFileID+"/legal-hold",
+				strings.NewReader(`{"reason":"litigation"}`),
+			)
+			req.Header = tc.headers
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+		})
+	}
+}
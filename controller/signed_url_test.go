@@ -0,0 +1,234 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestURLSigner(t *testing.T) {
+	t.Parallel()
+
+	const fileID = "55af1e60-0f28-454e-885e-ea6aab2bb288"
+
+	now := time.Now()
+
+	old, err := controller.NewURLSigner("old:asd")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := controller.NewURLSigner("new:qwe", "old:asd")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name     string
+		query    func() url.Values
+		expected error
+	}{
+		{
+			name: "success",
+			query: func() url.Values {
+				return signer.Sign(fileID, now.Add(time.Minute), url.Values{"w": {"100"}})
+			},
+			expected: nil,
+		},
+		{
+			name: "rotated key",
+			query: func() url.Values {
+				return old.Sign(fileID, now.Add(time.Minute), nil)
+			},
+			expected: nil,
+		},
+		{
+			name: "unknown key",
+			query: func() url.Values {
+				q := signer.Sign(fileID, now.Add(time.Minute), nil)
+				q.Set("kid", "unknown")
+				return q
+			},
+			expected: controller.ErrSignedURLInvalid,
+		},
+		{
+			name: "tampered transformation",
+			query: func() url.Values {
+				q := signer.Sign(fileID, now.Add(time.Minute), url.Values{"w": {"100"}})
+				q.Set("w", "2000")
+				return q
+			},
+			expected: controller.ErrSignedURLInvalid,
+		},
+		{
+			name: "added disposition",
+			query: func() url.Values {
+				q := signer.Sign(fileID, now.Add(time.Minute), nil)
+				q.Set("disposition", "attachment")
+				return q
+			},
+			expected: controller.ErrSignedURLInvalid,
+		},
+		{
+			name: "tampered expiration",
+			query: func() url.Values {
+				q := signer.Sign(fileID, now.Add(time.Minute), nil)
+				q.Set("expires", "9999999999")
+				return q
+			},
+			expected: controller.ErrSignedURLInvalid,
+		},
+		{
+			name: "expired",
+			query: func() url.Values {
+				return signer.Sign(fileID, now.Add(-time.Minute), nil)
+			},
+			expected: controller.ErrSignedURLExpired,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := signer.Verify(fileID, tc.query(), now)
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("got %v, want %v", err, tc.expected)
+			}
+		})
+	}
+
+	if _, err := controller.NewURLSigner("missing-secret"); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestGetFileWithSignedURL(t *testing.T) {
+	t.Parallel()
+
+	const fileID = "55af1e60-0f28-454e-885e-ea6aab2bb288"
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	metadataStorage := mock.NewMockMetadataStorage(c)
+	contentStorage := mock.NewMockContentStorage(c)
+
+	metadataStorage.EXPECT().GetFileByID(
+		gomock.Any(), fileID, gomock.Any(),
+	).Return(controller.FileMetadata{ //nolint: exhaustruct
+		ID:         fileID,
+		Name:       "my-file.txt",
+		Size:       13,
+		BucketID:   "default",
+		ETag:       `"55af1e60-0f28-454e-885e-ea6aab2bb288"`,
+		CreatedAt:  "2021-12-27T09:58:11Z",
+		UpdatedAt:  "2021-12-27T09:58:11Z",
+		IsUploaded: true,
+		MimeType:   "text/plain; charset=utf-8",
+	}, nil).Times(2)
+
+	metadataStorage.EXPECT().GetBucketByID(
+		gomock.Any(), "default", gomock.Any(),
+	).Return(controller.BucketMetadata{ //nolint: exhaustruct
+		ID:                   "default",
+		DownloadExpiration:   30,
+		PresignedURLsEnabled: true,
+	}, nil).Times(2)
+
+	contentStorage.EXPECT().GetFile(gomock.Any(), fileID, gomock.Any()).Return(
+		&controller.File{
+			StatusCode:    http.StatusOK,
+			Etag:          `"55af1e60-0f28-454e-885e-ea6aab2bb288"`,
+			Body:          io.NopCloser(strings.NewReader("Hello, world!")),
+			ContentLength: 13,
+			ExtraHeaders:  make(http.Header),
+		}, nil,
+	)
+
+	signer, err := controller.NewURLSigner("key1:asd")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctrl := controller.New(
+		"http://asd",
+		"/v1",
+		auth.NewAdminSecrets("asdasd"),
+		metadataStorage,
+		contentStorage,
+		nil,
+		nil,
+		nil,
+		nil,
+		signer,
+		logger,
+	)
+
+	router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+	responseRecorder := httptest.NewRecorder()
+	req, _ := http.NewRequestWithContext(
+		context.Background(),
+		"GET",
+		"/v1/files/"+fileID+"/presignedurl?disposition=attachment",
+		nil,
+	)
+	router.ServeHTTP(responseRecorder, req)
+
+	assert(t, http.StatusOK, responseRecorder.Code)
+
+	var resp struct {
+		Data controller.GetFilePresignedURLResponse `json:"data"`
+	}
+	if err := json.Unmarshal(responseRecorder.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	signedURL, err := url.Parse(resp.Data.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert(t, 30, resp.Data.Expiration)
+	assert(t, "/v1/files/"+fileID+"/presignedurl/content", signedURL.Path)
+
+	responseRecorder = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(
+		context.Background(), "GET", signedURL.RequestURI(), nil,
+	)
+	router.ServeHTTP(responseRecorder, req)
+
+	assert(t, http.StatusOK, responseRecorder.Code)
+	assert(t, "Hello, world!", responseRecorder.Body.String())
+	assert(
+		t,
+		`attachment; filename="my-file.txt"`,
+		responseRecorder.Header().Get("Content-Disposition"),
+	)
+
+	// the transformation parameters are covered by the signature
+	responseRecorder = httptest.NewRecorder()
+	req, _ = http.NewRequestWithContext(
+		context.Background(), "GET", signedURL.RequestURI()+"&w=100", nil,
+	)
+	router.ServeHTTP(responseRecorder, req)
+
+	assert(t, http.StatusForbidden, responseRecorder.Code)
+}
@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ListTrashResponse struct {
+	Files []FileMetadata `json:"files"`
+}
+
+// trashAccess returns the headers used to access the trash of the caller, the user its
+// files are limited to and whether the headers are trusted, see sessionAccess. Callers
+// that aren't trusted need at least an access token.
+func (ctrl *Controller) trashAccess(
+	ctx context.Context, headers http.Header,
+) (string, http.Header, bool, *APIError) {
+	owner, headers, trusted := ctrl.sessionAccess(ctx, headers)
+	if !trusted && !strings.HasPrefix(headers.Get("Authorization"), "Bearer ") {
+		return "", nil, false, NewAPIError(
+			http.StatusUnauthorized,
+			"a user session is required",
+			errors.New("no user id in the session"), //nolint: goerr113
+			nil,
+		)
+	}
+
+	return owner, headers, trusted, nil
+}
+
+func (ctrl *Controller) listTrash(ctx *gin.Context) (ListTrashResponse, *APIError) {
+	owner, headers, _, apiErr := ctrl.trashAccess(ctx, ctx.Request.Header)
+	if apiErr != nil {
+		return ListTrashResponse{}, apiErr
+	}
+
+	bucketID := ctx.Query("bucketId")
+	if bucketID != "" {
+		if apiErr := checkAPIKeyBucket(ctx.Request.Header, bucketID); apiErr != nil {
+			return ListTrashResponse{}, apiErr
+		}
+	}
+
+	files, apiErr := ctrl.metadataStorage.ListTrashedFiles(
+		ctx.Request.Context(),
+		FileFilter{UploadedBy: owner, BucketID: bucketID}, //nolint: exhaustruct
+		headers,
+	)
+	if apiErr != nil {
+		return ListTrashResponse{}, apiErr
+	}
+
+	res := ListTrashResponse{Files: make([]FileMetadata, 0, len(files))}
+	for _, file := range files {
+		if checkAPIKeyBucket(ctx.Request.Header, file.BucketID) == nil {
+			res.Files = append(res.Files, file)
+		}
+	}
+
+	return res, nil
+}
+
+func (ctrl *Controller) ListTrash(ctx *gin.Context) {
+	res, apiErr := ctrl.listTrash(ctx)
+	if apiErr != nil {
+		_ = ctx.Error(fmt.Errorf("problem processing request: %w", apiErr))
+
+		ctx.JSON(apiErr.statusCode, CommonResponse{
+			Code:    apiErr.statusCode,
+			Message: apiErr.PublicResponse().Message,
+		})
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, CommonResponse{http.StatusOK, "ok", res})
+}
+
+func (ctrl *Controller) restoreFile(ctx *gin.Context) (FileMetadata, *APIError) {
+	owner, headers, trusted, apiErr := ctrl.trashAccess(ctx, ctx.Request.Header)
+	if apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	fileMetadata, apiErr := ctrl.metadataStorage.GetFileByID(
+		ctx.Request.Context(), ctx.Param("id"), headers,
+	)
+	if apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	// users can only restore the files they uploaded
+	if fileMetadata.DeletedAt == "" || (owner != "" && fileMetadata.UploadedByUserID != owner) {
+		return FileMetadata{}, ErrFileNotFound
+	}
+
+	if apiErr := checkAPIKeyBucket(ctx.Request.Header, fileMetadata.BucketID); apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	// trusted callers restore the file with the admin secret so the event can be queued
+	// along with the change
+	var events []WebhookEvent
+	if trusted {
+		events = ctrl.events(ctx, Event{
+			Type:     EventFileRestored,
+			BucketID: fileMetadata.BucketID,
+			FileID:   fileMetadata.ID,
+		})
+	}
+
+	// files in the trash still count towards the quotas so there is nothing to check
+	fileMetadata, apiErr = ctrl.metadataStorage.RestoreFile(
+		ctx.Request.Context(), fileMetadata.ID, events, headers,
+	)
+	if apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	ctx.Set("FileChanged", fileMetadata.ID)
+	if !trusted {
+		ctrl.notify(ctx, Event{Type: EventFileRestored, BucketID: fileMetadata.BucketID, Data: fileMetadata})
+	}
+
+	return fileMetadata, nil
+}
+
+func (ctrl *Controller) RestoreFile(ctx *gin.Context) {
+	metadata, apiErr := ctrl.restoreFile(ctx)
+	if apiErr != nil {
+		_ = ctx.Error(fmt.Errorf("problem processing request: %w", apiErr))
+
+		ctx.JSON(apiErr.statusCode, CommonResponse{
+			Code:    apiErr.statusCode,
+			Message: apiErr.PublicResponse().Message,
+		})
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, CommonResponse{http.StatusOK, "ok", metadata})
+}
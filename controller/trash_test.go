@@ -0,0 +1,315 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+)
+
+const (
+	trashedFileID = "55af1e60-0f28-454e-885e-ea6aab2bb288"
+	trashOwnerID  = "ab5ba58e-932a-40dc-87e8-733998794ec2"
+)
+
+func trashedFile() controller.FileMetadata {
+	return controller.FileMetadata{ //nolint: exhaustruct
+		ID:               trashedFileID,
+		Name:             "report.pdf",
+		Size:             1024,
+		BucketID:         "default",
+		ETag:             `"some-etag"`,
+		UpdatedAt:        "2021-12-16T13:26:52.082485+00:00",
+		IsUploaded:       true,
+		MimeType:         "application/pdf",
+		UploadedByUserID: trashOwnerID,
+		DeletedAt:        "2021-12-16T13:26:52.082485+00:00",
+		DeletedByUserID:  trashOwnerID,
+	}
+}
+
+func TestListTrash(t *testing.T) {
+	t.Parallel()
+
+	token := bearer(t, trashOwnerID, "user")
+
+	cases := []struct {
+		name            string
+		path            string
+		headers         http.Header
+		unverified      bool
+		expectedFilter  *controller.FileFilter
+		expectedHeaders gomock.Matcher
+		expectedStatus  int
+	}{
+		{
+			name: "user",
+			path: "/v1/trash",
+			headers: http.Header{
+				"Authorization": []string{token},
+			},
+			expectedFilter:  &controller.FileFilter{UploadedBy: trashOwnerID}, //nolint: exhaustruct
+			expectedHeaders: gomock.Eq(http.Header{"x-hasura-admin-secret": []string{"asdasd"}}),
+			expectedStatus:  http.StatusOK,
+		},
+		{
+			name: "token not verified by the service",
+			path: "/v1/trash",
+			headers: http.Header{
+				"Authorization": []string{token},
+			},
+			unverified:      true,
+			expectedFilter:  &controller.FileFilter{}, //nolint: exhaustruct
+			expectedHeaders: gomock.Eq(http.Header{"Authorization": []string{token}}),
+			expectedStatus:  http.StatusOK,
+		},
+		{
+			name: "spoofed session headers",
+			path: "/v1/trash",
+			headers: http.Header{
+				"X-Hasura-User-Id": []string{trashOwnerID},
+				"X-Hasura-Role":    []string{"user"},
+			},
+			unverified:     true,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "admin in bucket",
+			path: "/v1/trash?bucketId=default",
+			headers: http.Header{
+				"X-Hasura-Admin-Secret": []string{"asdasd"},
+			},
+			expectedFilter:  &controller.FileFilter{BucketID: "default"}, //nolint: exhaustruct
+			expectedHeaders: gomock.Eq(http.Header{"x-hasura-admin-secret": []string{"asdasd"}}),
+			expectedStatus:  http.StatusOK,
+		},
+		{
+			name:           "no user",
+			path:           "/v1/trash",
+			headers:        http.Header{},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			if tc.expectedFilter != nil {
+				metadataStorage.EXPECT().ListTrashedFiles(
+					gomock.Any(), *tc.expectedFilter, tc.expectedHeaders,
+				).Return([]controller.FileMetadata{trashedFile()}, nil)
+			}
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			middleware := []gin.HandlerFunc{ginLogger(logger)}
+			if !tc.unverified {
+				middleware = append(middleware, verifyJWT(t))
+			}
+
+			router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, middleware...)
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(context.Background(), "GET", tc.path, nil)
+			req.Header = tc.headers
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+
+			if tc.expectedFilter == nil {
+				return
+			}
+
+			resp := struct {
+				Data controller.ListTrashResponse `json:"data"`
+			}{}
+			if err := json.Unmarshal(responseRecorder.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			assert(t, controller.ListTrashResponse{Files: []controller.FileMetadata{trashedFile()}}, resp.Data)
+		})
+	}
+}
+
+func TestRestoreFile(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name           string
+		file           controller.FileMetadata
+		userID         string
+		spoofed        bool
+		expectedStatus int
+	}{
+		{
+			name:           "owner",
+			file:           trashedFile(),
+			userID:         trashOwnerID,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "another user",
+			file:           trashedFile(),
+			userID:         "8c4b6e8e-2f3a-4ea0-a9a6-6d16ea6b1fc1",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "not in the trash",
+			file: func() controller.FileMetadata {
+				f := trashedFile()
+				f.DeletedAt = ""
+				f.DeletedByUserID = ""
+				return f
+			}(),
+			userID:         trashOwnerID,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "spoofed session headers",
+			file:           trashedFile(),
+			userID:         trashOwnerID,
+			spoofed:        true,
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			metadataStorage := mock.NewMockMetadataStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			if !tc.spoofed {
+				metadataStorage.EXPECT().GetFileByID(
+					gomock.Any(), trashedFileID, gomock.Any(),
+				).Return(tc.file, nil)
+			}
+
+			if tc.expectedStatus == http.StatusOK {
+				restored := tc.file
+				restored.DeletedAt = ""
+				restored.DeletedByUserID = ""
+
+				metadataStorage.EXPECT().RestoreFile(
+					gomock.Any(), trashedFileID, gomock.Any(), gomock.Any(),
+				).Return(restored, nil)
+			}
+
+			ctrl := controller.New(
+				"http://asd",
+				"/v1",
+				auth.NewAdminSecrets("asdasd"),
+				metadataStorage,
+				contentStorage,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				logger,
+			)
+
+			router, _ := ctrl.SetupRouter(
+				nil, "/v1", []string{"*"}, false, ginLogger(logger), verifyJWT(t),
+			)
+
+			responseRecorder := httptest.NewRecorder()
+
+			req, _ := http.NewRequestWithContext(
+				context.Background(), "POST", "/v1/files/"+trashedFileID+"/restore", nil,
+			)
+			if tc.spoofed {
+				req.Header.Set("X-Hasura-User-Id", tc.userID)
+				req.Header.Set("X-Hasura-Role", "user")
+			} else {
+				req.Header.Set("Authorization", bearer(t, tc.userID, "user"))
+			}
+
+			router.ServeHTTP(responseRecorder, req)
+
+			assert(t, tc.expectedStatus, responseRecorder.Code)
+		})
+	}
+}
+
+func TestGetTrashedFile(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	metadataStorage := mock.NewMockMetadataStorage(c)
+	contentStorage := mock.NewMockContentStorage(c)
+
+	metadataStorage.EXPECT().GetFileByID(
+		gomock.Any(), trashedFileID, gomock.Any(),
+	).Return(trashedFile(), nil)
+
+	ctrl := controller.New(
+		"http://asd",
+		"/v1",
+		auth.NewAdminSecrets("asdasd"),
+		metadataStorage,
+		contentStorage,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		logger,
+	)
+
+	router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+	responseRecorder := httptest.NewRecorder()
+
+	req, _ := http.NewRequestWithContext(
+		context.Background(), "GET", "/v1/files/"+trashedFileID, nil,
+	)
+
+	router.ServeHTTP(responseRecorder, req)
+
+	assert(t, http.StatusNotFound, responseRecorder.Code)
+}
@@ -74,6 +74,15 @@ func (ctrl *Controller) updateFile(ctx *gin.Context) (FileMetadata, *APIError) {
 		return FileMetadata{}, apiErr
 	}
 
+	condition, apiErr := checkWritePreconditions(ctx, originalMetadata)
+	if apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	if apiErr := checkFileLock(originalMetadata); apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
 	if apiErr = checkFileSize(
 		file.header, bucketMetadata.MinUploadFile, bucketMetadata.MaxUploadFile,
 	); apiErr != nil {
@@ -82,37 +91,79 @@ func (ctrl *Controller) updateFile(ctx *gin.Context) (FileMetadata, *APIError) {
 		)
 	}
 
-	if apiErr := ctrl.metadataStorage.SetIsUploaded(ctx, file.ID, false, ctx.Request.Header); apiErr != nil {
-		return FileMetadata{}, apiErr.ExtendError(
-			fmt.Sprintf(
-				"problem flagging file as pending upload %s: %s",
-				file.Name,
-				apiErr.Error(),
-			),
-		)
+	fileContent, contentType, detectedType, err := ctrl.getMultipartFile(file)
+	if err != nil {
+		return FileMetadata{}, err
 	}
+	defer fileContent.Close()
 
-	fileContent, contentType, err := ctrl.getMultipartFile(file)
+	if apiErr := checkContentType(bucketMetadata, file.Name, contentType, detectedType); apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+
+	fileContent, file, err = sanitizeFile(fileContent, file, detectedType)
 	if err != nil {
 		return FileMetadata{}, err
 	}
-	defer fileContent.Close()
 
+	hookReq, apiErr := ctrl.checkUpload(ctx, UploadHookRequest{ //nolint: exhaustruct
+		Operation: UploadOperationUpdate,
+		FileID:    file.ID,
+		BucketID:  originalMetadata.BucketID,
+		Name:      file.Name,
+		Size:      file.header.Size,
+		MimeType:  contentType,
+		Metadata:  file.Metadata,
+	}, ctx.Request.Header)
+	if apiErr != nil {
+		return FileMetadata{}, apiErr
+	}
+	file.Metadata = hookReq.Metadata
+
+	// replacing the content doesn't add a file, only the growth counts towards the quota
+	if delta := file.header.Size - originalMetadata.Size; delta > 0 {
+		if apiErr := ctrl.checkQuota(
+			ctx, ctx.Request.Header, originalMetadata.BucketID, delta, 0,
+		); apiErr != nil {
+			return FileMetadata{}, apiErr
+		}
+	}
+
+	objectKey := FileObjectKey(originalMetadata)
+
+	// infected content is rejected before the file is modified so it keeps its content
 	if err := ctrl.scanAndReportVirus(
-		ctx, fileContent, file.ID, file.Name, ctx.Request.Header,
+		ctx, fileContent, file, originalMetadata.BucketID, contentType, objectKey, ctx.Request.Header,
 	); err != nil {
 		return FileMetadata{}, err
 	}
 
-	objectKey := originalMetadata.ObjectKey
-	if objectKey == "" {
-		objectKey = file.ID
+	// flagging the file as pending upload is the first change so it's the one that has to
+	// match the version of the file the request was checked against
+	if apiErr := ctrl.metadataStorage.SetIsUploaded(
+		ctx, file.ID, false, condition, ctx.Request.Header,
+	); apiErr != nil {
+		return FileMetadata{}, apiErr.ExtendError(
+			fmt.Sprintf(
+				"problem flagging file as pending upload %s: %s",
+				file.Name,
+				apiErr.Error(),
+			),
+		)
+	}
+
+	if bucketMetadata.VersioningEnabled && originalMetadata.IsUploaded {
+		if apiErr := ctrl.preserveFileVersion(ctx, originalMetadata); apiErr != nil {
+			_ = ctrl.metadataStorage.SetIsUploaded(ctx, file.ID, true, FileCondition{}, ctx.Request.Header)
+
+			return FileMetadata{}, apiErr
+		}
 	}
 
 	etag, apiErr := ctrl.contentStorage.PutFile(ctx, fileContent, objectKey, contentType)
 	if apiErr != nil {
 		// let's revert the change to isUploaded
-		_ = ctrl.metadataStorage.SetIsUploaded(ctx, file.ID, true, ctx.Request.Header)
+		_ = ctrl.metadataStorage.SetIsUploaded(ctx, file.ID, true, FileCondition{}, ctx.Request.Header)
 
 		return FileMetadata{}, apiErr.ExtendError("problem uploading file to storage")
 	}
@@ -130,7 +181,11 @@ func (ctrl *Controller) updateFile(ctx *gin.Context) (FileMetadata, *APIError) {
 		file.header.Size,
 		1,
 		"",
+		// the file keeps its original uploader
+		"",
+		"",
 		file.Metadata,
+		nil,
 		ctx.Request.Header,
 	)
 	if apiErr != nil {
@@ -139,7 +194,11 @@ func (ctrl *Controller) updateFile(ctx *gin.Context) (FileMetadata, *APIError) {
 		)
 	}
 
+	ctrl.lockObject(ctx, newMetadata, bucketMetadata)
+
 	ctx.Set("FileChanged", file.ID)
+	ctrl.notify(ctx, Event{Type: EventFileUpdated, BucketID: newMetadata.BucketID, Data: newMetadata})
+
 	return newMetadata, nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"mime/multipart"
 	"net/http"
@@ -15,6 +16,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/nhost/hasura-storage/controller"
 	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
 	"github.com/sirupsen/logrus"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -123,7 +125,7 @@ func TestUpdateFile(t *testing.T) {
 			)
 
 			metadataStorage.EXPECT().SetIsUploaded(
-				gomock.Any(), file.md.ID, false, gomock.Any(),
+				gomock.Any(), file.md.ID, false, controller.FileCondition{}, gomock.Any(),
 			).Return(nil)
 
 			contentStorage.EXPECT().PutFile(
@@ -148,8 +150,11 @@ func TestUpdateFile(t *testing.T) {
 				int64(len(file.contents)),
 				int64(1),
 				"",
+				"",
+				"",
 				file.md.Metadata,
 				gomock.Any(),
+				gomock.Any(),
 			).Return(
 				controller.FileMetadata{
 					ID:               file.md.ID,
@@ -175,11 +180,14 @@ func TestUpdateFile(t *testing.T) {
 			ctrl := controller.New(
 				"http://asd",
 				"/v1",
-				"asdasd",
+				auth.NewAdminSecrets("asdasd"),
 				metadataStorage,
 				contentStorage,
 				nil,
 				av,
+				nil,
+				nil,
+				nil,
 				logger,
 			)
 
@@ -234,3 +242,268 @@ func TestUpdateFile(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateFileContentTypeMismatch(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	metadataStorage := mock.NewMockMetadataStorage(c)
+	contentStorage := mock.NewMockContentStorage(c)
+
+	metadataStorage.EXPECT().GetFileByID(
+		gomock.Any(), "55af1e60-0f28-454e-885e-ea6aab2bb288", gomock.Any(),
+	).Return(controller.FileMetadata{ //nolint: exhaustruct
+		ID:         "55af1e60-0f28-454e-885e-ea6aab2bb288",
+		Name:       "picture.png",
+		BucketID:   "default",
+		IsUploaded: true,
+		MimeType:   "image/png",
+	}, nil)
+
+	metadataStorage.EXPECT().GetBucketByID(
+		gomock.Any(), "default", gomock.Any(),
+	).Return(controller.BucketMetadata{ //nolint: exhaustruct
+		ID:            "default",
+		MaxUploadFile: 1024,
+	}, nil)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	formWriter, err := writer.CreatePart(map[string][]string{
+		"Content-Disposition": {`form-data; name="file"; filename="picture.png"`},
+		"Content-Type":        {"image/png"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.WriteString(
+		formWriter, "<html><body><script>alert(1)</script></body></html>",
+	); err != nil {
+		t.Fatal(err)
+	}
+	writer.Close()
+
+	ctrl := controller.New(
+		"http://asd",
+		"/v1",
+		auth.NewAdminSecrets("asdasd"),
+		metadataStorage,
+		contentStorage,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		logger,
+	)
+
+	router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+	responseRecorder := httptest.NewRecorder()
+
+	req, _ := http.NewRequestWithContext(
+		context.Background(),
+		"PUT",
+		"/v1/files/55af1e60-0f28-454e-885e-ea6aab2bb288",
+		body,
+	)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	router.ServeHTTP(responseRecorder, req)
+
+	assert(t, http.StatusBadRequest, responseRecorder.Code)
+}
+
+func TestUpdateFileDeclaredTypeNotAllowed(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	metadataStorage := mock.NewMockMetadataStorage(c)
+	contentStorage := mock.NewMockContentStorage(c)
+
+	metadataStorage.EXPECT().GetFileByID(
+		gomock.Any(), "55af1e60-0f28-454e-885e-ea6aab2bb288", gomock.Any(),
+	).Return(controller.FileMetadata{ //nolint: exhaustruct
+		ID:         "55af1e60-0f28-454e-885e-ea6aab2bb288",
+		Name:       "notes.txt",
+		BucketID:   "default",
+		IsUploaded: true,
+		MimeType:   "text/plain",
+	}, nil)
+
+	// the content is plain text but the type that would be stored isn't allowed
+	metadataStorage.EXPECT().GetBucketByID(
+		gomock.Any(), "default", gomock.Any(),
+	).Return(controller.BucketMetadata{ //nolint: exhaustruct
+		ID:              "default",
+		MaxUploadFile:   1024,
+		DeniedMimeTypes: []string{"text/csv"},
+	}, nil)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	formWriter, err := writer.CreatePart(map[string][]string{
+		"Content-Disposition": {`form-data; name="file"; filename="notes.txt"`},
+		"Content-Type":        {"text/csv"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.WriteString(formWriter, "some notes"); err != nil {
+		t.Fatal(err)
+	}
+	writer.Close()
+
+	ctrl := controller.New(
+		"http://asd",
+		"/v1",
+		auth.NewAdminSecrets("asdasd"),
+		metadataStorage,
+		contentStorage,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		logger,
+	)
+
+	router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+	responseRecorder := httptest.NewRecorder()
+
+	req, _ := http.NewRequestWithContext(
+		context.Background(),
+		"PUT",
+		"/v1/files/55af1e60-0f28-454e-885e-ea6aab2bb288",
+		body,
+	)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	router.ServeHTTP(responseRecorder, req)
+
+	assert(t, http.StatusBadRequest, responseRecorder.Code)
+}
+
+func TestUpdateFileVirusFound(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	file := fakeFile{
+		contents:    "some content",
+		contentType: "",
+		md: fakeFileMetadata{
+			Name: "a_file.txt",
+			ID:   uuid.New().String(),
+		},
+	}
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	metadataStorage := mock.NewMockMetadataStorage(c)
+	contentStorage := mock.NewMockContentStorage(c)
+	av := mock.NewMockAntivirus(c)
+
+	metadataStorage.EXPECT().GetFileByID(
+		gomock.Any(), file.md.ID, gomock.Any(),
+	).Return(
+		controller.FileMetadata{ //nolint: exhaustruct
+			ID:         file.md.ID,
+			Name:       file.md.Name,
+			Size:       int64(len(file.contents)),
+			BucketID:   "blah",
+			ETag:       "some-etag",
+			IsUploaded: true,
+			MimeType:   "text/plain; charset=utf-8",
+			ObjectKey:  file.md.ID,
+		},
+		nil,
+	)
+	metadataStorage.EXPECT().GetBucketByID(
+		gomock.Any(), "blah", gomock.Any(),
+	).Return(
+		controller.BucketMetadata{ //nolint: exhaustruct
+			ID:            "blah",
+			MaxUploadFile: 100,
+		},
+		nil,
+	)
+
+	virusErr := controller.ForbiddenError(errors.New("virus found"), "virus found") //nolint: goerr113
+	virusErr.SetData("virus", "Eicar-Signature")
+	av.EXPECT().ScanReader(gomock.Any()).Return(virusErr)
+
+	// the infected content goes to its own key and the file isn't touched
+	var quarantineKey string
+	contentStorage.EXPECT().PutFile(
+		gomock.Any(), ReaderMatcher(file.contents), gomock.Any(), "text/plain; charset=utf-8",
+	).DoAndReturn(
+		func(_ context.Context, _ io.ReadSeeker, filepath, _ string) (string, *controller.APIError) {
+			quarantineKey = filepath
+			return "some-etag", nil
+		},
+	)
+	metadataStorage.EXPECT().InsertVirus(
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+	).DoAndReturn(
+		func(
+			_ context.Context, virus controller.VirusMetadata, _ []controller.WebhookEvent, _ http.Header,
+		) *controller.APIError {
+			if virus.Quarantine == nil || virus.Quarantine.ObjectKey != quarantineKey {
+				t.Errorf("unexpected quarantine %+v", virus.Quarantine)
+			}
+			if !strings.HasPrefix(quarantineKey, ".quarantine/"+virus.ID+"/") ||
+				!strings.HasSuffix(quarantineKey, "/"+file.md.ID) {
+				t.Errorf("unexpected quarantine key %s", quarantineKey)
+			}
+			return nil
+		},
+	)
+
+	ctrl := controller.New(
+		"http://asd",
+		"/v1",
+		auth.NewAdminSecrets("asdasd"),
+		metadataStorage,
+		contentStorage,
+		nil,
+		av,
+		nil,
+		nil,
+		nil,
+		logger,
+	)
+
+	router, _ := ctrl.SetupRouter(nil, "/v1", []string{"*"}, false, ginLogger(logger))
+
+	body, contentType := createUpdateMultiForm(t, file)
+
+	responseRecorder := httptest.NewRecorder()
+
+	req, _ := http.NewRequestWithContext(
+		context.Background(),
+		"PUT",
+		"/v1/files/"+file.md.ID,
+		body,
+	)
+	req.Header.Set("Content-Type", contentType)
+
+	router.ServeHTTP(responseRecorder, req)
+
+	assert(t, http.StatusForbidden, responseRecorder.Code)
+}
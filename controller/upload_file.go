@@ -8,8 +8,8 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/url"
+	"strconv"
 
-	"github.com/gabriel-vasile/mimetype"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -21,77 +21,119 @@ type UploadFileResponse struct {
 }
 
 type fileData struct {
-	Name     string         `json:"name"`
-	ID       string         `json:"id"`
-	Metadata map[string]any `json:"metadata"`
-	header   *multipart.FileHeader
+	Name      string         `json:"name"`
+	ID        string         `json:"id"`
+	Metadata  map[string]any `json:"metadata"`
+	ExpiresAt string         `json:"expiresAt"`
+	header    *multipart.FileHeader
+	// open returns the content if it doesn't come from header, i.e. archive entries
+	open func() (multipart.File, error)
 }
 
 type uploadFileRequest struct {
 	bucketID     string
 	objectPrefix string
 	files        []fileData
+	extract      bool
 	headers      http.Header
 }
 
-func checkFileSize(file *multipart.FileHeader, minSize, maxSize int) *APIError {
-	if minSize > int(file.Size) {
-		return FileTooSmallError(file.Filename, int(file.Size), minSize)
-	} else if int(file.Size) > maxSize {
-		return FileTooBigError(file.Filename, int(file.Size), maxSize)
+func checkSize(filename string, size int64, minSize, maxSize int) *APIError {
+	if minSize > int(size) {
+		return FileTooSmallError(filename, int(size), minSize)
+	} else if int(size) > maxSize {
+		return FileTooBigError(filename, int(size), maxSize)
 	}
 
 	return nil
 }
 
-func (ctrl *Controller) getMultipartFile(file fileData) (multipart.File, string, *APIError) {
-	fileContent, err := file.header.Open()
+func checkFileSize(file *multipart.FileHeader, minSize, maxSize int) *APIError {
+	return checkSize(file.Filename, file.Size, minSize, maxSize)
+}
+
+// getMultipartFile returns the content of the file, the content type to store and the
+// content type detected from the content itself.
+func (ctrl *Controller) getMultipartFile(file fileData) (multipart.File, string, string, *APIError) {
+	open := file.header.Open
+	if file.open != nil {
+		open = file.open
+	}
+
+	fileContent, err := open()
 	if err != nil {
-		return nil, "", InternalServerError(
+		return nil, "", "", InternalServerError(
 			fmt.Errorf("problem opening file %s: %w", file.Name, err),
 		)
 	}
 
+	detected, apiErr := sniffContentType(fileContent)
+	if apiErr != nil {
+		_ = fileContent.Close()
+		return nil, "", "", apiErr.ExtendError("problem figuring out content type for file " + file.Name)
+	}
+
 	contentType := file.header.Header.Get("Content-Type")
-	if contentType != "" && contentType != "application/octet-stream" {
-		return fileContent, contentType, nil
+	if contentType == "" || contentType == "application/octet-stream" {
+		return fileContent, detected.String(), detected.String(), nil
 	}
 
-	mt, err := mimetype.DetectReader(fileContent)
-	if err != nil {
-		return nil, "",
-			InternalServerError(
-				fmt.Errorf("problem figuring out content type for file %s: %w", file.Name, err),
-			)
+	if !declaredTypeMatches(contentType, detected) {
+		_ = fileContent.Close()
+		return nil, "", "", ContentTypeMismatchError(file.Name, contentType, detected.String())
 	}
 
-	return fileContent, mt.String(), nil
+	return fileContent, contentType, detected.String(), nil
 }
 
+// scanAndReportVirus records the finding when the antivirus rejects the file. Infected
+// content is quarantined under its own object key so the file it was meant for is left
+// untouched.
 func (ctrl *Controller) scanAndReportVirus(
 	ctx context.Context,
 	fileContent multipart.File,
-	fileID string,
-	filename string,
+	file fileData,
+	bucketID, contentType, objectKey string,
 	headers http.Header,
 ) *APIError {
-	if err := ctrl.av.ScanReader(fileContent); err != nil {
-		err.SetData("file", filename)
-
-		userSession := GetUserSession(headers)
-
-		if err := ctrl.metadataStorage.InsertVirus(
-			ctx, fileID, filename, err.GetDataString("virus"), userSession,
-			http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret}},
-		); err != nil {
-			err := err.ExtendError("problem inserting virus into database")
-			return err
-		}
+	err := ctrl.av.ScanReader(fileContent)
+	if err == nil {
+		return nil
+	}
+	err.SetData("file", file.Name)
+
+	finding := VirusMetadata{ //nolint: exhaustruct
+		ID:          uuid.New().String(),
+		FileID:      file.ID,
+		Filename:    file.Name,
+		BucketID:    bucketID,
+		Virus:       err.GetDataString("virus"),
+		UserSession: GetUserSession(headers),
+	}
+	var events []WebhookEvent
+	if finding.Virus != "" {
+		finding.Quarantine = ctrl.quarantineFile(ctx, finding.ID, fileContent, file, contentType, objectKey)
+		events = ctrl.events(ctx, Event{
+			Type:     EventVirusDetected,
+			BucketID: bucketID,
+			Data: map[string]any{
+				"fileId":   file.ID,
+				"filename": file.Name,
+				"virus":    finding.Virus,
+			},
+		})
+	}
 
-		return err
+	if apiErr := ctrl.metadataStorage.InsertVirus(
+		ctx,
+		finding,
+		events,
+		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}},
+	); apiErr != nil {
+		return apiErr.ExtendError("problem inserting virus into database")
 	}
 
-	return nil
+	return err
 }
 
 func (ctrl *Controller) processFile(
@@ -107,27 +149,55 @@ func (ctrl *Controller) processFile(
 		)
 	}
 
-	objectKey, joinErr := url.JoinPath(objectPrefix, file.ID)
-	if joinErr != nil {
-		return FileMetadata{}, InternalServerError(
-			fmt.Errorf("problem joining path: %w", joinErr),
-		)
+	fileContent, contentType, detectedType, err := ctrl.getMultipartFile(file)
+	if err != nil {
+		return FileMetadata{}, err
 	}
+	defer fileContent.Close()
 
-	fileContent, contentType, err := ctrl.getMultipartFile(file)
+	if err := checkContentType(bucket, file.Name, contentType, detectedType); err != nil {
+		return FileMetadata{}, err
+	}
+
+	fileContent, file, err = sanitizeFile(fileContent, file, detectedType)
 	if err != nil {
 		return FileMetadata{}, err
 	}
-	defer fileContent.Close()
+
+	hookReq, err := ctrl.checkUpload(ctx, UploadHookRequest{ //nolint: exhaustruct
+		Operation:    UploadOperationUpload,
+		FileID:       file.ID,
+		BucketID:     bucket.ID,
+		Name:         file.Name,
+		Size:         file.header.Size,
+		MimeType:     contentType,
+		ObjectPrefix: objectPrefix,
+		Metadata:     file.Metadata,
+	}, headers)
+	if err != nil {
+		return FileMetadata{}, err
+	}
+	file.Metadata = hookReq.Metadata
+
+	if err := ctrl.checkQuota(ctx, headers, bucket.ID, file.header.Size, 1); err != nil {
+		return FileMetadata{}, err
+	}
+
+	objectKey, joinErr := url.JoinPath(hookReq.ObjectPrefix, file.ID)
+	if joinErr != nil {
+		return FileMetadata{}, InternalServerError(
+			fmt.Errorf("problem joining path: %w", joinErr),
+		)
+	}
 
 	if err := ctrl.metadataStorage.InitializeFile(
-		ctx, file.ID, file.Name, file.header.Size, bucket.ID, contentType, objectKey, file.header.Size, 1, "", headers,
+		ctx, file.ID, file.Name, file.header.Size, bucket.ID, contentType, objectKey, file.header.Size, 1, "", file.ExpiresAt, headers,
 	); err != nil {
 		return FileMetadata{}, err
 	}
 
 	if err := ctrl.scanAndReportVirus(
-		ctx, fileContent, file.ID, file.Name, headers,
+		ctx, fileContent, file, bucket.ID, contentType, objectKey, headers,
 	); err != nil {
 		return FileMetadata{}, err
 	}
@@ -137,15 +207,21 @@ func (ctrl *Controller) processFile(
 		_ = ctrl.metadataStorage.DeleteFileByID(
 			ctx,
 			file.ID,
-			http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret}},
+			FileCondition{},
+			nil,
+			http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}},
 		)
 		return FileMetadata{}, apiErr.ExtendError("problem uploading file to storage")
 	}
 
+	// the uploader is written with the admin secret so roles don't need to be allowed to
+	// set it, its role is kept along to match the quotas of the role
+	uploadedBy, uploadedByRole := ctrl.sessionVariables(ctx, headers)
 	metadata, apiErr := ctrl.metadataStorage.PopulateMetadata(
 		ctx,
-		file.ID, file.Name, file.header.Size, bucket.ID, etag, true, contentType, objectKey, file.header.Size, 1, "", file.Metadata,
-		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret}},
+		file.ID, file.Name, file.header.Size, bucket.ID, etag, true, contentType, objectKey, file.header.Size, 1, "", uploadedBy, uploadedByRole, file.Metadata,
+		ctrl.events(ctx, Event{Type: EventFileUploaded, BucketID: bucket.ID, FileID: file.ID}),
+		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}},
 	)
 	if apiErr != nil {
 		return FileMetadata{}, apiErr.ExtendError(
@@ -153,6 +229,8 @@ func (ctrl *Controller) processFile(
 		)
 	}
 
+	ctrl.lockObject(ctx, metadata, bucket)
+
 	return metadata, nil
 }
 
@@ -160,10 +238,14 @@ func (ctrl *Controller) upload(
 	ctx context.Context,
 	request uploadFileRequest,
 ) ([]FileMetadata, *APIError) {
+	if err := checkAPIKeyBucket(request.headers, request.bucketID); err != nil {
+		return nil, err
+	}
+
 	bucket, err := ctrl.metadataStorage.GetBucketByID(
 		ctx,
 		request.bucketID,
-		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret}},
+		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}},
 	)
 	if err != nil {
 		return nil, err
@@ -172,6 +254,15 @@ func (ctrl *Controller) upload(
 	filesMetadata := make([]FileMetadata, 0, len(request.files))
 
 	for _, file := range request.files {
+		if request.extract {
+			extracted, err := ctrl.processArchive(ctx, file, bucket, request.objectPrefix, request.headers)
+			filesMetadata = append(filesMetadata, extracted...)
+			if err != nil {
+				return filesMetadata, err
+			}
+			continue
+		}
+
 		metadata, err := ctrl.processFile(ctx, file, bucket, request.objectPrefix, request.headers)
 		if err != nil {
 			return filesMetadata, err
@@ -212,6 +303,19 @@ func getBucketIDFromFormValue(md map[string][]string) string {
 	return "default"
 }
 
+func getExtractFromFormValue(md map[string][]string) (bool, *APIError) {
+	extract, ok := md["extract"]
+	if !ok {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(extract[0])
+	if err != nil {
+		return false, BadDataError(err, "extract must be a boolean")
+	}
+	return b, nil
+}
+
 func getObjectPrefixFromFormValue(md map[string][]string) string {
 	objectPrefix, ok := md["object-prefix"]
 	if ok {
@@ -220,6 +324,14 @@ func getObjectPrefixFromFormValue(md map[string][]string) string {
 	return ""
 }
 
+func getExpiresAtFromFormValue(md map[string][]string) string {
+	expiresAt, ok := md["expires-at"]
+	if ok {
+		return expiresAt[0]
+	}
+	return ""
+}
+
 func parseUploadRequestOld(ctx *gin.Context) (uploadFileRequest, *APIError) {
 	form, err := ctx.MultipartForm()
 	if err != nil {
@@ -267,6 +379,7 @@ func parseUploadRequestOld(ctx *gin.Context) (uploadFileRequest, *APIError) {
 				header: fileHeader,
 			},
 		},
+		extract: false,
 		headers: ctx.Request.Header,
 	}, nil
 }
@@ -303,13 +416,25 @@ func parseUploadRequestNew(ctx *gin.Context) (uploadFileRequest, *APIError) {
 		if fileReq.ID == "" {
 			fileReq.ID = uuid.New().String()
 		}
+		if fileReq.ExpiresAt == "" {
+			fileReq.ExpiresAt = getExpiresAtFromFormValue(form.Value)
+		}
+		if fileReq.ExpiresAt, err = parseExpiresAt(fileReq.ExpiresAt); err != nil {
+			return uploadFileRequest{}, err
+		}
 		processedFiles[idx] = fileReq
 	}
 
+	extract, apiErr := getExtractFromFormValue(form.Value)
+	if apiErr != nil {
+		return uploadFileRequest{}, apiErr
+	}
+
 	return uploadFileRequest{
 		bucketID:     getBucketIDFromFormValue(form.Value),
 		objectPrefix: getObjectPrefixFromFormValue(form.Value),
 		files:        processedFiles,
+		extract:      extract,
 		headers:      ctx.Request.Header,
 	}, nil
 }
@@ -50,7 +50,7 @@ func (ctrl *Controller) uploadFileMultipartWithPresignedURL(ctx *gin.Context) *A
 		ctx.Request.Context(),
 		req.fileID,
 		false,
-		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret}},
+		http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}},
 	)
 	if apiErr != nil {
 		return apiErr
@@ -17,6 +17,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/nhost/hasura-storage/controller"
 	"github.com/nhost/hasura-storage/controller/mock"
+	"github.com/nhost/hasura-storage/middleware/auth"
 	"github.com/sirupsen/logrus"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -165,6 +166,7 @@ func TestUploadFile(t *testing.T) {
 					int64(len(file.contents)),
 					int64(1),
 					"",
+					"",
 					gomock.Any(),
 				).Return(nil)
 
@@ -190,8 +192,11 @@ func TestUploadFile(t *testing.T) {
 					int64(len(file.contents)),
 					int64(1),
 					"",
+					"",
+					"",
 					file.md.Metadata,
 					gomock.Any(),
+					gomock.Any(),
 				).Return(
 					controller.FileMetadata{
 						ID:               file.md.ID,
@@ -227,6 +232,7 @@ func TestUploadFile(t *testing.T) {
 					int64(len(file.contents)),
 					int64(1),
 					"",
+					"",
 					gomock.Any(),
 				).Return(nil)
 
@@ -252,8 +258,11 @@ func TestUploadFile(t *testing.T) {
 					int64(len(file.contents)),
 					int64(1),
 					"",
+					"",
+					"",
 					file.md.Metadata,
 					gomock.Any(),
+					gomock.Any(),
 				).Return(
 					controller.FileMetadata{
 						ID:               file.md.ID,
@@ -281,11 +290,14 @@ func TestUploadFile(t *testing.T) {
 			ctrl := controller.New(
 				"http://asd",
 				"/v1",
-				"asdasd",
+				auth.NewAdminSecrets("asdasd"),
 				metadataStorage,
 				contentStorage,
 				nil,
 				av,
+				nil,
+				nil,
+				nil,
 				logger,
 			)
 
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+const (
+	UploadOperationUpload    = "upload"
+	UploadOperationMultipart = "multipart"
+	UploadOperationUpdate    = "update"
+	UploadOperationCopy      = "copy"
+	UploadOperationMove      = "move"
+)
+
+// UploadHookRequest describes an upload that is about to be initialized.
+type UploadHookRequest struct {
+	Operation    string         `json:"operation"`
+	FileID       string         `json:"fileId"`
+	BucketID     string         `json:"bucketId"`
+	Name         string         `json:"name"`
+	Size         int64          `json:"size"`
+	MimeType     string         `json:"mimeType"`
+	ObjectPrefix string         `json:"objectPrefix"`
+	Metadata     map[string]any `json:"metadata"`
+	UserSession  map[string]any `json:"userSession"`
+}
+
+// UploadHookResponse is the decision of the pre-upload hook. A nil ObjectPrefix keeps
+// the one requested by the user and Metadata is merged on top of the user's metadata.
+type UploadHookResponse struct {
+	Allow        bool           `json:"allow"`
+	Message      string         `json:"message"`
+	ObjectPrefix *string        `json:"objectPrefix"`
+	Metadata     map[string]any `json:"metadata"`
+}
+
+// checkUpload asks the pre-upload hook, if any, whether the upload can proceed and
+// returns the request with the object prefix and metadata that should be used.
+// Updates keep their object key so the object prefix can't be rewritten for them.
+func (ctrl *Controller) checkUpload(
+	ctx context.Context,
+	req UploadHookRequest,
+	headers http.Header,
+) (UploadHookRequest, *APIError) {
+	if ctrl.uploadHook == nil {
+		return req, nil
+	}
+
+	req.UserSession = GetUserSession(headers)
+
+	resp, apiErr := ctrl.uploadHook.CheckUpload(ctx, req)
+	if apiErr != nil {
+		return UploadHookRequest{}, apiErr.ExtendError("problem calling pre-upload hook")
+	}
+
+	if !resp.Allow {
+		msg := resp.Message
+		if msg == "" {
+			msg = "upload denied"
+		}
+		return UploadHookRequest{}, ForbiddenError(errors.New(msg), msg) //nolint: goerr113
+	}
+
+	if resp.ObjectPrefix != nil && req.Operation != UploadOperationUpdate {
+		req.ObjectPrefix = *resp.ObjectPrefix
+	}
+
+	if len(resp.Metadata) > 0 {
+		metadata := make(map[string]any, len(req.Metadata)+len(resp.Metadata))
+		for k, v := range req.Metadata {
+			metadata[k] = v
+		}
+		for k, v := range resp.Metadata {
+			metadata[k] = v
+		}
+		req.Metadata = metadata
+	}
+
+	return req, nil
+}
@@ -1,10 +1,12 @@
 package controller
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/nhost/hasura-storage/middleware/auth"
 )
 
 func GetUserSession(headers http.Header) map[string]any {
@@ -38,3 +40,48 @@ func GetUserSession(headers http.Header) map[string]any {
 
 	return session
 }
+
+// sessionVariables returns the user id and role of the caller. Session headers are only
+// trusted for requests authenticated with the admin secret, otherwise they come from the
+// access token verified by the VerifyJWT middleware. Any other caller is anonymous.
+func (ctrl *Controller) sessionVariables(ctx context.Context, headers http.Header) (string, string) {
+	adminSecret := ctrl.hasuraAdminSecret.Primary()
+	if adminSecret != "" && headers.Get("X-Hasura-Admin-Secret") == adminSecret {
+		return headers.Get("X-Hasura-User-Id"), headers.Get("X-Hasura-Role")
+	}
+
+	session, ok := auth.VerifiedSession(ctx)
+	if !ok {
+		return "", ""
+	}
+
+	return session.Get("X-Hasura-User-Id"), session.Get("X-Hasura-Role")
+}
+
+// sessionAccess returns the user the request acts on behalf of and the headers to
+// query hasura with. Admins and callers with a verified session are trusted so the
+// admin secret is used, any other caller uses its own headers so hasura permissions
+// decide what it can do.
+func (ctrl *Controller) sessionAccess(
+	ctx context.Context, headers http.Header,
+) (string, http.Header, bool) {
+	adminHeaders := http.Header{"x-hasura-admin-secret": []string{ctrl.hasuraAdminSecret.Primary()}}
+
+	if ctrl.isAdmin(headers) {
+		return "", adminHeaders, true
+	}
+
+	if userID, _ := ctrl.sessionVariables(ctx, headers); userID != "" {
+		return userID, adminHeaders, true
+	}
+
+	return "", headers, false
+}
+
+// isAdmin returns true if the request uses the admin secret without impersonating
+// another role.
+func (ctrl *Controller) isAdmin(headers http.Header) bool {
+	role := headers.Get("X-Hasura-Role")
+	return headers.Get("X-Hasura-Admin-Secret") == ctrl.hasuraAdminSecret.Primary() &&
+		(role == "" || role == "admin")
+}
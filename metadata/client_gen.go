@@ -18,38 +18,99 @@ func NewClient(cli *http.Client, baseURL string, options *clientv2.Options, inte
 }
 
 type QueryRoot struct {
-	Bucket           *Buckets         "json:\"bucket,omitempty\" graphql:\"bucket\""
-	Buckets          []*Buckets       "json:\"buckets\" graphql:\"buckets\""
-	BucketsAggregate BucketsAggregate "json:\"bucketsAggregate\" graphql:\"bucketsAggregate\""
-	File             *Files           "json:\"file,omitempty\" graphql:\"file\""
-	Files            []*Files         "json:\"files\" graphql:\"files\""
-	FilesAggregate   FilesAggregate   "json:\"filesAggregate\" graphql:\"filesAggregate\""
-	Virus            *Virus           "json:\"virus,omitempty\" graphql:\"virus\""
-	Viruses          []*Virus         "json:\"viruses\" graphql:\"viruses\""
-	VirusesAggregate VirusAggregate   "json:\"virusesAggregate\" graphql:\"virusesAggregate\""
+	APIKey              *APIKeys               "json:\"apiKey,omitempty\" graphql:\"apiKey\""
+	APIKeys             []*APIKeys             "json:\"apiKeys\" graphql:\"apiKeys\""
+	Bucket              *Buckets               "json:\"bucket,omitempty\" graphql:\"bucket\""
+	Buckets             []*Buckets             "json:\"buckets\" graphql:\"buckets\""
+	BucketsAggregate    BucketsAggregate       "json:\"bucketsAggregate\" graphql:\"bucketsAggregate\""
+	ExpiredFileVersions []*ExpiredFileVersions "json:\"expiredFileVersions\" graphql:\"expiredFileVersions\""
+	ExpiredFiles        []*ExpiredFiles        "json:\"expiredFiles\" graphql:\"expiredFiles\""
+	ExpiredTrash        []*ExpiredTrash        "json:\"expiredTrash\" graphql:\"expiredTrash\""
+	File                *Files                 "json:\"file,omitempty\" graphql:\"file\""
+	FileAuditEvent      *FileAuditEvents       "json:\"fileAuditEvent,omitempty\" graphql:\"fileAuditEvent\""
+	FileAuditEvents     []*FileAuditEvents     "json:\"fileAuditEvents\" graphql:\"fileAuditEvents\""
+	FileVersion         *FileVersions          "json:\"fileVersion,omitempty\" graphql:\"fileVersion\""
+	FileVersions        []*FileVersions        "json:\"fileVersions\" graphql:\"fileVersions\""
+	Files               []*Files               "json:\"files\" graphql:\"files\""
+	FilesAggregate      FilesAggregate         "json:\"filesAggregate\" graphql:\"filesAggregate\""
+	Quota               *Quotas                "json:\"quota,omitempty\" graphql:\"quota\""
+	Quotas              []*Quotas              "json:\"quotas\" graphql:\"quotas\""
+	Share               *Shares                "json:\"share,omitempty\" graphql:\"share\""
+	Shares              []*Shares              "json:\"shares\" graphql:\"shares\""
+	Usage               *Usages                "json:\"usage,omitempty\" graphql:\"usage\""
+	Usages              []*Usages              "json:\"usages\" graphql:\"usages\""
+	Virus               *Virus                 "json:\"virus,omitempty\" graphql:\"virus\""
+	Viruses             []*Virus               "json:\"viruses\" graphql:\"viruses\""
+	VirusesAggregate    VirusAggregate         "json:\"virusesAggregate\" graphql:\"virusesAggregate\""
+	WebhookEvent        *WebhookEvents         "json:\"webhookEvent,omitempty\" graphql:\"webhookEvent\""
+	WebhookEvents       []*WebhookEvents       "json:\"webhookEvents\" graphql:\"webhookEvents\""
+	WebhookSecret       *WebhookSecrets        "json:\"webhookSecret,omitempty\" graphql:\"webhookSecret\""
+	WebhookSecrets      []*WebhookSecrets      "json:\"webhookSecrets\" graphql:\"webhookSecrets\""
 }
 type MutationRoot struct {
-	DeleteBucket      *Buckets                   "json:\"deleteBucket,omitempty\" graphql:\"deleteBucket\""
-	DeleteBuckets     *BucketsMutationResponse   "json:\"deleteBuckets,omitempty\" graphql:\"deleteBuckets\""
-	DeleteFile        *Files                     "json:\"deleteFile,omitempty\" graphql:\"deleteFile\""
-	DeleteFiles       *FilesMutationResponse     "json:\"deleteFiles,omitempty\" graphql:\"deleteFiles\""
-	DeleteVirus       *Virus                     "json:\"deleteVirus,omitempty\" graphql:\"deleteVirus\""
-	DeleteViruses     *VirusMutationResponse     "json:\"deleteViruses,omitempty\" graphql:\"deleteViruses\""
-	InsertBucket      *Buckets                   "json:\"insertBucket,omitempty\" graphql:\"insertBucket\""
-	InsertBuckets     *BucketsMutationResponse   "json:\"insertBuckets,omitempty\" graphql:\"insertBuckets\""
-	InsertFile        *Files                     "json:\"insertFile,omitempty\" graphql:\"insertFile\""
-	InsertFiles       *FilesMutationResponse     "json:\"insertFiles,omitempty\" graphql:\"insertFiles\""
-	InsertVirus       *Virus                     "json:\"insertVirus,omitempty\" graphql:\"insertVirus\""
-	InsertViruses     *VirusMutationResponse     "json:\"insertViruses,omitempty\" graphql:\"insertViruses\""
-	UpdateBucket      *Buckets                   "json:\"updateBucket,omitempty\" graphql:\"updateBucket\""
-	UpdateBuckets     *BucketsMutationResponse   "json:\"updateBuckets,omitempty\" graphql:\"updateBuckets\""
-	UpdateFile        *Files                     "json:\"updateFile,omitempty\" graphql:\"updateFile\""
-	UpdateFiles       *FilesMutationResponse     "json:\"updateFiles,omitempty\" graphql:\"updateFiles\""
-	UpdateVirus       *Virus                     "json:\"updateVirus,omitempty\" graphql:\"updateVirus\""
-	UpdateViruses     *VirusMutationResponse     "json:\"updateViruses,omitempty\" graphql:\"updateViruses\""
-	UpdateBucketsMany []*BucketsMutationResponse "json:\"update_buckets_many,omitempty\" graphql:\"update_buckets_many\""
-	UpdateFilesMany   []*FilesMutationResponse   "json:\"update_files_many,omitempty\" graphql:\"update_files_many\""
-	UpdateVirusMany   []*VirusMutationResponse   "json:\"update_virus_many,omitempty\" graphql:\"update_virus_many\""
+	DeleteAPIKey          *APIKeys                         "json:\"deleteApiKey,omitempty\" graphql:\"deleteApiKey\""
+	DeleteAPIKeys         *APIKeysMutationResponse         "json:\"deleteApiKeys,omitempty\" graphql:\"deleteApiKeys\""
+	DeleteBucket          *Buckets                         "json:\"deleteBucket,omitempty\" graphql:\"deleteBucket\""
+	DeleteBuckets         *BucketsMutationResponse         "json:\"deleteBuckets,omitempty\" graphql:\"deleteBuckets\""
+	DeleteFile            *Files                           "json:\"deleteFile,omitempty\" graphql:\"deleteFile\""
+	DeleteFileAuditEvent  *FileAuditEvents                 "json:\"deleteFileAuditEvent,omitempty\" graphql:\"deleteFileAuditEvent\""
+	DeleteFileAuditEvents *FileAuditEventsMutationResponse "json:\"deleteFileAuditEvents,omitempty\" graphql:\"deleteFileAuditEvents\""
+	DeleteFileVersion     *FileVersions                    "json:\"deleteFileVersion,omitempty\" graphql:\"deleteFileVersion\""
+	DeleteFileVersions    *FileVersionsMutationResponse    "json:\"deleteFileVersions,omitempty\" graphql:\"deleteFileVersions\""
+	DeleteFiles           *FilesMutationResponse           "json:\"deleteFiles,omitempty\" graphql:\"deleteFiles\""
+	DeleteQuota           *Quotas                          "json:\"deleteQuota,omitempty\" graphql:\"deleteQuota\""
+	DeleteQuotas          *QuotasMutationResponse          "json:\"deleteQuotas,omitempty\" graphql:\"deleteQuotas\""
+	DeleteShare           *Shares                          "json:\"deleteShare,omitempty\" graphql:\"deleteShare\""
+	DeleteShares          *SharesMutationResponse          "json:\"deleteShares,omitempty\" graphql:\"deleteShares\""
+	DeleteUsage           *Usages                          "json:\"deleteUsage,omitempty\" graphql:\"deleteUsage\""
+	DeleteUsages          *UsagesMutationResponse          "json:\"deleteUsages,omitempty\" graphql:\"deleteUsages\""
+	DeleteVirus           *Virus                           "json:\"deleteVirus,omitempty\" graphql:\"deleteVirus\""
+	DeleteViruses         *VirusMutationResponse           "json:\"deleteViruses,omitempty\" graphql:\"deleteViruses\""
+	DeleteWebhookEvent    *WebhookEvents                   "json:\"deleteWebhookEvent,omitempty\" graphql:\"deleteWebhookEvent\""
+	DeleteWebhookEvents   *WebhookEventsMutationResponse   "json:\"deleteWebhookEvents,omitempty\" graphql:\"deleteWebhookEvents\""
+	InsertAPIKey          *APIKeys                         "json:\"insertApiKey,omitempty\" graphql:\"insertApiKey\""
+	InsertAPIKeys         *APIKeysMutationResponse         "json:\"insertApiKeys,omitempty\" graphql:\"insertApiKeys\""
+	InsertBucket          *Buckets                         "json:\"insertBucket,omitempty\" graphql:\"insertBucket\""
+	InsertBuckets         *BucketsMutationResponse         "json:\"insertBuckets,omitempty\" graphql:\"insertBuckets\""
+	InsertFile            *Files                           "json:\"insertFile,omitempty\" graphql:\"insertFile\""
+	InsertFileAuditEvent  *FileAuditEvents                 "json:\"insertFileAuditEvent,omitempty\" graphql:\"insertFileAuditEvent\""
+	InsertFileAuditEvents *FileAuditEventsMutationResponse "json:\"insertFileAuditEvents,omitempty\" graphql:\"insertFileAuditEvents\""
+	InsertFileVersion     *FileVersions                    "json:\"insertFileVersion,omitempty\" graphql:\"insertFileVersion\""
+	InsertFileVersions    *FileVersionsMutationResponse    "json:\"insertFileVersions,omitempty\" graphql:\"insertFileVersions\""
+	InsertFiles           *FilesMutationResponse           "json:\"insertFiles,omitempty\" graphql:\"insertFiles\""
+	InsertQuota           *Quotas                          "json:\"insertQuota,omitempty\" graphql:\"insertQuota\""
+	InsertQuotas          *QuotasMutationResponse          "json:\"insertQuotas,omitempty\" graphql:\"insertQuotas\""
+	InsertShare           *Shares                          "json:\"insertShare,omitempty\" graphql:\"insertShare\""
+	InsertShares          *SharesMutationResponse          "json:\"insertShares,omitempty\" graphql:\"insertShares\""
+	InsertUsage           *Usages                          "json:\"insertUsage,omitempty\" graphql:\"insertUsage\""
+	InsertUsages          *UsagesMutationResponse          "json:\"insertUsages,omitempty\" graphql:\"insertUsages\""
+	InsertVirus           *Virus                           "json:\"insertVirus,omitempty\" graphql:\"insertVirus\""
+	InsertViruses         *VirusMutationResponse           "json:\"insertViruses,omitempty\" graphql:\"insertViruses\""
+	InsertWebhookEvent    *WebhookEvents                   "json:\"insertWebhookEvent,omitempty\" graphql:\"insertWebhookEvent\""
+	InsertWebhookEvents   *WebhookEventsMutationResponse   "json:\"insertWebhookEvents,omitempty\" graphql:\"insertWebhookEvents\""
+	UpdateAPIKey          *APIKeys                         "json:\"updateApiKey,omitempty\" graphql:\"updateApiKey\""
+	UpdateAPIKeys         *APIKeysMutationResponse         "json:\"updateApiKeys,omitempty\" graphql:\"updateApiKeys\""
+	UpdateBucket          *Buckets                         "json:\"updateBucket,omitempty\" graphql:\"updateBucket\""
+	UpdateBuckets         *BucketsMutationResponse         "json:\"updateBuckets,omitempty\" graphql:\"updateBuckets\""
+	UpdateFile            *Files                           "json:\"updateFile,omitempty\" graphql:\"updateFile\""
+	UpdateFileAuditEvent  *FileAuditEvents                 "json:\"updateFileAuditEvent,omitempty\" graphql:\"updateFileAuditEvent\""
+	UpdateFileAuditEvents *FileAuditEventsMutationResponse "json:\"updateFileAuditEvents,omitempty\" graphql:\"updateFileAuditEvents\""
+	UpdateFileVersion     *FileVersions                    "json:\"updateFileVersion,omitempty\" graphql:\"updateFileVersion\""
+	UpdateFileVersions    *FileVersionsMutationResponse    "json:\"updateFileVersions,omitempty\" graphql:\"updateFileVersions\""
+	UpdateFiles           *FilesMutationResponse           "json:\"updateFiles,omitempty\" graphql:\"updateFiles\""
+	UpdateQuota           *Quotas                          "json:\"updateQuota,omitempty\" graphql:\"updateQuota\""
+	UpdateQuotas          *QuotasMutationResponse          "json:\"updateQuotas,omitempty\" graphql:\"updateQuotas\""
+	UpdateShare           *Shares                          "json:\"updateShare,omitempty\" graphql:\"updateShare\""
+	UpdateShares          *SharesMutationResponse          "json:\"updateShares,omitempty\" graphql:\"updateShares\""
+	UpdateUsage           *Usages                          "json:\"updateUsage,omitempty\" graphql:\"updateUsage\""
+	UpdateUsages          *UsagesMutationResponse          "json:\"updateUsages,omitempty\" graphql:\"updateUsages\""
+	UpdateVirus           *Virus                           "json:\"updateVirus,omitempty\" graphql:\"updateVirus\""
+	UpdateViruses         *VirusMutationResponse           "json:\"updateViruses,omitempty\" graphql:\"updateViruses\""
+	UpdateWebhookEvent    *WebhookEvents                   "json:\"updateWebhookEvent,omitempty\" graphql:\"updateWebhookEvent\""
+	UpdateWebhookEvents   *WebhookEventsMutationResponse   "json:\"updateWebhookEvents,omitempty\" graphql:\"updateWebhookEvents\""
+	UpdateBucketsMany     []*BucketsMutationResponse       "json:\"update_buckets_many,omitempty\" graphql:\"update_buckets_many\""
+	UpdateFilesMany       []*FilesMutationResponse         "json:\"update_files_many,omitempty\" graphql:\"update_files_many\""
+	UpdateVirusMany       []*VirusMutationResponse         "json:\"update_virus_many,omitempty\" graphql:\"update_virus_many\""
 }
 type FileMetadataFragment struct {
 	ID               string                 "json:\"id\" graphql:\"id\""
@@ -67,6 +128,11 @@ type FileMetadataFragment struct {
 	ChunkSize        *int64                 "json:\"chunkSize,omitempty\" graphql:\"chunkSize\""
 	ChunkCount       *int64                 "json:\"chunkCount,omitempty\" graphql:\"chunkCount\""
 	UploadID         *string                "json:\"uploadId,omitempty\" graphql:\"uploadId\""
+	DeletedAt        *string                "json:\"deletedAt,omitempty\" graphql:\"deletedAt\""
+	DeletedByUserID  *string                "json:\"deletedByUserId,omitempty\" graphql:\"deletedByUserId\""
+	RetainUntil      *string                "json:\"retainUntil,omitempty\" graphql:\"retainUntil\""
+	LegalHold        bool                   "json:\"legalHold\" graphql:\"legalHold\""
+	ExpiresAt        *string                "json:\"expiresAt,omitempty\" graphql:\"expiresAt\""
 }
 
 func (t *FileMetadataFragment) GetID() string {
@@ -159,12 +225,43 @@ func (t *FileMetadataFragment) GetUploadID() *string {
 	}
 	return t.UploadID
 }
+func (t *FileMetadataFragment) GetDeletedAt() *string {
+	if t == nil {
+		t = &FileMetadataFragment{}
+	}
+	return t.DeletedAt
+}
+func (t *FileMetadataFragment) GetDeletedByUserID() *string {
+	if t == nil {
+		t = &FileMetadataFragment{}
+	}
+	return t.DeletedByUserID
+}
+func (t *FileMetadataFragment) GetRetainUntil() *string {
+	if t == nil {
+		t = &FileMetadataFragment{}
+	}
+	return t.RetainUntil
+}
+func (t *FileMetadataFragment) GetLegalHold() bool {
+	if t == nil {
+		t = &FileMetadataFragment{}
+	}
+	return t.LegalHold
+}
+func (t *FileMetadataFragment) GetExpiresAt() *string {
+	if t == nil {
+		t = &FileMetadataFragment{}
+	}
+	return t.ExpiresAt
+}
 
 type FileMetadataSummaryFragment struct {
-	ID         string  "json:\"id\" graphql:\"id\""
-	Name       *string "json:\"name,omitempty\" graphql:\"name\""
-	BucketID   string  "json:\"bucketId\" graphql:\"bucketId\""
-	IsUploaded *bool   "json:\"isUploaded,omitempty\" graphql:\"isUploaded\""
+	ID               string  "json:\"id\" graphql:\"id\""
+	Name             *string "json:\"name,omitempty\" graphql:\"name\""
+	BucketID         string  "json:\"bucketId\" graphql:\"bucketId\""
+	IsUploaded       *bool   "json:\"isUploaded,omitempty\" graphql:\"isUploaded\""
+	UploadedByUserID *string "json:\"uploadedByUserId,omitempty\" graphql:\"uploadedByUserId\""
 }
 
 func (t *FileMetadataSummaryFragment) GetID() string {
@@ -191,6 +288,12 @@ func (t *FileMetadataSummaryFragment) GetIsUploaded() *bool {
 	}
 	return t.IsUploaded
 }
+func (t *FileMetadataSummaryFragment) GetUploadedByUserID() *string {
+	if t == nil {
+		t = &FileMetadataSummaryFragment{}
+	}
+	return t.UploadedByUserID
+}
 
 type BucketMetadataFragment struct {
 	ID                   string  "json:\"id\" graphql:\"id\""
@@ -202,6 +305,19 @@ type BucketMetadataFragment struct {
 	UpdatedAt            string  "json:\"updatedAt\" graphql:\"updatedAt\""
 	CacheControl         *string "json:\"cacheControl,omitempty\" graphql:\"cacheControl\""
 	UploadExpiration     int64   "json:\"uploadExpiration\" graphql:\"uploadExpiration\""
+	WebhookURL           *string "json:\"webhookUrl,omitempty\" graphql:\"webhookUrl\""
+	AllowedMimeTypes     *string "json:\"allowedMimeTypes,omitempty\" graphql:\"allowedMimeTypes\""
+	DeniedMimeTypes      *string "json:\"deniedMimeTypes,omitempty\" graphql:\"deniedMimeTypes\""
+	AllowedExtensions    *string "json:\"allowedExtensions,omitempty\" graphql:\"allowedExtensions\""
+	DeniedExtensions     *string "json:\"deniedExtensions,omitempty\" graphql:\"deniedExtensions\""
+	RedirectDownloads    bool    "json:\"redirectDownloads\" graphql:\"redirectDownloads\""
+	VersioningEnabled    bool    "json:\"versioningEnabled\" graphql:\"versioningEnabled\""
+	MaxVersions          int64   "json:\"maxVersions\" graphql:\"maxVersions\""
+	VersionRetentionDays int64   "json:\"versionRetentionDays\" graphql:\"versionRetentionDays\""
+	SoftDeleteEnabled    bool    "json:\"softDeleteEnabled\" graphql:\"softDeleteEnabled\""
+	TrashRetentionDays   int64   "json:\"trashRetentionDays\" graphql:\"trashRetentionDays\""
+	RetentionDays        int64   "json:\"retentionDays\" graphql:\"retentionDays\""
+	RetentionMode        string  "json:\"retentionMode\" graphql:\"retentionMode\""
 }
 
 func (t *BucketMetadataFragment) GetID() string {
@@ -258,153 +374,2351 @@ func (t *BucketMetadataFragment) GetUploadExpiration() int64 {
 	}
 	return t.UploadExpiration
 }
+func (t *BucketMetadataFragment) GetWebhookURL() *string {
+	if t == nil {
+		t = &BucketMetadataFragment{}
+	}
+	return t.WebhookURL
+}
+func (t *BucketMetadataFragment) GetAllowedMimeTypes() *string {
+	if t == nil {
+		t = &BucketMetadataFragment{}
+	}
+	return t.AllowedMimeTypes
+}
+func (t *BucketMetadataFragment) GetDeniedMimeTypes() *string {
+	if t == nil {
+		t = &BucketMetadataFragment{}
+	}
+	return t.DeniedMimeTypes
+}
+func (t *BucketMetadataFragment) GetAllowedExtensions() *string {
+	if t == nil {
+		t = &BucketMetadataFragment{}
+	}
+	return t.AllowedExtensions
+}
+func (t *BucketMetadataFragment) GetDeniedExtensions() *string {
+	if t == nil {
+		t = &BucketMetadataFragment{}
+	}
+	return t.DeniedExtensions
+}
+func (t *BucketMetadataFragment) GetRedirectDownloads() bool {
+	if t == nil {
+		t = &BucketMetadataFragment{}
+	}
+	return t.RedirectDownloads
+}
+func (t *BucketMetadataFragment) GetVersioningEnabled() bool {
+	if t == nil {
+		t = &BucketMetadataFragment{}
+	}
+	return t.VersioningEnabled
+}
+func (t *BucketMetadataFragment) GetMaxVersions() int64 {
+	if t == nil {
+		t = &BucketMetadataFragment{}
+	}
+	return t.MaxVersions
+}
+func (t *BucketMetadataFragment) GetVersionRetentionDays() int64 {
+	if t == nil {
+		t = &BucketMetadataFragment{}
+	}
+	return t.VersionRetentionDays
+}
+func (t *BucketMetadataFragment) GetSoftDeleteEnabled() bool {
+	if t == nil {
+		t = &BucketMetadataFragment{}
+	}
+	return t.SoftDeleteEnabled
+}
+func (t *BucketMetadataFragment) GetTrashRetentionDays() int64 {
+	if t == nil {
+		t = &BucketMetadataFragment{}
+	}
+	return t.TrashRetentionDays
+}
+func (t *BucketMetadataFragment) GetRetentionDays() int64 {
+	if t == nil {
+		t = &BucketMetadataFragment{}
+	}
+	return t.RetentionDays
+}
+func (t *BucketMetadataFragment) GetRetentionMode() string {
+	if t == nil {
+		t = &BucketMetadataFragment{}
+	}
+	return t.RetentionMode
+}
 
-type InsertFile_InsertFile struct {
-	ID string "json:\"id\" graphql:\"id\""
+type VirusMetadataFragment struct {
+	ID            string                     "json:\"id\" graphql:\"id\""
+	CreatedAt     string                     "json:\"createdAt\" graphql:\"createdAt\""
+	UpdatedAt     string                     "json:\"updatedAt\" graphql:\"updatedAt\""
+	FileID        string                     "json:\"fileId\" graphql:\"fileId\""
+	Filename      string                     "json:\"filename\" graphql:\"filename\""
+	Virus         string                     "json:\"virus\" graphql:\"virus\""
+	FalsePositive bool                       "json:\"falsePositive\" graphql:\"falsePositive\""
+	UserSession   map[string]interface{}     "json:\"userSession\" graphql:\"userSession\""
+	Quarantine    map[string]interface{}     "json:\"quarantine,omitempty\" graphql:\"quarantine\""
+	File          VirusMetadataFragment_File "json:\"file\" graphql:\"file\""
 }
 
-func (t *InsertFile_InsertFile) GetID() string {
+func (t *VirusMetadataFragment) GetID() string {
 	if t == nil {
-		t = &InsertFile_InsertFile{}
+		t = &VirusMetadataFragment{}
 	}
 	return t.ID
 }
+func (t *VirusMetadataFragment) GetCreatedAt() string {
+	if t == nil {
+		t = &VirusMetadataFragment{}
+	}
+	return t.CreatedAt
+}
+func (t *VirusMetadataFragment) GetUpdatedAt() string {
+	if t == nil {
+		t = &VirusMetadataFragment{}
+	}
+	return t.UpdatedAt
+}
+func (t *VirusMetadataFragment) GetFileID() string {
+	if t == nil {
+		t = &VirusMetadataFragment{}
+	}
+	return t.FileID
+}
+func (t *VirusMetadataFragment) GetFilename() string {
+	if t == nil {
+		t = &VirusMetadataFragment{}
+	}
+	return t.Filename
+}
+func (t *VirusMetadataFragment) GetVirus() string {
+	if t == nil {
+		t = &VirusMetadataFragment{}
+	}
+	return t.Virus
+}
+func (t *VirusMetadataFragment) GetFalsePositive() bool {
+	if t == nil {
+		t = &VirusMetadataFragment{}
+	}
+	return t.FalsePositive
+}
+func (t *VirusMetadataFragment) GetUserSession() map[string]interface{} {
+	if t == nil {
+		t = &VirusMetadataFragment{}
+	}
+	return t.UserSession
+}
+func (t *VirusMetadataFragment) GetQuarantine() map[string]interface{} {
+	if t == nil {
+		t = &VirusMetadataFragment{}
+	}
+	return t.Quarantine
+}
+func (t *VirusMetadataFragment) GetFile() *VirusMetadataFragment_File {
+	if t == nil {
+		t = &VirusMetadataFragment{}
+	}
+	return &t.File
+}
 
-type DeleteFile_DeleteFile struct {
-	ID string "json:\"id\" graphql:\"id\""
+type VirusMetadataFragment_File struct {
+	BucketID string "json:\"bucketId\" graphql:\"bucketId\""
 }
 
-func (t *DeleteFile_DeleteFile) GetID() string {
+func (t *VirusMetadataFragment_File) GetBucketID() string {
 	if t == nil {
-		t = &DeleteFile_DeleteFile{}
+		t = &VirusMetadataFragment_File{}
+	}
+	return t.BucketID
+}
+
+type WebhookEventFragment struct {
+	ID            string                 "json:\"id\" graphql:\"id\""
+	CreatedAt     string                 "json:\"createdAt\" graphql:\"createdAt\""
+	EventType     string                 "json:\"eventType\" graphql:\"eventType\""
+	BucketID      *string                "json:\"bucketId,omitempty\" graphql:\"bucketId\""
+	FileID        *string                "json:\"fileId,omitempty\" graphql:\"fileId\""
+	Payload       map[string]interface{} "json:\"payload\" graphql:\"payload\""
+	Attempts      int64                  "json:\"attempts\" graphql:\"attempts\""
+	NextAttemptAt string                 "json:\"nextAttemptAt\" graphql:\"nextAttemptAt\""
+}
+
+func (t *WebhookEventFragment) GetID() string {
+	if t == nil {
+		t = &WebhookEventFragment{}
+	}
+	return t.ID
+}
+func (t *WebhookEventFragment) GetCreatedAt() string {
+	if t == nil {
+		t = &WebhookEventFragment{}
+	}
+	return t.CreatedAt
+}
+func (t *WebhookEventFragment) GetEventType() string {
+	if t == nil {
+		t = &WebhookEventFragment{}
+	}
+	return t.EventType
+}
+func (t *WebhookEventFragment) GetBucketID() *string {
+	if t == nil {
+		t = &WebhookEventFragment{}
+	}
+	return t.BucketID
+}
+func (t *WebhookEventFragment) GetFileID() *string {
+	if t == nil {
+		t = &WebhookEventFragment{}
+	}
+	return t.FileID
+}
+func (t *WebhookEventFragment) GetPayload() map[string]interface{} {
+	if t == nil {
+		t = &WebhookEventFragment{}
+	}
+	return t.Payload
+}
+func (t *WebhookEventFragment) GetAttempts() int64 {
+	if t == nil {
+		t = &WebhookEventFragment{}
+	}
+	return t.Attempts
+}
+func (t *WebhookEventFragment) GetNextAttemptAt() string {
+	if t == nil {
+		t = &WebhookEventFragment{}
+	}
+	return t.NextAttemptAt
+}
+
+type QuotaFragment struct {
+	ID       string  "json:\"id\" graphql:\"id\""
+	UserID   *string "json:\"userId,omitempty\" graphql:\"userId\""
+	Role     *string "json:\"role,omitempty\" graphql:\"role\""
+	BucketID *string "json:\"bucketId,omitempty\" graphql:\"bucketId\""
+	MaxBytes *int64  "json:\"maxBytes,omitempty\" graphql:\"maxBytes\""
+	MaxFiles *int64  "json:\"maxFiles,omitempty\" graphql:\"maxFiles\""
+}
+
+func (t *QuotaFragment) GetID() string {
+	if t == nil {
+		t = &QuotaFragment{}
 	}
 	return t.ID
 }
+func (t *QuotaFragment) GetUserID() *string {
+	if t == nil {
+		t = &QuotaFragment{}
+	}
+	return t.UserID
+}
+func (t *QuotaFragment) GetRole() *string {
+	if t == nil {
+		t = &QuotaFragment{}
+	}
+	return t.Role
+}
+func (t *QuotaFragment) GetBucketID() *string {
+	if t == nil {
+		t = &QuotaFragment{}
+	}
+	return t.BucketID
+}
+func (t *QuotaFragment) GetMaxBytes() *int64 {
+	if t == nil {
+		t = &QuotaFragment{}
+	}
+	return t.MaxBytes
+}
+func (t *QuotaFragment) GetMaxFiles() *int64 {
+	if t == nil {
+		t = &QuotaFragment{}
+	}
+	return t.MaxFiles
+}
 
-type InsertVirus_InsertVirus struct {
-	ID string "json:\"id\" graphql:\"id\""
+type UsageFragment struct {
+	BucketID string "json:\"bucketId\" graphql:\"bucketId\""
+	Bytes    int64  "json:\"bytes\" graphql:\"bytes\""
+	Files    int64  "json:\"files\" graphql:\"files\""
 }
 
-func (t *InsertVirus_InsertVirus) GetID() string {
+func (t *UsageFragment) GetBucketID() string {
 	if t == nil {
-		t = &InsertVirus_InsertVirus{}
+		t = &UsageFragment{}
+	}
+	return t.BucketID
+}
+func (t *UsageFragment) GetBytes() int64 {
+	if t == nil {
+		t = &UsageFragment{}
+	}
+	return t.Bytes
+}
+func (t *UsageFragment) GetFiles() int64 {
+	if t == nil {
+		t = &UsageFragment{}
+	}
+	return t.Files
+}
+
+type APIKeyFragment struct {
+	ID         string  "json:\"id\" graphql:\"id\""
+	Name       string  "json:\"name\" graphql:\"name\""
+	Role       string  "json:\"role\" graphql:\"role\""
+	Buckets    *string "json:\"buckets,omitempty\" graphql:\"buckets\""
+	Operations string  "json:\"operations\" graphql:\"operations\""
+	ExpiresAt  *string "json:\"expiresAt,omitempty\" graphql:\"expiresAt\""
+	LastUsedAt *string "json:\"lastUsedAt,omitempty\" graphql:\"lastUsedAt\""
+}
+
+func (t *APIKeyFragment) GetID() string {
+	if t == nil {
+		t = &APIKeyFragment{}
+	}
+	return t.ID
+}
+func (t *APIKeyFragment) GetName() string {
+	if t == nil {
+		t = &APIKeyFragment{}
+	}
+	return t.Name
+}
+func (t *APIKeyFragment) GetRole() string {
+	if t == nil {
+		t = &APIKeyFragment{}
+	}
+	return t.Role
+}
+func (t *APIKeyFragment) GetBuckets() *string {
+	if t == nil {
+		t = &APIKeyFragment{}
+	}
+	return t.Buckets
+}
+func (t *APIKeyFragment) GetOperations() string {
+	if t == nil {
+		t = &APIKeyFragment{}
+	}
+	return t.Operations
+}
+func (t *APIKeyFragment) GetExpiresAt() *string {
+	if t == nil {
+		t = &APIKeyFragment{}
+	}
+	return t.ExpiresAt
+}
+func (t *APIKeyFragment) GetLastUsedAt() *string {
+	if t == nil {
+		t = &APIKeyFragment{}
+	}
+	return t.LastUsedAt
+}
+
+type ShareFragment struct {
+	ID                     string  "json:\"id\" graphql:\"id\""
+	FileID                 string  "json:\"fileId\" graphql:\"fileId\""
+	CreatedByUserID        *string "json:\"createdByUserId,omitempty\" graphql:\"createdByUserId\""
+	ExpiresAt              *string "json:\"expiresAt,omitempty\" graphql:\"expiresAt\""
+	PasswordHash           *string "json:\"passwordHash,omitempty\" graphql:\"passwordHash\""
+	MaxDownloads           *int64  "json:\"maxDownloads,omitempty\" graphql:\"maxDownloads\""
+	DownloadCount          int64   "json:\"downloadCount\" graphql:\"downloadCount\""
+	AllowedTransformations *string "json:\"allowedTransformations,omitempty\" graphql:\"allowedTransformations\""
+	CreatedAt              string  "json:\"createdAt\" graphql:\"createdAt\""
+}
+
+func (t *ShareFragment) GetID() string {
+	if t == nil {
+		t = &ShareFragment{}
+	}
+	return t.ID
+}
+func (t *ShareFragment) GetFileID() string {
+	if t == nil {
+		t = &ShareFragment{}
+	}
+	return t.FileID
+}
+func (t *ShareFragment) GetCreatedByUserID() *string {
+	if t == nil {
+		t = &ShareFragment{}
+	}
+	return t.CreatedByUserID
+}
+func (t *ShareFragment) GetExpiresAt() *string {
+	if t == nil {
+		t = &ShareFragment{}
+	}
+	return t.ExpiresAt
+}
+func (t *ShareFragment) GetPasswordHash() *string {
+	if t == nil {
+		t = &ShareFragment{}
+	}
+	return t.PasswordHash
+}
+func (t *ShareFragment) GetMaxDownloads() *int64 {
+	if t == nil {
+		t = &ShareFragment{}
+	}
+	return t.MaxDownloads
+}
+func (t *ShareFragment) GetDownloadCount() int64 {
+	if t == nil {
+		t = &ShareFragment{}
+	}
+	return t.DownloadCount
+}
+func (t *ShareFragment) GetAllowedTransformations() *string {
+	if t == nil {
+		t = &ShareFragment{}
+	}
+	return t.AllowedTransformations
+}
+func (t *ShareFragment) GetCreatedAt() string {
+	if t == nil {
+		t = &ShareFragment{}
+	}
+	return t.CreatedAt
+}
+
+type FileVersionFragment struct {
+	ID               string                 "json:\"id\" graphql:\"id\""
+	CreatedAt        string                 "json:\"createdAt\" graphql:\"createdAt\""
+	FileID           string                 "json:\"fileId\" graphql:\"fileId\""
+	Name             *string                "json:\"name,omitempty\" graphql:\"name\""
+	Size             *int64                 "json:\"size,omitempty\" graphql:\"size\""
+	MimeType         *string                "json:\"mimeType,omitempty\" graphql:\"mimeType\""
+	Etag             *string                "json:\"etag,omitempty\" graphql:\"etag\""
+	ObjectKey        string                 "json:\"objectKey\" graphql:\"objectKey\""
+	Metadata         map[string]interface{} "json:\"metadata,omitempty\" graphql:\"metadata\""
+	UploadedByUserID *string                "json:\"uploadedByUserId,omitempty\" graphql:\"uploadedByUserId\""
+}
+
+func (t *FileVersionFragment) GetID() string {
+	if t == nil {
+		t = &FileVersionFragment{}
+	}
+	return t.ID
+}
+func (t *FileVersionFragment) GetCreatedAt() string {
+	if t == nil {
+		t = &FileVersionFragment{}
+	}
+	return t.CreatedAt
+}
+func (t *FileVersionFragment) GetFileID() string {
+	if t == nil {
+		t = &FileVersionFragment{}
+	}
+	return t.FileID
+}
+func (t *FileVersionFragment) GetName() *string {
+	if t == nil {
+		t = &FileVersionFragment{}
+	}
+	return t.Name
+}
+func (t *FileVersionFragment) GetSize() *int64 {
+	if t == nil {
+		t = &FileVersionFragment{}
+	}
+	return t.Size
+}
+func (t *FileVersionFragment) GetMimeType() *string {
+	if t == nil {
+		t = &FileVersionFragment{}
+	}
+	return t.MimeType
+}
+func (t *FileVersionFragment) GetEtag() *string {
+	if t == nil {
+		t = &FileVersionFragment{}
+	}
+	return t.Etag
+}
+func (t *FileVersionFragment) GetObjectKey() string {
+	if t == nil {
+		t = &FileVersionFragment{}
+	}
+	return t.ObjectKey
+}
+func (t *FileVersionFragment) GetMetadata() map[string]interface{} {
+	if t == nil {
+		t = &FileVersionFragment{}
+	}
+	return t.Metadata
+}
+func (t *FileVersionFragment) GetUploadedByUserID() *string {
+	if t == nil {
+		t = &FileVersionFragment{}
+	}
+	return t.UploadedByUserID
+}
+
+type InsertFile_InsertFile struct {
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *InsertFile_InsertFile) GetID() string {
+	if t == nil {
+		t = &InsertFile_InsertFile{}
+	}
+	return t.ID
+}
+
+type DeleteFile_DeleteFile struct {
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *DeleteFile_DeleteFile) GetID() string {
+	if t == nil {
+		t = &DeleteFile_DeleteFile{}
+	}
+	return t.ID
+}
+
+type UpdateFiles_UpdateFiles struct {
+	AffectedRows int64                   "json:\"affected_rows\" graphql:\"affected_rows\""
+	Returning    []*FileMetadataFragment "json:\"returning\" graphql:\"returning\""
+}
+
+func (t *UpdateFiles_UpdateFiles) GetAffectedRows() int64 {
+	if t == nil {
+		t = &UpdateFiles_UpdateFiles{}
+	}
+	return t.AffectedRows
+}
+func (t *UpdateFiles_UpdateFiles) GetReturning() []*FileMetadataFragment {
+	if t == nil {
+		t = &UpdateFiles_UpdateFiles{}
+	}
+	return t.Returning
+}
+
+type DeleteFiles_DeleteFiles struct {
+	AffectedRows int64 "json:\"affected_rows\" graphql:\"affected_rows\""
+}
+
+func (t *DeleteFiles_DeleteFiles) GetAffectedRows() int64 {
+	if t == nil {
+		t = &DeleteFiles_DeleteFiles{}
+	}
+	return t.AffectedRows
+}
+
+type InsertVirus_InsertVirus struct {
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *InsertVirus_InsertVirus) GetID() string {
+	if t == nil {
+		t = &InsertVirus_InsertVirus{}
+	}
+	return t.ID
+}
+
+type UpdateVirus_UpdateVirus struct {
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *UpdateVirus_UpdateVirus) GetID() string {
+	if t == nil {
+		t = &UpdateVirus_UpdateVirus{}
+	}
+	return t.ID
+}
+
+type DeleteVirusesByFileID_DeleteViruses struct {
+	AffectedRows int64 "json:\"affected_rows\" graphql:\"affected_rows\""
+}
+
+func (t *DeleteVirusesByFileID_DeleteViruses) GetAffectedRows() int64 {
+	if t == nil {
+		t = &DeleteVirusesByFileID_DeleteViruses{}
+	}
+	return t.AffectedRows
+}
+
+type InsertWebhookEvents_InsertWebhookEvents struct {
+	AffectedRows int64 "json:\"affected_rows\" graphql:\"affected_rows\""
+}
+
+func (t *InsertWebhookEvents_InsertWebhookEvents) GetAffectedRows() int64 {
+	if t == nil {
+		t = &InsertWebhookEvents_InsertWebhookEvents{}
+	}
+	return t.AffectedRows
+}
+
+type ClaimWebhookEvent_UpdateWebhookEvents struct {
+	AffectedRows int64 "json:\"affected_rows\" graphql:\"affected_rows\""
+}
+
+func (t *ClaimWebhookEvent_UpdateWebhookEvents) GetAffectedRows() int64 {
+	if t == nil {
+		t = &ClaimWebhookEvent_UpdateWebhookEvents{}
+	}
+	return t.AffectedRows
+}
+
+type UpdateWebhookEvent_UpdateWebhookEvent struct {
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *UpdateWebhookEvent_UpdateWebhookEvent) GetID() string {
+	if t == nil {
+		t = &UpdateWebhookEvent_UpdateWebhookEvent{}
+	}
+	return t.ID
+}
+
+type GetWebhookSecret_WebhookSecret struct {
+	Secret string "json:\"secret\" graphql:\"secret\""
+}
+
+func (t *GetWebhookSecret_WebhookSecret) GetSecret() string {
+	if t == nil {
+		t = &GetWebhookSecret_WebhookSecret{}
+	}
+	return t.Secret
+}
+
+type SetAPIKeyLastUsed_UpdateAPIKey struct {
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *SetAPIKeyLastUsed_UpdateAPIKey) GetID() string {
+	if t == nil {
+		t = &SetAPIKeyLastUsed_UpdateAPIKey{}
+	}
+	return t.ID
+}
+
+type DeleteShares_DeleteShares struct {
+	AffectedRows int64 "json:\"affected_rows\" graphql:\"affected_rows\""
+}
+
+func (t *DeleteShares_DeleteShares) GetAffectedRows() int64 {
+	if t == nil {
+		t = &DeleteShares_DeleteShares{}
+	}
+	return t.AffectedRows
+}
+
+type IncrementShareDownloads_UpdateShares struct {
+	AffectedRows int64 "json:\"affected_rows\" graphql:\"affected_rows\""
+}
+
+func (t *IncrementShareDownloads_UpdateShares) GetAffectedRows() int64 {
+	if t == nil {
+		t = &IncrementShareDownloads_UpdateShares{}
+	}
+	return t.AffectedRows
+}
+
+type DeleteFileVersion_DeleteFileVersion struct {
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *DeleteFileVersion_DeleteFileVersion) GetID() string {
+	if t == nil {
+		t = &DeleteFileVersion_DeleteFileVersion{}
+	}
+	return t.ID
+}
+
+type ListExpiredFileVersions_ExpiredFileVersions struct {
+	ID        *string "json:\"id,omitempty\" graphql:\"id\""
+	ObjectKey *string "json:\"objectKey,omitempty\" graphql:\"objectKey\""
+}
+
+func (t *ListExpiredFileVersions_ExpiredFileVersions) GetID() *string {
+	if t == nil {
+		t = &ListExpiredFileVersions_ExpiredFileVersions{}
+	}
+	return t.ID
+}
+func (t *ListExpiredFileVersions_ExpiredFileVersions) GetObjectKey() *string {
+	if t == nil {
+		t = &ListExpiredFileVersions_ExpiredFileVersions{}
+	}
+	return t.ObjectKey
+}
+
+type ListExpiredTrash_ExpiredTrash struct {
+	ID        *string "json:\"id,omitempty\" graphql:\"id\""
+	BucketID  *string "json:\"bucketId,omitempty\" graphql:\"bucketId\""
+	ObjectKey *string "json:\"objectKey,omitempty\" graphql:\"objectKey\""
+	UpdatedAt *string "json:\"updatedAt,omitempty\" graphql:\"updatedAt\""
+}
+
+func (t *ListExpiredTrash_ExpiredTrash) GetID() *string {
+	if t == nil {
+		t = &ListExpiredTrash_ExpiredTrash{}
+	}
+	return t.ID
+}
+func (t *ListExpiredTrash_ExpiredTrash) GetBucketID() *string {
+	if t == nil {
+		t = &ListExpiredTrash_ExpiredTrash{}
+	}
+	return t.BucketID
+}
+func (t *ListExpiredTrash_ExpiredTrash) GetObjectKey() *string {
+	if t == nil {
+		t = &ListExpiredTrash_ExpiredTrash{}
+	}
+	return t.ObjectKey
+}
+func (t *ListExpiredTrash_ExpiredTrash) GetUpdatedAt() *string {
+	if t == nil {
+		t = &ListExpiredTrash_ExpiredTrash{}
+	}
+	return t.UpdatedAt
+}
+
+type ListExpiredFiles_ExpiredFiles struct {
+	ID        *string "json:\"id,omitempty\" graphql:\"id\""
+	BucketID  *string "json:\"bucketId,omitempty\" graphql:\"bucketId\""
+	ObjectKey *string "json:\"objectKey,omitempty\" graphql:\"objectKey\""
+	UpdatedAt *string "json:\"updatedAt,omitempty\" graphql:\"updatedAt\""
+}
+
+func (t *ListExpiredFiles_ExpiredFiles) GetID() *string {
+	if t == nil {
+		t = &ListExpiredFiles_ExpiredFiles{}
+	}
+	return t.ID
+}
+func (t *ListExpiredFiles_ExpiredFiles) GetBucketID() *string {
+	if t == nil {
+		t = &ListExpiredFiles_ExpiredFiles{}
+	}
+	return t.BucketID
+}
+func (t *ListExpiredFiles_ExpiredFiles) GetObjectKey() *string {
+	if t == nil {
+		t = &ListExpiredFiles_ExpiredFiles{}
+	}
+	return t.ObjectKey
+}
+func (t *ListExpiredFiles_ExpiredFiles) GetUpdatedAt() *string {
+	if t == nil {
+		t = &ListExpiredFiles_ExpiredFiles{}
+	}
+	return t.UpdatedAt
+}
+
+type UpdateFileWithEvents_InsertWebhookEvents struct {
+	Returning []*WebhookEventFragment "json:\"returning\" graphql:\"returning\""
+}
+
+func (t *UpdateFileWithEvents_InsertWebhookEvents) GetReturning() []*WebhookEventFragment {
+	if t == nil {
+		t = &UpdateFileWithEvents_InsertWebhookEvents{}
+	}
+	return t.Returning
+}
+
+type UpdateFilesWithEvents_UpdateFiles struct {
+	AffectedRows int64                   "json:\"affected_rows\" graphql:\"affected_rows\""
+	Returning    []*FileMetadataFragment "json:\"returning\" graphql:\"returning\""
+}
+
+func (t *UpdateFilesWithEvents_UpdateFiles) GetAffectedRows() int64 {
+	if t == nil {
+		t = &UpdateFilesWithEvents_UpdateFiles{}
+	}
+	return t.AffectedRows
+}
+func (t *UpdateFilesWithEvents_UpdateFiles) GetReturning() []*FileMetadataFragment {
+	if t == nil {
+		t = &UpdateFilesWithEvents_UpdateFiles{}
+	}
+	return t.Returning
+}
+
+type UpdateFilesWithEvents_InsertWebhookEvents struct {
+	Returning []*WebhookEventFragment "json:\"returning\" graphql:\"returning\""
+}
+
+func (t *UpdateFilesWithEvents_InsertWebhookEvents) GetReturning() []*WebhookEventFragment {
+	if t == nil {
+		t = &UpdateFilesWithEvents_InsertWebhookEvents{}
+	}
+	return t.Returning
+}
+
+type DeleteFilesWithEvents_DeleteFiles struct {
+	AffectedRows int64 "json:\"affected_rows\" graphql:\"affected_rows\""
+}
+
+func (t *DeleteFilesWithEvents_DeleteFiles) GetAffectedRows() int64 {
+	if t == nil {
+		t = &DeleteFilesWithEvents_DeleteFiles{}
+	}
+	return t.AffectedRows
+}
+
+type DeleteFilesWithEvents_InsertWebhookEvents struct {
+	Returning []*WebhookEventFragment "json:\"returning\" graphql:\"returning\""
+}
+
+func (t *DeleteFilesWithEvents_InsertWebhookEvents) GetReturning() []*WebhookEventFragment {
+	if t == nil {
+		t = &DeleteFilesWithEvents_InsertWebhookEvents{}
+	}
+	return t.Returning
+}
+
+type InsertVirusWithEvents_InsertVirus struct {
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *InsertVirusWithEvents_InsertVirus) GetID() string {
+	if t == nil {
+		t = &InsertVirusWithEvents_InsertVirus{}
+	}
+	return t.ID
+}
+
+type InsertVirusWithEvents_InsertWebhookEvents struct {
+	Returning []*WebhookEventFragment "json:\"returning\" graphql:\"returning\""
+}
+
+func (t *InsertVirusWithEvents_InsertWebhookEvents) GetReturning() []*WebhookEventFragment {
+	if t == nil {
+		t = &InsertVirusWithEvents_InsertWebhookEvents{}
+	}
+	return t.Returning
+}
+
+type DeleteWebhookEvents_DeleteWebhookEvents struct {
+	AffectedRows int64 "json:\"affected_rows\" graphql:\"affected_rows\""
+}
+
+func (t *DeleteWebhookEvents_DeleteWebhookEvents) GetAffectedRows() int64 {
+	if t == nil {
+		t = &DeleteWebhookEvents_DeleteWebhookEvents{}
+	}
+	return t.AffectedRows
+}
+
+type SetLegalHold_InsertFileAuditEvent struct {
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *SetLegalHold_InsertFileAuditEvent) GetID() string {
+	if t == nil {
+		t = &SetLegalHold_InsertFileAuditEvent{}
+	}
+	return t.ID
+}
+
+type GetBucket struct {
+	Bucket *BucketMetadataFragment "json:\"bucket,omitempty\" graphql:\"bucket\""
+}
+
+func (t *GetBucket) GetBucket() *BucketMetadataFragment {
+	if t == nil {
+		t = &GetBucket{}
+	}
+	return t.Bucket
+}
+
+type GetFile struct {
+	File *FileMetadataFragment "json:\"file,omitempty\" graphql:\"file\""
+}
+
+func (t *GetFile) GetFile() *FileMetadataFragment {
+	if t == nil {
+		t = &GetFile{}
+	}
+	return t.File
+}
+
+type GetFilesByETag struct {
+	Files []*FileMetadataFragment "json:\"files\" graphql:\"files\""
+}
+
+func (t *GetFilesByETag) GetFiles() []*FileMetadataFragment {
+	if t == nil {
+		t = &GetFilesByETag{}
+	}
+	return t.Files
+}
+
+type ListFilesSummary struct {
+	Files []*FileMetadataSummaryFragment "json:\"files\" graphql:\"files\""
+}
+
+func (t *ListFilesSummary) GetFiles() []*FileMetadataSummaryFragment {
+	if t == nil {
+		t = &ListFilesSummary{}
+	}
+	return t.Files
+}
+
+type InsertFile struct {
+	InsertFile *InsertFile_InsertFile "json:\"insertFile,omitempty\" graphql:\"insertFile\""
+}
+
+func (t *InsertFile) GetInsertFile() *InsertFile_InsertFile {
+	if t == nil {
+		t = &InsertFile{}
+	}
+	return t.InsertFile
+}
+
+type UpdateFile struct {
+	UpdateFile *FileMetadataFragment "json:\"updateFile,omitempty\" graphql:\"updateFile\""
+}
+
+func (t *UpdateFile) GetUpdateFile() *FileMetadataFragment {
+	if t == nil {
+		t = &UpdateFile{}
+	}
+	return t.UpdateFile
+}
+
+type DeleteFile struct {
+	DeleteFile *DeleteFile_DeleteFile "json:\"deleteFile,omitempty\" graphql:\"deleteFile\""
+}
+
+func (t *DeleteFile) GetDeleteFile() *DeleteFile_DeleteFile {
+	if t == nil {
+		t = &DeleteFile{}
+	}
+	return t.DeleteFile
+}
+
+type UpdateFiles struct {
+	UpdateFiles *UpdateFiles_UpdateFiles "json:\"updateFiles,omitempty\" graphql:\"updateFiles\""
+}
+
+func (t *UpdateFiles) GetUpdateFiles() *UpdateFiles_UpdateFiles {
+	if t == nil {
+		t = &UpdateFiles{}
+	}
+	return t.UpdateFiles
+}
+
+type DeleteFiles struct {
+	DeleteFiles *DeleteFiles_DeleteFiles "json:\"deleteFiles,omitempty\" graphql:\"deleteFiles\""
+}
+
+func (t *DeleteFiles) GetDeleteFiles() *DeleteFiles_DeleteFiles {
+	if t == nil {
+		t = &DeleteFiles{}
+	}
+	return t.DeleteFiles
+}
+
+type InsertVirus struct {
+	InsertVirus *InsertVirus_InsertVirus "json:\"insertVirus,omitempty\" graphql:\"insertVirus\""
+}
+
+func (t *InsertVirus) GetInsertVirus() *InsertVirus_InsertVirus {
+	if t == nil {
+		t = &InsertVirus{}
+	}
+	return t.InsertVirus
+}
+
+type ListViruses struct {
+	Viruses []*VirusMetadataFragment "json:\"viruses\" graphql:\"viruses\""
+}
+
+func (t *ListViruses) GetViruses() []*VirusMetadataFragment {
+	if t == nil {
+		t = &ListViruses{}
+	}
+	return t.Viruses
+}
+
+type GetVirus struct {
+	Virus *VirusMetadataFragment "json:\"virus,omitempty\" graphql:\"virus\""
+}
+
+func (t *GetVirus) GetVirus() *VirusMetadataFragment {
+	if t == nil {
+		t = &GetVirus{}
+	}
+	return t.Virus
+}
+
+type UpdateVirus struct {
+	UpdateVirus *UpdateVirus_UpdateVirus "json:\"updateVirus,omitempty\" graphql:\"updateVirus\""
+}
+
+func (t *UpdateVirus) GetUpdateVirus() *UpdateVirus_UpdateVirus {
+	if t == nil {
+		t = &UpdateVirus{}
+	}
+	return t.UpdateVirus
+}
+
+type DeleteVirusesByFileID struct {
+	DeleteViruses *DeleteVirusesByFileID_DeleteViruses "json:\"deleteViruses,omitempty\" graphql:\"deleteViruses\""
+}
+
+func (t *DeleteVirusesByFileID) GetDeleteViruses() *DeleteVirusesByFileID_DeleteViruses {
+	if t == nil {
+		t = &DeleteVirusesByFileID{}
+	}
+	return t.DeleteViruses
+}
+
+type ListPendingWebhookEvents struct {
+	WebhookEvents []*WebhookEventFragment "json:\"webhookEvents\" graphql:\"webhookEvents\""
+}
+
+func (t *ListPendingWebhookEvents) GetWebhookEvents() []*WebhookEventFragment {
+	if t == nil {
+		t = &ListPendingWebhookEvents{}
+	}
+	return t.WebhookEvents
+}
+
+type InsertWebhookEvents struct {
+	InsertWebhookEvents *InsertWebhookEvents_InsertWebhookEvents "json:\"insertWebhookEvents,omitempty\" graphql:\"insertWebhookEvents\""
+}
+
+func (t *InsertWebhookEvents) GetInsertWebhookEvents() *InsertWebhookEvents_InsertWebhookEvents {
+	if t == nil {
+		t = &InsertWebhookEvents{}
+	}
+	return t.InsertWebhookEvents
+}
+
+type ClaimWebhookEvent struct {
+	UpdateWebhookEvents *ClaimWebhookEvent_UpdateWebhookEvents "json:\"updateWebhookEvents,omitempty\" graphql:\"updateWebhookEvents\""
+}
+
+func (t *ClaimWebhookEvent) GetUpdateWebhookEvents() *ClaimWebhookEvent_UpdateWebhookEvents {
+	if t == nil {
+		t = &ClaimWebhookEvent{}
+	}
+	return t.UpdateWebhookEvents
+}
+
+type UpdateWebhookEvent struct {
+	UpdateWebhookEvent *UpdateWebhookEvent_UpdateWebhookEvent "json:\"updateWebhookEvent,omitempty\" graphql:\"updateWebhookEvent\""
+}
+
+func (t *UpdateWebhookEvent) GetUpdateWebhookEvent() *UpdateWebhookEvent_UpdateWebhookEvent {
+	if t == nil {
+		t = &UpdateWebhookEvent{}
+	}
+	return t.UpdateWebhookEvent
+}
+
+type GetWebhookSecret struct {
+	WebhookSecret *GetWebhookSecret_WebhookSecret "json:\"webhookSecret,omitempty\" graphql:\"webhookSecret\""
+}
+
+func (t *GetWebhookSecret) GetWebhookSecret() *GetWebhookSecret_WebhookSecret {
+	if t == nil {
+		t = &GetWebhookSecret{}
+	}
+	return t.WebhookSecret
+}
+
+type GetQuotas struct {
+	Quotas []*QuotaFragment "json:\"quotas\" graphql:\"quotas\""
+}
+
+func (t *GetQuotas) GetQuotas() []*QuotaFragment {
+	if t == nil {
+		t = &GetQuotas{}
+	}
+	return t.Quotas
+}
+
+type GetUsage struct {
+	Usages []*UsageFragment "json:\"usages\" graphql:\"usages\""
+}
+
+func (t *GetUsage) GetUsages() []*UsageFragment {
+	if t == nil {
+		t = &GetUsage{}
+	}
+	return t.Usages
+}
+
+type GetAPIKeyByHash struct {
+	APIKeys []*APIKeyFragment "json:\"apiKeys\" graphql:\"apiKeys\""
+}
+
+func (t *GetAPIKeyByHash) GetAPIKeys() []*APIKeyFragment {
+	if t == nil {
+		t = &GetAPIKeyByHash{}
+	}
+	return t.APIKeys
+}
+
+type SetAPIKeyLastUsed struct {
+	UpdateAPIKey *SetAPIKeyLastUsed_UpdateAPIKey "json:\"updateApiKey,omitempty\" graphql:\"updateApiKey\""
+}
+
+func (t *SetAPIKeyLastUsed) GetUpdateAPIKey() *SetAPIKeyLastUsed_UpdateAPIKey {
+	if t == nil {
+		t = &SetAPIKeyLastUsed{}
+	}
+	return t.UpdateAPIKey
+}
+
+type InsertShare struct {
+	InsertShare *ShareFragment "json:\"insertShare,omitempty\" graphql:\"insertShare\""
+}
+
+func (t *InsertShare) GetInsertShare() *ShareFragment {
+	if t == nil {
+		t = &InsertShare{}
+	}
+	return t.InsertShare
+}
+
+type GetShareByTokenHash struct {
+	Shares []*ShareFragment "json:\"shares\" graphql:\"shares\""
+}
+
+func (t *GetShareByTokenHash) GetShares() []*ShareFragment {
+	if t == nil {
+		t = &GetShareByTokenHash{}
+	}
+	return t.Shares
+}
+
+type DeleteShares struct {
+	DeleteShares *DeleteShares_DeleteShares "json:\"deleteShares,omitempty\" graphql:\"deleteShares\""
+}
+
+func (t *DeleteShares) GetDeleteShares() *DeleteShares_DeleteShares {
+	if t == nil {
+		t = &DeleteShares{}
+	}
+	return t.DeleteShares
+}
+
+type IncrementShareDownloads struct {
+	UpdateShares *IncrementShareDownloads_UpdateShares "json:\"updateShares,omitempty\" graphql:\"updateShares\""
+}
+
+func (t *IncrementShareDownloads) GetUpdateShares() *IncrementShareDownloads_UpdateShares {
+	if t == nil {
+		t = &IncrementShareDownloads{}
+	}
+	return t.UpdateShares
+}
+
+type InsertFileVersion struct {
+	InsertFileVersion *FileVersionFragment "json:\"insertFileVersion,omitempty\" graphql:\"insertFileVersion\""
+}
+
+func (t *InsertFileVersion) GetInsertFileVersion() *FileVersionFragment {
+	if t == nil {
+		t = &InsertFileVersion{}
+	}
+	return t.InsertFileVersion
+}
+
+type ListFileVersions struct {
+	FileVersions []*FileVersionFragment "json:\"fileVersions\" graphql:\"fileVersions\""
+}
+
+func (t *ListFileVersions) GetFileVersions() []*FileVersionFragment {
+	if t == nil {
+		t = &ListFileVersions{}
+	}
+	return t.FileVersions
+}
+
+type GetFileVersion struct {
+	FileVersion *FileVersionFragment "json:\"fileVersion,omitempty\" graphql:\"fileVersion\""
+}
+
+func (t *GetFileVersion) GetFileVersion() *FileVersionFragment {
+	if t == nil {
+		t = &GetFileVersion{}
+	}
+	return t.FileVersion
+}
+
+type DeleteFileVersion struct {
+	DeleteFileVersion *DeleteFileVersion_DeleteFileVersion "json:\"deleteFileVersion,omitempty\" graphql:\"deleteFileVersion\""
+}
+
+func (t *DeleteFileVersion) GetDeleteFileVersion() *DeleteFileVersion_DeleteFileVersion {
+	if t == nil {
+		t = &DeleteFileVersion{}
+	}
+	return t.DeleteFileVersion
+}
+
+type ListExpiredFileVersions struct {
+	ExpiredFileVersions []*ListExpiredFileVersions_ExpiredFileVersions "json:\"expiredFileVersions\" graphql:\"expiredFileVersions\""
+}
+
+func (t *ListExpiredFileVersions) GetExpiredFileVersions() []*ListExpiredFileVersions_ExpiredFileVersions {
+	if t == nil {
+		t = &ListExpiredFileVersions{}
+	}
+	return t.ExpiredFileVersions
+}
+
+type ListDeletedFiles struct {
+	Files []*FileMetadataFragment "json:\"files\" graphql:\"files\""
+}
+
+func (t *ListDeletedFiles) GetFiles() []*FileMetadataFragment {
+	if t == nil {
+		t = &ListDeletedFiles{}
+	}
+	return t.Files
+}
+
+type ListExpiredTrash struct {
+	ExpiredTrash []*ListExpiredTrash_ExpiredTrash "json:\"expiredTrash\" graphql:\"expiredTrash\""
+}
+
+func (t *ListExpiredTrash) GetExpiredTrash() []*ListExpiredTrash_ExpiredTrash {
+	if t == nil {
+		t = &ListExpiredTrash{}
+	}
+	return t.ExpiredTrash
+}
+
+type ListExpiredFiles struct {
+	ExpiredFiles []*ListExpiredFiles_ExpiredFiles "json:\"expiredFiles\" graphql:\"expiredFiles\""
+}
+
+func (t *ListExpiredFiles) GetExpiredFiles() []*ListExpiredFiles_ExpiredFiles {
+	if t == nil {
+		t = &ListExpiredFiles{}
+	}
+	return t.ExpiredFiles
+}
+
+type UpdateFileWithEvents struct {
+	UpdateFile          *FileMetadataFragment                     "json:\"updateFile,omitempty\" graphql:\"updateFile\""
+	InsertWebhookEvents *UpdateFileWithEvents_InsertWebhookEvents "json:\"insertWebhookEvents,omitempty\" graphql:\"insertWebhookEvents\""
+}
+
+func (t *UpdateFileWithEvents) GetUpdateFile() *FileMetadataFragment {
+	if t == nil {
+		t = &UpdateFileWithEvents{}
+	}
+	return t.UpdateFile
+}
+func (t *UpdateFileWithEvents) GetInsertWebhookEvents() *UpdateFileWithEvents_InsertWebhookEvents {
+	if t == nil {
+		t = &UpdateFileWithEvents{}
+	}
+	return t.InsertWebhookEvents
+}
+
+type UpdateFilesWithEvents struct {
+	UpdateFiles         *UpdateFilesWithEvents_UpdateFiles         "json:\"updateFiles,omitempty\" graphql:\"updateFiles\""
+	InsertWebhookEvents *UpdateFilesWithEvents_InsertWebhookEvents "json:\"insertWebhookEvents,omitempty\" graphql:\"insertWebhookEvents\""
+}
+
+func (t *UpdateFilesWithEvents) GetUpdateFiles() *UpdateFilesWithEvents_UpdateFiles {
+	if t == nil {
+		t = &UpdateFilesWithEvents{}
+	}
+	return t.UpdateFiles
+}
+func (t *UpdateFilesWithEvents) GetInsertWebhookEvents() *UpdateFilesWithEvents_InsertWebhookEvents {
+	if t == nil {
+		t = &UpdateFilesWithEvents{}
+	}
+	return t.InsertWebhookEvents
+}
+
+type DeleteFilesWithEvents struct {
+	DeleteFiles         *DeleteFilesWithEvents_DeleteFiles         "json:\"deleteFiles,omitempty\" graphql:\"deleteFiles\""
+	InsertWebhookEvents *DeleteFilesWithEvents_InsertWebhookEvents "json:\"insertWebhookEvents,omitempty\" graphql:\"insertWebhookEvents\""
+}
+
+func (t *DeleteFilesWithEvents) GetDeleteFiles() *DeleteFilesWithEvents_DeleteFiles {
+	if t == nil {
+		t = &DeleteFilesWithEvents{}
+	}
+	return t.DeleteFiles
+}
+func (t *DeleteFilesWithEvents) GetInsertWebhookEvents() *DeleteFilesWithEvents_InsertWebhookEvents {
+	if t == nil {
+		t = &DeleteFilesWithEvents{}
+	}
+	return t.InsertWebhookEvents
+}
+
+type InsertVirusWithEvents struct {
+	InsertVirus         *InsertVirusWithEvents_InsertVirus         "json:\"insertVirus,omitempty\" graphql:\"insertVirus\""
+	InsertWebhookEvents *InsertVirusWithEvents_InsertWebhookEvents "json:\"insertWebhookEvents,omitempty\" graphql:\"insertWebhookEvents\""
+}
+
+func (t *InsertVirusWithEvents) GetInsertVirus() *InsertVirusWithEvents_InsertVirus {
+	if t == nil {
+		t = &InsertVirusWithEvents{}
+	}
+	return t.InsertVirus
+}
+func (t *InsertVirusWithEvents) GetInsertWebhookEvents() *InsertVirusWithEvents_InsertWebhookEvents {
+	if t == nil {
+		t = &InsertVirusWithEvents{}
+	}
+	return t.InsertWebhookEvents
+}
+
+type DeleteWebhookEvents struct {
+	DeleteWebhookEvents *DeleteWebhookEvents_DeleteWebhookEvents "json:\"deleteWebhookEvents,omitempty\" graphql:\"deleteWebhookEvents\""
+}
+
+func (t *DeleteWebhookEvents) GetDeleteWebhookEvents() *DeleteWebhookEvents_DeleteWebhookEvents {
+	if t == nil {
+		t = &DeleteWebhookEvents{}
+	}
+	return t.DeleteWebhookEvents
+}
+
+type SetLegalHold struct {
+	UpdateFile           *FileMetadataFragment              "json:\"updateFile,omitempty\" graphql:\"updateFile\""
+	InsertFileAuditEvent *SetLegalHold_InsertFileAuditEvent "json:\"insertFileAuditEvent,omitempty\" graphql:\"insertFileAuditEvent\""
+}
+
+func (t *SetLegalHold) GetUpdateFile() *FileMetadataFragment {
+	if t == nil {
+		t = &SetLegalHold{}
+	}
+	return t.UpdateFile
+}
+func (t *SetLegalHold) GetInsertFileAuditEvent() *SetLegalHold_InsertFileAuditEvent {
+	if t == nil {
+		t = &SetLegalHold{}
+	}
+	return t.InsertFileAuditEvent
+}
+
+const GetBucketDocument = `query GetBucket ($id: String!) {
+	bucket(id: $id) {
+		... BucketMetadataFragment
+	}
+}
+fragment BucketMetadataFragment on buckets {
+	id
+	minUploadFileSize
+	maxUploadFileSize
+	presignedUrlsEnabled
+	downloadExpiration
+	createdAt
+	updatedAt
+	cacheControl
+	uploadExpiration
+	webhookUrl
+	allowedMimeTypes
+	deniedMimeTypes
+	allowedExtensions
+	deniedExtensions
+	redirectDownloads
+	versioningEnabled
+	maxVersions
+	versionRetentionDays
+	softDeleteEnabled
+	trashRetentionDays
+	retentionDays
+	retentionMode
+}
+`
+
+func (c *Client) GetBucket(ctx context.Context, id string, interceptors ...clientv2.RequestInterceptor) (*GetBucket, error) {
+	vars := map[string]any{
+		"id": id,
+	}
+
+	var res GetBucket
+	if err := c.Client.Post(ctx, "GetBucket", GetBucketDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const GetFileDocument = `query GetFile ($id: uuid!) {
+	file(id: $id) {
+		... FileMetadataFragment
+	}
+}
+fragment FileMetadataFragment on files {
+	id
+	name
+	size
+	bucketId
+	etag
+	createdAt
+	updatedAt
+	isUploaded
+	mimeType
+	uploadedByUserId
+	metadata
+	objectKey
+	chunkSize
+	chunkCount
+	uploadId
+	deletedAt
+	deletedByUserId
+	retainUntil
+	legalHold
+	expiresAt
+}
+`
+
+func (c *Client) GetFile(ctx context.Context, id string, interceptors ...clientv2.RequestInterceptor) (*GetFile, error) {
+	vars := map[string]any{
+		"id": id,
+	}
+
+	var res GetFile
+	if err := c.Client.Post(ctx, "GetFile", GetFileDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const GetFilesByETagDocument = `query GetFilesByETag ($etag: String!) {
+	files(where: {etag:{_eq:$etag}}, order_by: {createdAt:desc}) {
+		... FileMetadataFragment
+	}
+}
+fragment FileMetadataFragment on files {
+	id
+	name
+	size
+	bucketId
+	etag
+	createdAt
+	updatedAt
+	isUploaded
+	mimeType
+	uploadedByUserId
+	metadata
+	objectKey
+	chunkSize
+	chunkCount
+	uploadId
+	deletedAt
+	deletedByUserId
+	retainUntil
+	legalHold
+	expiresAt
+}
+`
+
+func (c *Client) GetFilesByETag(ctx context.Context, etag string, interceptors ...clientv2.RequestInterceptor) (*GetFilesByETag, error) {
+	vars := map[string]any{
+		"etag": etag,
+	}
+
+	var res GetFilesByETag
+	if err := c.Client.Post(ctx, "GetFilesByETag", GetFilesByETagDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const ListFilesSummaryDocument = `query ListFilesSummary ($where: files_bool_exp!, $limit: Int) {
+	files(where: $where, limit: $limit) {
+		... FileMetadataSummaryFragment
+	}
+}
+fragment FileMetadataSummaryFragment on files {
+	id
+	name
+	bucketId
+	isUploaded
+	uploadedByUserId
+}
+`
+
+func (c *Client) ListFilesSummary(ctx context.Context, where FilesBoolExp, limit *int64, interceptors ...clientv2.RequestInterceptor) (*ListFilesSummary, error) {
+	vars := map[string]any{
+		"where": where,
+		"limit": limit,
+	}
+
+	var res ListFilesSummary
+	if err := c.Client.Post(ctx, "ListFilesSummary", ListFilesSummaryDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const InsertFileDocument = `mutation InsertFile ($object: files_insert_input!) {
+	insertFile(object: $object) {
+		id
+	}
+}
+`
+
+func (c *Client) InsertFile(ctx context.Context, object FilesInsertInput, interceptors ...clientv2.RequestInterceptor) (*InsertFile, error) {
+	vars := map[string]any{
+		"object": object,
+	}
+
+	var res InsertFile
+	if err := c.Client.Post(ctx, "InsertFile", InsertFileDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const UpdateFileDocument = `mutation UpdateFile ($id: uuid!, $_set: files_set_input!) {
+	updateFile(pk_columns: {id:$id}, _set: $_set) {
+		... FileMetadataFragment
+	}
+}
+fragment FileMetadataFragment on files {
+	id
+	name
+	size
+	bucketId
+	etag
+	createdAt
+	updatedAt
+	isUploaded
+	mimeType
+	uploadedByUserId
+	metadata
+	objectKey
+	chunkSize
+	chunkCount
+	uploadId
+	deletedAt
+	deletedByUserId
+	retainUntil
+	legalHold
+	expiresAt
+}
+`
+
+func (c *Client) UpdateFile(ctx context.Context, id string, set FilesSetInput, interceptors ...clientv2.RequestInterceptor) (*UpdateFile, error) {
+	vars := map[string]any{
+		"id":   id,
+		"_set": set,
+	}
+
+	var res UpdateFile
+	if err := c.Client.Post(ctx, "UpdateFile", UpdateFileDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const DeleteFileDocument = `mutation DeleteFile ($id: uuid!) {
+	deleteFile(id: $id) {
+		id
+	}
+}
+`
+
+func (c *Client) DeleteFile(ctx context.Context, id string, interceptors ...clientv2.RequestInterceptor) (*DeleteFile, error) {
+	vars := map[string]any{
+		"id": id,
+	}
+
+	var res DeleteFile
+	if err := c.Client.Post(ctx, "DeleteFile", DeleteFileDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const UpdateFilesDocument = `mutation UpdateFiles ($where: files_bool_exp!, $_set: files_set_input!) {
+	updateFiles(where: $where, _set: $_set) {
+		affected_rows
+		returning {
+			... FileMetadataFragment
+		}
+	}
+}
+fragment FileMetadataFragment on files {
+	id
+	name
+	size
+	bucketId
+	etag
+	createdAt
+	updatedAt
+	isUploaded
+	mimeType
+	uploadedByUserId
+	metadata
+	objectKey
+	chunkSize
+	chunkCount
+	uploadId
+	deletedAt
+	deletedByUserId
+	retainUntil
+	legalHold
+	expiresAt
+}
+`
+
+func (c *Client) UpdateFiles(ctx context.Context, where FilesBoolExp, set FilesSetInput, interceptors ...clientv2.RequestInterceptor) (*UpdateFiles, error) {
+	vars := map[string]any{
+		"where": where,
+		"_set":  set,
+	}
+
+	var res UpdateFiles
+	if err := c.Client.Post(ctx, "UpdateFiles", UpdateFilesDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const DeleteFilesDocument = `mutation DeleteFiles ($where: files_bool_exp!) {
+	deleteFiles(where: $where) {
+		affected_rows
+	}
+}
+`
+
+func (c *Client) DeleteFiles(ctx context.Context, where FilesBoolExp, interceptors ...clientv2.RequestInterceptor) (*DeleteFiles, error) {
+	vars := map[string]any{
+		"where": where,
+	}
+
+	var res DeleteFiles
+	if err := c.Client.Post(ctx, "DeleteFiles", DeleteFilesDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const InsertVirusDocument = `mutation InsertVirus ($object: virus_insert_input!) {
+	insertVirus(object: $object) {
+		id
+	}
+}
+`
+
+func (c *Client) InsertVirus(ctx context.Context, object VirusInsertInput, interceptors ...clientv2.RequestInterceptor) (*InsertVirus, error) {
+	vars := map[string]any{
+		"object": object,
+	}
+
+	var res InsertVirus
+	if err := c.Client.Post(ctx, "InsertVirus", InsertVirusDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const ListVirusesDocument = `query ListViruses ($where: virus_bool_exp!) {
+	viruses(where: $where, order_by: {createdAt:desc}) {
+		... VirusMetadataFragment
+	}
+}
+fragment VirusMetadataFragment on virus {
+	id
+	createdAt
+	updatedAt
+	fileId
+	filename
+	virus
+	falsePositive
+	userSession
+	quarantine
+	file {
+		bucketId
+	}
+}
+`
+
+func (c *Client) ListViruses(ctx context.Context, where VirusBoolExp, interceptors ...clientv2.RequestInterceptor) (*ListViruses, error) {
+	vars := map[string]any{
+		"where": where,
+	}
+
+	var res ListViruses
+	if err := c.Client.Post(ctx, "ListViruses", ListVirusesDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const GetVirusDocument = `query GetVirus ($id: uuid!) {
+	virus(id: $id) {
+		... VirusMetadataFragment
+	}
+}
+fragment VirusMetadataFragment on virus {
+	id
+	createdAt
+	updatedAt
+	fileId
+	filename
+	virus
+	falsePositive
+	userSession
+	quarantine
+	file {
+		bucketId
+	}
+}
+`
+
+func (c *Client) GetVirus(ctx context.Context, id string, interceptors ...clientv2.RequestInterceptor) (*GetVirus, error) {
+	vars := map[string]any{
+		"id": id,
+	}
+
+	var res GetVirus
+	if err := c.Client.Post(ctx, "GetVirus", GetVirusDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const UpdateVirusDocument = `mutation UpdateVirus ($id: uuid!, $_set: virus_set_input!) {
+	updateVirus(pk_columns: {id:$id}, _set: $_set) {
+		id
+	}
+}
+`
+
+func (c *Client) UpdateVirus(ctx context.Context, id string, set VirusSetInput, interceptors ...clientv2.RequestInterceptor) (*UpdateVirus, error) {
+	vars := map[string]any{
+		"id":   id,
+		"_set": set,
+	}
+
+	var res UpdateVirus
+	if err := c.Client.Post(ctx, "UpdateVirus", UpdateVirusDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const DeleteVirusesByFileIDDocument = `mutation DeleteVirusesByFileID ($fileId: uuid!) {
+	deleteViruses(where: {fileId:{_eq:$fileId}}) {
+		affected_rows
+	}
+}
+`
+
+func (c *Client) DeleteVirusesByFileID(ctx context.Context, fileID string, interceptors ...clientv2.RequestInterceptor) (*DeleteVirusesByFileID, error) {
+	vars := map[string]any{
+		"fileId": fileID,
+	}
+
+	var res DeleteVirusesByFileID
+	if err := c.Client.Post(ctx, "DeleteVirusesByFileID", DeleteVirusesByFileIDDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const ListPendingWebhookEventsDocument = `query ListPendingWebhookEvents ($now: timestamptz!, $maxAttempts: Int!, $limit: Int!) {
+	webhookEvents(where: {deliveredAt:{_is_null:true},nextAttemptAt:{_lte:$now},attempts:{_lt:$maxAttempts}}, order_by: {nextAttemptAt:asc}, limit: $limit) {
+		... WebhookEventFragment
+	}
+}
+fragment WebhookEventFragment on webhookEvents {
+	id
+	createdAt
+	eventType
+	bucketId
+	fileId
+	payload
+	attempts
+	nextAttemptAt
+}
+`
+
+func (c *Client) ListPendingWebhookEvents(ctx context.Context, now string, maxAttempts int64, limit int64, interceptors ...clientv2.RequestInterceptor) (*ListPendingWebhookEvents, error) {
+	vars := map[string]any{
+		"now":         now,
+		"maxAttempts": maxAttempts,
+		"limit":       limit,
+	}
+
+	var res ListPendingWebhookEvents
+	if err := c.Client.Post(ctx, "ListPendingWebhookEvents", ListPendingWebhookEventsDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const InsertWebhookEventsDocument = `mutation InsertWebhookEvents ($objects: [webhookEvents_insert_input!]!) {
+	insertWebhookEvents(objects: $objects) {
+		affected_rows
+	}
+}
+`
+
+func (c *Client) InsertWebhookEvents(ctx context.Context, objects []*WebhookEventsInsertInput, interceptors ...clientv2.RequestInterceptor) (*InsertWebhookEvents, error) {
+	vars := map[string]any{
+		"objects": objects,
+	}
+
+	var res InsertWebhookEvents
+	if err := c.Client.Post(ctx, "InsertWebhookEvents", InsertWebhookEventsDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const ClaimWebhookEventDocument = `mutation ClaimWebhookEvent ($id: uuid!, $nextAttemptAt: timestamptz!, $leaseUntil: timestamptz!) {
+	updateWebhookEvents(where: {id:{_eq:$id},nextAttemptAt:{_eq:$nextAttemptAt},deliveredAt:{_is_null:true}}, _set: {nextAttemptAt:$leaseUntil}) {
+		affected_rows
+	}
+}
+`
+
+func (c *Client) ClaimWebhookEvent(ctx context.Context, id string, nextAttemptAt string, leaseUntil string, interceptors ...clientv2.RequestInterceptor) (*ClaimWebhookEvent, error) {
+	vars := map[string]any{
+		"id":            id,
+		"nextAttemptAt": nextAttemptAt,
+		"leaseUntil":    leaseUntil,
+	}
+
+	var res ClaimWebhookEvent
+	if err := c.Client.Post(ctx, "ClaimWebhookEvent", ClaimWebhookEventDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const UpdateWebhookEventDocument = `mutation UpdateWebhookEvent ($id: uuid!, $_set: webhookEvents_set_input!) {
+	updateWebhookEvent(pk_columns: {id:$id}, _set: $_set) {
+		id
+	}
+}
+`
+
+func (c *Client) UpdateWebhookEvent(ctx context.Context, id string, set WebhookEventsSetInput, interceptors ...clientv2.RequestInterceptor) (*UpdateWebhookEvent, error) {
+	vars := map[string]any{
+		"id":   id,
+		"_set": set,
+	}
+
+	var res UpdateWebhookEvent
+	if err := c.Client.Post(ctx, "UpdateWebhookEvent", UpdateWebhookEventDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const GetWebhookSecretDocument = `query GetWebhookSecret ($bucketId: String!) {
+	webhookSecret(bucketId: $bucketId) {
+		secret
+	}
+}
+`
+
+func (c *Client) GetWebhookSecret(ctx context.Context, bucketID string, interceptors ...clientv2.RequestInterceptor) (*GetWebhookSecret, error) {
+	vars := map[string]any{
+		"bucketId": bucketID,
+	}
+
+	var res GetWebhookSecret
+	if err := c.Client.Post(ctx, "GetWebhookSecret", GetWebhookSecretDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const GetQuotasDocument = `query GetQuotas ($where: quotas_bool_exp!) {
+	quotas(where: $where) {
+		... QuotaFragment
+	}
+}
+fragment QuotaFragment on quotas {
+	id
+	userId
+	role
+	bucketId
+	maxBytes
+	maxFiles
+}
+`
+
+func (c *Client) GetQuotas(ctx context.Context, where QuotasBoolExp, interceptors ...clientv2.RequestInterceptor) (*GetQuotas, error) {
+	vars := map[string]any{
+		"where": where,
+	}
+
+	var res GetQuotas
+	if err := c.Client.Post(ctx, "GetQuotas", GetQuotasDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const GetUsageDocument = `query GetUsage ($userId: uuid!) {
+	usages(where: {userId:{_eq:$userId}}) {
+		... UsageFragment
+	}
+}
+fragment UsageFragment on usages {
+	bucketId
+	bytes
+	files
+}
+`
+
+func (c *Client) GetUsage(ctx context.Context, userID string, interceptors ...clientv2.RequestInterceptor) (*GetUsage, error) {
+	vars := map[string]any{
+		"userId": userID,
+	}
+
+	var res GetUsage
+	if err := c.Client.Post(ctx, "GetUsage", GetUsageDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const GetAPIKeyByHashDocument = `query GetAPIKeyByHash ($keyHash: String!) {
+	apiKeys(where: {keyHash:{_eq:$keyHash}}, limit: 1) {
+		... APIKeyFragment
+	}
+}
+fragment APIKeyFragment on apiKeys {
+	id
+	name
+	role
+	buckets
+	operations
+	expiresAt
+	lastUsedAt
+}
+`
+
+func (c *Client) GetAPIKeyByHash(ctx context.Context, keyHash string, interceptors ...clientv2.RequestInterceptor) (*GetAPIKeyByHash, error) {
+	vars := map[string]any{
+		"keyHash": keyHash,
+	}
+
+	var res GetAPIKeyByHash
+	if err := c.Client.Post(ctx, "GetAPIKeyByHash", GetAPIKeyByHashDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const SetAPIKeyLastUsedDocument = `mutation SetAPIKeyLastUsed ($id: uuid!, $lastUsedAt: timestamptz!) {
+	updateApiKey(pk_columns: {id:$id}, _set: {lastUsedAt:$lastUsedAt}) {
+		id
+	}
+}
+`
+
+func (c *Client) SetAPIKeyLastUsed(ctx context.Context, id string, lastUsedAt string, interceptors ...clientv2.RequestInterceptor) (*SetAPIKeyLastUsed, error) {
+	vars := map[string]any{
+		"id":         id,
+		"lastUsedAt": lastUsedAt,
+	}
+
+	var res SetAPIKeyLastUsed
+	if err := c.Client.Post(ctx, "SetAPIKeyLastUsed", SetAPIKeyLastUsedDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const InsertShareDocument = `mutation InsertShare ($object: shares_insert_input!) {
+	insertShare(object: $object) {
+		... ShareFragment
+	}
+}
+fragment ShareFragment on shares {
+	id
+	fileId
+	createdByUserId
+	expiresAt
+	passwordHash
+	maxDownloads
+	downloadCount
+	allowedTransformations
+	createdAt
+}
+`
+
+func (c *Client) InsertShare(ctx context.Context, object SharesInsertInput, interceptors ...clientv2.RequestInterceptor) (*InsertShare, error) {
+	vars := map[string]any{
+		"object": object,
+	}
+
+	var res InsertShare
+	if err := c.Client.Post(ctx, "InsertShare", InsertShareDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const GetShareByTokenHashDocument = `query GetShareByTokenHash ($tokenHash: String!) {
+	shares(where: {tokenHash:{_eq:$tokenHash}}, limit: 1) {
+		... ShareFragment
+	}
+}
+fragment ShareFragment on shares {
+	id
+	fileId
+	createdByUserId
+	expiresAt
+	passwordHash
+	maxDownloads
+	downloadCount
+	allowedTransformations
+	createdAt
+}
+`
+
+func (c *Client) GetShareByTokenHash(ctx context.Context, tokenHash string, interceptors ...clientv2.RequestInterceptor) (*GetShareByTokenHash, error) {
+	vars := map[string]any{
+		"tokenHash": tokenHash,
+	}
+
+	var res GetShareByTokenHash
+	if err := c.Client.Post(ctx, "GetShareByTokenHash", GetShareByTokenHashDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const DeleteSharesDocument = `mutation DeleteShares ($where: shares_bool_exp!) {
+	deleteShares(where: $where) {
+		affected_rows
+	}
+}
+`
+
+func (c *Client) DeleteShares(ctx context.Context, where SharesBoolExp, interceptors ...clientv2.RequestInterceptor) (*DeleteShares, error) {
+	vars := map[string]any{
+		"where": where,
+	}
+
+	var res DeleteShares
+	if err := c.Client.Post(ctx, "DeleteShares", DeleteSharesDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const IncrementShareDownloadsDocument = `mutation IncrementShareDownloads ($id: uuid!, $maxDownloads: Int!) {
+	updateShares(where: {id:{_eq:$id},downloadCount:{_lt:$maxDownloads}}, _inc: {downloadCount:1}) {
+		affected_rows
+	}
+}
+`
+
+func (c *Client) IncrementShareDownloads(ctx context.Context, id string, maxDownloads int64, interceptors ...clientv2.RequestInterceptor) (*IncrementShareDownloads, error) {
+	vars := map[string]any{
+		"id":           id,
+		"maxDownloads": maxDownloads,
+	}
+
+	var res IncrementShareDownloads
+	if err := c.Client.Post(ctx, "IncrementShareDownloads", IncrementShareDownloadsDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const InsertFileVersionDocument = `mutation InsertFileVersion ($object: fileVersions_insert_input!) {
+	insertFileVersion(object: $object) {
+		... FileVersionFragment
+	}
+}
+fragment FileVersionFragment on fileVersions {
+	id
+	createdAt
+	fileId
+	name
+	size
+	mimeType
+	etag
+	objectKey
+	metadata
+	uploadedByUserId
+}
+`
+
+func (c *Client) InsertFileVersion(ctx context.Context, object FileVersionsInsertInput, interceptors ...clientv2.RequestInterceptor) (*InsertFileVersion, error) {
+	vars := map[string]any{
+		"object": object,
+	}
+
+	var res InsertFileVersion
+	if err := c.Client.Post(ctx, "InsertFileVersion", InsertFileVersionDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
 	}
-	return t.ID
-}
 
-type GetBucket struct {
-	Bucket *BucketMetadataFragment "json:\"bucket,omitempty\" graphql:\"bucket\""
+	return &res, nil
 }
 
-func (t *GetBucket) GetBucket() *BucketMetadataFragment {
-	if t == nil {
-		t = &GetBucket{}
+const ListFileVersionsDocument = `query ListFileVersions ($fileId: uuid!) {
+	fileVersions(where: {fileId:{_eq:$fileId}}, order_by: {createdAt:desc}) {
+		... FileVersionFragment
 	}
-	return t.Bucket
 }
-
-type GetFile struct {
-	File *FileMetadataFragment "json:\"file,omitempty\" graphql:\"file\""
+fragment FileVersionFragment on fileVersions {
+	id
+	createdAt
+	fileId
+	name
+	size
+	mimeType
+	etag
+	objectKey
+	metadata
+	uploadedByUserId
 }
+`
 
-func (t *GetFile) GetFile() *FileMetadataFragment {
-	if t == nil {
-		t = &GetFile{}
+func (c *Client) ListFileVersions(ctx context.Context, fileID string, interceptors ...clientv2.RequestInterceptor) (*ListFileVersions, error) {
+	vars := map[string]any{
+		"fileId": fileID,
 	}
-	return t.File
-}
 
-type GetFilesByETag struct {
-	Files []*FileMetadataFragment "json:\"files\" graphql:\"files\""
-}
+	var res ListFileVersions
+	if err := c.Client.Post(ctx, "ListFileVersions", ListFileVersionsDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
 
-func (t *GetFilesByETag) GetFiles() []*FileMetadataFragment {
-	if t == nil {
-		t = &GetFilesByETag{}
+		return nil, err
 	}
-	return t.Files
-}
 
-type ListFilesSummary struct {
-	Files []*FileMetadataSummaryFragment "json:\"files\" graphql:\"files\""
+	return &res, nil
 }
 
-func (t *ListFilesSummary) GetFiles() []*FileMetadataSummaryFragment {
-	if t == nil {
-		t = &ListFilesSummary{}
+const GetFileVersionDocument = `query GetFileVersion ($id: uuid!) {
+	fileVersion(id: $id) {
+		... FileVersionFragment
 	}
-	return t.Files
 }
-
-type InsertFile struct {
-	InsertFile *InsertFile_InsertFile "json:\"insertFile,omitempty\" graphql:\"insertFile\""
+fragment FileVersionFragment on fileVersions {
+	id
+	createdAt
+	fileId
+	name
+	size
+	mimeType
+	etag
+	objectKey
+	metadata
+	uploadedByUserId
 }
+`
 
-func (t *InsertFile) GetInsertFile() *InsertFile_InsertFile {
-	if t == nil {
-		t = &InsertFile{}
+func (c *Client) GetFileVersion(ctx context.Context, id string, interceptors ...clientv2.RequestInterceptor) (*GetFileVersion, error) {
+	vars := map[string]any{
+		"id": id,
 	}
-	return t.InsertFile
-}
 
-type UpdateFile struct {
-	UpdateFile *FileMetadataFragment "json:\"updateFile,omitempty\" graphql:\"updateFile\""
-}
+	var res GetFileVersion
+	if err := c.Client.Post(ctx, "GetFileVersion", GetFileVersionDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
 
-func (t *UpdateFile) GetUpdateFile() *FileMetadataFragment {
-	if t == nil {
-		t = &UpdateFile{}
+		return nil, err
 	}
-	return t.UpdateFile
-}
 
-type DeleteFile struct {
-	DeleteFile *DeleteFile_DeleteFile "json:\"deleteFile,omitempty\" graphql:\"deleteFile\""
+	return &res, nil
 }
 
-func (t *DeleteFile) GetDeleteFile() *DeleteFile_DeleteFile {
-	if t == nil {
-		t = &DeleteFile{}
+const DeleteFileVersionDocument = `mutation DeleteFileVersion ($id: uuid!) {
+	deleteFileVersion(id: $id) {
+		id
 	}
-	return t.DeleteFile
 }
+`
 
-type InsertVirus struct {
-	InsertVirus *InsertVirus_InsertVirus "json:\"insertVirus,omitempty\" graphql:\"insertVirus\""
-}
+func (c *Client) DeleteFileVersion(ctx context.Context, id string, interceptors ...clientv2.RequestInterceptor) (*DeleteFileVersion, error) {
+	vars := map[string]any{
+		"id": id,
+	}
 
-func (t *InsertVirus) GetInsertVirus() *InsertVirus_InsertVirus {
-	if t == nil {
-		t = &InsertVirus{}
+	var res DeleteFileVersion
+	if err := c.Client.Post(ctx, "DeleteFileVersion", DeleteFileVersionDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
 	}
-	return t.InsertVirus
+
+	return &res, nil
 }
 
-const GetBucketDocument = `query GetBucket ($id: String!) {
-	bucket(id: $id) {
-		... BucketMetadataFragment
+const ListExpiredFileVersionsDocument = `query ListExpiredFileVersions ($limit: Int!) {
+	expiredFileVersions(order_by: {createdAt:asc}, limit: $limit) {
+		id
+		objectKey
 	}
 }
-fragment BucketMetadataFragment on buckets {
-	id
-	minUploadFileSize
-	maxUploadFileSize
-	presignedUrlsEnabled
-	downloadExpiration
-	createdAt
-	updatedAt
-	cacheControl
-	uploadExpiration
-}
 `
 
-func (c *Client) GetBucket(ctx context.Context, id string, interceptors ...clientv2.RequestInterceptor) (*GetBucket, error) {
+func (c *Client) ListExpiredFileVersions(ctx context.Context, limit int64, interceptors ...clientv2.RequestInterceptor) (*ListExpiredFileVersions, error) {
 	vars := map[string]any{
-		"id": id,
+		"limit": limit,
 	}
 
-	var res GetBucket
-	if err := c.Client.Post(ctx, "GetBucket", GetBucketDocument, &res, vars, interceptors...); err != nil {
+	var res ListExpiredFileVersions
+	if err := c.Client.Post(ctx, "ListExpiredFileVersions", ListExpiredFileVersionsDocument, &res, vars, interceptors...); err != nil {
 		if c.Client.ParseDataWhenErrors {
 			return &res, err
 		}
@@ -415,8 +2729,8 @@ func (c *Client) GetBucket(ctx context.Context, id string, interceptors ...clien
 	return &res, nil
 }
 
-const GetFileDocument = `query GetFile ($id: uuid!) {
-	file(id: $id) {
+const ListDeletedFilesDocument = `query ListDeletedFiles ($where: files_bool_exp!) {
+	files(where: $where, order_by: {deletedAt:desc}) {
 		... FileMetadataFragment
 	}
 }
@@ -436,16 +2750,21 @@ fragment FileMetadataFragment on files {
 	chunkSize
 	chunkCount
 	uploadId
+	deletedAt
+	deletedByUserId
+	retainUntil
+	legalHold
+	expiresAt
 }
 `
 
-func (c *Client) GetFile(ctx context.Context, id string, interceptors ...clientv2.RequestInterceptor) (*GetFile, error) {
+func (c *Client) ListDeletedFiles(ctx context.Context, where FilesBoolExp, interceptors ...clientv2.RequestInterceptor) (*ListDeletedFiles, error) {
 	vars := map[string]any{
-		"id": id,
+		"where": where,
 	}
 
-	var res GetFile
-	if err := c.Client.Post(ctx, "GetFile", GetFileDocument, &res, vars, interceptors...); err != nil {
+	var res ListDeletedFiles
+	if err := c.Client.Post(ctx, "ListDeletedFiles", ListDeletedFilesDocument, &res, vars, interceptors...); err != nil {
 		if c.Client.ParseDataWhenErrors {
 			return &res, err
 		}
@@ -456,10 +2775,40 @@ func (c *Client) GetFile(ctx context.Context, id string, interceptors ...clientv
 	return &res, nil
 }
 
-const GetFilesByETagDocument = `query GetFilesByETag ($etag: String!) {
-	files(where: {etag:{_eq:$etag}}, order_by: {createdAt:desc}) {
+const ListExpiredTrashDocument = `query ListExpiredTrash ($limit: Int!) {
+	expiredTrash(order_by: {deletedAt:asc}, limit: $limit) {
+		id
+		bucketId
+		objectKey
+		updatedAt
+	}
+}
+`
+
+func (c *Client) ListExpiredTrash(ctx context.Context, limit int64, interceptors ...clientv2.RequestInterceptor) (*ListExpiredTrash, error) {
+	vars := map[string]any{
+		"limit": limit,
+	}
+
+	var res ListExpiredTrash
+	if err := c.Client.Post(ctx, "ListExpiredTrash", ListExpiredTrashDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const SetLegalHoldDocument = `mutation SetLegalHold ($id: uuid!, $legalHold: Boolean!, $event: fileAuditEvents_insert_input!) {
+	updateFile(pk_columns: {id:$id}, _set: {legalHold:$legalHold}) {
 		... FileMetadataFragment
 	}
+	insertFileAuditEvent(object: $event) {
+		id
+	}
 }
 fragment FileMetadataFragment on files {
 	id
@@ -477,16 +2826,23 @@ fragment FileMetadataFragment on files {
 	chunkSize
 	chunkCount
 	uploadId
+	deletedAt
+	deletedByUserId
+	retainUntil
+	legalHold
+	expiresAt
 }
 `
 
-func (c *Client) GetFilesByETag(ctx context.Context, etag string, interceptors ...clientv2.RequestInterceptor) (*GetFilesByETag, error) {
+func (c *Client) SetLegalHold(ctx context.Context, id string, legalHold bool, event FileAuditEventsInsertInput, interceptors ...clientv2.RequestInterceptor) (*SetLegalHold, error) {
 	vars := map[string]any{
-		"etag": etag,
+		"id":        id,
+		"legalHold": legalHold,
+		"event":     event,
 	}
 
-	var res GetFilesByETag
-	if err := c.Client.Post(ctx, "GetFilesByETag", GetFilesByETagDocument, &res, vars, interceptors...); err != nil {
+	var res SetLegalHold
+	if err := c.Client.Post(ctx, "SetLegalHold", SetLegalHoldDocument, &res, vars, interceptors...); err != nil {
 		if c.Client.ParseDataWhenErrors {
 			return &res, err
 		}
@@ -497,24 +2853,23 @@ func (c *Client) GetFilesByETag(ctx context.Context, etag string, interceptors .
 	return &res, nil
 }
 
-const ListFilesSummaryDocument = `query ListFilesSummary {
-	files {
-		... FileMetadataSummaryFragment
+const ListExpiredFilesDocument = `query ListExpiredFiles ($limit: Int!) {
+	expiredFiles(order_by: {expiresAt:asc}, limit: $limit) {
+		id
+		bucketId
+		objectKey
+		updatedAt
 	}
 }
-fragment FileMetadataSummaryFragment on files {
-	id
-	name
-	bucketId
-	isUploaded
-}
 `
 
-func (c *Client) ListFilesSummary(ctx context.Context, interceptors ...clientv2.RequestInterceptor) (*ListFilesSummary, error) {
-	vars := map[string]any{}
+func (c *Client) ListExpiredFiles(ctx context.Context, limit int64, interceptors ...clientv2.RequestInterceptor) (*ListExpiredFiles, error) {
+	vars := map[string]any{
+		"limit": limit,
+	}
 
-	var res ListFilesSummary
-	if err := c.Client.Post(ctx, "ListFilesSummary", ListFilesSummaryDocument, &res, vars, interceptors...); err != nil {
+	var res ListExpiredFiles
+	if err := c.Client.Post(ctx, "ListExpiredFiles", ListExpiredFilesDocument, &res, vars, interceptors...); err != nil {
 		if c.Client.ParseDataWhenErrors {
 			return &res, err
 		}
@@ -525,20 +2880,59 @@ func (c *Client) ListFilesSummary(ctx context.Context, interceptors ...clientv2.
 	return &res, nil
 }
 
-const InsertFileDocument = `mutation InsertFile ($object: files_insert_input!) {
-	insertFile(object: $object) {
-		id
+const UpdateFileWithEventsDocument = `mutation UpdateFileWithEvents ($id: uuid!, $_set: files_set_input!, $events: [webhookEvents_insert_input!]!) {
+	updateFile(pk_columns: {id:$id}, _set: $_set) {
+		... FileMetadataFragment
+	}
+	insertWebhookEvents(objects: $events) {
+		returning {
+			... WebhookEventFragment
+		}
 	}
 }
+fragment FileMetadataFragment on files {
+	id
+	name
+	size
+	bucketId
+	etag
+	createdAt
+	updatedAt
+	isUploaded
+	mimeType
+	uploadedByUserId
+	metadata
+	objectKey
+	chunkSize
+	chunkCount
+	uploadId
+	deletedAt
+	deletedByUserId
+	retainUntil
+	legalHold
+	expiresAt
+}
+fragment WebhookEventFragment on webhookEvents {
+	id
+	createdAt
+	eventType
+	bucketId
+	fileId
+	payload
+	attempts
+	nextAttemptAt
+}
 `
 
-func (c *Client) InsertFile(ctx context.Context, object FilesInsertInput, interceptors ...clientv2.RequestInterceptor) (*InsertFile, error) {
+func (c *Client) UpdateFileWithEvents(ctx context.Context, id string, set FilesSetInput, events []*WebhookEventsInsertInput, interceptors ...clientv2.RequestInterceptor) (*UpdateFileWithEvents, error) {
 	vars := map[string]any{
-		"object": object,
+		"id":     id,
+		"_set":   set,
+		"events": events,
 	}
 
-	var res InsertFile
-	if err := c.Client.Post(ctx, "InsertFile", InsertFileDocument, &res, vars, interceptors...); err != nil {
+	var res UpdateFileWithEvents
+	if err := c.Client.Post(ctx, "UpdateFileWithEvents", UpdateFileWithEventsDocument, &res, vars, interceptors...); err != nil {
 		if c.Client.ParseDataWhenErrors {
 			return &res, err
 		}
@@ -549,9 +2943,17 @@ func (c *Client) InsertFile(ctx context.Context, object FilesInsertInput, interc
 	return &res, nil
 }
 
-const UpdateFileDocument = `mutation UpdateFile ($id: uuid!, $_set: files_set_input!) {
-	updateFile(pk_columns: {id:$id}, _set: $_set) {
-		... FileMetadataFragment
+const UpdateFilesWithEventsDocument = `mutation UpdateFilesWithEvents ($where: files_bool_exp!, $_set: files_set_input!, $events: [webhookEvents_insert_input!]!) {
+	updateFiles(where: $where, _set: $_set) {
+		affected_rows
+		returning {
+			... FileMetadataFragment
+		}
+	}
+	insertWebhookEvents(objects: $events) {
+		returning {
+			... WebhookEventFragment
+		}
 	}
 }
 fragment FileMetadataFragment on files {
@@ -570,17 +2972,33 @@ fragment FileMetadataFragment on files {
 	chunkSize
 	chunkCount
 	uploadId
+	deletedAt
+	deletedByUserId
+	retainUntil
+	legalHold
+	expiresAt
+}
+fragment WebhookEventFragment on webhookEvents {
+	id
+	createdAt
+	eventType
+	bucketId
+	fileId
+	payload
+	attempts
+	nextAttemptAt
 }
 `
 
-func (c *Client) UpdateFile(ctx context.Context, id string, set FilesSetInput, interceptors ...clientv2.RequestInterceptor) (*UpdateFile, error) {
+func (c *Client) UpdateFilesWithEvents(ctx context.Context, where FilesBoolExp, set FilesSetInput, events []*WebhookEventsInsertInput, interceptors ...clientv2.RequestInterceptor) (*UpdateFilesWithEvents, error) {
 	vars := map[string]any{
-		"id":   id,
-		"_set": set,
+		"where":  where,
+		"_set":   set,
+		"events": events,
 	}
 
-	var res UpdateFile
-	if err := c.Client.Post(ctx, "UpdateFile", UpdateFileDocument, &res, vars, interceptors...); err != nil {
+	var res UpdateFilesWithEvents
+	if err := c.Client.Post(ctx, "UpdateFilesWithEvents", UpdateFilesWithEventsDocument, &res, vars, interceptors...); err != nil {
 		if c.Client.ParseDataWhenErrors {
 			return &res, err
 		}
@@ -591,20 +3009,36 @@ func (c *Client) UpdateFile(ctx context.Context, id string, set FilesSetInput, i
 	return &res, nil
 }
 
-const DeleteFileDocument = `mutation DeleteFile ($id: uuid!) {
-	deleteFile(id: $id) {
-		id
+const DeleteFilesWithEventsDocument = `mutation DeleteFilesWithEvents ($where: files_bool_exp!, $events: [webhookEvents_insert_input!]!) {
+	deleteFiles(where: $where) {
+		affected_rows
+	}
+	insertWebhookEvents(objects: $events) {
+		returning {
+			... WebhookEventFragment
+		}
 	}
 }
+fragment WebhookEventFragment on webhookEvents {
+	id
+	createdAt
+	eventType
+	bucketId
+	fileId
+	payload
+	attempts
+	nextAttemptAt
+}
 `
 
-func (c *Client) DeleteFile(ctx context.Context, id string, interceptors ...clientv2.RequestInterceptor) (*DeleteFile, error) {
+func (c *Client) DeleteFilesWithEvents(ctx context.Context, where FilesBoolExp, events []*WebhookEventsInsertInput, interceptors ...clientv2.RequestInterceptor) (*DeleteFilesWithEvents, error) {
 	vars := map[string]any{
-		"id": id,
+		"where":  where,
+		"events": events,
 	}
 
-	var res DeleteFile
-	if err := c.Client.Post(ctx, "DeleteFile", DeleteFileDocument, &res, vars, interceptors...); err != nil {
+	var res DeleteFilesWithEvents
+	if err := c.Client.Post(ctx, "DeleteFilesWithEvents", DeleteFilesWithEventsDocument, &res, vars, interceptors...); err != nil {
 		if c.Client.ParseDataWhenErrors {
 			return &res, err
 		}
@@ -615,20 +3049,60 @@ func (c *Client) DeleteFile(ctx context.Context, id string, interceptors ...clie
 	return &res, nil
 }
 
-const InsertVirusDocument = `mutation InsertVirus ($object: virus_insert_input!) {
+const InsertVirusWithEventsDocument = `mutation InsertVirusWithEvents ($object: virus_insert_input!, $events: [webhookEvents_insert_input!]!) {
 	insertVirus(object: $object) {
 		id
 	}
+	insertWebhookEvents(objects: $events) {
+		returning {
+			... WebhookEventFragment
+		}
+	}
+}
+fragment WebhookEventFragment on webhookEvents {
+	id
+	createdAt
+	eventType
+	bucketId
+	fileId
+	payload
+	attempts
+	nextAttemptAt
 }
 `
 
-func (c *Client) InsertVirus(ctx context.Context, object VirusInsertInput, interceptors ...clientv2.RequestInterceptor) (*InsertVirus, error) {
+func (c *Client) InsertVirusWithEvents(ctx context.Context, object VirusInsertInput, events []*WebhookEventsInsertInput, interceptors ...clientv2.RequestInterceptor) (*InsertVirusWithEvents, error) {
 	vars := map[string]any{
 		"object": object,
+		"events": events,
 	}
 
-	var res InsertVirus
-	if err := c.Client.Post(ctx, "InsertVirus", InsertVirusDocument, &res, vars, interceptors...); err != nil {
+	var res InsertVirusWithEvents
+	if err := c.Client.Post(ctx, "InsertVirusWithEvents", InsertVirusWithEventsDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const DeleteWebhookEventsDocument = `mutation DeleteWebhookEvents ($ids: [uuid!]!) {
+	deleteWebhookEvents(where: {id:{_in:$ids}}) {
+		affected_rows
+	}
+}
+`
+
+func (c *Client) DeleteWebhookEvents(ctx context.Context, ids []string, interceptors ...clientv2.RequestInterceptor) (*DeleteWebhookEvents, error) {
+	vars := map[string]any{
+		"ids": ids,
+	}
+
+	var res DeleteWebhookEvents
+	if err := c.Client.Post(ctx, "DeleteWebhookEvents", DeleteWebhookEventsDocument, &res, vars, interceptors...); err != nil {
 		if c.Client.ParseDataWhenErrors {
 			return &res, err
 		}
@@ -640,12 +3114,45 @@ func (c *Client) InsertVirus(ctx context.Context, object VirusInsertInput, inter
 }
 
 var DocumentOperationNames = map[string]string{
-	GetBucketDocument:        "GetBucket",
-	GetFileDocument:          "GetFile",
-	GetFilesByETagDocument:   "GetFilesByETag",
-	ListFilesSummaryDocument: "ListFilesSummary",
-	InsertFileDocument:       "InsertFile",
-	UpdateFileDocument:       "UpdateFile",
-	DeleteFileDocument:       "DeleteFile",
-	InsertVirusDocument:      "InsertVirus",
+	GetBucketDocument:                "GetBucket",
+	GetFileDocument:                  "GetFile",
+	GetFilesByETagDocument:           "GetFilesByETag",
+	ListFilesSummaryDocument:         "ListFilesSummary",
+	InsertFileDocument:               "InsertFile",
+	UpdateFileDocument:               "UpdateFile",
+	DeleteFileDocument:               "DeleteFile",
+	UpdateFilesDocument:              "UpdateFiles",
+	DeleteFilesDocument:              "DeleteFiles",
+	InsertVirusDocument:              "InsertVirus",
+	ListVirusesDocument:              "ListViruses",
+	GetVirusDocument:                 "GetVirus",
+	UpdateVirusDocument:              "UpdateVirus",
+	DeleteVirusesByFileIDDocument:    "DeleteVirusesByFileID",
+	ListPendingWebhookEventsDocument: "ListPendingWebhookEvents",
+	InsertWebhookEventsDocument:      "InsertWebhookEvents",
+	ClaimWebhookEventDocument:        "ClaimWebhookEvent",
+	UpdateWebhookEventDocument:       "UpdateWebhookEvent",
+	GetWebhookSecretDocument:         "GetWebhookSecret",
+	GetQuotasDocument:                "GetQuotas",
+	GetUsageDocument:                 "GetUsage",
+	GetAPIKeyByHashDocument:          "GetAPIKeyByHash",
+	SetAPIKeyLastUsedDocument:        "SetAPIKeyLastUsed",
+	InsertShareDocument:              "InsertShare",
+	GetShareByTokenHashDocument:      "GetShareByTokenHash",
+	DeleteSharesDocument:             "DeleteShares",
+	IncrementShareDownloadsDocument:  "IncrementShareDownloads",
+	InsertFileVersionDocument:        "InsertFileVersion",
+	ListFileVersionsDocument:         "ListFileVersions",
+	GetFileVersionDocument:           "GetFileVersion",
+	DeleteFileVersionDocument:        "DeleteFileVersion",
+	ListExpiredFileVersionsDocument:  "ListExpiredFileVersions",
+	ListDeletedFilesDocument:         "ListDeletedFiles",
+	ListExpiredTrashDocument:         "ListExpiredTrash",
+	SetLegalHoldDocument:             "SetLegalHold",
+	ListExpiredFilesDocument:         "ListExpiredFiles",
+	UpdateFileWithEventsDocument:     "UpdateFileWithEvents",
+	UpdateFilesWithEventsDocument:    "UpdateFilesWithEvents",
+	DeleteFilesWithEventsDocument:    "DeleteFilesWithEvents",
+	InsertVirusWithEventsDocument:    "InsertVirusWithEvents",
+	DeleteWebhookEventsDocument:      "DeleteWebhookEvents",
 }
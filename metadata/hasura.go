@@ -2,8 +2,12 @@ package metadata
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"math"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/Yamashou/gqlgenc/clientv2"
 	"github.com/nhost/hasura-storage/controller"
@@ -13,6 +17,42 @@ func ptr[T any](x T) *T {
 	return &x
 }
 
+// optional returns nil for empty values so the column is left untouched.
+func optional(x string) *string {
+	if x == "" {
+		return nil
+	}
+	return &x
+}
+
+func deref[T any](x *T) T {
+	if x == nil {
+		var zero T
+		return zero
+	}
+	return *x
+}
+
+// splitList parses the comma separated lists stored in the database.
+func splitList(s *string) []string {
+	if s == nil {
+		return nil
+	}
+
+	var list []string
+	for _, v := range strings.Split(*s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
 func parseGraphqlError(err error) *controller.APIError {
 	var ghErr *clientv2.ErrorResponse
 	if errors.As(err, &ghErr) {
@@ -24,6 +64,10 @@ func parseGraphqlError(err error) *controller.APIError {
 		case "access-denied", "validation-failed", "permission-error":
 			return controller.ForbiddenError(ghErr, "you are not authorized")
 		case "data-exception", "constraint-violation":
+			// raised by the usage trigger, which enforces quotas atomically
+			if strings.Contains(ghErr.Error(), "storage quota exceeded") {
+				return controller.ErrQuotaExceeded
+			}
 			return controller.BadDataError(err, ghErr.Error())
 		default:
 			return controller.InternalServerError(err)
@@ -35,10 +79,11 @@ func parseGraphqlError(err error) *controller.APIError {
 
 func (md *FileMetadataSummaryFragment) ToControllerType() controller.FileSummary {
 	return controller.FileSummary{
-		ID:         md.GetID(),
-		Name:       *md.GetName(),
-		BucketID:   md.GetBucketID(),
-		IsUploaded: *md.GetIsUploaded(),
+		ID:               md.GetID(),
+		Name:             *md.GetName(),
+		BucketID:         md.GetBucketID(),
+		IsUploaded:       *md.GetIsUploaded(),
+		UploadedByUserID: deref(md.GetUploadedByUserID()),
 	}
 }
 
@@ -53,6 +98,79 @@ func (md *BucketMetadataFragment) ToControllerType() controller.BucketMetadata {
 		UpdatedAt:            md.GetUpdatedAt(),
 		CacheControl:         *md.GetCacheControl(),
 		UploadExpiration:     int(md.GetUploadExpiration()),
+		WebhookURL:           deref(md.GetWebhookURL()),
+		AllowedMimeTypes:     splitList(md.GetAllowedMimeTypes()),
+		DeniedMimeTypes:      splitList(md.GetDeniedMimeTypes()),
+		AllowedExtensions:    splitList(md.GetAllowedExtensions()),
+		DeniedExtensions:     splitList(md.GetDeniedExtensions()),
+		RedirectDownloads:    md.GetRedirectDownloads(),
+		VersioningEnabled:    md.GetVersioningEnabled(),
+		MaxVersions:          int(md.GetMaxVersions()),
+		VersionRetentionDays: int(md.GetVersionRetentionDays()),
+		SoftDeleteEnabled:    md.GetSoftDeleteEnabled(),
+		TrashRetentionDays:   int(md.GetTrashRetentionDays()),
+		RetentionDays:        int(md.GetRetentionDays()),
+		RetentionMode:        md.GetRetentionMode(),
+	}
+}
+
+func (md *QuotaFragment) ToControllerType() controller.Quota {
+	return controller.Quota{
+		ID:       md.GetID(),
+		UserID:   deref(md.GetUserID()),
+		Role:     deref(md.GetRole()),
+		BucketID: deref(md.GetBucketID()),
+		MaxBytes: deref(md.GetMaxBytes()),
+		MaxFiles: deref(md.GetMaxFiles()),
+	}
+}
+
+func (md *APIKeyFragment) ToControllerType() controller.APIKey {
+	return controller.APIKey{
+		ID:         md.GetID(),
+		Name:       md.GetName(),
+		Role:       md.GetRole(),
+		Buckets:    splitList(md.GetBuckets()),
+		Operations: splitList(ptr(md.GetOperations())),
+		ExpiresAt:  deref(md.GetExpiresAt()),
+		LastUsedAt: deref(md.GetLastUsedAt()),
+	}
+}
+
+func (md *ShareFragment) ToControllerType() controller.Share {
+	return controller.Share{
+		ID:                     md.GetID(),
+		FileID:                 md.GetFileID(),
+		CreatedByUserID:        deref(md.GetCreatedByUserID()),
+		ExpiresAt:              deref(md.GetExpiresAt()),
+		PasswordHash:           deref(md.GetPasswordHash()),
+		MaxDownloads:           deref(md.GetMaxDownloads()),
+		DownloadCount:          md.GetDownloadCount(),
+		AllowedTransformations: splitList(md.GetAllowedTransformations()),
+		CreatedAt:              md.GetCreatedAt(),
+	}
+}
+
+func (md *FileVersionFragment) ToControllerType() controller.FileVersion {
+	return controller.FileVersion{
+		ID:               md.GetID(),
+		FileID:           md.GetFileID(),
+		Name:             deref(md.GetName()),
+		Size:             deref(md.GetSize()),
+		MimeType:         deref(md.GetMimeType()),
+		ETag:             deref(md.GetEtag()),
+		ObjectKey:        md.GetObjectKey(),
+		UploadedByUserID: deref(md.GetUploadedByUserID()),
+		Metadata:         md.GetMetadata(),
+		CreatedAt:        md.GetCreatedAt(),
+	}
+}
+
+func (md *UsageFragment) ToControllerType() controller.Usage {
+	return controller.Usage{
+		BucketID: md.GetBucketID(),
+		Bytes:    md.GetBytes(),
+		Files:    md.GetFiles(),
 	}
 }
 
@@ -112,21 +230,187 @@ func (md *FileMetadataFragment) ToControllerType() controller.FileMetadata {
 		UploadID = *md.GetUploadID()
 	}
 
+	UploadedByUserID := ""
+	if md.GetUploadedByUserID() != nil {
+		UploadedByUserID = *md.GetUploadedByUserID()
+	}
+
 	return controller.FileMetadata{
-		ID:         ID,
-		Name:       Name,
-		Size:       Size,
-		BucketID:   BucketID,
-		ETag:       ETag,
-		CreatedAt:  CreatedAt,
-		UpdatedAt:  UpdatedAt,
-		IsUploaded: IsUploaded,
-		MimeType:   MimeType,
-		Metadata:   Metadata,
-		ObjectKey:  ObjectKey,
-		ChunkSize:  ChunkSize,
-		ChunkCount: ChunkCount,
-		UploadID:   UploadID,
+		ID:               ID,
+		Name:             Name,
+		Size:             Size,
+		BucketID:         BucketID,
+		ETag:             ETag,
+		CreatedAt:        CreatedAt,
+		UpdatedAt:        UpdatedAt,
+		IsUploaded:       IsUploaded,
+		MimeType:         MimeType,
+		UploadedByUserID: UploadedByUserID,
+		Metadata:         Metadata,
+		ObjectKey:        ObjectKey,
+		ChunkSize:        ChunkSize,
+		ChunkCount:       ChunkCount,
+		UploadID:         UploadID,
+		DeletedAt:        deref(md.GetDeletedAt()),
+		DeletedByUserID:  deref(md.GetDeletedByUserID()),
+		RetainUntil:      deref(md.GetRetainUntil()),
+		LegalHold:        md.GetLegalHold(),
+		ExpiresAt:        deref(md.GetExpiresAt()),
+	}
+}
+
+func (md *VirusMetadataFragment) ToControllerType() controller.VirusMetadata {
+	return controller.VirusMetadata{
+		ID:            md.GetID(),
+		FileID:        md.GetFileID(),
+		Filename:      md.GetFilename(),
+		BucketID:      md.GetFile().GetBucketID(),
+		Virus:         md.GetVirus(),
+		FalsePositive: md.GetFalsePositive(),
+		UserSession:   md.GetUserSession(),
+		Quarantine:    quarantineFromMap(md.GetQuarantine()),
+		CreatedAt:     md.GetCreatedAt(),
+		UpdatedAt:     md.GetUpdatedAt(),
+	}
+}
+
+// quarantineToMap and quarantineFromMap convert the quarantined file to and from the
+// value of the jsonb column.
+func quarantineToMap(quarantine *controller.QuarantinedFile) map[string]any {
+	if quarantine == nil {
+		return nil
+	}
+
+	b, err := json.Marshal(quarantine)
+	if err != nil {
+		return nil
+	}
+
+	var res map[string]any
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil
+	}
+
+	return res
+}
+
+func quarantineFromMap(m map[string]any) *controller.QuarantinedFile {
+	if len(m) == 0 {
+		return nil
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil
+	}
+
+	var quarantine controller.QuarantinedFile
+	if err := json.Unmarshal(b, &quarantine); err != nil {
+		return nil
+	}
+
+	return &quarantine
+}
+
+// quotasBoolExp matches the quotas that apply to the user, quotas with null columns
+// apply to everybody.
+func quotasBoolExp(userID, role, bucketID string) QuotasBoolExp {
+	where := QuotasBoolExp{
+		And: []*QuotasBoolExp{
+			{
+				Or: []*QuotasBoolExp{
+					{UserID: &UUIDComparisonExp{IsNull: ptr(true)}},
+					{UserID: &UUIDComparisonExp{Eq: ptr(userID)}},
+				},
+			},
+			{
+				Or: []*QuotasBoolExp{
+					{Role: &StringComparisonExp{IsNull: ptr(true)}},
+					{Role: &StringComparisonExp{Eq: ptr(role)}},
+				},
+			},
+		},
+	}
+
+	if bucketID != "" {
+		where.And = append(where.And, &QuotasBoolExp{
+			Or: []*QuotasBoolExp{
+				{BucketID: &StringComparisonExp{IsNull: ptr(true)}},
+				{BucketID: &StringComparisonExp{Eq: ptr(bucketID)}},
+			},
+		})
+	}
+
+	return where
+}
+
+func virusFilterToBoolExp(filter controller.VirusFilter) VirusBoolExp {
+	where := VirusBoolExp{}
+
+	if filter.BucketID != "" {
+		where.File = &FilesBoolExp{
+			BucketID: &StringComparisonExp{Eq: ptr(filter.BucketID)},
+		}
+	}
+
+	if filter.UserID != "" {
+		// the user session is stored as sent by the client so the user id can be
+		// either in the hasura headers or in the claims of the access token
+		where.Or = []*VirusBoolExp{
+			{
+				UserSession: &JsonbComparisonExp{
+					Contains: map[string]any{
+						"hasura_headers": map[string]any{
+							"X-Hasura-User-Id": []string{filter.UserID},
+						},
+					},
+				},
+			},
+			{
+				UserSession: &JsonbComparisonExp{
+					Contains: map[string]any{
+						"access_token_claims": map[string]any{
+							"https://hasura.io/jwt/claims": map[string]any{
+								"x-hasura-user-id": filter.UserID,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	if filter.Virus != "" {
+		where.Virus = &StringComparisonExp{Ilike: ptr("%" + filter.Virus + "%")}
+	}
+
+	if filter.CreatedAfter != "" || filter.CreatedBefore != "" {
+		where.CreatedAt = &TimestamptzComparisonExp{}
+		if filter.CreatedAfter != "" {
+			where.CreatedAt.Gte = ptr(filter.CreatedAfter)
+		}
+		if filter.CreatedBefore != "" {
+			where.CreatedAt.Lt = ptr(filter.CreatedBefore)
+		}
+	}
+
+	if filter.FalsePositive != nil {
+		where.FalsePositive = &BooleanComparisonExp{Eq: filter.FalsePositive}
+	}
+
+	return where
+}
+
+func (md *WebhookEventFragment) ToControllerType() controller.WebhookEvent {
+	return controller.WebhookEvent{
+		ID:            md.GetID(),
+		CreatedAt:     md.GetCreatedAt(),
+		EventType:     md.GetEventType(),
+		BucketID:      deref(md.GetBucketID()),
+		FileID:        deref(md.GetFileID()),
+		Payload:       md.GetPayload(),
+		Attempts:      int(md.GetAttempts()),
+		NextAttemptAt: md.GetNextAttemptAt(),
 	}
 }
 
@@ -187,6 +471,7 @@ func (h *Hasura) InitializeFile(
 	ctx context.Context,
 	fileID, name string, size int64, bucketID, mimeType string,
 	objectKey string, chunkSize int64, chunkCount int64, uploadId string,
+	expiresAt string,
 	headers http.Header,
 ) *controller.APIError {
 	if objectKey == "" {
@@ -208,6 +493,7 @@ func (h *Hasura) InitializeFile(
 			ChunkSize:  ptr(chunkSize),
 			ChunkCount: ptr(chunkCount),
 			UploadID:   ptr(uploadId),
+			ExpiresAt:  optional(expiresAt),
 		},
 		WithHeaders(headers),
 	)
@@ -223,7 +509,9 @@ func (h *Hasura) PopulateMetadata(
 	ctx context.Context,
 	fileID, name string, size int64, bucketID, etag string, isUploaded bool, mimeType string,
 	objectKey string, chunkSize int64, chunkCount int64, uploadId string,
+	uploadedByUserID, uploadedByRole string,
 	metadata map[string]any,
+	events []controller.WebhookEvent,
 	headers http.Header,
 ) (controller.FileMetadata, *controller.APIError) {
 	if objectKey == "" {
@@ -233,34 +521,120 @@ func (h *Hasura) PopulateMetadata(
 		chunkSize = size
 		chunkCount = 1
 	}
-	resp, err := h.cl.UpdateFile(
-		ctx,
-		fileID,
-		FilesSetInput{
-			BucketID:   ptr(bucketID),
-			Etag:       ptr(etag),
-			IsUploaded: ptr(isUploaded),
-			Metadata:   metadata,
-			MimeType:   ptr(mimeType),
-			Name:       ptr(name),
-			Size:       ptr(size),
-			ObjectKey:  ptr(objectKey),
-			ChunkSize:  ptr(chunkSize),
-			ChunkCount: ptr(chunkCount),
-			UploadID:   ptr(uploadId),
-		},
-		WithHeaders(headers),
+	set := FilesSetInput{
+		BucketID:         ptr(bucketID),
+		Etag:             ptr(etag),
+		IsUploaded:       ptr(isUploaded),
+		Metadata:         metadata,
+		MimeType:         ptr(mimeType),
+		Name:             ptr(name),
+		Size:             ptr(size),
+		ObjectKey:        ptr(objectKey),
+		ChunkSize:        ptr(chunkSize),
+		ChunkCount:       ptr(chunkCount),
+		UploadID:         ptr(uploadId),
+		UploadedByUserID: optional(uploadedByUserID),
+		UploadedByRole:   optional(uploadedByRole),
+	}
+
+	var (
+		file   *FileMetadataFragment
+		queued []*WebhookEventFragment
+		err    error
 	)
+	// only admins can queue events so they are left out of the mutation when there are none
+	if len(events) == 0 {
+		var resp *UpdateFile
+		resp, err = h.cl.UpdateFile(ctx, fileID, set, WithHeaders(headers))
+		file = resp.GetUpdateFile()
+	} else {
+		var resp *UpdateFileWithEvents
+		resp, err = h.cl.UpdateFileWithEvents(
+			ctx, fileID, set, webhookEventsToInsertInput(events), WithHeaders(headers),
+		)
+		file = resp.GetUpdateFile()
+		queued = resp.GetInsertWebhookEvents().GetReturning()
+	}
 	if err != nil {
 		aerr := parseGraphqlError(err)
 		return controller.FileMetadata{}, aerr.ExtendError("problem populating file metadata")
 	}
 
-	if resp.UpdateFile == nil || resp.UpdateFile.ID == "" {
+	if file == nil || file.ID == "" {
+		if apiErr := h.discardWebhookEvents(ctx, queued, headers); apiErr != nil {
+			return controller.FileMetadata{}, apiErr
+		}
 		return controller.FileMetadata{}, controller.ErrFileNotFound
 	}
 
-	return resp.UpdateFile.ToControllerType(), nil
+	return file.ToControllerType(), nil
+}
+
+// fileConditionToBoolExp matches the file only while it still is in the version
+// described by the condition.
+func fileConditionToBoolExp(fileID string, condition controller.FileCondition) FilesBoolExp {
+	where := FilesBoolExp{
+		ID: &UUIDComparisonExp{Eq: ptr(fileID)},
+	}
+
+	if condition.ETag != "" {
+		where.Etag = &StringComparisonExp{Eq: ptr(condition.ETag)}
+	}
+
+	if condition.UpdatedAt != "" {
+		where.UpdatedAt = &TimestamptzComparisonExp{Eq: ptr(condition.UpdatedAt)}
+	}
+
+	return where
+}
+
+// errFileNotMatched is returned when a conditional change didn't match any file, files
+// that disappeared in the meantime were modified as well.
+func errFileNotMatched(condition controller.FileCondition) *controller.APIError {
+	if condition.IsZero() {
+		return controller.ErrFileNotFound
+	}
+	return controller.ErrPreconditionFailed
+}
+
+// UpdateFileMetadata doesn't use FilesSetInput as it omits empty maps and the metadata
+// needs to be sent even when it is empty so it can be cleared.
+func (h *Hasura) UpdateFileMetadata(
+	ctx context.Context,
+	fileID, name, bucketID string,
+	metadata map[string]any,
+	condition controller.FileCondition,
+	headers http.Header,
+) (controller.FileMetadata, *controller.APIError) {
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+
+	var resp UpdateFiles
+	if err := h.cl.Client.Post(
+		ctx,
+		"UpdateFiles",
+		UpdateFilesDocument,
+		&resp,
+		map[string]any{
+			"where": fileConditionToBoolExp(fileID, condition),
+			"_set": map[string]any{
+				"name":     name,
+				"bucketId": bucketID,
+				"metadata": metadata,
+			},
+		},
+		WithHeaders(headers),
+	); err != nil {
+		aerr := parseGraphqlError(err)
+		return controller.FileMetadata{}, aerr.ExtendError("problem updating file metadata")
+	}
+
+	if resp.UpdateFiles == nil || len(resp.UpdateFiles.Returning) == 0 {
+		return controller.FileMetadata{}, errFileNotMatched(condition)
+	}
+
+	return resp.UpdateFiles.Returning[0].ToControllerType(), nil
 }
 
 func (h *Hasura) GetFileByID(
@@ -309,11 +683,15 @@ func (h *Hasura) GetFilesByETag(
 }
 
 func (h *Hasura) SetIsUploaded(
-	ctx context.Context, fileID string, isUploaded bool, headers http.Header,
+	ctx context.Context,
+	fileID string,
+	isUploaded bool,
+	condition controller.FileCondition,
+	headers http.Header,
 ) *controller.APIError {
-	resp, err := h.cl.UpdateFile(
+	resp, err := h.cl.UpdateFiles(
 		ctx,
-		fileID,
+		fileConditionToBoolExp(fileID, condition),
 		FilesSetInput{
 			IsUploaded: ptr(isUploaded),
 		},
@@ -324,8 +702,8 @@ func (h *Hasura) SetIsUploaded(
 		return aerr.ExtendError("problem setting file as uploaded")
 	}
 
-	if resp.UpdateFile == nil || resp.UpdateFile.ID == "" {
-		return controller.ErrFileNotFound
+	if resp.UpdateFiles == nil || resp.UpdateFiles.AffectedRows == 0 {
+		return errFileNotMatched(condition)
 	}
 
 	return nil
@@ -334,39 +712,132 @@ func (h *Hasura) SetIsUploaded(
 func (h *Hasura) DeleteFileByID(
 	ctx context.Context,
 	fileID string,
+	condition controller.FileCondition,
+	events []controller.WebhookEvent,
 	headers http.Header,
 ) *controller.APIError {
-	resp, err := h.cl.DeleteFile(
-		ctx,
-		fileID,
-		WithHeaders(headers),
+	where := fileConditionToBoolExp(fileID, condition)
+
+	var (
+		affectedRows int64
+		queued       []*WebhookEventFragment
+		err          error
 	)
+	if len(events) == 0 {
+		var resp *DeleteFiles
+		resp, err = h.cl.DeleteFiles(ctx, where, WithHeaders(headers))
+		affectedRows = resp.GetDeleteFiles().GetAffectedRows()
+	} else {
+		var resp *DeleteFilesWithEvents
+		resp, err = h.cl.DeleteFilesWithEvents(
+			ctx, where, webhookEventsToInsertInput(events), WithHeaders(headers),
+		)
+		affectedRows = resp.GetDeleteFiles().GetAffectedRows()
+		queued = resp.GetInsertWebhookEvents().GetReturning()
+	}
 	if err != nil {
 		aerr := parseGraphqlError(err)
 		return aerr.ExtendError("problem deleting file")
 	}
 
-	if resp.DeleteFile == nil || resp.DeleteFile.ID == "" {
-		return controller.ErrFileNotFound
+	if affectedRows == 0 {
+		if apiErr := h.discardWebhookEvents(ctx, queued, headers); apiErr != nil {
+			return apiErr
+		}
+		return errFileNotMatched(condition)
 	}
 
 	return nil
 }
 
-func (h *Hasura) ListFiles(
+func (h *Hasura) TrashFile(
 	ctx context.Context,
+	fileID, deletedByUserID string,
+	condition controller.FileCondition,
 	headers http.Header,
-) ([]controller.FileSummary, *controller.APIError) {
-	resp, err := h.cl.ListFilesSummary(
+) (controller.FileMetadata, *controller.APIError) {
+	where := fileConditionToBoolExp(fileID, condition)
+	where.DeletedAt = &TimestamptzComparisonExp{IsNull: ptr(true)}
+
+	resp, err := h.cl.UpdateFiles(
 		ctx,
+		where,
+		FilesSetInput{
+			DeletedAt:       ptr(time.Now().UTC().Format(time.RFC3339Nano)),
+			DeletedByUserID: optional(deletedByUserID),
+		},
 		WithHeaders(headers),
 	)
 	if err != nil {
 		aerr := parseGraphqlError(err)
-		return nil, aerr.ExtendError("problem listing files")
+		return controller.FileMetadata{}, aerr.ExtendError("problem moving file to the trash")
 	}
 
-	files := make([]controller.FileSummary, len(resp.Files))
+	if resp.UpdateFiles == nil || len(resp.UpdateFiles.Returning) == 0 {
+		return controller.FileMetadata{}, errFileNotMatched(condition)
+	}
+
+	return resp.UpdateFiles.Returning[0].ToControllerType(), nil
+}
+
+// RestoreFile doesn't use FilesSetInput as it omits nil values and the columns need to
+// be cleared.
+func (h *Hasura) RestoreFile(
+	ctx context.Context,
+	fileID string,
+	events []controller.WebhookEvent,
+	headers http.Header,
+) (controller.FileMetadata, *controller.APIError) {
+	operation, document := "UpdateFiles", UpdateFilesDocument
+	vars := map[string]any{
+		"where": FilesBoolExp{
+			ID:        &UUIDComparisonExp{Eq: ptr(fileID)},
+			DeletedAt: &TimestamptzComparisonExp{IsNull: ptr(false)},
+		},
+		"_set": map[string]any{
+			"deletedAt":       nil,
+			"deletedByUserId": nil,
+		},
+	}
+	if len(events) > 0 {
+		operation, document = "UpdateFilesWithEvents", UpdateFilesWithEventsDocument
+		vars["events"] = webhookEventsToInsertInput(events)
+	}
+
+	// both operations share the shape of the response
+	var resp UpdateFilesWithEvents
+	if err := h.cl.Client.Post(
+		ctx, operation, document, &resp, vars, WithHeaders(headers),
+	); err != nil {
+		aerr := parseGraphqlError(err)
+		return controller.FileMetadata{}, aerr.ExtendError("problem restoring file")
+	}
+
+	if resp.UpdateFiles == nil || len(resp.UpdateFiles.Returning) == 0 {
+		queued := resp.GetInsertWebhookEvents().GetReturning()
+		if apiErr := h.discardWebhookEvents(ctx, queued, headers); apiErr != nil {
+			return controller.FileMetadata{}, apiErr
+		}
+		return controller.FileMetadata{}, controller.ErrFileNotFound
+	}
+
+	return resp.UpdateFiles.Returning[0].ToControllerType(), nil
+}
+
+func (h *Hasura) ListTrashedFiles(
+	ctx context.Context,
+	filter controller.FileFilter,
+	headers http.Header,
+) ([]controller.FileMetadata, *controller.APIError) {
+	filter.Trashed = ptr(true)
+
+	resp, err := h.cl.ListDeletedFiles(ctx, fileFilterToBoolExp(filter), WithHeaders(headers))
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return nil, aerr.ExtendError("problem listing trashed files")
+	}
+
+	files := make([]controller.FileMetadata, len(resp.Files))
 	for i, f := range resp.Files {
 		files[i] = f.ToControllerType()
 	}
@@ -374,26 +845,718 @@ func (h *Hasura) ListFiles(
 	return files, nil
 }
 
-func (h *Hasura) InsertVirus(
+// ListExpiredTrash returns the files that have been in the trash for longer than the
+// retention period of their bucket, only ID, BucketID, ObjectKey and UpdatedAt are
+// populated
+func (h *Hasura) ListExpiredTrash(
 	ctx context.Context,
-	fileID, filename, virus string,
-	userSession map[string]any,
+	limit int,
 	headers http.Header,
-) *controller.APIError {
-	_, err := h.cl.InsertVirus(
+) ([]controller.FileMetadata, *controller.APIError) {
+	resp, err := h.cl.ListExpiredTrash(ctx, int64(limit), WithHeaders(headers))
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return nil, aerr.ExtendError("problem listing expired trash")
+	}
+
+	files := make([]controller.FileMetadata, len(resp.ExpiredTrash))
+	for i, f := range resp.ExpiredTrash {
+		files[i] = controller.FileMetadata{ //nolint: exhaustruct
+			ID:        deref(f.GetID()),
+			BucketID:  deref(f.GetBucketID()),
+			ObjectKey: deref(f.GetObjectKey()),
+			UpdatedAt: deref(f.GetUpdatedAt()),
+		}
+	}
+
+	return files, nil
+}
+
+func (h *Hasura) ListExpiredFiles(
+	ctx context.Context,
+	limit int,
+	headers http.Header,
+) ([]controller.FileMetadata, *controller.APIError) {
+	resp, err := h.cl.ListExpiredFiles(ctx, int64(limit), WithHeaders(headers))
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return nil, aerr.ExtendError("problem listing expired files")
+	}
+
+	files := make([]controller.FileMetadata, len(resp.ExpiredFiles))
+	for i, f := range resp.ExpiredFiles {
+		files[i] = controller.FileMetadata{ //nolint: exhaustruct
+			ID:        deref(f.GetID()),
+			BucketID:  deref(f.GetBucketID()),
+			ObjectKey: deref(f.GetObjectKey()),
+			UpdatedAt: deref(f.GetUpdatedAt()),
+		}
+	}
+
+	return files, nil
+}
+
+func (h *Hasura) SetLegalHold(
+	ctx context.Context,
+	fileID string,
+	legalHold bool,
+	event controller.FileAuditEvent,
+	headers http.Header,
+) (controller.FileMetadata, *controller.APIError) {
+	resp, err := h.cl.SetLegalHold(
 		ctx,
-		VirusInsertInput{
-			FileID:      ptr(fileID),
-			Filename:    ptr(filename),
-			UserSession: userSession,
-			Virus:       ptr(virus),
+		fileID,
+		legalHold,
+		FileAuditEventsInsertInput{ //nolint: exhaustruct
+			FileID:   ptr(fileID),
+			Action:   ptr(event.Action),
+			Reason:   optional(event.Reason),
+			UserID:   optional(event.UserID),
+			APIKeyID: optional(event.APIKeyID),
 		},
 		WithHeaders(headers),
 	)
 	if err != nil {
 		aerr := parseGraphqlError(err)
-		return aerr.ExtendError("problem inserting virus")
+		return controller.FileMetadata{}, aerr.ExtendError("problem setting legal hold")
 	}
 
-	return nil
+	if resp.UpdateFile == nil {
+		return controller.FileMetadata{}, controller.ErrFileNotFound
+	}
+
+	return resp.UpdateFile.ToControllerType(), nil
+}
+
+func fileFilterToBoolExp(filter controller.FileFilter) FilesBoolExp {
+	where := FilesBoolExp{}
+
+	if filter.UploadedBy != "" {
+		where.UploadedByUserID = &UUIDComparisonExp{Eq: ptr(filter.UploadedBy)}
+	}
+
+	if filter.BucketID != "" {
+		where.BucketID = &StringComparisonExp{Eq: ptr(filter.BucketID)}
+	}
+
+	if filter.ObjectPrefix != "" {
+		where.ObjectKey = &StringComparisonExp{Like: ptr(escapeLike(filter.ObjectPrefix) + "%")}
+	}
+
+	if filter.Trashed != nil {
+		where.DeletedAt = &TimestamptzComparisonExp{IsNull: ptr(!*filter.Trashed)}
+	}
+
+	if filter.Uploaded != nil {
+		where.IsUploaded = &BooleanComparisonExp{Eq: filter.Uploaded}
+	}
+
+	return where
+}
+
+func (h *Hasura) ListFiles(
+	ctx context.Context,
+	filter controller.FileFilter,
+	headers http.Header,
+) ([]controller.FileSummary, *controller.APIError) {
+	var limit *int64
+	if filter.Limit > 0 {
+		limit = ptr(int64(filter.Limit))
+	}
+
+	resp, err := h.cl.ListFilesSummary(
+		ctx,
+		fileFilterToBoolExp(filter),
+		limit,
+		WithHeaders(headers),
+	)
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return nil, aerr.ExtendError("problem listing files")
+	}
+
+	files := make([]controller.FileSummary, len(resp.Files))
+	for i, f := range resp.Files {
+		files[i] = f.ToControllerType()
+	}
+
+	return files, nil
+}
+
+func (h *Hasura) InsertVirus(
+	ctx context.Context,
+	virus controller.VirusMetadata,
+	events []controller.WebhookEvent,
+	headers http.Header,
+) *controller.APIError {
+	object := VirusInsertInput{
+		ID:          optional(virus.ID),
+		FileID:      ptr(virus.FileID),
+		Filename:    ptr(virus.Filename),
+		UserSession: virus.UserSession,
+		Virus:       ptr(virus.Virus),
+		Quarantine:  quarantineToMap(virus.Quarantine),
+	}
+
+	var err error
+	if len(events) == 0 {
+		_, err = h.cl.InsertVirus(ctx, object, WithHeaders(headers))
+	} else {
+		_, err = h.cl.InsertVirusWithEvents(
+			ctx, object, webhookEventsToInsertInput(events), WithHeaders(headers),
+		)
+	}
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return aerr.ExtendError("problem inserting virus")
+	}
+
+	return nil
+}
+
+func (h *Hasura) ListViruses(
+	ctx context.Context,
+	filter controller.VirusFilter,
+	headers http.Header,
+) ([]controller.VirusMetadata, *controller.APIError) {
+	resp, err := h.cl.ListViruses(
+		ctx,
+		virusFilterToBoolExp(filter),
+		WithHeaders(headers),
+	)
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return nil, aerr.ExtendError("problem listing viruses")
+	}
+
+	viruses := make([]controller.VirusMetadata, len(resp.Viruses))
+	for i, v := range resp.Viruses {
+		viruses[i] = v.ToControllerType()
+	}
+
+	return viruses, nil
+}
+
+func (h *Hasura) GetVirusByID(
+	ctx context.Context,
+	id string,
+	headers http.Header,
+) (controller.VirusMetadata, *controller.APIError) {
+	resp, err := h.cl.GetVirus(
+		ctx,
+		id,
+		WithHeaders(headers),
+	)
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return controller.VirusMetadata{}, aerr.ExtendError("problem getting virus")
+	}
+
+	if resp.Virus == nil || resp.Virus.ID == "" {
+		return controller.VirusMetadata{}, controller.ErrVirusNotFound
+	}
+
+	return resp.Virus.ToControllerType(), nil
+}
+
+func (h *Hasura) SetVirusFalsePositive(
+	ctx context.Context,
+	id string,
+	falsePositive bool,
+	headers http.Header,
+) *controller.APIError {
+	resp, err := h.cl.UpdateVirus(
+		ctx,
+		id,
+		VirusSetInput{
+			FalsePositive: ptr(falsePositive),
+		},
+		WithHeaders(headers),
+	)
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return aerr.ExtendError("problem updating virus")
+	}
+
+	if resp.UpdateVirus == nil || resp.UpdateVirus.ID == "" {
+		return controller.ErrVirusNotFound
+	}
+
+	return nil
+}
+
+func (h *Hasura) DeleteVirusesByFileID(
+	ctx context.Context,
+	fileID string,
+	headers http.Header,
+) *controller.APIError {
+	_, err := h.cl.DeleteVirusesByFileID(
+		ctx,
+		fileID,
+		WithHeaders(headers),
+	)
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return aerr.ExtendError("problem deleting viruses")
+	}
+
+	return nil
+}
+
+func webhookEventsToInsertInput(events []controller.WebhookEvent) []*WebhookEventsInsertInput {
+	objects := make([]*WebhookEventsInsertInput, len(events))
+	for i, e := range events {
+		objects[i] = &WebhookEventsInsertInput{ //nolint: exhaustruct
+			EventType: ptr(e.EventType),
+			BucketID:  optional(e.BucketID),
+			FileID:    optional(e.FileID),
+			Payload:   e.Payload,
+		}
+	}
+	return objects
+}
+
+// discardWebhookEvents removes the events queued along with a change that didn't match
+// any file so receivers aren't told about something that didn't happen.
+func (h *Hasura) discardWebhookEvents(
+	ctx context.Context,
+	events []*WebhookEventFragment,
+	headers http.Header,
+) *controller.APIError {
+	if len(events) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(events))
+	for i, e := range events {
+		ids[i] = e.GetID()
+	}
+
+	if _, err := h.cl.DeleteWebhookEvents(ctx, ids, WithHeaders(headers)); err != nil {
+		aerr := parseGraphqlError(err)
+		return aerr.ExtendError("problem discarding webhook events")
+	}
+
+	return nil
+}
+
+func (h *Hasura) InsertWebhookEvents(
+	ctx context.Context,
+	events []controller.WebhookEvent,
+	headers http.Header,
+) *controller.APIError {
+	if _, err := h.cl.InsertWebhookEvents(
+		ctx, webhookEventsToInsertInput(events), WithHeaders(headers),
+	); err != nil {
+		aerr := parseGraphqlError(err)
+		return aerr.ExtendError("problem inserting webhook events")
+	}
+
+	return nil
+}
+
+func (h *Hasura) ListPendingWebhookEvents(
+	ctx context.Context,
+	now time.Time,
+	maxAttempts, limit int,
+	headers http.Header,
+) ([]controller.WebhookEvent, *controller.APIError) {
+	resp, err := h.cl.ListPendingWebhookEvents(
+		ctx,
+		now.Format(time.RFC3339Nano),
+		int64(maxAttempts),
+		int64(limit),
+		WithHeaders(headers),
+	)
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return nil, aerr.ExtendError("problem listing webhook events")
+	}
+
+	events := make([]controller.WebhookEvent, len(resp.WebhookEvents))
+	for i, e := range resp.WebhookEvents {
+		events[i] = e.ToControllerType()
+	}
+
+	return events, nil
+}
+
+func (h *Hasura) ClaimWebhookEvent(
+	ctx context.Context,
+	id, nextAttemptAt string,
+	leaseUntil time.Time,
+	headers http.Header,
+) (bool, *controller.APIError) {
+	resp, err := h.cl.ClaimWebhookEvent(
+		ctx,
+		id,
+		nextAttemptAt,
+		leaseUntil.Format(time.RFC3339Nano),
+		WithHeaders(headers),
+	)
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return false, aerr.ExtendError("problem claiming webhook event")
+	}
+
+	return resp.GetUpdateWebhookEvents().GetAffectedRows() == 1, nil
+}
+
+func (h *Hasura) SetWebhookEventDelivered(
+	ctx context.Context,
+	id string,
+	attempts int,
+	headers http.Header,
+) *controller.APIError {
+	_, err := h.cl.UpdateWebhookEvent(
+		ctx,
+		id,
+		WebhookEventsSetInput{
+			Attempts:    ptr(int64(attempts)),
+			DeliveredAt: ptr(time.Now().Format(time.RFC3339Nano)),
+		},
+		WithHeaders(headers),
+	)
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return aerr.ExtendError("problem flagging webhook event as delivered")
+	}
+
+	return nil
+}
+
+func (h *Hasura) SetWebhookEventFailed(
+	ctx context.Context,
+	id string,
+	attempts int,
+	nextAttemptAt time.Time,
+	lastError string,
+	headers http.Header,
+) *controller.APIError {
+	_, err := h.cl.UpdateWebhookEvent(
+		ctx,
+		id,
+		WebhookEventsSetInput{
+			Attempts:      ptr(int64(attempts)),
+			NextAttemptAt: ptr(nextAttemptAt.Format(time.RFC3339Nano)),
+			LastError:     ptr(lastError),
+		},
+		WithHeaders(headers),
+	)
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return aerr.ExtendError("problem flagging webhook event as failed")
+	}
+
+	return nil
+}
+
+func (h *Hasura) GetWebhookSecret(
+	ctx context.Context,
+	bucketID string,
+	headers http.Header,
+) (string, *controller.APIError) {
+	resp, err := h.cl.GetWebhookSecret(
+		ctx,
+		bucketID,
+		WithHeaders(headers),
+	)
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return "", aerr.ExtendError("problem getting webhook secret")
+	}
+
+	return resp.GetWebhookSecret().GetSecret(), nil
+}
+
+func (h *Hasura) GetQuotas(
+	ctx context.Context,
+	userID, role, bucketID string,
+	headers http.Header,
+) ([]controller.Quota, *controller.APIError) {
+	resp, err := h.cl.GetQuotas(
+		ctx,
+		quotasBoolExp(userID, role, bucketID),
+		WithHeaders(headers),
+	)
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return nil, aerr.ExtendError("problem getting quotas")
+	}
+
+	quotas := make([]controller.Quota, len(resp.Quotas))
+	for i, q := range resp.Quotas {
+		quotas[i] = q.ToControllerType()
+	}
+
+	return quotas, nil
+}
+
+func (h *Hasura) GetUsage(
+	ctx context.Context,
+	userID string,
+	headers http.Header,
+) ([]controller.Usage, *controller.APIError) {
+	resp, err := h.cl.GetUsage(
+		ctx,
+		userID,
+		WithHeaders(headers),
+	)
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return nil, aerr.ExtendError("problem getting usage")
+	}
+
+	usage := make([]controller.Usage, len(resp.Usages))
+	for i, u := range resp.Usages {
+		usage[i] = u.ToControllerType()
+	}
+
+	return usage, nil
+}
+
+func (h *Hasura) GetAPIKeyByHash(
+	ctx context.Context,
+	keyHash string,
+	headers http.Header,
+) (controller.APIKey, *controller.APIError) {
+	resp, err := h.cl.GetAPIKeyByHash(
+		ctx,
+		keyHash,
+		WithHeaders(headers),
+	)
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return controller.APIKey{}, aerr.ExtendError("problem getting api key")
+	}
+
+	if len(resp.APIKeys) == 0 {
+		return controller.APIKey{}, controller.ErrAPIKeyNotFound
+	}
+
+	return resp.APIKeys[0].ToControllerType(), nil
+}
+
+func (h *Hasura) SetAPIKeyLastUsed(
+	ctx context.Context,
+	id string,
+	headers http.Header,
+) *controller.APIError {
+	resp, err := h.cl.SetAPIKeyLastUsed(
+		ctx,
+		id,
+		time.Now().Format(time.RFC3339Nano),
+		WithHeaders(headers),
+	)
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return aerr.ExtendError("problem setting api key last used")
+	}
+
+	if resp.UpdateAPIKey == nil || resp.UpdateAPIKey.ID == "" {
+		return controller.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+func (h *Hasura) InsertShare(
+	ctx context.Context,
+	share controller.Share,
+	tokenHash string,
+	headers http.Header,
+) (controller.Share, *controller.APIError) {
+	object := SharesInsertInput{
+		FileID:          ptr(share.FileID),
+		TokenHash:       ptr(tokenHash),
+		CreatedByUserID: optional(share.CreatedByUserID),
+		ExpiresAt:       optional(share.ExpiresAt),
+		PasswordHash:    optional(share.PasswordHash),
+	}
+	if share.MaxDownloads > 0 {
+		object.MaxDownloads = ptr(share.MaxDownloads)
+	}
+	if len(share.AllowedTransformations) > 0 {
+		object.AllowedTransformations = ptr(strings.Join(share.AllowedTransformations, ","))
+	}
+
+	resp, err := h.cl.InsertShare(ctx, object, WithHeaders(headers))
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return controller.Share{}, aerr.ExtendError("problem inserting share")
+	}
+
+	return resp.InsertShare.ToControllerType(), nil
+}
+
+func (h *Hasura) GetShareByTokenHash(
+	ctx context.Context,
+	tokenHash string,
+	headers http.Header,
+) (controller.Share, *controller.APIError) {
+	resp, err := h.cl.GetShareByTokenHash(
+		ctx,
+		tokenHash,
+		WithHeaders(headers),
+	)
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return controller.Share{}, aerr.ExtendError("problem getting share")
+	}
+
+	if len(resp.Shares) == 0 {
+		return controller.Share{}, controller.ErrShareNotFound
+	}
+
+	return resp.Shares[0].ToControllerType(), nil
+}
+
+func (h *Hasura) DeleteShare(
+	ctx context.Context,
+	id, fileID, createdByUserID string,
+	headers http.Header,
+) *controller.APIError {
+	where := SharesBoolExp{
+		ID:     &UUIDComparisonExp{Eq: ptr(id)},
+		FileID: &UUIDComparisonExp{Eq: ptr(fileID)},
+	}
+	if createdByUserID != "" {
+		where.CreatedByUserID = &UUIDComparisonExp{Eq: ptr(createdByUserID)}
+	}
+
+	resp, err := h.cl.DeleteShares(ctx, where, WithHeaders(headers))
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return aerr.ExtendError("problem deleting share")
+	}
+
+	if resp.DeleteShares == nil || resp.DeleteShares.AffectedRows == 0 {
+		return controller.ErrShareNotFound
+	}
+
+	return nil
+}
+
+func (h *Hasura) IncrementShareDownloads(
+	ctx context.Context,
+	id string,
+	maxDownloads int64,
+	headers http.Header,
+) (bool, *controller.APIError) {
+	if maxDownloads <= 0 {
+		maxDownloads = math.MaxInt32
+	}
+
+	resp, err := h.cl.IncrementShareDownloads(ctx, id, maxDownloads, WithHeaders(headers))
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return false, aerr.ExtendError("problem incrementing share downloads")
+	}
+
+	return resp.UpdateShares != nil && resp.UpdateShares.AffectedRows > 0, nil
+}
+
+func (h *Hasura) InsertFileVersion(
+	ctx context.Context,
+	version controller.FileVersion,
+	headers http.Header,
+) (controller.FileVersion, *controller.APIError) {
+	object := FileVersionsInsertInput{
+		ID:               optional(version.ID),
+		FileID:           ptr(version.FileID),
+		Name:             ptr(version.Name),
+		Size:             ptr(version.Size),
+		MimeType:         ptr(version.MimeType),
+		Etag:             ptr(version.ETag),
+		ObjectKey:        ptr(version.ObjectKey),
+		Metadata:         version.Metadata,
+		UploadedByUserID: optional(version.UploadedByUserID),
+	}
+
+	resp, err := h.cl.InsertFileVersion(ctx, object, WithHeaders(headers))
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return controller.FileVersion{}, aerr.ExtendError("problem inserting file version")
+	}
+
+	return resp.InsertFileVersion.ToControllerType(), nil
+}
+
+func (h *Hasura) ListFileVersions(
+	ctx context.Context,
+	fileID string,
+	headers http.Header,
+) ([]controller.FileVersion, *controller.APIError) {
+	resp, err := h.cl.ListFileVersions(ctx, fileID, WithHeaders(headers))
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return nil, aerr.ExtendError("problem listing file versions")
+	}
+
+	versions := make([]controller.FileVersion, len(resp.FileVersions))
+	for i, v := range resp.FileVersions {
+		versions[i] = v.ToControllerType()
+	}
+
+	return versions, nil
+}
+
+func (h *Hasura) GetFileVersionByID(
+	ctx context.Context,
+	id string,
+	headers http.Header,
+) (controller.FileVersion, *controller.APIError) {
+	resp, err := h.cl.GetFileVersion(ctx, id, WithHeaders(headers))
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return controller.FileVersion{}, aerr.ExtendError("problem getting file version")
+	}
+
+	if resp.FileVersion == nil {
+		return controller.FileVersion{}, controller.ErrFileVersionNotFound
+	}
+
+	return resp.FileVersion.ToControllerType(), nil
+}
+
+func (h *Hasura) DeleteFileVersion(
+	ctx context.Context,
+	id string,
+	headers http.Header,
+) *controller.APIError {
+	resp, err := h.cl.DeleteFileVersion(ctx, id, WithHeaders(headers))
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return aerr.ExtendError("problem deleting file version")
+	}
+
+	if resp.DeleteFileVersion == nil {
+		return controller.ErrFileVersionNotFound
+	}
+
+	return nil
+}
+
+// ListExpiredFileVersions returns the versions that fall outside of the retention
+// policy of their bucket, only ID and ObjectKey are populated
+func (h *Hasura) ListExpiredFileVersions(
+	ctx context.Context,
+	limit int,
+	headers http.Header,
+) ([]controller.FileVersion, *controller.APIError) {
+	resp, err := h.cl.ListExpiredFileVersions(ctx, int64(limit), WithHeaders(headers))
+	if err != nil {
+		aerr := parseGraphqlError(err)
+		return nil, aerr.ExtendError("problem listing expired file versions")
+	}
+
+	versions := make([]controller.FileVersion, len(resp.ExpiredFileVersions))
+	for i, v := range resp.ExpiredFileVersions {
+		versions[i] = controller.FileVersion{ //nolint: exhaustruct
+			ID:        deref(v.GetID()),
+			ObjectKey: deref(v.GetObjectKey()),
+		}
+	}
+
+	return versions, nil
 }
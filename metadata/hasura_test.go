@@ -413,6 +413,7 @@ func TestSetIsUploaded(t *testing.T) {
 	cases := []struct {
 		name                   string
 		fileID                 string
+		condition              controller.FileCondition
 		headers                http.Header
 		expectedStatusCode     int
 		expectedPublicResponse *controller.ErrorResponse
@@ -424,6 +425,16 @@ func TestSetIsUploaded(t *testing.T) {
 			expectedStatusCode:     0,
 			expectedPublicResponse: &controller.ErrorResponse{},
 		},
+		{
+			name:               "file modified",
+			fileID:             fileID,
+			condition:          controller.FileCondition{ETag: `"not-the-etag"`}, //nolint: exhaustruct
+			headers:            getAuthHeader(),
+			expectedStatusCode: http.StatusPreconditionFailed,
+			expectedPublicResponse: &controller.ErrorResponse{
+				Message: "file was modified",
+			},
+		},
 		{
 			name:               "file not found",
 			fileID:             "aaaaaaaa-1111-bbbb-2222-cccccccccccc",
@@ -457,7 +468,9 @@ func TestSetIsUploaded(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			err := hasura.SetIsUploaded(context.Background(), tc.fileID, true, tc.headers)
+			err := hasura.SetIsUploaded(
+				context.Background(), tc.fileID, true, tc.condition, tc.headers,
+			)
 			if tc.expectedStatusCode != err.StatusCode() {
 				t.Errorf(
 					"wrong status code, expected %d, got %d",
@@ -536,7 +549,9 @@ func TestDeleteFileByID(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			err := hasura.DeleteFileByID(context.Background(), tc.fileID, tc.headers)
+			err := hasura.DeleteFileByID(
+				context.Background(), tc.fileID, controller.FileCondition{}, tc.headers,
+			)
 			if tc.expectedStatusCode != err.StatusCode() {
 				t.Errorf(
 					"wrong status code, expected %d, got %d",
@@ -8,6 +8,112 @@ import (
 	"strconv"
 )
 
+// columns and relationships of "storage.api_keys"
+type APIKeys struct {
+	Buckets    *string `json:"buckets,omitempty"`
+	CreatedAt  string  `json:"createdAt"`
+	ExpiresAt  *string `json:"expiresAt,omitempty"`
+	ID         string  `json:"id"`
+	KeyHash    string  `json:"keyHash"`
+	LastUsedAt *string `json:"lastUsedAt,omitempty"`
+	Name       string  `json:"name"`
+	Operations string  `json:"operations"`
+	Role       string  `json:"role"`
+	UpdatedAt  string  `json:"updatedAt"`
+}
+
+// Boolean expression to filter rows from the table "storage.api_keys". All fields are combined with a logical 'AND'.
+type APIKeysBoolExp struct {
+	And        []*APIKeysBoolExp         `json:"_and,omitempty"`
+	Not        *APIKeysBoolExp           `json:"_not,omitempty"`
+	Or         []*APIKeysBoolExp         `json:"_or,omitempty"`
+	Buckets    *StringComparisonExp      `json:"buckets,omitempty"`
+	CreatedAt  *TimestamptzComparisonExp `json:"createdAt,omitempty"`
+	ExpiresAt  *TimestamptzComparisonExp `json:"expiresAt,omitempty"`
+	ID         *UUIDComparisonExp        `json:"id,omitempty"`
+	KeyHash    *StringComparisonExp      `json:"keyHash,omitempty"`
+	LastUsedAt *TimestamptzComparisonExp `json:"lastUsedAt,omitempty"`
+	Name       *StringComparisonExp      `json:"name,omitempty"`
+	Operations *StringComparisonExp      `json:"operations,omitempty"`
+	Role       *StringComparisonExp      `json:"role,omitempty"`
+	UpdatedAt  *TimestamptzComparisonExp `json:"updatedAt,omitempty"`
+}
+
+// input type for inserting data into table "storage.api_keys"
+type APIKeysInsertInput struct {
+	Buckets    *string `json:"buckets,omitempty"`
+	CreatedAt  *string `json:"createdAt,omitempty"`
+	ExpiresAt  *string `json:"expiresAt,omitempty"`
+	ID         *string `json:"id,omitempty"`
+	KeyHash    *string `json:"keyHash,omitempty"`
+	LastUsedAt *string `json:"lastUsedAt,omitempty"`
+	Name       *string `json:"name,omitempty"`
+	Operations *string `json:"operations,omitempty"`
+	Role       *string `json:"role,omitempty"`
+	UpdatedAt  *string `json:"updatedAt,omitempty"`
+}
+
+// response of any mutation on the table "storage.api_keys"
+type APIKeysMutationResponse struct {
+	// number of rows affected by the mutation
+	AffectedRows int64 `json:"affected_rows"`
+	// data from the rows affected by the mutation
+	Returning []*APIKeys `json:"returning"`
+}
+
+// on_conflict condition type for table "storage.api_keys"
+type APIKeysOnConflict struct {
+	Constraint    APIKeysConstraint     `json:"constraint"`
+	UpdateColumns []APIKeysUpdateColumn `json:"update_columns"`
+	Where         *APIKeysBoolExp       `json:"where,omitempty"`
+}
+
+// Ordering options when selecting data from "storage.api_keys".
+type APIKeysOrderBy struct {
+	Buckets    *OrderBy `json:"buckets,omitempty"`
+	CreatedAt  *OrderBy `json:"createdAt,omitempty"`
+	ExpiresAt  *OrderBy `json:"expiresAt,omitempty"`
+	ID         *OrderBy `json:"id,omitempty"`
+	KeyHash    *OrderBy `json:"keyHash,omitempty"`
+	LastUsedAt *OrderBy `json:"lastUsedAt,omitempty"`
+	Name       *OrderBy `json:"name,omitempty"`
+	Operations *OrderBy `json:"operations,omitempty"`
+	Role       *OrderBy `json:"role,omitempty"`
+	UpdatedAt  *OrderBy `json:"updatedAt,omitempty"`
+}
+
+// primary key columns input for table: storage.api_keys
+type APIKeysPkColumnsInput struct {
+	ID string `json:"id"`
+}
+
+// input type for updating data in table "storage.api_keys"
+type APIKeysSetInput struct {
+	Buckets    *string `json:"buckets,omitempty"`
+	CreatedAt  *string `json:"createdAt,omitempty"`
+	ExpiresAt  *string `json:"expiresAt,omitempty"`
+	ID         *string `json:"id,omitempty"`
+	KeyHash    *string `json:"keyHash,omitempty"`
+	LastUsedAt *string `json:"lastUsedAt,omitempty"`
+	Name       *string `json:"name,omitempty"`
+	Operations *string `json:"operations,omitempty"`
+	Role       *string `json:"role,omitempty"`
+	UpdatedAt  *string `json:"updatedAt,omitempty"`
+}
+
+// Boolean expression to compare columns of type "bigint". All fields are combined with logical 'AND'.
+type BigintComparisonExp struct {
+	Eq     *int64  `json:"_eq,omitempty"`
+	Gt     *int64  `json:"_gt,omitempty"`
+	Gte    *int64  `json:"_gte,omitempty"`
+	In     []int64 `json:"_in,omitempty"`
+	IsNull *bool   `json:"_is_null,omitempty"`
+	Lt     *int64  `json:"_lt,omitempty"`
+	Lte    *int64  `json:"_lte,omitempty"`
+	Neq    *int64  `json:"_neq,omitempty"`
+	Nin    []int64 `json:"_nin,omitempty"`
+}
+
 // Boolean expression to compare columns of type "Boolean". All fields are combined with logical 'AND'.
 type BooleanComparisonExp struct {
 	Eq     *bool  `json:"_eq,omitempty"`
@@ -21,6 +127,269 @@ type BooleanComparisonExp struct {
 	Nin    []bool `json:"_nin,omitempty"`
 }
 
+// columns and relationships of "storage.expired_file_versions"
+type ExpiredFileVersions struct {
+	CreatedAt *string `json:"createdAt,omitempty"`
+	FileID    *string `json:"fileId,omitempty"`
+	ID        *string `json:"id,omitempty"`
+	ObjectKey *string `json:"objectKey,omitempty"`
+}
+
+// Boolean expression to filter rows from the table "storage.expired_file_versions". All fields are combined with a logical 'AND'.
+type ExpiredFileVersionsBoolExp struct {
+	And       []*ExpiredFileVersionsBoolExp `json:"_and,omitempty"`
+	Not       *ExpiredFileVersionsBoolExp   `json:"_not,omitempty"`
+	Or        []*ExpiredFileVersionsBoolExp `json:"_or,omitempty"`
+	CreatedAt *TimestamptzComparisonExp     `json:"createdAt,omitempty"`
+	FileID    *UUIDComparisonExp            `json:"fileId,omitempty"`
+	ID        *UUIDComparisonExp            `json:"id,omitempty"`
+	ObjectKey *StringComparisonExp          `json:"objectKey,omitempty"`
+}
+
+// Ordering options when selecting data from "storage.expired_file_versions".
+type ExpiredFileVersionsOrderBy struct {
+	CreatedAt *OrderBy `json:"createdAt,omitempty"`
+	FileID    *OrderBy `json:"fileId,omitempty"`
+	ID        *OrderBy `json:"id,omitempty"`
+	ObjectKey *OrderBy `json:"objectKey,omitempty"`
+}
+
+// columns and relationships of "storage.expired_files"
+type ExpiredFiles struct {
+	BucketID  *string `json:"bucketId,omitempty"`
+	ExpiresAt *string `json:"expiresAt,omitempty"`
+	ID        *string `json:"id,omitempty"`
+	ObjectKey *string `json:"objectKey,omitempty"`
+	UpdatedAt *string `json:"updatedAt,omitempty"`
+}
+
+// Boolean expression to filter rows from the table "storage.expired_files". All fields are combined with a logical 'AND'.
+type ExpiredFilesBoolExp struct {
+	And       []*ExpiredFilesBoolExp    `json:"_and,omitempty"`
+	Not       *ExpiredFilesBoolExp      `json:"_not,omitempty"`
+	Or        []*ExpiredFilesBoolExp    `json:"_or,omitempty"`
+	BucketID  *StringComparisonExp      `json:"bucketId,omitempty"`
+	ExpiresAt *TimestamptzComparisonExp `json:"expiresAt,omitempty"`
+	ID        *UUIDComparisonExp        `json:"id,omitempty"`
+	ObjectKey *StringComparisonExp      `json:"objectKey,omitempty"`
+	UpdatedAt *TimestamptzComparisonExp `json:"updatedAt,omitempty"`
+}
+
+// Ordering options when selecting data from "storage.expired_files".
+type ExpiredFilesOrderBy struct {
+	BucketID  *OrderBy `json:"bucketId,omitempty"`
+	ExpiresAt *OrderBy `json:"expiresAt,omitempty"`
+	ID        *OrderBy `json:"id,omitempty"`
+	ObjectKey *OrderBy `json:"objectKey,omitempty"`
+	UpdatedAt *OrderBy `json:"updatedAt,omitempty"`
+}
+
+// columns and relationships of "storage.expired_trash"
+type ExpiredTrash struct {
+	BucketID  *string `json:"bucketId,omitempty"`
+	DeletedAt *string `json:"deletedAt,omitempty"`
+	ID        *string `json:"id,omitempty"`
+	ObjectKey *string `json:"objectKey,omitempty"`
+	UpdatedAt *string `json:"updatedAt,omitempty"`
+}
+
+// Boolean expression to filter rows from the table "storage.expired_trash". All fields are combined with a logical 'AND'.
+type ExpiredTrashBoolExp struct {
+	And       []*ExpiredTrashBoolExp    `json:"_and,omitempty"`
+	Not       *ExpiredTrashBoolExp      `json:"_not,omitempty"`
+	Or        []*ExpiredTrashBoolExp    `json:"_or,omitempty"`
+	BucketID  *StringComparisonExp      `json:"bucketId,omitempty"`
+	DeletedAt *TimestamptzComparisonExp `json:"deletedAt,omitempty"`
+	ID        *UUIDComparisonExp        `json:"id,omitempty"`
+	ObjectKey *StringComparisonExp      `json:"objectKey,omitempty"`
+	UpdatedAt *TimestamptzComparisonExp `json:"updatedAt,omitempty"`
+}
+
+// Ordering options when selecting data from "storage.expired_trash".
+type ExpiredTrashOrderBy struct {
+	BucketID  *OrderBy `json:"bucketId,omitempty"`
+	DeletedAt *OrderBy `json:"deletedAt,omitempty"`
+	ID        *OrderBy `json:"id,omitempty"`
+	ObjectKey *OrderBy `json:"objectKey,omitempty"`
+	UpdatedAt *OrderBy `json:"updatedAt,omitempty"`
+}
+
+// columns and relationships of "storage.file_audit_events"
+type FileAuditEvents struct {
+	Action    string  `json:"action"`
+	APIKeyID  *string `json:"apiKeyId,omitempty"`
+	CreatedAt string  `json:"createdAt"`
+	FileID    string  `json:"fileId"`
+	ID        string  `json:"id"`
+	Reason    *string `json:"reason,omitempty"`
+	UserID    *string `json:"userId,omitempty"`
+}
+
+// Boolean expression to filter rows from the table "storage.file_audit_events". All fields are combined with a logical 'AND'.
+type FileAuditEventsBoolExp struct {
+	And       []*FileAuditEventsBoolExp `json:"_and,omitempty"`
+	Not       *FileAuditEventsBoolExp   `json:"_not,omitempty"`
+	Or        []*FileAuditEventsBoolExp `json:"_or,omitempty"`
+	Action    *StringComparisonExp      `json:"action,omitempty"`
+	APIKeyID  *UUIDComparisonExp        `json:"apiKeyId,omitempty"`
+	CreatedAt *TimestamptzComparisonExp `json:"createdAt,omitempty"`
+	FileID    *UUIDComparisonExp        `json:"fileId,omitempty"`
+	ID        *UUIDComparisonExp        `json:"id,omitempty"`
+	Reason    *StringComparisonExp      `json:"reason,omitempty"`
+	UserID    *UUIDComparisonExp        `json:"userId,omitempty"`
+}
+
+// input type for inserting data into table "storage.file_audit_events"
+type FileAuditEventsInsertInput struct {
+	Action    *string `json:"action,omitempty"`
+	APIKeyID  *string `json:"apiKeyId,omitempty"`
+	CreatedAt *string `json:"createdAt,omitempty"`
+	FileID    *string `json:"fileId,omitempty"`
+	ID        *string `json:"id,omitempty"`
+	Reason    *string `json:"reason,omitempty"`
+	UserID    *string `json:"userId,omitempty"`
+}
+
+// response of any mutation on the table "storage.file_audit_events"
+type FileAuditEventsMutationResponse struct {
+	// number of rows affected by the mutation
+	AffectedRows int64 `json:"affected_rows"`
+	// data from the rows affected by the mutation
+	Returning []*FileAuditEvents `json:"returning"`
+}
+
+// on_conflict condition type for table "storage.file_audit_events"
+type FileAuditEventsOnConflict struct {
+	Constraint    FileAuditEventsConstraint     `json:"constraint"`
+	UpdateColumns []FileAuditEventsUpdateColumn `json:"update_columns"`
+	Where         *FileAuditEventsBoolExp       `json:"where,omitempty"`
+}
+
+// Ordering options when selecting data from "storage.file_audit_events".
+type FileAuditEventsOrderBy struct {
+	Action    *OrderBy `json:"action,omitempty"`
+	APIKeyID  *OrderBy `json:"apiKeyId,omitempty"`
+	CreatedAt *OrderBy `json:"createdAt,omitempty"`
+	FileID    *OrderBy `json:"fileId,omitempty"`
+	ID        *OrderBy `json:"id,omitempty"`
+	Reason    *OrderBy `json:"reason,omitempty"`
+	UserID    *OrderBy `json:"userId,omitempty"`
+}
+
+// primary key columns input for table: storage.file_audit_events
+type FileAuditEventsPkColumnsInput struct {
+	ID string `json:"id"`
+}
+
+// input type for updating data in table "storage.file_audit_events"
+type FileAuditEventsSetInput struct {
+	Action    *string `json:"action,omitempty"`
+	APIKeyID  *string `json:"apiKeyId,omitempty"`
+	CreatedAt *string `json:"createdAt,omitempty"`
+	FileID    *string `json:"fileId,omitempty"`
+	ID        *string `json:"id,omitempty"`
+	Reason    *string `json:"reason,omitempty"`
+	UserID    *string `json:"userId,omitempty"`
+}
+
+// columns and relationships of "storage.file_versions"
+type FileVersions struct {
+	CreatedAt        string                 `json:"createdAt"`
+	Etag             *string                `json:"etag,omitempty"`
+	FileID           string                 `json:"fileId"`
+	ID               string                 `json:"id"`
+	Metadata         map[string]interface{} `json:"metadata,omitempty"`
+	MimeType         *string                `json:"mimeType,omitempty"`
+	Name             *string                `json:"name,omitempty"`
+	ObjectKey        string                 `json:"objectKey"`
+	Size             *int64                 `json:"size,omitempty"`
+	UploadedByUserID *string                `json:"uploadedByUserId,omitempty"`
+}
+
+// Boolean expression to filter rows from the table "storage.file_versions". All fields are combined with a logical 'AND'.
+type FileVersionsBoolExp struct {
+	And              []*FileVersionsBoolExp    `json:"_and,omitempty"`
+	Not              *FileVersionsBoolExp      `json:"_not,omitempty"`
+	Or               []*FileVersionsBoolExp    `json:"_or,omitempty"`
+	CreatedAt        *TimestamptzComparisonExp `json:"createdAt,omitempty"`
+	Etag             *StringComparisonExp      `json:"etag,omitempty"`
+	FileID           *UUIDComparisonExp        `json:"fileId,omitempty"`
+	ID               *UUIDComparisonExp        `json:"id,omitempty"`
+	Metadata         *JsonbComparisonExp       `json:"metadata,omitempty"`
+	MimeType         *StringComparisonExp      `json:"mimeType,omitempty"`
+	Name             *StringComparisonExp      `json:"name,omitempty"`
+	ObjectKey        *StringComparisonExp      `json:"objectKey,omitempty"`
+	Size             *IntComparisonExp         `json:"size,omitempty"`
+	UploadedByUserID *UUIDComparisonExp        `json:"uploadedByUserId,omitempty"`
+}
+
+// input type for incrementing numeric columns in table "storage.file_versions"
+type FileVersionsIncInput struct {
+	Size *int64 `json:"size,omitempty"`
+}
+
+// input type for inserting data into table "storage.file_versions"
+type FileVersionsInsertInput struct {
+	CreatedAt        *string                `json:"createdAt,omitempty"`
+	Etag             *string                `json:"etag,omitempty"`
+	FileID           *string                `json:"fileId,omitempty"`
+	ID               *string                `json:"id,omitempty"`
+	Metadata         map[string]interface{} `json:"metadata,omitempty"`
+	MimeType         *string                `json:"mimeType,omitempty"`
+	Name             *string                `json:"name,omitempty"`
+	ObjectKey        *string                `json:"objectKey,omitempty"`
+	Size             *int64                 `json:"size,omitempty"`
+	UploadedByUserID *string                `json:"uploadedByUserId,omitempty"`
+}
+
+// response of any mutation on the table "storage.file_versions"
+type FileVersionsMutationResponse struct {
+	// number of rows affected by the mutation
+	AffectedRows int64 `json:"affected_rows"`
+	// data from the rows affected by the mutation
+	Returning []*FileVersions `json:"returning"`
+}
+
+// on_conflict condition type for table "storage.file_versions"
+type FileVersionsOnConflict struct {
+	Constraint    FileVersionsConstraint     `json:"constraint"`
+	UpdateColumns []FileVersionsUpdateColumn `json:"update_columns"`
+	Where         *FileVersionsBoolExp       `json:"where,omitempty"`
+}
+
+// Ordering options when selecting data from "storage.file_versions".
+type FileVersionsOrderBy struct {
+	CreatedAt        *OrderBy `json:"createdAt,omitempty"`
+	Etag             *OrderBy `json:"etag,omitempty"`
+	FileID           *OrderBy `json:"fileId,omitempty"`
+	ID               *OrderBy `json:"id,omitempty"`
+	Metadata         *OrderBy `json:"metadata,omitempty"`
+	MimeType         *OrderBy `json:"mimeType,omitempty"`
+	Name             *OrderBy `json:"name,omitempty"`
+	ObjectKey        *OrderBy `json:"objectKey,omitempty"`
+	Size             *OrderBy `json:"size,omitempty"`
+	UploadedByUserID *OrderBy `json:"uploadedByUserId,omitempty"`
+}
+
+// primary key columns input for table: storage.file_versions
+type FileVersionsPkColumnsInput struct {
+	ID string `json:"id"`
+}
+
+// input type for updating data in table "storage.file_versions"
+type FileVersionsSetInput struct {
+	CreatedAt        *string                `json:"createdAt,omitempty"`
+	Etag             *string                `json:"etag,omitempty"`
+	FileID           *string                `json:"fileId,omitempty"`
+	ID               *string                `json:"id,omitempty"`
+	Metadata         map[string]interface{} `json:"metadata,omitempty"`
+	MimeType         *string                `json:"mimeType,omitempty"`
+	Name             *string                `json:"name,omitempty"`
+	ObjectKey        *string                `json:"objectKey,omitempty"`
+	Size             *int64                 `json:"size,omitempty"`
+	UploadedByUserID *string                `json:"uploadedByUserId,omitempty"`
+}
+
 // Boolean expression to compare columns of type "Int". All fields are combined with logical 'AND'.
 type IntComparisonExp struct {
 	Eq     *int64  `json:"_eq,omitempty"`
@@ -34,6 +403,199 @@ type IntComparisonExp struct {
 	Nin    []int64 `json:"_nin,omitempty"`
 }
 
+// columns and relationships of "storage.quotas"
+type Quotas struct {
+	BucketID  *string `json:"bucketId,omitempty"`
+	CreatedAt string  `json:"createdAt"`
+	ID        string  `json:"id"`
+	MaxBytes  *int64  `json:"maxBytes,omitempty"`
+	MaxFiles  *int64  `json:"maxFiles,omitempty"`
+	Role      *string `json:"role,omitempty"`
+	UpdatedAt string  `json:"updatedAt"`
+	UserID    *string `json:"userId,omitempty"`
+}
+
+// Boolean expression to filter rows from the table "storage.quotas". All fields are combined with a logical 'AND'.
+type QuotasBoolExp struct {
+	And       []*QuotasBoolExp          `json:"_and,omitempty"`
+	Not       *QuotasBoolExp            `json:"_not,omitempty"`
+	Or        []*QuotasBoolExp          `json:"_or,omitempty"`
+	BucketID  *StringComparisonExp      `json:"bucketId,omitempty"`
+	CreatedAt *TimestamptzComparisonExp `json:"createdAt,omitempty"`
+	ID        *UUIDComparisonExp        `json:"id,omitempty"`
+	MaxBytes  *BigintComparisonExp      `json:"maxBytes,omitempty"`
+	MaxFiles  *IntComparisonExp         `json:"maxFiles,omitempty"`
+	Role      *StringComparisonExp      `json:"role,omitempty"`
+	UpdatedAt *TimestamptzComparisonExp `json:"updatedAt,omitempty"`
+	UserID    *UUIDComparisonExp        `json:"userId,omitempty"`
+}
+
+// input type for incrementing numeric columns in table "storage.quotas"
+type QuotasIncInput struct {
+	MaxBytes *int64 `json:"maxBytes,omitempty"`
+	MaxFiles *int64 `json:"maxFiles,omitempty"`
+}
+
+// input type for inserting data into table "storage.quotas"
+type QuotasInsertInput struct {
+	BucketID  *string `json:"bucketId,omitempty"`
+	CreatedAt *string `json:"createdAt,omitempty"`
+	ID        *string `json:"id,omitempty"`
+	MaxBytes  *int64  `json:"maxBytes,omitempty"`
+	MaxFiles  *int64  `json:"maxFiles,omitempty"`
+	Role      *string `json:"role,omitempty"`
+	UpdatedAt *string `json:"updatedAt,omitempty"`
+	UserID    *string `json:"userId,omitempty"`
+}
+
+// response of any mutation on the table "storage.quotas"
+type QuotasMutationResponse struct {
+	// number of rows affected by the mutation
+	AffectedRows int64 `json:"affected_rows"`
+	// data from the rows affected by the mutation
+	Returning []*Quotas `json:"returning"`
+}
+
+// on_conflict condition type for table "storage.quotas"
+type QuotasOnConflict struct {
+	Constraint    QuotasConstraint     `json:"constraint"`
+	UpdateColumns []QuotasUpdateColumn `json:"update_columns"`
+	Where         *QuotasBoolExp       `json:"where,omitempty"`
+}
+
+// Ordering options when selecting data from "storage.quotas".
+type QuotasOrderBy struct {
+	BucketID  *OrderBy `json:"bucketId,omitempty"`
+	CreatedAt *OrderBy `json:"createdAt,omitempty"`
+	ID        *OrderBy `json:"id,omitempty"`
+	MaxBytes  *OrderBy `json:"maxBytes,omitempty"`
+	MaxFiles  *OrderBy `json:"maxFiles,omitempty"`
+	Role      *OrderBy `json:"role,omitempty"`
+	UpdatedAt *OrderBy `json:"updatedAt,omitempty"`
+	UserID    *OrderBy `json:"userId,omitempty"`
+}
+
+// primary key columns input for table: storage.quotas
+type QuotasPkColumnsInput struct {
+	ID string `json:"id"`
+}
+
+// input type for updating data in table "storage.quotas"
+type QuotasSetInput struct {
+	BucketID  *string `json:"bucketId,omitempty"`
+	CreatedAt *string `json:"createdAt,omitempty"`
+	ID        *string `json:"id,omitempty"`
+	MaxBytes  *int64  `json:"maxBytes,omitempty"`
+	MaxFiles  *int64  `json:"maxFiles,omitempty"`
+	Role      *string `json:"role,omitempty"`
+	UpdatedAt *string `json:"updatedAt,omitempty"`
+	UserID    *string `json:"userId,omitempty"`
+}
+
+// columns and relationships of "storage.shares"
+type Shares struct {
+	AllowedTransformations *string `json:"allowedTransformations,omitempty"`
+	CreatedAt              string  `json:"createdAt"`
+	CreatedByUserID        *string `json:"createdByUserId,omitempty"`
+	DownloadCount          int64   `json:"downloadCount"`
+	ExpiresAt              *string `json:"expiresAt,omitempty"`
+	FileID                 string  `json:"fileId"`
+	ID                     string  `json:"id"`
+	MaxDownloads           *int64  `json:"maxDownloads,omitempty"`
+	PasswordHash           *string `json:"passwordHash,omitempty"`
+	TokenHash              string  `json:"tokenHash"`
+	UpdatedAt              string  `json:"updatedAt"`
+}
+
+// Boolean expression to filter rows from the table "storage.shares". All fields are combined with a logical 'AND'.
+type SharesBoolExp struct {
+	And                    []*SharesBoolExp          `json:"_and,omitempty"`
+	Not                    *SharesBoolExp            `json:"_not,omitempty"`
+	Or                     []*SharesBoolExp          `json:"_or,omitempty"`
+	AllowedTransformations *StringComparisonExp      `json:"allowedTransformations,omitempty"`
+	CreatedAt              *TimestamptzComparisonExp `json:"createdAt,omitempty"`
+	CreatedByUserID        *UUIDComparisonExp        `json:"createdByUserId,omitempty"`
+	DownloadCount          *IntComparisonExp         `json:"downloadCount,omitempty"`
+	ExpiresAt              *TimestamptzComparisonExp `json:"expiresAt,omitempty"`
+	FileID                 *UUIDComparisonExp        `json:"fileId,omitempty"`
+	ID                     *UUIDComparisonExp        `json:"id,omitempty"`
+	MaxDownloads           *IntComparisonExp         `json:"maxDownloads,omitempty"`
+	PasswordHash           *StringComparisonExp      `json:"passwordHash,omitempty"`
+	TokenHash              *StringComparisonExp      `json:"tokenHash,omitempty"`
+	UpdatedAt              *TimestamptzComparisonExp `json:"updatedAt,omitempty"`
+}
+
+// input type for incrementing numeric columns in table "storage.shares"
+type SharesIncInput struct {
+	DownloadCount *int64 `json:"downloadCount,omitempty"`
+	MaxDownloads  *int64 `json:"maxDownloads,omitempty"`
+}
+
+// input type for inserting data into table "storage.shares"
+type SharesInsertInput struct {
+	AllowedTransformations *string `json:"allowedTransformations,omitempty"`
+	CreatedAt              *string `json:"createdAt,omitempty"`
+	CreatedByUserID        *string `json:"createdByUserId,omitempty"`
+	DownloadCount          *int64  `json:"downloadCount,omitempty"`
+	ExpiresAt              *string `json:"expiresAt,omitempty"`
+	FileID                 *string `json:"fileId,omitempty"`
+	ID                     *string `json:"id,omitempty"`
+	MaxDownloads           *int64  `json:"maxDownloads,omitempty"`
+	PasswordHash           *string `json:"passwordHash,omitempty"`
+	TokenHash              *string `json:"tokenHash,omitempty"`
+	UpdatedAt              *string `json:"updatedAt,omitempty"`
+}
+
+// response of any mutation on the table "storage.shares"
+type SharesMutationResponse struct {
+	// number of rows affected by the mutation
+	AffectedRows int64 `json:"affected_rows"`
+	// data from the rows affected by the mutation
+	Returning []*Shares `json:"returning"`
+}
+
+// on_conflict condition type for table "storage.shares"
+type SharesOnConflict struct {
+	Constraint    SharesConstraint     `json:"constraint"`
+	UpdateColumns []SharesUpdateColumn `json:"update_columns"`
+	Where         *SharesBoolExp       `json:"where,omitempty"`
+}
+
+// Ordering options when selecting data from "storage.shares".
+type SharesOrderBy struct {
+	AllowedTransformations *OrderBy `json:"allowedTransformations,omitempty"`
+	CreatedAt              *OrderBy `json:"createdAt,omitempty"`
+	CreatedByUserID        *OrderBy `json:"createdByUserId,omitempty"`
+	DownloadCount          *OrderBy `json:"downloadCount,omitempty"`
+	ExpiresAt              *OrderBy `json:"expiresAt,omitempty"`
+	FileID                 *OrderBy `json:"fileId,omitempty"`
+	ID                     *OrderBy `json:"id,omitempty"`
+	MaxDownloads           *OrderBy `json:"maxDownloads,omitempty"`
+	PasswordHash           *OrderBy `json:"passwordHash,omitempty"`
+	TokenHash              *OrderBy `json:"tokenHash,omitempty"`
+	UpdatedAt              *OrderBy `json:"updatedAt,omitempty"`
+}
+
+// primary key columns input for table: storage.shares
+type SharesPkColumnsInput struct {
+	ID string `json:"id"`
+}
+
+// input type for updating data in table "storage.shares"
+type SharesSetInput struct {
+	AllowedTransformations *string `json:"allowedTransformations,omitempty"`
+	CreatedAt              *string `json:"createdAt,omitempty"`
+	CreatedByUserID        *string `json:"createdByUserId,omitempty"`
+	DownloadCount          *int64  `json:"downloadCount,omitempty"`
+	ExpiresAt              *string `json:"expiresAt,omitempty"`
+	FileID                 *string `json:"fileId,omitempty"`
+	ID                     *string `json:"id,omitempty"`
+	MaxDownloads           *int64  `json:"maxDownloads,omitempty"`
+	PasswordHash           *string `json:"passwordHash,omitempty"`
+	TokenHash              *string `json:"tokenHash,omitempty"`
+	UpdatedAt              *string `json:"updatedAt,omitempty"`
+}
+
 // Boolean expression to compare columns of type "String". All fields are combined with logical 'AND'.
 type StringComparisonExp struct {
 	Eq  *string `json:"_eq,omitempty"`
@@ -69,19 +631,33 @@ type StringComparisonExp struct {
 
 // columns and relationships of "storage.buckets"
 type Buckets struct {
+	AllowedExtensions  *string `json:"allowedExtensions,omitempty"`
+	AllowedMimeTypes   *string `json:"allowedMimeTypes,omitempty"`
 	CacheControl       *string `json:"cacheControl,omitempty"`
 	CreatedAt          string  `json:"createdAt"`
+	DefaultTTLSeconds  int64   `json:"defaultTtlSeconds"`
+	DeniedExtensions   *string `json:"deniedExtensions,omitempty"`
+	DeniedMimeTypes    *string `json:"deniedMimeTypes,omitempty"`
 	DownloadExpiration int64   `json:"downloadExpiration"`
 	// An array relationship
 	Files []*Files `json:"files"`
 	// An aggregate relationship
 	FilesAggregate       FilesAggregate `json:"files_aggregate"`
-	ID                   string          `json:"id"`
-	MaxUploadFileSize    int64           `json:"maxUploadFileSize"`
-	MinUploadFileSize    int64           `json:"minUploadFileSize"`
-	PresignedUrlsEnabled bool            `json:"presignedUrlsEnabled"`
-	UpdatedAt            string          `json:"updatedAt"`
-	UploadExpiration     int64           `json:"uploadExpiration"`
+	ID                   string         `json:"id"`
+	MaxUploadFileSize    int64          `json:"maxUploadFileSize"`
+	MaxVersions          int64          `json:"maxVersions"`
+	MinUploadFileSize    int64          `json:"minUploadFileSize"`
+	PresignedUrlsEnabled bool           `json:"presignedUrlsEnabled"`
+	RedirectDownloads    bool           `json:"redirectDownloads"`
+	RetentionDays        int64          `json:"retentionDays"`
+	RetentionMode        string         `json:"retentionMode"`
+	SoftDeleteEnabled    bool           `json:"softDeleteEnabled"`
+	TrashRetentionDays   int64          `json:"trashRetentionDays"`
+	UpdatedAt            string         `json:"updatedAt"`
+	UploadExpiration     int64          `json:"uploadExpiration"`
+	VersionRetentionDays int64          `json:"versionRetentionDays"`
+	VersioningEnabled    bool           `json:"versioningEnabled"`
+	WebhookURL           *string        `json:"webhookUrl,omitempty"`
 }
 
 // aggregated selection of "storage.buckets"
@@ -107,74 +683,134 @@ type BucketsAggregateFields struct {
 
 // aggregate avg on columns
 type BucketsAvgFields struct {
-	DownloadExpiration *float64 `json:"downloadExpiration,omitempty"`
-	MaxUploadFileSize  *float64 `json:"maxUploadFileSize,omitempty"`
-	MinUploadFileSize  *float64 `json:"minUploadFileSize,omitempty"`
-	UploadExpiration   *float64 `json:"uploadExpiration,omitempty"`
+	DefaultTTLSeconds    *float64 `json:"defaultTtlSeconds,omitempty"`
+	DownloadExpiration   *float64 `json:"downloadExpiration,omitempty"`
+	MaxUploadFileSize    *float64 `json:"maxUploadFileSize,omitempty"`
+	MaxVersions          *float64 `json:"maxVersions,omitempty"`
+	MinUploadFileSize    *float64 `json:"minUploadFileSize,omitempty"`
+	RetentionDays        *float64 `json:"retentionDays,omitempty"`
+	TrashRetentionDays   *float64 `json:"trashRetentionDays,omitempty"`
+	UploadExpiration     *float64 `json:"uploadExpiration,omitempty"`
+	VersionRetentionDays *float64 `json:"versionRetentionDays,omitempty"`
 }
 
 // Boolean expression to filter rows from the table "storage.buckets". All fields are combined with a logical 'AND'.
 type BucketsBoolExp struct {
 	And                  []*BucketsBoolExp         `json:"_and,omitempty"`
+	DefaultTTLSeconds    *IntComparisonExp         `json:"defaultTtlSeconds,omitempty"`
 	Not                  *BucketsBoolExp           `json:"_not,omitempty"`
 	Or                   []*BucketsBoolExp         `json:"_or,omitempty"`
+	AllowedExtensions    *StringComparisonExp      `json:"allowedExtensions,omitempty"`
+	AllowedMimeTypes     *StringComparisonExp      `json:"allowedMimeTypes,omitempty"`
 	CacheControl         *StringComparisonExp      `json:"cacheControl,omitempty"`
 	CreatedAt            *TimestamptzComparisonExp `json:"createdAt,omitempty"`
+	DeniedExtensions     *StringComparisonExp      `json:"deniedExtensions,omitempty"`
+	DeniedMimeTypes      *StringComparisonExp      `json:"deniedMimeTypes,omitempty"`
 	DownloadExpiration   *IntComparisonExp         `json:"downloadExpiration,omitempty"`
 	Files                *FilesBoolExp             `json:"files,omitempty"`
 	FilesAggregate       *FilesAggregateBoolExp    `json:"files_aggregate,omitempty"`
 	ID                   *StringComparisonExp      `json:"id,omitempty"`
 	MaxUploadFileSize    *IntComparisonExp         `json:"maxUploadFileSize,omitempty"`
+	MaxVersions          *IntComparisonExp         `json:"maxVersions,omitempty"`
 	MinUploadFileSize    *IntComparisonExp         `json:"minUploadFileSize,omitempty"`
 	PresignedUrlsEnabled *BooleanComparisonExp     `json:"presignedUrlsEnabled,omitempty"`
+	RedirectDownloads    *BooleanComparisonExp     `json:"redirectDownloads,omitempty"`
+	RetentionDays        *IntComparisonExp         `json:"retentionDays,omitempty"`
+	RetentionMode        *StringComparisonExp      `json:"retentionMode,omitempty"`
+	SoftDeleteEnabled    *BooleanComparisonExp     `json:"softDeleteEnabled,omitempty"`
+	TrashRetentionDays   *IntComparisonExp         `json:"trashRetentionDays,omitempty"`
 	UpdatedAt            *TimestamptzComparisonExp `json:"updatedAt,omitempty"`
 	UploadExpiration     *IntComparisonExp         `json:"uploadExpiration,omitempty"`
+	VersionRetentionDays *IntComparisonExp         `json:"versionRetentionDays,omitempty"`
+	VersioningEnabled    *BooleanComparisonExp     `json:"versioningEnabled,omitempty"`
+	WebhookURL           *StringComparisonExp      `json:"webhookUrl,omitempty"`
 }
 
 // input type for incrementing numeric columns in table "storage.buckets"
 type BucketsIncInput struct {
-	DownloadExpiration *int64 `json:"downloadExpiration,omitempty"`
-	MaxUploadFileSize  *int64 `json:"maxUploadFileSize,omitempty"`
-	MinUploadFileSize  *int64 `json:"minUploadFileSize,omitempty"`
-	UploadExpiration   *int64 `json:"uploadExpiration,omitempty"`
+	DefaultTTLSeconds    *int64 `json:"defaultTtlSeconds,omitempty"`
+	DownloadExpiration   *int64 `json:"downloadExpiration,omitempty"`
+	MaxUploadFileSize    *int64 `json:"maxUploadFileSize,omitempty"`
+	MaxVersions          *int64 `json:"maxVersions,omitempty"`
+	MinUploadFileSize    *int64 `json:"minUploadFileSize,omitempty"`
+	RetentionDays        *int64 `json:"retentionDays,omitempty"`
+	TrashRetentionDays   *int64 `json:"trashRetentionDays,omitempty"`
+	UploadExpiration     *int64 `json:"uploadExpiration,omitempty"`
+	VersionRetentionDays *int64 `json:"versionRetentionDays,omitempty"`
 }
 
 // input type for inserting data into table "storage.buckets"
 type BucketsInsertInput struct {
+	AllowedExtensions    *string                 `json:"allowedExtensions,omitempty"`
+	AllowedMimeTypes     *string                 `json:"allowedMimeTypes,omitempty"`
 	CacheControl         *string                 `json:"cacheControl,omitempty"`
 	CreatedAt            *string                 `json:"createdAt,omitempty"`
+	DefaultTTLSeconds    *int64                  `json:"defaultTtlSeconds,omitempty"`
+	DeniedExtensions     *string                 `json:"deniedExtensions,omitempty"`
+	DeniedMimeTypes      *string                 `json:"deniedMimeTypes,omitempty"`
 	DownloadExpiration   *int64                  `json:"downloadExpiration,omitempty"`
 	Files                *FilesArrRelInsertInput `json:"files,omitempty"`
 	ID                   *string                 `json:"id,omitempty"`
 	MaxUploadFileSize    *int64                  `json:"maxUploadFileSize,omitempty"`
+	MaxVersions          *int64                  `json:"maxVersions,omitempty"`
 	MinUploadFileSize    *int64                  `json:"minUploadFileSize,omitempty"`
 	PresignedUrlsEnabled *bool                   `json:"presignedUrlsEnabled,omitempty"`
+	RedirectDownloads    *bool                   `json:"redirectDownloads,omitempty"`
+	RetentionDays        *int64                  `json:"retentionDays,omitempty"`
+	RetentionMode        *string                 `json:"retentionMode,omitempty"`
+	SoftDeleteEnabled    *bool                   `json:"softDeleteEnabled,omitempty"`
+	TrashRetentionDays   *int64                  `json:"trashRetentionDays,omitempty"`
 	UpdatedAt            *string                 `json:"updatedAt,omitempty"`
 	UploadExpiration     *int64                  `json:"uploadExpiration,omitempty"`
+	VersionRetentionDays *int64                  `json:"versionRetentionDays,omitempty"`
+	VersioningEnabled    *bool                   `json:"versioningEnabled,omitempty"`
+	WebhookURL           *string                 `json:"webhookUrl,omitempty"`
 }
 
 // aggregate max on columns
 type BucketsMaxFields struct {
-	CacheControl       *string `json:"cacheControl,omitempty"`
-	CreatedAt          *string `json:"createdAt,omitempty"`
-	DownloadExpiration *int64  `json:"downloadExpiration,omitempty"`
-	ID                 *string `json:"id,omitempty"`
-	MaxUploadFileSize  *int64  `json:"maxUploadFileSize,omitempty"`
-	MinUploadFileSize  *int64  `json:"minUploadFileSize,omitempty"`
-	UpdatedAt          *string `json:"updatedAt,omitempty"`
-	UploadExpiration   *int64  `json:"uploadExpiration,omitempty"`
+	AllowedExtensions    *string `json:"allowedExtensions,omitempty"`
+	AllowedMimeTypes     *string `json:"allowedMimeTypes,omitempty"`
+	CacheControl         *string `json:"cacheControl,omitempty"`
+	CreatedAt            *string `json:"createdAt,omitempty"`
+	DefaultTTLSeconds    *int64  `json:"defaultTtlSeconds,omitempty"`
+	DeniedExtensions     *string `json:"deniedExtensions,omitempty"`
+	DeniedMimeTypes      *string `json:"deniedMimeTypes,omitempty"`
+	DownloadExpiration   *int64  `json:"downloadExpiration,omitempty"`
+	ID                   *string `json:"id,omitempty"`
+	MaxUploadFileSize    *int64  `json:"maxUploadFileSize,omitempty"`
+	MaxVersions          *int64  `json:"maxVersions,omitempty"`
+	MinUploadFileSize    *int64  `json:"minUploadFileSize,omitempty"`
+	RetentionDays        *int64  `json:"retentionDays,omitempty"`
+	RetentionMode        *string `json:"retentionMode,omitempty"`
+	TrashRetentionDays   *int64  `json:"trashRetentionDays,omitempty"`
+	UpdatedAt            *string `json:"updatedAt,omitempty"`
+	UploadExpiration     *int64  `json:"uploadExpiration,omitempty"`
+	VersionRetentionDays *int64  `json:"versionRetentionDays,omitempty"`
+	WebhookURL           *string `json:"webhookUrl,omitempty"`
 }
 
 // aggregate min on columns
 type BucketsMinFields struct {
-	CacheControl       *string `json:"cacheControl,omitempty"`
-	CreatedAt          *string `json:"createdAt,omitempty"`
-	DownloadExpiration *int64  `json:"downloadExpiration,omitempty"`
-	ID                 *string `json:"id,omitempty"`
-	MaxUploadFileSize  *int64  `json:"maxUploadFileSize,omitempty"`
-	MinUploadFileSize  *int64  `json:"minUploadFileSize,omitempty"`
-	UpdatedAt          *string `json:"updatedAt,omitempty"`
-	UploadExpiration   *int64  `json:"uploadExpiration,omitempty"`
+	AllowedExtensions    *string `json:"allowedExtensions,omitempty"`
+	AllowedMimeTypes     *string `json:"allowedMimeTypes,omitempty"`
+	CacheControl         *string `json:"cacheControl,omitempty"`
+	CreatedAt            *string `json:"createdAt,omitempty"`
+	DefaultTTLSeconds    *int64  `json:"defaultTtlSeconds,omitempty"`
+	DeniedExtensions     *string `json:"deniedExtensions,omitempty"`
+	DeniedMimeTypes      *string `json:"deniedMimeTypes,omitempty"`
+	DownloadExpiration   *int64  `json:"downloadExpiration,omitempty"`
+	ID                   *string `json:"id,omitempty"`
+	MaxUploadFileSize    *int64  `json:"maxUploadFileSize,omitempty"`
+	MaxVersions          *int64  `json:"maxVersions,omitempty"`
+	MinUploadFileSize    *int64  `json:"minUploadFileSize,omitempty"`
+	RetentionDays        *int64  `json:"retentionDays,omitempty"`
+	RetentionMode        *string `json:"retentionMode,omitempty"`
+	TrashRetentionDays   *int64  `json:"trashRetentionDays,omitempty"`
+	UpdatedAt            *string `json:"updatedAt,omitempty"`
+	UploadExpiration     *int64  `json:"uploadExpiration,omitempty"`
+	VersionRetentionDays *int64  `json:"versionRetentionDays,omitempty"`
+	WebhookURL           *string `json:"webhookUrl,omitempty"`
 }
 
 // response of any mutation on the table "storage.buckets"
@@ -201,16 +837,30 @@ type BucketsOnConflict struct {
 
 // Ordering options when selecting data from "storage.buckets".
 type BucketsOrderBy struct {
+	AllowedExtensions    *OrderBy               `json:"allowedExtensions,omitempty"`
+	AllowedMimeTypes     *OrderBy               `json:"allowedMimeTypes,omitempty"`
 	CacheControl         *OrderBy               `json:"cacheControl,omitempty"`
 	CreatedAt            *OrderBy               `json:"createdAt,omitempty"`
+	DefaultTTLSeconds    *OrderBy               `json:"defaultTtlSeconds,omitempty"`
+	DeniedExtensions     *OrderBy               `json:"deniedExtensions,omitempty"`
+	DeniedMimeTypes      *OrderBy               `json:"deniedMimeTypes,omitempty"`
 	DownloadExpiration   *OrderBy               `json:"downloadExpiration,omitempty"`
 	FilesAggregate       *FilesAggregateOrderBy `json:"files_aggregate,omitempty"`
 	ID                   *OrderBy               `json:"id,omitempty"`
 	MaxUploadFileSize    *OrderBy               `json:"maxUploadFileSize,omitempty"`
+	MaxVersions          *OrderBy               `json:"maxVersions,omitempty"`
 	MinUploadFileSize    *OrderBy               `json:"minUploadFileSize,omitempty"`
 	PresignedUrlsEnabled *OrderBy               `json:"presignedUrlsEnabled,omitempty"`
+	RedirectDownloads    *OrderBy               `json:"redirectDownloads,omitempty"`
+	RetentionDays        *OrderBy               `json:"retentionDays,omitempty"`
+	RetentionMode        *OrderBy               `json:"retentionMode,omitempty"`
+	SoftDeleteEnabled    *OrderBy               `json:"softDeleteEnabled,omitempty"`
+	TrashRetentionDays   *OrderBy               `json:"trashRetentionDays,omitempty"`
 	UpdatedAt            *OrderBy               `json:"updatedAt,omitempty"`
 	UploadExpiration     *OrderBy               `json:"uploadExpiration,omitempty"`
+	VersionRetentionDays *OrderBy               `json:"versionRetentionDays,omitempty"`
+	VersioningEnabled    *OrderBy               `json:"versioningEnabled,omitempty"`
+	WebhookURL           *OrderBy               `json:"webhookUrl,omitempty"`
 }
 
 // primary key columns input for table: storage.buckets
@@ -220,39 +870,68 @@ type BucketsPkColumnsInput struct {
 
 // input type for updating data in table "storage.buckets"
 type BucketsSetInput struct {
+	AllowedExtensions    *string `json:"allowedExtensions,omitempty"`
+	AllowedMimeTypes     *string `json:"allowedMimeTypes,omitempty"`
 	CacheControl         *string `json:"cacheControl,omitempty"`
 	CreatedAt            *string `json:"createdAt,omitempty"`
+	DefaultTTLSeconds    *int64  `json:"defaultTtlSeconds,omitempty"`
+	DeniedExtensions     *string `json:"deniedExtensions,omitempty"`
+	DeniedMimeTypes      *string `json:"deniedMimeTypes,omitempty"`
 	DownloadExpiration   *int64  `json:"downloadExpiration,omitempty"`
 	ID                   *string `json:"id,omitempty"`
 	MaxUploadFileSize    *int64  `json:"maxUploadFileSize,omitempty"`
+	MaxVersions          *int64  `json:"maxVersions,omitempty"`
 	MinUploadFileSize    *int64  `json:"minUploadFileSize,omitempty"`
 	PresignedUrlsEnabled *bool   `json:"presignedUrlsEnabled,omitempty"`
+	RedirectDownloads    *bool   `json:"redirectDownloads,omitempty"`
+	RetentionDays        *int64  `json:"retentionDays,omitempty"`
+	RetentionMode        *string `json:"retentionMode,omitempty"`
+	SoftDeleteEnabled    *bool   `json:"softDeleteEnabled,omitempty"`
+	TrashRetentionDays   *int64  `json:"trashRetentionDays,omitempty"`
 	UpdatedAt            *string `json:"updatedAt,omitempty"`
 	UploadExpiration     *int64  `json:"uploadExpiration,omitempty"`
+	VersionRetentionDays *int64  `json:"versionRetentionDays,omitempty"`
+	VersioningEnabled    *bool   `json:"versioningEnabled,omitempty"`
+	WebhookURL           *string `json:"webhookUrl,omitempty"`
 }
 
 // aggregate stddev on columns
 type BucketsStddevFields struct {
-	DownloadExpiration *float64 `json:"downloadExpiration,omitempty"`
-	MaxUploadFileSize  *float64 `json:"maxUploadFileSize,omitempty"`
-	MinUploadFileSize  *float64 `json:"minUploadFileSize,omitempty"`
-	UploadExpiration   *float64 `json:"uploadExpiration,omitempty"`
+	DefaultTTLSeconds    *float64 `json:"defaultTtlSeconds,omitempty"`
+	DownloadExpiration   *float64 `json:"downloadExpiration,omitempty"`
+	MaxUploadFileSize    *float64 `json:"maxUploadFileSize,omitempty"`
+	MaxVersions          *float64 `json:"maxVersions,omitempty"`
+	MinUploadFileSize    *float64 `json:"minUploadFileSize,omitempty"`
+	RetentionDays        *float64 `json:"retentionDays,omitempty"`
+	TrashRetentionDays   *float64 `json:"trashRetentionDays,omitempty"`
+	UploadExpiration     *float64 `json:"uploadExpiration,omitempty"`
+	VersionRetentionDays *float64 `json:"versionRetentionDays,omitempty"`
 }
 
 // aggregate stddev_pop on columns
 type BucketsStddevPopFields struct {
-	DownloadExpiration *float64 `json:"downloadExpiration,omitempty"`
-	MaxUploadFileSize  *float64 `json:"maxUploadFileSize,omitempty"`
-	MinUploadFileSize  *float64 `json:"minUploadFileSize,omitempty"`
-	UploadExpiration   *float64 `json:"uploadExpiration,omitempty"`
+	DefaultTTLSeconds    *float64 `json:"defaultTtlSeconds,omitempty"`
+	DownloadExpiration   *float64 `json:"downloadExpiration,omitempty"`
+	MaxUploadFileSize    *float64 `json:"maxUploadFileSize,omitempty"`
+	MaxVersions          *float64 `json:"maxVersions,omitempty"`
+	MinUploadFileSize    *float64 `json:"minUploadFileSize,omitempty"`
+	RetentionDays        *float64 `json:"retentionDays,omitempty"`
+	TrashRetentionDays   *float64 `json:"trashRetentionDays,omitempty"`
+	UploadExpiration     *float64 `json:"uploadExpiration,omitempty"`
+	VersionRetentionDays *float64 `json:"versionRetentionDays,omitempty"`
 }
 
 // aggregate stddev_samp on columns
 type BucketsStddevSampFields struct {
-	DownloadExpiration *float64 `json:"downloadExpiration,omitempty"`
-	MaxUploadFileSize  *float64 `json:"maxUploadFileSize,omitempty"`
-	MinUploadFileSize  *float64 `json:"minUploadFileSize,omitempty"`
-	UploadExpiration   *float64 `json:"uploadExpiration,omitempty"`
+	DefaultTTLSeconds    *float64 `json:"defaultTtlSeconds,omitempty"`
+	DownloadExpiration   *float64 `json:"downloadExpiration,omitempty"`
+	MaxUploadFileSize    *float64 `json:"maxUploadFileSize,omitempty"`
+	MaxVersions          *float64 `json:"maxVersions,omitempty"`
+	MinUploadFileSize    *float64 `json:"minUploadFileSize,omitempty"`
+	RetentionDays        *float64 `json:"retentionDays,omitempty"`
+	TrashRetentionDays   *float64 `json:"trashRetentionDays,omitempty"`
+	UploadExpiration     *float64 `json:"uploadExpiration,omitempty"`
+	VersionRetentionDays *float64 `json:"versionRetentionDays,omitempty"`
 }
 
 // Streaming cursor of the table "buckets"
@@ -265,23 +944,42 @@ type BucketsStreamCursorInput struct {
 
 // Initial value of the column from where the streaming should start
 type BucketsStreamCursorValueInput struct {
+	AllowedExtensions    *string `json:"allowedExtensions,omitempty"`
+	AllowedMimeTypes     *string `json:"allowedMimeTypes,omitempty"`
 	CacheControl         *string `json:"cacheControl,omitempty"`
 	CreatedAt            *string `json:"createdAt,omitempty"`
+	DefaultTTLSeconds    *int64  `json:"defaultTtlSeconds,omitempty"`
+	DeniedExtensions     *string `json:"deniedExtensions,omitempty"`
+	DeniedMimeTypes      *string `json:"deniedMimeTypes,omitempty"`
 	DownloadExpiration   *int64  `json:"downloadExpiration,omitempty"`
 	ID                   *string `json:"id,omitempty"`
 	MaxUploadFileSize    *int64  `json:"maxUploadFileSize,omitempty"`
+	MaxVersions          *int64  `json:"maxVersions,omitempty"`
 	MinUploadFileSize    *int64  `json:"minUploadFileSize,omitempty"`
 	PresignedUrlsEnabled *bool   `json:"presignedUrlsEnabled,omitempty"`
+	RedirectDownloads    *bool   `json:"redirectDownloads,omitempty"`
+	RetentionDays        *int64  `json:"retentionDays,omitempty"`
+	RetentionMode        *string `json:"retentionMode,omitempty"`
+	SoftDeleteEnabled    *bool   `json:"softDeleteEnabled,omitempty"`
+	TrashRetentionDays   *int64  `json:"trashRetentionDays,omitempty"`
 	UpdatedAt            *string `json:"updatedAt,omitempty"`
 	UploadExpiration     *int64  `json:"uploadExpiration,omitempty"`
+	VersionRetentionDays *int64  `json:"versionRetentionDays,omitempty"`
+	VersioningEnabled    *bool   `json:"versioningEnabled,omitempty"`
+	WebhookURL           *string `json:"webhookUrl,omitempty"`
 }
 
 // aggregate sum on columns
 type BucketsSumFields struct {
-	DownloadExpiration *int64 `json:"downloadExpiration,omitempty"`
-	MaxUploadFileSize  *int64 `json:"maxUploadFileSize,omitempty"`
-	MinUploadFileSize  *int64 `json:"minUploadFileSize,omitempty"`
-	UploadExpiration   *int64 `json:"uploadExpiration,omitempty"`
+	DefaultTTLSeconds    *int64 `json:"defaultTtlSeconds,omitempty"`
+	DownloadExpiration   *int64 `json:"downloadExpiration,omitempty"`
+	MaxUploadFileSize    *int64 `json:"maxUploadFileSize,omitempty"`
+	MaxVersions          *int64 `json:"maxVersions,omitempty"`
+	MinUploadFileSize    *int64 `json:"minUploadFileSize,omitempty"`
+	RetentionDays        *int64 `json:"retentionDays,omitempty"`
+	TrashRetentionDays   *int64 `json:"trashRetentionDays,omitempty"`
+	UploadExpiration     *int64 `json:"uploadExpiration,omitempty"`
+	VersionRetentionDays *int64 `json:"versionRetentionDays,omitempty"`
 }
 
 type BucketsUpdates struct {
@@ -295,26 +993,41 @@ type BucketsUpdates struct {
 
 // aggregate var_pop on columns
 type BucketsVarPopFields struct {
-	DownloadExpiration *float64 `json:"downloadExpiration,omitempty"`
-	MaxUploadFileSize  *float64 `json:"maxUploadFileSize,omitempty"`
-	MinUploadFileSize  *float64 `json:"minUploadFileSize,omitempty"`
-	UploadExpiration   *float64 `json:"uploadExpiration,omitempty"`
+	DefaultTTLSeconds    *float64 `json:"defaultTtlSeconds,omitempty"`
+	DownloadExpiration   *float64 `json:"downloadExpiration,omitempty"`
+	MaxUploadFileSize    *float64 `json:"maxUploadFileSize,omitempty"`
+	MaxVersions          *float64 `json:"maxVersions,omitempty"`
+	MinUploadFileSize    *float64 `json:"minUploadFileSize,omitempty"`
+	RetentionDays        *float64 `json:"retentionDays,omitempty"`
+	TrashRetentionDays   *float64 `json:"trashRetentionDays,omitempty"`
+	UploadExpiration     *float64 `json:"uploadExpiration,omitempty"`
+	VersionRetentionDays *float64 `json:"versionRetentionDays,omitempty"`
 }
 
 // aggregate var_samp on columns
 type BucketsVarSampFields struct {
-	DownloadExpiration *float64 `json:"downloadExpiration,omitempty"`
-	MaxUploadFileSize  *float64 `json:"maxUploadFileSize,omitempty"`
-	MinUploadFileSize  *float64 `json:"minUploadFileSize,omitempty"`
-	UploadExpiration   *float64 `json:"uploadExpiration,omitempty"`
+	DefaultTTLSeconds    *float64 `json:"defaultTtlSeconds,omitempty"`
+	DownloadExpiration   *float64 `json:"downloadExpiration,omitempty"`
+	MaxUploadFileSize    *float64 `json:"maxUploadFileSize,omitempty"`
+	MaxVersions          *float64 `json:"maxVersions,omitempty"`
+	MinUploadFileSize    *float64 `json:"minUploadFileSize,omitempty"`
+	RetentionDays        *float64 `json:"retentionDays,omitempty"`
+	TrashRetentionDays   *float64 `json:"trashRetentionDays,omitempty"`
+	UploadExpiration     *float64 `json:"uploadExpiration,omitempty"`
+	VersionRetentionDays *float64 `json:"versionRetentionDays,omitempty"`
 }
 
 // aggregate variance on columns
 type BucketsVarianceFields struct {
-	DownloadExpiration *float64 `json:"downloadExpiration,omitempty"`
-	MaxUploadFileSize  *float64 `json:"maxUploadFileSize,omitempty"`
-	MinUploadFileSize  *float64 `json:"minUploadFileSize,omitempty"`
-	UploadExpiration   *float64 `json:"uploadExpiration,omitempty"`
+	DefaultTTLSeconds    *float64 `json:"defaultTtlSeconds,omitempty"`
+	DownloadExpiration   *float64 `json:"downloadExpiration,omitempty"`
+	MaxUploadFileSize    *float64 `json:"maxUploadFileSize,omitempty"`
+	MaxVersions          *float64 `json:"maxVersions,omitempty"`
+	MinUploadFileSize    *float64 `json:"minUploadFileSize,omitempty"`
+	RetentionDays        *float64 `json:"retentionDays,omitempty"`
+	TrashRetentionDays   *float64 `json:"trashRetentionDays,omitempty"`
+	UploadExpiration     *float64 `json:"uploadExpiration,omitempty"`
+	VersionRetentionDays *float64 `json:"versionRetentionDays,omitempty"`
 }
 
 // columns and relationships of "storage.files"
@@ -325,15 +1038,21 @@ type Files struct {
 	ChunkCount       *int64                 `json:"chunkCount,omitempty"`
 	ChunkSize        *int64                 `json:"chunkSize,omitempty"`
 	CreatedAt        string                 `json:"createdAt"`
+	DeletedAt        *string                `json:"deletedAt,omitempty"`
+	DeletedByUserID  *string                `json:"deletedByUserId,omitempty"`
 	Etag             *string                `json:"etag,omitempty"`
+	ExpiresAt        *string                `json:"expiresAt,omitempty"`
 	ID               string                 `json:"id"`
 	IsUploaded       *bool                  `json:"isUploaded,omitempty"`
+	LegalHold        bool                   `json:"legalHold"`
 	Metadata         map[string]interface{} `json:"metadata,omitempty"`
 	MimeType         *string                `json:"mimeType,omitempty"`
 	Name             *string                `json:"name,omitempty"`
 	ObjectKey        *string                `json:"objectKey,omitempty"`
+	RetainUntil      *string                `json:"retainUntil,omitempty"`
 	Size             *int64                 `json:"size,omitempty"`
 	UpdatedAt        string                 `json:"updatedAt"`
+	UploadedByRole   *string                `json:"uploadedByRole,omitempty"`
 	UploadID         *string                `json:"uploadId,omitempty"`
 	UploadedByUserID *string                `json:"uploadedByUserId,omitempty"`
 }
@@ -430,6 +1149,10 @@ type FilesAvgOrderBy struct {
 // Boolean expression to filter rows from the table "storage.files". All fields are combined with a logical 'AND'.
 type FilesBoolExp struct {
 	And              []*FilesBoolExp           `json:"_and,omitempty"`
+	DeletedAt        *TimestamptzComparisonExp `json:"deletedAt,omitempty"`
+	DeletedByUserID  *UUIDComparisonExp        `json:"deletedByUserId,omitempty"`
+	ExpiresAt        *TimestamptzComparisonExp `json:"expiresAt,omitempty"`
+	LegalHold        *BooleanComparisonExp     `json:"legalHold,omitempty"`
 	Not              *FilesBoolExp             `json:"_not,omitempty"`
 	Or               []*FilesBoolExp           `json:"_or,omitempty"`
 	Bucket           *BucketsBoolExp           `json:"bucket,omitempty"`
@@ -444,8 +1167,10 @@ type FilesBoolExp struct {
 	MimeType         *StringComparisonExp      `json:"mimeType,omitempty"`
 	Name             *StringComparisonExp      `json:"name,omitempty"`
 	ObjectKey        *StringComparisonExp      `json:"objectKey,omitempty"`
+	RetainUntil      *TimestamptzComparisonExp `json:"retainUntil,omitempty"`
 	Size             *IntComparisonExp         `json:"size,omitempty"`
 	UpdatedAt        *TimestamptzComparisonExp `json:"updatedAt,omitempty"`
+	UploadedByRole   *StringComparisonExp      `json:"uploadedByRole,omitempty"`
 	UploadID         *StringComparisonExp      `json:"uploadId,omitempty"`
 	UploadedByUserID *UUIDComparisonExp        `json:"uploadedByUserId,omitempty"`
 }
@@ -479,15 +1204,21 @@ type FilesInsertInput struct {
 	ChunkCount       *int64                    `json:"chunkCount,omitempty"`
 	ChunkSize        *int64                    `json:"chunkSize,omitempty"`
 	CreatedAt        *string                   `json:"createdAt,omitempty"`
+	DeletedAt        *string                   `json:"deletedAt,omitempty"`
+	DeletedByUserID  *string                   `json:"deletedByUserId,omitempty"`
 	Etag             *string                   `json:"etag,omitempty"`
+	ExpiresAt        *string                   `json:"expiresAt,omitempty"`
 	ID               *string                   `json:"id,omitempty"`
 	IsUploaded       *bool                     `json:"isUploaded,omitempty"`
+	LegalHold        *bool                     `json:"legalHold,omitempty"`
 	Metadata         map[string]interface{}    `json:"metadata,omitempty"`
 	MimeType         *string                   `json:"mimeType,omitempty"`
 	Name             *string                   `json:"name,omitempty"`
 	ObjectKey        *string                   `json:"objectKey,omitempty"`
+	RetainUntil      *string                   `json:"retainUntil,omitempty"`
 	Size             *int64                    `json:"size,omitempty"`
 	UpdatedAt        *string                   `json:"updatedAt,omitempty"`
+	UploadedByRole   *string                   `json:"uploadedByRole,omitempty"`
 	UploadID         *string                   `json:"uploadId,omitempty"`
 	UploadedByUserID *string                   `json:"uploadedByUserId,omitempty"`
 }
@@ -498,13 +1229,18 @@ type FilesMaxFields struct {
 	ChunkCount       *int64  `json:"chunkCount,omitempty"`
 	ChunkSize        *int64  `json:"chunkSize,omitempty"`
 	CreatedAt        *string `json:"createdAt,omitempty"`
+	DeletedAt        *string `json:"deletedAt,omitempty"`
+	DeletedByUserID  *string `json:"deletedByUserId,omitempty"`
 	Etag             *string `json:"etag,omitempty"`
+	ExpiresAt        *string `json:"expiresAt,omitempty"`
 	ID               *string `json:"id,omitempty"`
 	MimeType         *string `json:"mimeType,omitempty"`
 	Name             *string `json:"name,omitempty"`
 	ObjectKey        *string `json:"objectKey,omitempty"`
+	RetainUntil      *string `json:"retainUntil,omitempty"`
 	Size             *int64  `json:"size,omitempty"`
 	UpdatedAt        *string `json:"updatedAt,omitempty"`
+	UploadedByRole   *string `json:"uploadedByRole,omitempty"`
 	UploadID         *string `json:"uploadId,omitempty"`
 	UploadedByUserID *string `json:"uploadedByUserId,omitempty"`
 }
@@ -515,13 +1251,18 @@ type FilesMaxOrderBy struct {
 	ChunkCount       *OrderBy `json:"chunkCount,omitempty"`
 	ChunkSize        *OrderBy `json:"chunkSize,omitempty"`
 	CreatedAt        *OrderBy `json:"createdAt,omitempty"`
+	DeletedAt        *OrderBy `json:"deletedAt,omitempty"`
+	DeletedByUserID  *OrderBy `json:"deletedByUserId,omitempty"`
 	Etag             *OrderBy `json:"etag,omitempty"`
+	ExpiresAt        *OrderBy `json:"expiresAt,omitempty"`
 	ID               *OrderBy `json:"id,omitempty"`
 	MimeType         *OrderBy `json:"mimeType,omitempty"`
 	Name             *OrderBy `json:"name,omitempty"`
 	ObjectKey        *OrderBy `json:"objectKey,omitempty"`
+	RetainUntil      *OrderBy `json:"retainUntil,omitempty"`
 	Size             *OrderBy `json:"size,omitempty"`
 	UpdatedAt        *OrderBy `json:"updatedAt,omitempty"`
+	UploadedByRole   *OrderBy `json:"uploadedByRole,omitempty"`
 	UploadID         *OrderBy `json:"uploadId,omitempty"`
 	UploadedByUserID *OrderBy `json:"uploadedByUserId,omitempty"`
 }
@@ -532,13 +1273,18 @@ type FilesMinFields struct {
 	ChunkCount       *int64  `json:"chunkCount,omitempty"`
 	ChunkSize        *int64  `json:"chunkSize,omitempty"`
 	CreatedAt        *string `json:"createdAt,omitempty"`
+	DeletedAt        *string `json:"deletedAt,omitempty"`
+	DeletedByUserID  *string `json:"deletedByUserId,omitempty"`
 	Etag             *string `json:"etag,omitempty"`
+	ExpiresAt        *string `json:"expiresAt,omitempty"`
 	ID               *string `json:"id,omitempty"`
 	MimeType         *string `json:"mimeType,omitempty"`
 	Name             *string `json:"name,omitempty"`
 	ObjectKey        *string `json:"objectKey,omitempty"`
+	RetainUntil      *string `json:"retainUntil,omitempty"`
 	Size             *int64  `json:"size,omitempty"`
 	UpdatedAt        *string `json:"updatedAt,omitempty"`
+	UploadedByRole   *string `json:"uploadedByRole,omitempty"`
 	UploadID         *string `json:"uploadId,omitempty"`
 	UploadedByUserID *string `json:"uploadedByUserId,omitempty"`
 }
@@ -549,13 +1295,18 @@ type FilesMinOrderBy struct {
 	ChunkCount       *OrderBy `json:"chunkCount,omitempty"`
 	ChunkSize        *OrderBy `json:"chunkSize,omitempty"`
 	CreatedAt        *OrderBy `json:"createdAt,omitempty"`
+	DeletedAt        *OrderBy `json:"deletedAt,omitempty"`
+	DeletedByUserID  *OrderBy `json:"deletedByUserId,omitempty"`
 	Etag             *OrderBy `json:"etag,omitempty"`
+	ExpiresAt        *OrderBy `json:"expiresAt,omitempty"`
 	ID               *OrderBy `json:"id,omitempty"`
 	MimeType         *OrderBy `json:"mimeType,omitempty"`
 	Name             *OrderBy `json:"name,omitempty"`
 	ObjectKey        *OrderBy `json:"objectKey,omitempty"`
+	RetainUntil      *OrderBy `json:"retainUntil,omitempty"`
 	Size             *OrderBy `json:"size,omitempty"`
 	UpdatedAt        *OrderBy `json:"updatedAt,omitempty"`
+	UploadedByRole   *OrderBy `json:"uploadedByRole,omitempty"`
 	UploadID         *OrderBy `json:"uploadId,omitempty"`
 	UploadedByUserID *OrderBy `json:"uploadedByUserId,omitempty"`
 }
@@ -589,15 +1340,21 @@ type FilesOrderBy struct {
 	ChunkCount       *OrderBy        `json:"chunkCount,omitempty"`
 	ChunkSize        *OrderBy        `json:"chunkSize,omitempty"`
 	CreatedAt        *OrderBy        `json:"createdAt,omitempty"`
+	DeletedAt        *OrderBy        `json:"deletedAt,omitempty"`
+	DeletedByUserID  *OrderBy        `json:"deletedByUserId,omitempty"`
 	Etag             *OrderBy        `json:"etag,omitempty"`
+	ExpiresAt        *OrderBy        `json:"expiresAt,omitempty"`
 	ID               *OrderBy        `json:"id,omitempty"`
 	IsUploaded       *OrderBy        `json:"isUploaded,omitempty"`
+	LegalHold        *OrderBy        `json:"legalHold,omitempty"`
 	Metadata         *OrderBy        `json:"metadata,omitempty"`
 	MimeType         *OrderBy        `json:"mimeType,omitempty"`
 	Name             *OrderBy        `json:"name,omitempty"`
 	ObjectKey        *OrderBy        `json:"objectKey,omitempty"`
+	RetainUntil      *OrderBy        `json:"retainUntil,omitempty"`
 	Size             *OrderBy        `json:"size,omitempty"`
 	UpdatedAt        *OrderBy        `json:"updatedAt,omitempty"`
+	UploadedByRole   *OrderBy        `json:"uploadedByRole,omitempty"`
 	UploadID         *OrderBy        `json:"uploadId,omitempty"`
 	UploadedByUserID *OrderBy        `json:"uploadedByUserId,omitempty"`
 }
@@ -618,15 +1375,21 @@ type FilesSetInput struct {
 	ChunkCount       *int64                 `json:"chunkCount,omitempty"`
 	ChunkSize        *int64                 `json:"chunkSize,omitempty"`
 	CreatedAt        *string                `json:"createdAt,omitempty"`
+	DeletedAt        *string                `json:"deletedAt,omitempty"`
+	DeletedByUserID  *string                `json:"deletedByUserId,omitempty"`
 	Etag             *string                `json:"etag,omitempty"`
+	ExpiresAt        *string                `json:"expiresAt,omitempty"`
 	ID               *string                `json:"id,omitempty"`
 	IsUploaded       *bool                  `json:"isUploaded,omitempty"`
+	LegalHold        *bool                  `json:"legalHold,omitempty"`
 	Metadata         map[string]interface{} `json:"metadata,omitempty"`
 	MimeType         *string                `json:"mimeType,omitempty"`
 	Name             *string                `json:"name,omitempty"`
 	ObjectKey        *string                `json:"objectKey,omitempty"`
+	RetainUntil      *string                `json:"retainUntil,omitempty"`
 	Size             *int64                 `json:"size,omitempty"`
 	UpdatedAt        *string                `json:"updatedAt,omitempty"`
+	UploadedByRole   *string                `json:"uploadedByRole,omitempty"`
 	UploadID         *string                `json:"uploadId,omitempty"`
 	UploadedByUserID *string                `json:"uploadedByUserId,omitempty"`
 }
@@ -687,15 +1450,21 @@ type FilesStreamCursorValueInput struct {
 	ChunkCount       *int64                 `json:"chunkCount,omitempty"`
 	ChunkSize        *int64                 `json:"chunkSize,omitempty"`
 	CreatedAt        *string                `json:"createdAt,omitempty"`
+	DeletedAt        *string                `json:"deletedAt,omitempty"`
+	DeletedByUserID  *string                `json:"deletedByUserId,omitempty"`
 	Etag             *string                `json:"etag,omitempty"`
+	ExpiresAt        *string                `json:"expiresAt,omitempty"`
 	ID               *string                `json:"id,omitempty"`
 	IsUploaded       *bool                  `json:"isUploaded,omitempty"`
+	LegalHold        *bool                  `json:"legalHold,omitempty"`
 	Metadata         map[string]interface{} `json:"metadata,omitempty"`
 	MimeType         *string                `json:"mimeType,omitempty"`
 	Name             *string                `json:"name,omitempty"`
 	ObjectKey        *string                `json:"objectKey,omitempty"`
+	RetainUntil      *string                `json:"retainUntil,omitempty"`
 	Size             *int64                 `json:"size,omitempty"`
 	UpdatedAt        *string                `json:"updatedAt,omitempty"`
+	UploadedByRole   *string                `json:"uploadedByRole,omitempty"`
 	UploadID         *string                `json:"uploadId,omitempty"`
 	UploadedByUserID *string                `json:"uploadedByUserId,omitempty"`
 }
@@ -843,6 +1612,76 @@ type TimestamptzComparisonExp struct {
 	Nin    []string `json:"_nin,omitempty"`
 }
 
+// columns and relationships of "storage.usage"
+type Usages struct {
+	BucketID string `json:"bucketId"`
+	Bytes    int64  `json:"bytes"`
+	Files    int64  `json:"files"`
+	UserID   string `json:"userId"`
+}
+
+// Boolean expression to filter rows from the table "storage.usage". All fields are combined with a logical 'AND'.
+type UsagesBoolExp struct {
+	And      []*UsagesBoolExp     `json:"_and,omitempty"`
+	Not      *UsagesBoolExp       `json:"_not,omitempty"`
+	Or       []*UsagesBoolExp     `json:"_or,omitempty"`
+	BucketID *StringComparisonExp `json:"bucketId,omitempty"`
+	Bytes    *BigintComparisonExp `json:"bytes,omitempty"`
+	Files    *IntComparisonExp    `json:"files,omitempty"`
+	UserID   *UUIDComparisonExp   `json:"userId,omitempty"`
+}
+
+// input type for incrementing numeric columns in table "storage.usage"
+type UsagesIncInput struct {
+	Bytes *int64 `json:"bytes,omitempty"`
+	Files *int64 `json:"files,omitempty"`
+}
+
+// input type for inserting data into table "storage.usage"
+type UsagesInsertInput struct {
+	BucketID *string `json:"bucketId,omitempty"`
+	Bytes    *int64  `json:"bytes,omitempty"`
+	Files    *int64  `json:"files,omitempty"`
+	UserID   *string `json:"userId,omitempty"`
+}
+
+// response of any mutation on the table "storage.usage"
+type UsagesMutationResponse struct {
+	// number of rows affected by the mutation
+	AffectedRows int64 `json:"affected_rows"`
+	// data from the rows affected by the mutation
+	Returning []*Usages `json:"returning"`
+}
+
+// on_conflict condition type for table "storage.usage"
+type UsagesOnConflict struct {
+	Constraint    UsagesConstraint     `json:"constraint"`
+	UpdateColumns []UsagesUpdateColumn `json:"update_columns"`
+	Where         *UsagesBoolExp       `json:"where,omitempty"`
+}
+
+// Ordering options when selecting data from "storage.usage".
+type UsagesOrderBy struct {
+	BucketID *OrderBy `json:"bucketId,omitempty"`
+	Bytes    *OrderBy `json:"bytes,omitempty"`
+	Files    *OrderBy `json:"files,omitempty"`
+	UserID   *OrderBy `json:"userId,omitempty"`
+}
+
+// primary key columns input for table: storage.usage
+type UsagesPkColumnsInput struct {
+	BucketID string `json:"bucketId"`
+	UserID   string `json:"userId"`
+}
+
+// input type for updating data in table "storage.usage"
+type UsagesSetInput struct {
+	BucketID *string `json:"bucketId,omitempty"`
+	Bytes    *int64  `json:"bytes,omitempty"`
+	Files    *int64  `json:"files,omitempty"`
+	UserID   *string `json:"userId,omitempty"`
+}
+
 // Boolean expression to compare columns of type "uuid". All fields are combined with logical 'AND'.
 type UUIDComparisonExp struct {
 	Eq     *string  `json:"_eq,omitempty"`
@@ -858,12 +1697,14 @@ type UUIDComparisonExp struct {
 
 // columns and relationships of "storage.virus"
 type Virus struct {
-	CreatedAt string `json:"createdAt"`
+	CreatedAt     string `json:"createdAt"`
+	FalsePositive bool   `json:"falsePositive"`
 	// An object relationship
 	File        Files                  `json:"file"`
 	FileID      string                 `json:"fileId"`
 	Filename    string                 `json:"filename"`
 	ID          string                 `json:"id"`
+	Quarantine  map[string]interface{} `json:"quarantine,omitempty"`
 	UpdatedAt   string                 `json:"updatedAt"`
 	UserSession map[string]interface{} `json:"userSession"`
 	Virus       string                 `json:"virus"`
@@ -884,49 +1725,57 @@ type VirusAggregateFields struct {
 
 // append existing jsonb value of filtered columns with new jsonb value
 type VirusAppendInput struct {
+	Quarantine  map[string]interface{} `json:"quarantine,omitempty"`
 	UserSession map[string]interface{} `json:"userSession,omitempty"`
 }
 
 // Boolean expression to filter rows from the table "storage.virus". All fields are combined with a logical 'AND'.
 type VirusBoolExp struct {
-	And         []*VirusBoolExp           `json:"_and,omitempty"`
-	Not         *VirusBoolExp             `json:"_not,omitempty"`
-	Or          []*VirusBoolExp           `json:"_or,omitempty"`
-	CreatedAt   *TimestamptzComparisonExp `json:"createdAt,omitempty"`
-	File        *FilesBoolExp             `json:"file,omitempty"`
-	FileID      *UUIDComparisonExp        `json:"fileId,omitempty"`
-	Filename    *StringComparisonExp      `json:"filename,omitempty"`
-	ID          *UUIDComparisonExp        `json:"id,omitempty"`
-	UpdatedAt   *TimestamptzComparisonExp `json:"updatedAt,omitempty"`
-	UserSession *JsonbComparisonExp       `json:"userSession,omitempty"`
-	Virus       *StringComparisonExp      `json:"virus,omitempty"`
+	And           []*VirusBoolExp           `json:"_and,omitempty"`
+	Not           *VirusBoolExp             `json:"_not,omitempty"`
+	Or            []*VirusBoolExp           `json:"_or,omitempty"`
+	CreatedAt     *TimestamptzComparisonExp `json:"createdAt,omitempty"`
+	FalsePositive *BooleanComparisonExp     `json:"falsePositive,omitempty"`
+	File          *FilesBoolExp             `json:"file,omitempty"`
+	FileID        *UUIDComparisonExp        `json:"fileId,omitempty"`
+	Filename      *StringComparisonExp      `json:"filename,omitempty"`
+	ID            *UUIDComparisonExp        `json:"id,omitempty"`
+	Quarantine    *JsonbComparisonExp       `json:"quarantine,omitempty"`
+	UpdatedAt     *TimestamptzComparisonExp `json:"updatedAt,omitempty"`
+	UserSession   *JsonbComparisonExp       `json:"userSession,omitempty"`
+	Virus         *StringComparisonExp      `json:"virus,omitempty"`
 }
 
 // delete the field or element with specified path (for JSON arrays, negative integers count from the end)
 type VirusDeleteAtPathInput struct {
+	Quarantine  []string `json:"quarantine,omitempty"`
 	UserSession []string `json:"userSession,omitempty"`
 }
 
 // delete the array element with specified index (negative integers count from the end). throws an error if top level container is not an array
 type VirusDeleteElemInput struct {
+	Quarantine  *int64 `json:"quarantine,omitempty"`
 	UserSession *int64 `json:"userSession,omitempty"`
 }
 
 // delete key/value pair or string element. key/value pairs are matched based on their key value
 type VirusDeleteKeyInput struct {
+	Quarantine  *string `json:"quarantine,omitempty"`
 	UserSession *string `json:"userSession,omitempty"`
 }
 
 // input type for inserting data into table "storage.virus"
 type VirusInsertInput struct {
-	CreatedAt   *string                 `json:"createdAt,omitempty"`
-	File        *FilesObjRelInsertInput `json:"file,omitempty"`
-	FileID      *string                 `json:"fileId,omitempty"`
-	Filename    *string                 `json:"filename,omitempty"`
-	ID          *string                 `json:"id,omitempty"`
-	UpdatedAt   *string                 `json:"updatedAt,omitempty"`
-	UserSession map[string]interface{}  `json:"userSession,omitempty"`
-	Virus       *string                 `json:"virus,omitempty"`
+	CreatedAt     *string                 `json:"createdAt,omitempty"`
+	FalsePositive *bool                   `json:"falsePositive,omitempty"`
+	File          *FilesObjRelInsertInput `json:"file,omitempty"`
+	FileID        *string                 `json:"fileId,omitempty"`
+	Filename      *string                 `json:"filename,omitempty"`
+	ID            *string                 `json:"id,omitempty"`
+	Quarantine    map[string]interface{}  `json:"quarantine,omitempty"`
+	UpdatedAt     *string                 `json:"updatedAt,omitempty"`
+	UserSession   map[string]interface{}  `json:"userSession,omitempty"`
+	Virus         *string                 `json:"virus,omitempty"`
 }
 
 // aggregate max on columns
@@ -966,14 +1815,16 @@ type VirusOnConflict struct {
 
 // Ordering options when selecting data from "storage.virus".
 type VirusOrderBy struct {
-	CreatedAt   *OrderBy      `json:"createdAt,omitempty"`
-	File        *FilesOrderBy `json:"file,omitempty"`
-	FileID      *OrderBy      `json:"fileId,omitempty"`
-	Filename    *OrderBy      `json:"filename,omitempty"`
-	ID          *OrderBy      `json:"id,omitempty"`
-	UpdatedAt   *OrderBy      `json:"updatedAt,omitempty"`
-	UserSession *OrderBy      `json:"userSession,omitempty"`
-	Virus       *OrderBy      `json:"virus,omitempty"`
+	CreatedAt     *OrderBy      `json:"createdAt,omitempty"`
+	FalsePositive *OrderBy      `json:"falsePositive,omitempty"`
+	File          *FilesOrderBy `json:"file,omitempty"`
+	FileID        *OrderBy      `json:"fileId,omitempty"`
+	Filename      *OrderBy      `json:"filename,omitempty"`
+	ID            *OrderBy      `json:"id,omitempty"`
+	Quarantine    *OrderBy      `json:"quarantine,omitempty"`
+	UpdatedAt     *OrderBy      `json:"updatedAt,omitempty"`
+	UserSession   *OrderBy      `json:"userSession,omitempty"`
+	Virus         *OrderBy      `json:"virus,omitempty"`
 }
 
 // primary key columns input for table: storage.virus
@@ -983,18 +1834,21 @@ type VirusPkColumnsInput struct {
 
 // prepend existing jsonb value of filtered columns with new jsonb value
 type VirusPrependInput struct {
+	Quarantine  map[string]interface{} `json:"quarantine,omitempty"`
 	UserSession map[string]interface{} `json:"userSession,omitempty"`
 }
 
 // input type for updating data in table "storage.virus"
 type VirusSetInput struct {
-	CreatedAt   *string                `json:"createdAt,omitempty"`
-	FileID      *string                `json:"fileId,omitempty"`
-	Filename    *string                `json:"filename,omitempty"`
-	ID          *string                `json:"id,omitempty"`
-	UpdatedAt   *string                `json:"updatedAt,omitempty"`
-	UserSession map[string]interface{} `json:"userSession,omitempty"`
-	Virus       *string                `json:"virus,omitempty"`
+	CreatedAt     *string                `json:"createdAt,omitempty"`
+	FalsePositive *bool                  `json:"falsePositive,omitempty"`
+	FileID        *string                `json:"fileId,omitempty"`
+	Filename      *string                `json:"filename,omitempty"`
+	ID            *string                `json:"id,omitempty"`
+	Quarantine    map[string]interface{} `json:"quarantine,omitempty"`
+	UpdatedAt     *string                `json:"updatedAt,omitempty"`
+	UserSession   map[string]interface{} `json:"userSession,omitempty"`
+	Virus         *string                `json:"virus,omitempty"`
 }
 
 // Streaming cursor of the table "virus"
@@ -1007,13 +1861,15 @@ type VirusStreamCursorInput struct {
 
 // Initial value of the column from where the streaming should start
 type VirusStreamCursorValueInput struct {
-	CreatedAt   *string                `json:"createdAt,omitempty"`
-	FileID      *string                `json:"fileId,omitempty"`
-	Filename    *string                `json:"filename,omitempty"`
-	ID          *string                `json:"id,omitempty"`
-	UpdatedAt   *string                `json:"updatedAt,omitempty"`
-	UserSession map[string]interface{} `json:"userSession,omitempty"`
-	Virus       *string                `json:"virus,omitempty"`
+	CreatedAt     *string                `json:"createdAt,omitempty"`
+	FalsePositive *bool                  `json:"falsePositive,omitempty"`
+	FileID        *string                `json:"fileId,omitempty"`
+	Filename      *string                `json:"filename,omitempty"`
+	ID            *string                `json:"id,omitempty"`
+	Quarantine    map[string]interface{} `json:"quarantine,omitempty"`
+	UpdatedAt     *string                `json:"updatedAt,omitempty"`
+	UserSession   map[string]interface{} `json:"userSession,omitempty"`
+	Virus         *string                `json:"virus,omitempty"`
 }
 
 type VirusUpdates struct {
@@ -1033,86 +1889,414 @@ type VirusUpdates struct {
 	Where VirusBoolExp `json:"where"`
 }
 
-// unique or primary key constraints on table "storage.buckets"
-type BucketsConstraint string
+// columns and relationships of "storage.webhook_events"
+type WebhookEvents struct {
+	Attempts      int64                  `json:"attempts"`
+	BucketID      *string                `json:"bucketId,omitempty"`
+	CreatedAt     string                 `json:"createdAt"`
+	DeliveredAt   *string                `json:"deliveredAt,omitempty"`
+	EventType     string                 `json:"eventType"`
+	FileID        *string                `json:"fileId,omitempty"`
+	ID            string                 `json:"id"`
+	LastError     *string                `json:"lastError,omitempty"`
+	NextAttemptAt string                 `json:"nextAttemptAt"`
+	Payload       map[string]interface{} `json:"payload"`
+	UpdatedAt     string                 `json:"updatedAt"`
+}
+
+// Boolean expression to filter rows from the table "storage.webhook_events". All fields are combined with a logical 'AND'.
+type WebhookEventsBoolExp struct {
+	And           []*WebhookEventsBoolExp   `json:"_and,omitempty"`
+	Not           *WebhookEventsBoolExp     `json:"_not,omitempty"`
+	Or            []*WebhookEventsBoolExp   `json:"_or,omitempty"`
+	Attempts      *IntComparisonExp         `json:"attempts,omitempty"`
+	BucketID      *StringComparisonExp      `json:"bucketId,omitempty"`
+	CreatedAt     *TimestamptzComparisonExp `json:"createdAt,omitempty"`
+	DeliveredAt   *TimestamptzComparisonExp `json:"deliveredAt,omitempty"`
+	EventType     *StringComparisonExp      `json:"eventType,omitempty"`
+	FileID        *UUIDComparisonExp        `json:"fileId,omitempty"`
+	ID            *UUIDComparisonExp        `json:"id,omitempty"`
+	LastError     *StringComparisonExp      `json:"lastError,omitempty"`
+	NextAttemptAt *TimestamptzComparisonExp `json:"nextAttemptAt,omitempty"`
+	Payload       *JsonbComparisonExp       `json:"payload,omitempty"`
+	UpdatedAt     *TimestamptzComparisonExp `json:"updatedAt,omitempty"`
+}
+
+// input type for incrementing numeric columns in table "storage.webhook_events"
+type WebhookEventsIncInput struct {
+	Attempts *int64 `json:"attempts,omitempty"`
+}
+
+// input type for inserting data into table "storage.webhook_events"
+type WebhookEventsInsertInput struct {
+	Attempts      *int64                 `json:"attempts,omitempty"`
+	BucketID      *string                `json:"bucketId,omitempty"`
+	CreatedAt     *string                `json:"createdAt,omitempty"`
+	DeliveredAt   *string                `json:"deliveredAt,omitempty"`
+	EventType     *string                `json:"eventType,omitempty"`
+	FileID        *string                `json:"fileId,omitempty"`
+	ID            *string                `json:"id,omitempty"`
+	LastError     *string                `json:"lastError,omitempty"`
+	NextAttemptAt *string                `json:"nextAttemptAt,omitempty"`
+	Payload       map[string]interface{} `json:"payload,omitempty"`
+	UpdatedAt     *string                `json:"updatedAt,omitempty"`
+}
+
+// response of any mutation on the table "storage.webhook_events"
+type WebhookEventsMutationResponse struct {
+	// number of rows affected by the mutation
+	AffectedRows int64 `json:"affected_rows"`
+	// data from the rows affected by the mutation
+	Returning []*WebhookEvents `json:"returning"`
+}
+
+// on_conflict condition type for table "storage.webhook_events"
+type WebhookEventsOnConflict struct {
+	Constraint    WebhookEventsConstraint     `json:"constraint"`
+	UpdateColumns []WebhookEventsUpdateColumn `json:"update_columns"`
+	Where         *WebhookEventsBoolExp       `json:"where,omitempty"`
+}
+
+// Ordering options when selecting data from "storage.webhook_events".
+type WebhookEventsOrderBy struct {
+	Attempts      *OrderBy `json:"attempts,omitempty"`
+	BucketID      *OrderBy `json:"bucketId,omitempty"`
+	CreatedAt     *OrderBy `json:"createdAt,omitempty"`
+	DeliveredAt   *OrderBy `json:"deliveredAt,omitempty"`
+	EventType     *OrderBy `json:"eventType,omitempty"`
+	FileID        *OrderBy `json:"fileId,omitempty"`
+	ID            *OrderBy `json:"id,omitempty"`
+	LastError     *OrderBy `json:"lastError,omitempty"`
+	NextAttemptAt *OrderBy `json:"nextAttemptAt,omitempty"`
+	Payload       *OrderBy `json:"payload,omitempty"`
+	UpdatedAt     *OrderBy `json:"updatedAt,omitempty"`
+}
+
+// primary key columns input for table: storage.webhook_events
+type WebhookEventsPkColumnsInput struct {
+	ID string `json:"id"`
+}
+
+// input type for updating data in table "storage.webhook_events"
+type WebhookEventsSetInput struct {
+	Attempts      *int64                 `json:"attempts,omitempty"`
+	BucketID      *string                `json:"bucketId,omitempty"`
+	CreatedAt     *string                `json:"createdAt,omitempty"`
+	DeliveredAt   *string                `json:"deliveredAt,omitempty"`
+	EventType     *string                `json:"eventType,omitempty"`
+	FileID        *string                `json:"fileId,omitempty"`
+	ID            *string                `json:"id,omitempty"`
+	LastError     *string                `json:"lastError,omitempty"`
+	NextAttemptAt *string                `json:"nextAttemptAt,omitempty"`
+	Payload       map[string]interface{} `json:"payload,omitempty"`
+	UpdatedAt     *string                `json:"updatedAt,omitempty"`
+}
+
+// columns and relationships of "storage.webhook_secrets"
+type WebhookSecrets struct {
+	BucketID string `json:"bucketId"`
+	Secret   string `json:"secret"`
+}
+
+// unique or primary key constraints on table "storage.api_keys"
+type APIKeysConstraint string
 
 const (
 	// unique or primary key constraint on columns "id"
-	BucketsConstraintBucketsPkey BucketsConstraint = "buckets_pkey"
+	APIKeysConstraintApiKeysPkey APIKeysConstraint = "api_keys_pkey"
 )
 
-var AllBucketsConstraint = []BucketsConstraint{
-	BucketsConstraintBucketsPkey,
+var AllAPIKeysConstraint = []APIKeysConstraint{
+	APIKeysConstraintApiKeysPkey,
 }
 
-func (e BucketsConstraint) IsValid() bool {
+func (e APIKeysConstraint) IsValid() bool {
 	switch e {
-	case BucketsConstraintBucketsPkey:
+	case APIKeysConstraintApiKeysPkey:
 		return true
 	}
 	return false
 }
 
-func (e BucketsConstraint) String() string {
+func (e APIKeysConstraint) String() string {
 	return string(e)
 }
 
-func (e *BucketsConstraint) UnmarshalGQL(v interface{}) error {
+func (e *APIKeysConstraint) UnmarshalGQL(v interface{}) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = BucketsConstraint(str)
+	*e = APIKeysConstraint(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid buckets_constraint", str)
+		return fmt.Errorf("%s is not a valid apiKeys_constraint", str)
 	}
 	return nil
 }
 
-func (e BucketsConstraint) MarshalGQL(w io.Writer) {
+func (e APIKeysConstraint) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-// select columns of table "storage.buckets"
-type BucketsSelectColumn string
+// select columns of table "storage.api_keys"
+type APIKeysSelectColumn string
 
 const (
 	// column name
-	BucketsSelectColumnCacheControl BucketsSelectColumn = "cacheControl"
+	APIKeysSelectColumnBuckets APIKeysSelectColumn = "buckets"
 	// column name
-	BucketsSelectColumnCreatedAt BucketsSelectColumn = "createdAt"
+	APIKeysSelectColumnCreatedAt APIKeysSelectColumn = "createdAt"
 	// column name
-	BucketsSelectColumnDownloadExpiration BucketsSelectColumn = "downloadExpiration"
+	APIKeysSelectColumnExpiresAt APIKeysSelectColumn = "expiresAt"
 	// column name
-	BucketsSelectColumnID BucketsSelectColumn = "id"
+	APIKeysSelectColumnID APIKeysSelectColumn = "id"
 	// column name
-	BucketsSelectColumnMaxUploadFileSize BucketsSelectColumn = "maxUploadFileSize"
+	APIKeysSelectColumnKeyHash APIKeysSelectColumn = "keyHash"
 	// column name
-	BucketsSelectColumnMinUploadFileSize BucketsSelectColumn = "minUploadFileSize"
+	APIKeysSelectColumnLastUsedAt APIKeysSelectColumn = "lastUsedAt"
 	// column name
-	BucketsSelectColumnPresignedUrlsEnabled BucketsSelectColumn = "presignedUrlsEnabled"
+	APIKeysSelectColumnName APIKeysSelectColumn = "name"
 	// column name
-	BucketsSelectColumnUpdatedAt BucketsSelectColumn = "updatedAt"
+	APIKeysSelectColumnOperations APIKeysSelectColumn = "operations"
 	// column name
-	BucketsSelectColumnUploadExpiration BucketsSelectColumn = "uploadExpiration"
+	APIKeysSelectColumnRole APIKeysSelectColumn = "role"
+	// column name
+	APIKeysSelectColumnUpdatedAt APIKeysSelectColumn = "updatedAt"
 )
 
-var AllBucketsSelectColumn = []BucketsSelectColumn{
-	BucketsSelectColumnCacheControl,
-	BucketsSelectColumnCreatedAt,
-	BucketsSelectColumnDownloadExpiration,
-	BucketsSelectColumnID,
-	BucketsSelectColumnMaxUploadFileSize,
-	BucketsSelectColumnMinUploadFileSize,
-	BucketsSelectColumnPresignedUrlsEnabled,
+var AllAPIKeysSelectColumn = []APIKeysSelectColumn{
+	APIKeysSelectColumnBuckets,
+	APIKeysSelectColumnCreatedAt,
+	APIKeysSelectColumnExpiresAt,
+	APIKeysSelectColumnID,
+	APIKeysSelectColumnKeyHash,
+	APIKeysSelectColumnLastUsedAt,
+	APIKeysSelectColumnName,
+	APIKeysSelectColumnOperations,
+	APIKeysSelectColumnRole,
+	APIKeysSelectColumnUpdatedAt,
+}
+
+func (e APIKeysSelectColumn) IsValid() bool {
+	switch e {
+	case APIKeysSelectColumnBuckets, APIKeysSelectColumnCreatedAt, APIKeysSelectColumnExpiresAt, APIKeysSelectColumnID, APIKeysSelectColumnKeyHash, APIKeysSelectColumnLastUsedAt, APIKeysSelectColumnName, APIKeysSelectColumnOperations, APIKeysSelectColumnRole, APIKeysSelectColumnUpdatedAt:
+		return true
+	}
+	return false
+}
+
+func (e APIKeysSelectColumn) String() string {
+	return string(e)
+}
+
+func (e *APIKeysSelectColumn) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = APIKeysSelectColumn(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid apiKeys_select_column", str)
+	}
+	return nil
+}
+
+func (e APIKeysSelectColumn) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// update columns of table "storage.api_keys"
+type APIKeysUpdateColumn string
+
+const (
+	// column name
+	APIKeysUpdateColumnBuckets APIKeysUpdateColumn = "buckets"
+	// column name
+	APIKeysUpdateColumnCreatedAt APIKeysUpdateColumn = "createdAt"
+	// column name
+	APIKeysUpdateColumnExpiresAt APIKeysUpdateColumn = "expiresAt"
+	// column name
+	APIKeysUpdateColumnID APIKeysUpdateColumn = "id"
+	// column name
+	APIKeysUpdateColumnKeyHash APIKeysUpdateColumn = "keyHash"
+	// column name
+	APIKeysUpdateColumnLastUsedAt APIKeysUpdateColumn = "lastUsedAt"
+	// column name
+	APIKeysUpdateColumnName APIKeysUpdateColumn = "name"
+	// column name
+	APIKeysUpdateColumnOperations APIKeysUpdateColumn = "operations"
+	// column name
+	APIKeysUpdateColumnRole APIKeysUpdateColumn = "role"
+	// column name
+	APIKeysUpdateColumnUpdatedAt APIKeysUpdateColumn = "updatedAt"
+)
+
+var AllAPIKeysUpdateColumn = []APIKeysUpdateColumn{
+	APIKeysUpdateColumnBuckets,
+	APIKeysUpdateColumnCreatedAt,
+	APIKeysUpdateColumnExpiresAt,
+	APIKeysUpdateColumnID,
+	APIKeysUpdateColumnKeyHash,
+	APIKeysUpdateColumnLastUsedAt,
+	APIKeysUpdateColumnName,
+	APIKeysUpdateColumnOperations,
+	APIKeysUpdateColumnRole,
+	APIKeysUpdateColumnUpdatedAt,
+}
+
+func (e APIKeysUpdateColumn) IsValid() bool {
+	switch e {
+	case APIKeysUpdateColumnBuckets, APIKeysUpdateColumnCreatedAt, APIKeysUpdateColumnExpiresAt, APIKeysUpdateColumnID, APIKeysUpdateColumnKeyHash, APIKeysUpdateColumnLastUsedAt, APIKeysUpdateColumnName, APIKeysUpdateColumnOperations, APIKeysUpdateColumnRole, APIKeysUpdateColumnUpdatedAt:
+		return true
+	}
+	return false
+}
+
+func (e APIKeysUpdateColumn) String() string {
+	return string(e)
+}
+
+func (e *APIKeysUpdateColumn) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = APIKeysUpdateColumn(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid apiKeys_update_column", str)
+	}
+	return nil
+}
+
+func (e APIKeysUpdateColumn) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// unique or primary key constraints on table "storage.buckets"
+type BucketsConstraint string
+
+const (
+	// unique or primary key constraint on columns "id"
+	BucketsConstraintBucketsPkey BucketsConstraint = "buckets_pkey"
+)
+
+var AllBucketsConstraint = []BucketsConstraint{
+	BucketsConstraintBucketsPkey,
+}
+
+func (e BucketsConstraint) IsValid() bool {
+	switch e {
+	case BucketsConstraintBucketsPkey:
+		return true
+	}
+	return false
+}
+
+func (e BucketsConstraint) String() string {
+	return string(e)
+}
+
+func (e *BucketsConstraint) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = BucketsConstraint(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid buckets_constraint", str)
+	}
+	return nil
+}
+
+func (e BucketsConstraint) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// select columns of table "storage.buckets"
+type BucketsSelectColumn string
+
+const (
+	// column name
+	BucketsSelectColumnAllowedExtensions BucketsSelectColumn = "allowedExtensions"
+	// column name
+	BucketsSelectColumnAllowedMimeTypes BucketsSelectColumn = "allowedMimeTypes"
+	// column name
+	BucketsSelectColumnCacheControl BucketsSelectColumn = "cacheControl"
+	// column name
+	BucketsSelectColumnCreatedAt BucketsSelectColumn = "createdAt"
+	// column name
+	BucketsSelectColumnDefaultTTLSeconds BucketsSelectColumn = "defaultTtlSeconds"
+	// column name
+	BucketsSelectColumnDeniedExtensions BucketsSelectColumn = "deniedExtensions"
+	// column name
+	BucketsSelectColumnDeniedMimeTypes BucketsSelectColumn = "deniedMimeTypes"
+	// column name
+	BucketsSelectColumnDownloadExpiration BucketsSelectColumn = "downloadExpiration"
+	// column name
+	BucketsSelectColumnID BucketsSelectColumn = "id"
+	// column name
+	BucketsSelectColumnMaxUploadFileSize BucketsSelectColumn = "maxUploadFileSize"
+	// column name
+	BucketsSelectColumnMaxVersions BucketsSelectColumn = "maxVersions"
+	// column name
+	BucketsSelectColumnMinUploadFileSize BucketsSelectColumn = "minUploadFileSize"
+	// column name
+	BucketsSelectColumnPresignedUrlsEnabled BucketsSelectColumn = "presignedUrlsEnabled"
+	// column name
+	BucketsSelectColumnRedirectDownloads BucketsSelectColumn = "redirectDownloads"
+	// column name
+	BucketsSelectColumnRetentionDays BucketsSelectColumn = "retentionDays"
+	// column name
+	BucketsSelectColumnRetentionMode BucketsSelectColumn = "retentionMode"
+	// column name
+	BucketsSelectColumnSoftDeleteEnabled BucketsSelectColumn = "softDeleteEnabled"
+	// column name
+	BucketsSelectColumnTrashRetentionDays BucketsSelectColumn = "trashRetentionDays"
+	// column name
+	BucketsSelectColumnUpdatedAt BucketsSelectColumn = "updatedAt"
+	// column name
+	BucketsSelectColumnUploadExpiration BucketsSelectColumn = "uploadExpiration"
+	// column name
+	BucketsSelectColumnVersionRetentionDays BucketsSelectColumn = "versionRetentionDays"
+	// column name
+	BucketsSelectColumnVersioningEnabled BucketsSelectColumn = "versioningEnabled"
+	// column name
+	BucketsSelectColumnWebhookURL BucketsSelectColumn = "webhookUrl"
+)
+
+var AllBucketsSelectColumn = []BucketsSelectColumn{
+	BucketsSelectColumnAllowedExtensions,
+	BucketsSelectColumnAllowedMimeTypes,
+	BucketsSelectColumnCacheControl,
+	BucketsSelectColumnCreatedAt,
+	BucketsSelectColumnDefaultTTLSeconds,
+	BucketsSelectColumnDeniedExtensions,
+	BucketsSelectColumnDeniedMimeTypes,
+	BucketsSelectColumnDownloadExpiration,
+	BucketsSelectColumnID,
+	BucketsSelectColumnMaxUploadFileSize,
+	BucketsSelectColumnMaxVersions,
+	BucketsSelectColumnMinUploadFileSize,
+	BucketsSelectColumnPresignedUrlsEnabled,
+	BucketsSelectColumnRedirectDownloads,
+	BucketsSelectColumnRetentionDays,
+	BucketsSelectColumnRetentionMode,
+	BucketsSelectColumnSoftDeleteEnabled,
+	BucketsSelectColumnTrashRetentionDays,
 	BucketsSelectColumnUpdatedAt,
 	BucketsSelectColumnUploadExpiration,
+	BucketsSelectColumnVersioningEnabled,
+	BucketsSelectColumnVersionRetentionDays,
+	BucketsSelectColumnWebhookURL,
 }
 
 func (e BucketsSelectColumn) IsValid() bool {
 	switch e {
-	case BucketsSelectColumnCacheControl, BucketsSelectColumnCreatedAt, BucketsSelectColumnDownloadExpiration, BucketsSelectColumnID, BucketsSelectColumnMaxUploadFileSize, BucketsSelectColumnMinUploadFileSize, BucketsSelectColumnPresignedUrlsEnabled, BucketsSelectColumnUpdatedAt, BucketsSelectColumnUploadExpiration:
+	case BucketsSelectColumnAllowedExtensions, BucketsSelectColumnAllowedMimeTypes, BucketsSelectColumnCacheControl, BucketsSelectColumnCreatedAt, BucketsSelectColumnDefaultTTLSeconds, BucketsSelectColumnDeniedExtensions, BucketsSelectColumnDeniedMimeTypes, BucketsSelectColumnDownloadExpiration, BucketsSelectColumnID, BucketsSelectColumnMaxUploadFileSize, BucketsSelectColumnMaxVersions, BucketsSelectColumnMinUploadFileSize, BucketsSelectColumnPresignedUrlsEnabled, BucketsSelectColumnRedirectDownloads, BucketsSelectColumnRetentionDays, BucketsSelectColumnRetentionMode, BucketsSelectColumnSoftDeleteEnabled, BucketsSelectColumnTrashRetentionDays, BucketsSelectColumnUpdatedAt, BucketsSelectColumnUploadExpiration, BucketsSelectColumnVersioningEnabled, BucketsSelectColumnVersionRetentionDays, BucketsSelectColumnWebhookURL:
 		return true
 	}
 	return false
@@ -1143,41 +2327,83 @@ func (e BucketsSelectColumn) MarshalGQL(w io.Writer) {
 type BucketsUpdateColumn string
 
 const (
+	// column name
+	BucketsUpdateColumnAllowedExtensions BucketsUpdateColumn = "allowedExtensions"
+	// column name
+	BucketsUpdateColumnAllowedMimeTypes BucketsUpdateColumn = "allowedMimeTypes"
 	// column name
 	BucketsUpdateColumnCacheControl BucketsUpdateColumn = "cacheControl"
 	// column name
 	BucketsUpdateColumnCreatedAt BucketsUpdateColumn = "createdAt"
 	// column name
+	BucketsUpdateColumnDefaultTTLSeconds BucketsUpdateColumn = "defaultTtlSeconds"
+	// column name
+	BucketsUpdateColumnDeniedExtensions BucketsUpdateColumn = "deniedExtensions"
+	// column name
+	BucketsUpdateColumnDeniedMimeTypes BucketsUpdateColumn = "deniedMimeTypes"
+	// column name
 	BucketsUpdateColumnDownloadExpiration BucketsUpdateColumn = "downloadExpiration"
 	// column name
 	BucketsUpdateColumnID BucketsUpdateColumn = "id"
 	// column name
 	BucketsUpdateColumnMaxUploadFileSize BucketsUpdateColumn = "maxUploadFileSize"
 	// column name
+	BucketsUpdateColumnMaxVersions BucketsUpdateColumn = "maxVersions"
+	// column name
 	BucketsUpdateColumnMinUploadFileSize BucketsUpdateColumn = "minUploadFileSize"
 	// column name
 	BucketsUpdateColumnPresignedUrlsEnabled BucketsUpdateColumn = "presignedUrlsEnabled"
 	// column name
+	BucketsUpdateColumnRedirectDownloads BucketsUpdateColumn = "redirectDownloads"
+	// column name
+	BucketsUpdateColumnRetentionDays BucketsUpdateColumn = "retentionDays"
+	// column name
+	BucketsUpdateColumnRetentionMode BucketsUpdateColumn = "retentionMode"
+	// column name
+	BucketsUpdateColumnSoftDeleteEnabled BucketsUpdateColumn = "softDeleteEnabled"
+	// column name
+	BucketsUpdateColumnTrashRetentionDays BucketsUpdateColumn = "trashRetentionDays"
+	// column name
 	BucketsUpdateColumnUpdatedAt BucketsUpdateColumn = "updatedAt"
 	// column name
 	BucketsUpdateColumnUploadExpiration BucketsUpdateColumn = "uploadExpiration"
+	// column name
+	BucketsUpdateColumnVersionRetentionDays BucketsUpdateColumn = "versionRetentionDays"
+	// column name
+	BucketsUpdateColumnVersioningEnabled BucketsUpdateColumn = "versioningEnabled"
+	// column name
+	BucketsUpdateColumnWebhookURL BucketsUpdateColumn = "webhookUrl"
 )
 
 var AllBucketsUpdateColumn = []BucketsUpdateColumn{
+	BucketsUpdateColumnAllowedExtensions,
+	BucketsUpdateColumnAllowedMimeTypes,
 	BucketsUpdateColumnCacheControl,
 	BucketsUpdateColumnCreatedAt,
+	BucketsUpdateColumnDefaultTTLSeconds,
+	BucketsUpdateColumnDeniedExtensions,
+	BucketsUpdateColumnDeniedMimeTypes,
 	BucketsUpdateColumnDownloadExpiration,
 	BucketsUpdateColumnID,
 	BucketsUpdateColumnMaxUploadFileSize,
+	BucketsUpdateColumnMaxVersions,
 	BucketsUpdateColumnMinUploadFileSize,
 	BucketsUpdateColumnPresignedUrlsEnabled,
+	BucketsUpdateColumnRedirectDownloads,
+	BucketsUpdateColumnRetentionDays,
+	BucketsUpdateColumnRetentionMode,
+	BucketsUpdateColumnSoftDeleteEnabled,
+	BucketsUpdateColumnTrashRetentionDays,
 	BucketsUpdateColumnUpdatedAt,
 	BucketsUpdateColumnUploadExpiration,
+	BucketsUpdateColumnVersioningEnabled,
+	BucketsUpdateColumnVersionRetentionDays,
+	BucketsUpdateColumnWebhookURL,
 }
 
 func (e BucketsUpdateColumn) IsValid() bool {
 	switch e {
-	case BucketsUpdateColumnCacheControl, BucketsUpdateColumnCreatedAt, BucketsUpdateColumnDownloadExpiration, BucketsUpdateColumnID, BucketsUpdateColumnMaxUploadFileSize, BucketsUpdateColumnMinUploadFileSize, BucketsUpdateColumnPresignedUrlsEnabled, BucketsUpdateColumnUpdatedAt, BucketsUpdateColumnUploadExpiration:
+	case BucketsUpdateColumnAllowedExtensions, BucketsUpdateColumnAllowedMimeTypes, BucketsUpdateColumnCacheControl, BucketsUpdateColumnCreatedAt, BucketsUpdateColumnDefaultTTLSeconds, BucketsUpdateColumnDeniedExtensions, BucketsUpdateColumnDeniedMimeTypes, BucketsUpdateColumnDownloadExpiration, BucketsUpdateColumnID, BucketsUpdateColumnMaxUploadFileSize, BucketsUpdateColumnMaxVersions, BucketsUpdateColumnMinUploadFileSize, BucketsUpdateColumnPresignedUrlsEnabled, BucketsUpdateColumnRedirectDownloads, BucketsUpdateColumnRetentionDays, BucketsUpdateColumnRetentionMode, BucketsUpdateColumnSoftDeleteEnabled, BucketsUpdateColumnTrashRetentionDays, BucketsUpdateColumnUpdatedAt, BucketsUpdateColumnUploadExpiration, BucketsUpdateColumnVersioningEnabled, BucketsUpdateColumnVersionRetentionDays, BucketsUpdateColumnWebhookURL:
 		return true
 	}
 	return false
@@ -1248,348 +2474,1371 @@ func (e CursorOrdering) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-// unique or primary key constraints on table "storage.files"
-type FilesConstraint string
+// select columns of table "storage.expired_file_versions"
+type ExpiredFileVersionsSelectColumn string
 
 const (
-	// unique or primary key constraint on columns "id"
-	FilesConstraintFilesPkey FilesConstraint = "files_pkey"
+	// column name
+	ExpiredFileVersionsSelectColumnCreatedAt ExpiredFileVersionsSelectColumn = "createdAt"
+	// column name
+	ExpiredFileVersionsSelectColumnFileID ExpiredFileVersionsSelectColumn = "fileId"
+	// column name
+	ExpiredFileVersionsSelectColumnID ExpiredFileVersionsSelectColumn = "id"
+	// column name
+	ExpiredFileVersionsSelectColumnObjectKey ExpiredFileVersionsSelectColumn = "objectKey"
 )
 
-var AllFilesConstraint = []FilesConstraint{
-	FilesConstraintFilesPkey,
+var AllExpiredFileVersionsSelectColumn = []ExpiredFileVersionsSelectColumn{
+	ExpiredFileVersionsSelectColumnCreatedAt,
+	ExpiredFileVersionsSelectColumnFileID,
+	ExpiredFileVersionsSelectColumnID,
+	ExpiredFileVersionsSelectColumnObjectKey,
 }
 
-func (e FilesConstraint) IsValid() bool {
+func (e ExpiredFileVersionsSelectColumn) IsValid() bool {
 	switch e {
-	case FilesConstraintFilesPkey:
+	case ExpiredFileVersionsSelectColumnCreatedAt, ExpiredFileVersionsSelectColumnFileID, ExpiredFileVersionsSelectColumnID, ExpiredFileVersionsSelectColumnObjectKey:
 		return true
 	}
 	return false
 }
 
-func (e FilesConstraint) String() string {
+func (e ExpiredFileVersionsSelectColumn) String() string {
 	return string(e)
 }
 
-func (e *FilesConstraint) UnmarshalGQL(v interface{}) error {
+func (e *ExpiredFileVersionsSelectColumn) UnmarshalGQL(v interface{}) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = FilesConstraint(str)
+	*e = ExpiredFileVersionsSelectColumn(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid files_constraint", str)
+		return fmt.Errorf("%s is not a valid expiredFileVersions_select_column", str)
 	}
 	return nil
 }
 
-func (e FilesConstraint) MarshalGQL(w io.Writer) {
+func (e ExpiredFileVersionsSelectColumn) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-// select columns of table "storage.files"
-type FilesSelectColumn string
+// select columns of table "storage.expired_files"
+type ExpiredFilesSelectColumn string
 
 const (
 	// column name
-	FilesSelectColumnBucketID FilesSelectColumn = "bucketId"
+	ExpiredFilesSelectColumnBucketID ExpiredFilesSelectColumn = "bucketId"
 	// column name
-	FilesSelectColumnChunkCount FilesSelectColumn = "chunkCount"
+	ExpiredFilesSelectColumnExpiresAt ExpiredFilesSelectColumn = "expiresAt"
 	// column name
-	FilesSelectColumnChunkSize FilesSelectColumn = "chunkSize"
+	ExpiredFilesSelectColumnID ExpiredFilesSelectColumn = "id"
 	// column name
-	FilesSelectColumnCreatedAt FilesSelectColumn = "createdAt"
+	ExpiredFilesSelectColumnObjectKey ExpiredFilesSelectColumn = "objectKey"
 	// column name
-	FilesSelectColumnEtag FilesSelectColumn = "etag"
+	ExpiredFilesSelectColumnUpdatedAt ExpiredFilesSelectColumn = "updatedAt"
+)
+
+var AllExpiredFilesSelectColumn = []ExpiredFilesSelectColumn{
+	ExpiredFilesSelectColumnBucketID,
+	ExpiredFilesSelectColumnExpiresAt,
+	ExpiredFilesSelectColumnID,
+	ExpiredFilesSelectColumnObjectKey,
+	ExpiredFilesSelectColumnUpdatedAt,
+}
+
+func (e ExpiredFilesSelectColumn) IsValid() bool {
+	switch e {
+	case ExpiredFilesSelectColumnBucketID, ExpiredFilesSelectColumnExpiresAt, ExpiredFilesSelectColumnID, ExpiredFilesSelectColumnObjectKey, ExpiredFilesSelectColumnUpdatedAt:
+		return true
+	}
+	return false
+}
+
+func (e ExpiredFilesSelectColumn) String() string {
+	return string(e)
+}
+
+func (e *ExpiredFilesSelectColumn) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ExpiredFilesSelectColumn(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid expiredFiles_select_column", str)
+	}
+	return nil
+}
+
+func (e ExpiredFilesSelectColumn) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// select columns of table "storage.expired_trash"
+type ExpiredTrashSelectColumn string
+
+const (
 	// column name
-	FilesSelectColumnID FilesSelectColumn = "id"
+	ExpiredTrashSelectColumnBucketID ExpiredTrashSelectColumn = "bucketId"
 	// column name
-	FilesSelectColumnIsUploaded FilesSelectColumn = "isUploaded"
+	ExpiredTrashSelectColumnDeletedAt ExpiredTrashSelectColumn = "deletedAt"
+	// column name
+	ExpiredTrashSelectColumnID ExpiredTrashSelectColumn = "id"
+	// column name
+	ExpiredTrashSelectColumnObjectKey ExpiredTrashSelectColumn = "objectKey"
+	// column name
+	ExpiredTrashSelectColumnUpdatedAt ExpiredTrashSelectColumn = "updatedAt"
+)
+
+var AllExpiredTrashSelectColumn = []ExpiredTrashSelectColumn{
+	ExpiredTrashSelectColumnBucketID,
+	ExpiredTrashSelectColumnDeletedAt,
+	ExpiredTrashSelectColumnID,
+	ExpiredTrashSelectColumnObjectKey,
+	ExpiredTrashSelectColumnUpdatedAt,
+}
+
+func (e ExpiredTrashSelectColumn) IsValid() bool {
+	switch e {
+	case ExpiredTrashSelectColumnBucketID, ExpiredTrashSelectColumnDeletedAt, ExpiredTrashSelectColumnID, ExpiredTrashSelectColumnObjectKey, ExpiredTrashSelectColumnUpdatedAt:
+		return true
+	}
+	return false
+}
+
+func (e ExpiredTrashSelectColumn) String() string {
+	return string(e)
+}
+
+func (e *ExpiredTrashSelectColumn) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ExpiredTrashSelectColumn(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid expiredTrash_select_column", str)
+	}
+	return nil
+}
+
+func (e ExpiredTrashSelectColumn) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// unique or primary key constraints on table "storage.file_audit_events"
+type FileAuditEventsConstraint string
+
+const (
+	// unique or primary key constraint on columns "id"
+	FileAuditEventsConstraintFileAuditEventsPkey FileAuditEventsConstraint = "file_audit_events_pkey"
+)
+
+var AllFileAuditEventsConstraint = []FileAuditEventsConstraint{
+	FileAuditEventsConstraintFileAuditEventsPkey,
+}
+
+func (e FileAuditEventsConstraint) IsValid() bool {
+	switch e {
+	case FileAuditEventsConstraintFileAuditEventsPkey:
+		return true
+	}
+	return false
+}
+
+func (e FileAuditEventsConstraint) String() string {
+	return string(e)
+}
+
+func (e *FileAuditEventsConstraint) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FileAuditEventsConstraint(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid fileAuditEvents_constraint", str)
+	}
+	return nil
+}
+
+func (e FileAuditEventsConstraint) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// select columns of table "storage.file_audit_events"
+type FileAuditEventsSelectColumn string
+
+const (
+	// column name
+	FileAuditEventsSelectColumnAction FileAuditEventsSelectColumn = "action"
+	// column name
+	FileAuditEventsSelectColumnAPIKeyID FileAuditEventsSelectColumn = "apiKeyId"
+	// column name
+	FileAuditEventsSelectColumnCreatedAt FileAuditEventsSelectColumn = "createdAt"
+	// column name
+	FileAuditEventsSelectColumnFileID FileAuditEventsSelectColumn = "fileId"
+	// column name
+	FileAuditEventsSelectColumnID FileAuditEventsSelectColumn = "id"
+	// column name
+	FileAuditEventsSelectColumnReason FileAuditEventsSelectColumn = "reason"
+	// column name
+	FileAuditEventsSelectColumnUserID FileAuditEventsSelectColumn = "userId"
+)
+
+var AllFileAuditEventsSelectColumn = []FileAuditEventsSelectColumn{
+	FileAuditEventsSelectColumnAction,
+	FileAuditEventsSelectColumnAPIKeyID,
+	FileAuditEventsSelectColumnCreatedAt,
+	FileAuditEventsSelectColumnFileID,
+	FileAuditEventsSelectColumnID,
+	FileAuditEventsSelectColumnReason,
+	FileAuditEventsSelectColumnUserID,
+}
+
+func (e FileAuditEventsSelectColumn) IsValid() bool {
+	switch e {
+	case FileAuditEventsSelectColumnAction, FileAuditEventsSelectColumnAPIKeyID, FileAuditEventsSelectColumnCreatedAt, FileAuditEventsSelectColumnFileID, FileAuditEventsSelectColumnID, FileAuditEventsSelectColumnReason, FileAuditEventsSelectColumnUserID:
+		return true
+	}
+	return false
+}
+
+func (e FileAuditEventsSelectColumn) String() string {
+	return string(e)
+}
+
+func (e *FileAuditEventsSelectColumn) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FileAuditEventsSelectColumn(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid fileAuditEvents_select_column", str)
+	}
+	return nil
+}
+
+func (e FileAuditEventsSelectColumn) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// update columns of table "storage.file_audit_events"
+type FileAuditEventsUpdateColumn string
+
+const (
+	// column name
+	FileAuditEventsUpdateColumnAction FileAuditEventsUpdateColumn = "action"
+	// column name
+	FileAuditEventsUpdateColumnAPIKeyID FileAuditEventsUpdateColumn = "apiKeyId"
+	// column name
+	FileAuditEventsUpdateColumnCreatedAt FileAuditEventsUpdateColumn = "createdAt"
+	// column name
+	FileAuditEventsUpdateColumnFileID FileAuditEventsUpdateColumn = "fileId"
+	// column name
+	FileAuditEventsUpdateColumnID FileAuditEventsUpdateColumn = "id"
+	// column name
+	FileAuditEventsUpdateColumnReason FileAuditEventsUpdateColumn = "reason"
+	// column name
+	FileAuditEventsUpdateColumnUserID FileAuditEventsUpdateColumn = "userId"
+)
+
+var AllFileAuditEventsUpdateColumn = []FileAuditEventsUpdateColumn{
+	FileAuditEventsUpdateColumnAction,
+	FileAuditEventsUpdateColumnAPIKeyID,
+	FileAuditEventsUpdateColumnCreatedAt,
+	FileAuditEventsUpdateColumnFileID,
+	FileAuditEventsUpdateColumnID,
+	FileAuditEventsUpdateColumnReason,
+	FileAuditEventsUpdateColumnUserID,
+}
+
+func (e FileAuditEventsUpdateColumn) IsValid() bool {
+	switch e {
+	case FileAuditEventsUpdateColumnAction, FileAuditEventsUpdateColumnAPIKeyID, FileAuditEventsUpdateColumnCreatedAt, FileAuditEventsUpdateColumnFileID, FileAuditEventsUpdateColumnID, FileAuditEventsUpdateColumnReason, FileAuditEventsUpdateColumnUserID:
+		return true
+	}
+	return false
+}
+
+func (e FileAuditEventsUpdateColumn) String() string {
+	return string(e)
+}
+
+func (e *FileAuditEventsUpdateColumn) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FileAuditEventsUpdateColumn(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid fileAuditEvents_update_column", str)
+	}
+	return nil
+}
+
+func (e FileAuditEventsUpdateColumn) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// unique or primary key constraints on table "storage.file_versions"
+type FileVersionsConstraint string
+
+const (
+	// unique or primary key constraint on columns "id"
+	FileVersionsConstraintFileVersionsPkey FileVersionsConstraint = "file_versions_pkey"
+)
+
+var AllFileVersionsConstraint = []FileVersionsConstraint{
+	FileVersionsConstraintFileVersionsPkey,
+}
+
+func (e FileVersionsConstraint) IsValid() bool {
+	switch e {
+	case FileVersionsConstraintFileVersionsPkey:
+		return true
+	}
+	return false
+}
+
+func (e FileVersionsConstraint) String() string {
+	return string(e)
+}
+
+func (e *FileVersionsConstraint) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FileVersionsConstraint(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid fileVersions_constraint", str)
+	}
+	return nil
+}
+
+func (e FileVersionsConstraint) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// select columns of table "storage.file_versions"
+type FileVersionsSelectColumn string
+
+const (
+	// column name
+	FileVersionsSelectColumnCreatedAt FileVersionsSelectColumn = "createdAt"
+	// column name
+	FileVersionsSelectColumnEtag FileVersionsSelectColumn = "etag"
+	// column name
+	FileVersionsSelectColumnFileID FileVersionsSelectColumn = "fileId"
+	// column name
+	FileVersionsSelectColumnID FileVersionsSelectColumn = "id"
+	// column name
+	FileVersionsSelectColumnMetadata FileVersionsSelectColumn = "metadata"
+	// column name
+	FileVersionsSelectColumnMimeType FileVersionsSelectColumn = "mimeType"
+	// column name
+	FileVersionsSelectColumnName FileVersionsSelectColumn = "name"
+	// column name
+	FileVersionsSelectColumnObjectKey FileVersionsSelectColumn = "objectKey"
+	// column name
+	FileVersionsSelectColumnSize FileVersionsSelectColumn = "size"
+	// column name
+	FileVersionsSelectColumnUploadedByUserID FileVersionsSelectColumn = "uploadedByUserId"
+)
+
+var AllFileVersionsSelectColumn = []FileVersionsSelectColumn{
+	FileVersionsSelectColumnCreatedAt,
+	FileVersionsSelectColumnEtag,
+	FileVersionsSelectColumnFileID,
+	FileVersionsSelectColumnID,
+	FileVersionsSelectColumnMetadata,
+	FileVersionsSelectColumnMimeType,
+	FileVersionsSelectColumnName,
+	FileVersionsSelectColumnObjectKey,
+	FileVersionsSelectColumnSize,
+	FileVersionsSelectColumnUploadedByUserID,
+}
+
+func (e FileVersionsSelectColumn) IsValid() bool {
+	switch e {
+	case FileVersionsSelectColumnCreatedAt, FileVersionsSelectColumnEtag, FileVersionsSelectColumnFileID, FileVersionsSelectColumnID, FileVersionsSelectColumnMetadata, FileVersionsSelectColumnMimeType, FileVersionsSelectColumnName, FileVersionsSelectColumnObjectKey, FileVersionsSelectColumnSize, FileVersionsSelectColumnUploadedByUserID:
+		return true
+	}
+	return false
+}
+
+func (e FileVersionsSelectColumn) String() string {
+	return string(e)
+}
+
+func (e *FileVersionsSelectColumn) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FileVersionsSelectColumn(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid fileVersions_select_column", str)
+	}
+	return nil
+}
+
+func (e FileVersionsSelectColumn) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// update columns of table "storage.file_versions"
+type FileVersionsUpdateColumn string
+
+const (
+	// column name
+	FileVersionsUpdateColumnCreatedAt FileVersionsUpdateColumn = "createdAt"
+	// column name
+	FileVersionsUpdateColumnEtag FileVersionsUpdateColumn = "etag"
+	// column name
+	FileVersionsUpdateColumnFileID FileVersionsUpdateColumn = "fileId"
+	// column name
+	FileVersionsUpdateColumnID FileVersionsUpdateColumn = "id"
+	// column name
+	FileVersionsUpdateColumnMetadata FileVersionsUpdateColumn = "metadata"
+	// column name
+	FileVersionsUpdateColumnMimeType FileVersionsUpdateColumn = "mimeType"
+	// column name
+	FileVersionsUpdateColumnName FileVersionsUpdateColumn = "name"
+	// column name
+	FileVersionsUpdateColumnObjectKey FileVersionsUpdateColumn = "objectKey"
+	// column name
+	FileVersionsUpdateColumnSize FileVersionsUpdateColumn = "size"
+	// column name
+	FileVersionsUpdateColumnUploadedByUserID FileVersionsUpdateColumn = "uploadedByUserId"
+)
+
+var AllFileVersionsUpdateColumn = []FileVersionsUpdateColumn{
+	FileVersionsUpdateColumnCreatedAt,
+	FileVersionsUpdateColumnEtag,
+	FileVersionsUpdateColumnFileID,
+	FileVersionsUpdateColumnID,
+	FileVersionsUpdateColumnMetadata,
+	FileVersionsUpdateColumnMimeType,
+	FileVersionsUpdateColumnName,
+	FileVersionsUpdateColumnObjectKey,
+	FileVersionsUpdateColumnSize,
+	FileVersionsUpdateColumnUploadedByUserID,
+}
+
+func (e FileVersionsUpdateColumn) IsValid() bool {
+	switch e {
+	case FileVersionsUpdateColumnCreatedAt, FileVersionsUpdateColumnEtag, FileVersionsUpdateColumnFileID, FileVersionsUpdateColumnID, FileVersionsUpdateColumnMetadata, FileVersionsUpdateColumnMimeType, FileVersionsUpdateColumnName, FileVersionsUpdateColumnObjectKey, FileVersionsUpdateColumnSize, FileVersionsUpdateColumnUploadedByUserID:
+		return true
+	}
+	return false
+}
+
+func (e FileVersionsUpdateColumn) String() string {
+	return string(e)
+}
+
+func (e *FileVersionsUpdateColumn) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FileVersionsUpdateColumn(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid fileVersions_update_column", str)
+	}
+	return nil
+}
+
+func (e FileVersionsUpdateColumn) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// unique or primary key constraints on table "storage.files"
+type FilesConstraint string
+
+const (
+	// unique or primary key constraint on columns "id"
+	FilesConstraintFilesPkey FilesConstraint = "files_pkey"
+)
+
+var AllFilesConstraint = []FilesConstraint{
+	FilesConstraintFilesPkey,
+}
+
+func (e FilesConstraint) IsValid() bool {
+	switch e {
+	case FilesConstraintFilesPkey:
+		return true
+	}
+	return false
+}
+
+func (e FilesConstraint) String() string {
+	return string(e)
+}
+
+func (e *FilesConstraint) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FilesConstraint(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid files_constraint", str)
+	}
+	return nil
+}
+
+func (e FilesConstraint) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// select columns of table "storage.files"
+type FilesSelectColumn string
+
+const (
+	// column name
+	FilesSelectColumnBucketID FilesSelectColumn = "bucketId"
+	// column name
+	FilesSelectColumnChunkCount FilesSelectColumn = "chunkCount"
+	// column name
+	FilesSelectColumnChunkSize FilesSelectColumn = "chunkSize"
+	// column name
+	FilesSelectColumnCreatedAt FilesSelectColumn = "createdAt"
+	// column name
+	FilesSelectColumnDeletedAt FilesSelectColumn = "deletedAt"
+	// column name
+	FilesSelectColumnDeletedByUserID FilesSelectColumn = "deletedByUserId"
+	// column name
+	FilesSelectColumnEtag FilesSelectColumn = "etag"
+	// column name
+	FilesSelectColumnExpiresAt FilesSelectColumn = "expiresAt"
+	// column name
+	FilesSelectColumnID FilesSelectColumn = "id"
+	// column name
+	FilesSelectColumnIsUploaded FilesSelectColumn = "isUploaded"
+	// column name
+	FilesSelectColumnLegalHold FilesSelectColumn = "legalHold"
+	// column name
+	FilesSelectColumnMetadata FilesSelectColumn = "metadata"
+	// column name
+	FilesSelectColumnMimeType FilesSelectColumn = "mimeType"
+	// column name
+	FilesSelectColumnName FilesSelectColumn = "name"
+	// column name
+	FilesSelectColumnObjectKey FilesSelectColumn = "objectKey"
+	// column name
+	FilesSelectColumnRetainUntil FilesSelectColumn = "retainUntil"
+	// column name
+	FilesSelectColumnSize FilesSelectColumn = "size"
+	// column name
+	FilesSelectColumnUpdatedAt FilesSelectColumn = "updatedAt"
+	// column name
+	FilesSelectColumnUploadedByRole FilesSelectColumn = "uploadedByRole"
+	// column name
+	FilesSelectColumnUploadID FilesSelectColumn = "uploadId"
+	// column name
+	FilesSelectColumnUploadedByUserID FilesSelectColumn = "uploadedByUserId"
+)
+
+var AllFilesSelectColumn = []FilesSelectColumn{
+	FilesSelectColumnBucketID,
+	FilesSelectColumnChunkCount,
+	FilesSelectColumnChunkSize,
+	FilesSelectColumnCreatedAt,
+	FilesSelectColumnDeletedAt,
+	FilesSelectColumnDeletedByUserID,
+	FilesSelectColumnEtag,
+	FilesSelectColumnExpiresAt,
+	FilesSelectColumnID,
+	FilesSelectColumnIsUploaded,
+	FilesSelectColumnLegalHold,
+	FilesSelectColumnMetadata,
+	FilesSelectColumnMimeType,
+	FilesSelectColumnName,
+	FilesSelectColumnObjectKey,
+	FilesSelectColumnRetainUntil,
+	FilesSelectColumnSize,
+	FilesSelectColumnUpdatedAt,
+	FilesSelectColumnUploadedByRole,
+	FilesSelectColumnUploadedByUserID,
+	FilesSelectColumnUploadID,
+}
+
+func (e FilesSelectColumn) IsValid() bool {
+	switch e {
+	case FilesSelectColumnBucketID, FilesSelectColumnChunkCount, FilesSelectColumnChunkSize, FilesSelectColumnCreatedAt, FilesSelectColumnDeletedAt, FilesSelectColumnDeletedByUserID, FilesSelectColumnEtag, FilesSelectColumnExpiresAt, FilesSelectColumnID, FilesSelectColumnIsUploaded, FilesSelectColumnLegalHold, FilesSelectColumnMetadata, FilesSelectColumnMimeType, FilesSelectColumnName, FilesSelectColumnObjectKey, FilesSelectColumnRetainUntil, FilesSelectColumnSize, FilesSelectColumnUpdatedAt, FilesSelectColumnUploadedByRole, FilesSelectColumnUploadedByUserID, FilesSelectColumnUploadID:
+		return true
+	}
+	return false
+}
+
+func (e FilesSelectColumn) String() string {
+	return string(e)
+}
+
+func (e *FilesSelectColumn) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FilesSelectColumn(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid files_select_column", str)
+	}
+	return nil
+}
+
+func (e FilesSelectColumn) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// select "files_aggregate_bool_exp_bool_and_arguments_columns" columns of table "storage.files"
+type FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumns string
+
+const (
+	// column name
+	FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumnsIsUploaded FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumns = "isUploaded"
+	// column name
+	FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumnsLegalHold FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumns = "legalHold"
+)
+
+var AllFilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumns = []FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumns{
+	FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumnsIsUploaded,
+	FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumnsLegalHold,
+}
+
+func (e FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumns) IsValid() bool {
+	switch e {
+	case FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumnsIsUploaded, FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumnsLegalHold:
+		return true
+	}
+	return false
+}
+
+func (e FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumns) String() string {
+	return string(e)
+}
+
+func (e *FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumns) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumns(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid files_select_column_files_aggregate_bool_exp_bool_and_arguments_columns", str)
+	}
+	return nil
+}
+
+func (e FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumns) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// select "files_aggregate_bool_exp_bool_or_arguments_columns" columns of table "storage.files"
+type FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumns string
+
+const (
+	// column name
+	FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumnsIsUploaded FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumns = "isUploaded"
+	// column name
+	FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumnsLegalHold FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumns = "legalHold"
+)
+
+var AllFilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumns = []FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumns{
+	FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumnsIsUploaded,
+	FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumnsLegalHold,
+}
+
+func (e FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumns) IsValid() bool {
+	switch e {
+	case FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumnsIsUploaded, FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumnsLegalHold:
+		return true
+	}
+	return false
+}
+
+func (e FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumns) String() string {
+	return string(e)
+}
+
+func (e *FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumns) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumns(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid files_select_column_files_aggregate_bool_exp_bool_or_arguments_columns", str)
+	}
+	return nil
+}
+
+func (e FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumns) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// update columns of table "storage.files"
+type FilesUpdateColumn string
+
+const (
+	// column name
+	FilesUpdateColumnBucketID FilesUpdateColumn = "bucketId"
+	// column name
+	FilesUpdateColumnChunkCount FilesUpdateColumn = "chunkCount"
+	// column name
+	FilesUpdateColumnChunkSize FilesUpdateColumn = "chunkSize"
+	// column name
+	FilesUpdateColumnCreatedAt FilesUpdateColumn = "createdAt"
+	// column name
+	FilesUpdateColumnDeletedAt FilesUpdateColumn = "deletedAt"
+	// column name
+	FilesUpdateColumnDeletedByUserID FilesUpdateColumn = "deletedByUserId"
+	// column name
+	FilesUpdateColumnEtag FilesUpdateColumn = "etag"
+	// column name
+	FilesUpdateColumnExpiresAt FilesUpdateColumn = "expiresAt"
+	// column name
+	FilesUpdateColumnID FilesUpdateColumn = "id"
+	// column name
+	FilesUpdateColumnIsUploaded FilesUpdateColumn = "isUploaded"
+	// column name
+	FilesUpdateColumnLegalHold FilesUpdateColumn = "legalHold"
+	// column name
+	FilesUpdateColumnMetadata FilesUpdateColumn = "metadata"
+	// column name
+	FilesUpdateColumnMimeType FilesUpdateColumn = "mimeType"
+	// column name
+	FilesUpdateColumnName FilesUpdateColumn = "name"
+	// column name
+	FilesUpdateColumnObjectKey FilesUpdateColumn = "objectKey"
+	// column name
+	FilesUpdateColumnRetainUntil FilesUpdateColumn = "retainUntil"
+	// column name
+	FilesUpdateColumnSize FilesUpdateColumn = "size"
+	// column name
+	FilesUpdateColumnUpdatedAt FilesUpdateColumn = "updatedAt"
+	// column name
+	FilesUpdateColumnUploadedByRole FilesUpdateColumn = "uploadedByRole"
+	// column name
+	FilesUpdateColumnUploadID FilesUpdateColumn = "uploadId"
+	// column name
+	FilesUpdateColumnUploadedByUserID FilesUpdateColumn = "uploadedByUserId"
+)
+
+var AllFilesUpdateColumn = []FilesUpdateColumn{
+	FilesUpdateColumnBucketID,
+	FilesUpdateColumnChunkCount,
+	FilesUpdateColumnChunkSize,
+	FilesUpdateColumnCreatedAt,
+	FilesUpdateColumnDeletedAt,
+	FilesUpdateColumnDeletedByUserID,
+	FilesUpdateColumnEtag,
+	FilesUpdateColumnExpiresAt,
+	FilesUpdateColumnID,
+	FilesUpdateColumnIsUploaded,
+	FilesUpdateColumnLegalHold,
+	FilesUpdateColumnMetadata,
+	FilesUpdateColumnMimeType,
+	FilesUpdateColumnName,
+	FilesUpdateColumnObjectKey,
+	FilesUpdateColumnRetainUntil,
+	FilesUpdateColumnSize,
+	FilesUpdateColumnUpdatedAt,
+	FilesUpdateColumnUploadedByRole,
+	FilesUpdateColumnUploadedByUserID,
+	FilesUpdateColumnUploadID,
+}
+
+func (e FilesUpdateColumn) IsValid() bool {
+	switch e {
+	case FilesUpdateColumnBucketID, FilesUpdateColumnChunkCount, FilesUpdateColumnChunkSize, FilesUpdateColumnCreatedAt, FilesUpdateColumnDeletedAt, FilesUpdateColumnDeletedByUserID, FilesUpdateColumnEtag, FilesUpdateColumnExpiresAt, FilesUpdateColumnID, FilesUpdateColumnIsUploaded, FilesUpdateColumnLegalHold, FilesUpdateColumnMetadata, FilesUpdateColumnMimeType, FilesUpdateColumnName, FilesUpdateColumnObjectKey, FilesUpdateColumnRetainUntil, FilesUpdateColumnSize, FilesUpdateColumnUpdatedAt, FilesUpdateColumnUploadedByRole, FilesUpdateColumnUploadedByUserID, FilesUpdateColumnUploadID:
+		return true
+	}
+	return false
+}
+
+func (e FilesUpdateColumn) String() string {
+	return string(e)
+}
+
+func (e *FilesUpdateColumn) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FilesUpdateColumn(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid files_update_column", str)
+	}
+	return nil
+}
+
+func (e FilesUpdateColumn) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// column ordering options
+type OrderBy string
+
+const (
+	// in ascending order, nulls last
+	OrderByAsc OrderBy = "asc"
+	// in ascending order, nulls first
+	OrderByAscNullsFirst OrderBy = "asc_nulls_first"
+	// in ascending order, nulls last
+	OrderByAscNullsLast OrderBy = "asc_nulls_last"
+	// in descending order, nulls first
+	OrderByDesc OrderBy = "desc"
+	// in descending order, nulls first
+	OrderByDescNullsFirst OrderBy = "desc_nulls_first"
+	// in descending order, nulls last
+	OrderByDescNullsLast OrderBy = "desc_nulls_last"
+)
+
+var AllOrderBy = []OrderBy{
+	OrderByAsc,
+	OrderByAscNullsFirst,
+	OrderByAscNullsLast,
+	OrderByDesc,
+	OrderByDescNullsFirst,
+	OrderByDescNullsLast,
+}
+
+func (e OrderBy) IsValid() bool {
+	switch e {
+	case OrderByAsc, OrderByAscNullsFirst, OrderByAscNullsLast, OrderByDesc, OrderByDescNullsFirst, OrderByDescNullsLast:
+		return true
+	}
+	return false
+}
+
+func (e OrderBy) String() string {
+	return string(e)
+}
+
+func (e *OrderBy) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderBy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid order_by", str)
+	}
+	return nil
+}
+
+func (e OrderBy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// unique or primary key constraints on table "storage.quotas"
+type QuotasConstraint string
+
+const (
+	// unique or primary key constraint on columns "id"
+	QuotasConstraintQuotasPkey QuotasConstraint = "quotas_pkey"
+)
+
+var AllQuotasConstraint = []QuotasConstraint{
+	QuotasConstraintQuotasPkey,
+}
+
+func (e QuotasConstraint) IsValid() bool {
+	switch e {
+	case QuotasConstraintQuotasPkey:
+		return true
+	}
+	return false
+}
+
+func (e QuotasConstraint) String() string {
+	return string(e)
+}
+
+func (e *QuotasConstraint) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = QuotasConstraint(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid quotas_constraint", str)
+	}
+	return nil
+}
+
+func (e QuotasConstraint) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// select columns of table "storage.quotas"
+type QuotasSelectColumn string
+
+const (
+	// column name
+	QuotasSelectColumnBucketID QuotasSelectColumn = "bucketId"
+	// column name
+	QuotasSelectColumnCreatedAt QuotasSelectColumn = "createdAt"
+	// column name
+	QuotasSelectColumnID QuotasSelectColumn = "id"
+	// column name
+	QuotasSelectColumnMaxBytes QuotasSelectColumn = "maxBytes"
+	// column name
+	QuotasSelectColumnMaxFiles QuotasSelectColumn = "maxFiles"
+	// column name
+	QuotasSelectColumnRole QuotasSelectColumn = "role"
+	// column name
+	QuotasSelectColumnUpdatedAt QuotasSelectColumn = "updatedAt"
+	// column name
+	QuotasSelectColumnUserID QuotasSelectColumn = "userId"
+)
+
+var AllQuotasSelectColumn = []QuotasSelectColumn{
+	QuotasSelectColumnBucketID,
+	QuotasSelectColumnCreatedAt,
+	QuotasSelectColumnID,
+	QuotasSelectColumnMaxBytes,
+	QuotasSelectColumnMaxFiles,
+	QuotasSelectColumnRole,
+	QuotasSelectColumnUpdatedAt,
+	QuotasSelectColumnUserID,
+}
+
+func (e QuotasSelectColumn) IsValid() bool {
+	switch e {
+	case QuotasSelectColumnBucketID, QuotasSelectColumnCreatedAt, QuotasSelectColumnID, QuotasSelectColumnMaxBytes, QuotasSelectColumnMaxFiles, QuotasSelectColumnRole, QuotasSelectColumnUpdatedAt, QuotasSelectColumnUserID:
+		return true
+	}
+	return false
+}
+
+func (e QuotasSelectColumn) String() string {
+	return string(e)
+}
+
+func (e *QuotasSelectColumn) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = QuotasSelectColumn(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid quotas_select_column", str)
+	}
+	return nil
+}
+
+func (e QuotasSelectColumn) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// update columns of table "storage.quotas"
+type QuotasUpdateColumn string
+
+const (
+	// column name
+	QuotasUpdateColumnBucketID QuotasUpdateColumn = "bucketId"
+	// column name
+	QuotasUpdateColumnCreatedAt QuotasUpdateColumn = "createdAt"
+	// column name
+	QuotasUpdateColumnID QuotasUpdateColumn = "id"
+	// column name
+	QuotasUpdateColumnMaxBytes QuotasUpdateColumn = "maxBytes"
+	// column name
+	QuotasUpdateColumnMaxFiles QuotasUpdateColumn = "maxFiles"
+	// column name
+	QuotasUpdateColumnRole QuotasUpdateColumn = "role"
+	// column name
+	QuotasUpdateColumnUpdatedAt QuotasUpdateColumn = "updatedAt"
+	// column name
+	QuotasUpdateColumnUserID QuotasUpdateColumn = "userId"
+)
+
+var AllQuotasUpdateColumn = []QuotasUpdateColumn{
+	QuotasUpdateColumnBucketID,
+	QuotasUpdateColumnCreatedAt,
+	QuotasUpdateColumnID,
+	QuotasUpdateColumnMaxBytes,
+	QuotasUpdateColumnMaxFiles,
+	QuotasUpdateColumnRole,
+	QuotasUpdateColumnUpdatedAt,
+	QuotasUpdateColumnUserID,
+}
+
+func (e QuotasUpdateColumn) IsValid() bool {
+	switch e {
+	case QuotasUpdateColumnBucketID, QuotasUpdateColumnCreatedAt, QuotasUpdateColumnID, QuotasUpdateColumnMaxBytes, QuotasUpdateColumnMaxFiles, QuotasUpdateColumnRole, QuotasUpdateColumnUpdatedAt, QuotasUpdateColumnUserID:
+		return true
+	}
+	return false
+}
+
+func (e QuotasUpdateColumn) String() string {
+	return string(e)
+}
+
+func (e *QuotasUpdateColumn) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = QuotasUpdateColumn(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid quotas_update_column", str)
+	}
+	return nil
+}
+
+func (e QuotasUpdateColumn) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// unique or primary key constraints on table "storage.shares"
+type SharesConstraint string
+
+const (
+	// unique or primary key constraint on columns "id"
+	SharesConstraintSharesPkey SharesConstraint = "shares_pkey"
+)
+
+var AllSharesConstraint = []SharesConstraint{
+	SharesConstraintSharesPkey,
+}
+
+func (e SharesConstraint) IsValid() bool {
+	switch e {
+	case SharesConstraintSharesPkey:
+		return true
+	}
+	return false
+}
+
+func (e SharesConstraint) String() string {
+	return string(e)
+}
+
+func (e *SharesConstraint) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SharesConstraint(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid shares_constraint", str)
+	}
+	return nil
+}
+
+func (e SharesConstraint) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// select columns of table "storage.shares"
+type SharesSelectColumn string
+
+const (
+	// column name
+	SharesSelectColumnAllowedTransformations SharesSelectColumn = "allowedTransformations"
+	// column name
+	SharesSelectColumnCreatedAt SharesSelectColumn = "createdAt"
+	// column name
+	SharesSelectColumnCreatedByUserID SharesSelectColumn = "createdByUserId"
 	// column name
-	FilesSelectColumnMetadata FilesSelectColumn = "metadata"
+	SharesSelectColumnDownloadCount SharesSelectColumn = "downloadCount"
 	// column name
-	FilesSelectColumnMimeType FilesSelectColumn = "mimeType"
+	SharesSelectColumnExpiresAt SharesSelectColumn = "expiresAt"
 	// column name
-	FilesSelectColumnName FilesSelectColumn = "name"
+	SharesSelectColumnFileID SharesSelectColumn = "fileId"
 	// column name
-	FilesSelectColumnObjectKey FilesSelectColumn = "objectKey"
+	SharesSelectColumnID SharesSelectColumn = "id"
 	// column name
-	FilesSelectColumnSize FilesSelectColumn = "size"
+	SharesSelectColumnMaxDownloads SharesSelectColumn = "maxDownloads"
 	// column name
-	FilesSelectColumnUpdatedAt FilesSelectColumn = "updatedAt"
+	SharesSelectColumnPasswordHash SharesSelectColumn = "passwordHash"
 	// column name
-	FilesSelectColumnUploadID FilesSelectColumn = "uploadId"
+	SharesSelectColumnTokenHash SharesSelectColumn = "tokenHash"
 	// column name
-	FilesSelectColumnUploadedByUserID FilesSelectColumn = "uploadedByUserId"
+	SharesSelectColumnUpdatedAt SharesSelectColumn = "updatedAt"
 )
 
-var AllFilesSelectColumn = []FilesSelectColumn{
-	FilesSelectColumnBucketID,
-	FilesSelectColumnChunkCount,
-	FilesSelectColumnChunkSize,
-	FilesSelectColumnCreatedAt,
-	FilesSelectColumnEtag,
-	FilesSelectColumnID,
-	FilesSelectColumnIsUploaded,
-	FilesSelectColumnMetadata,
-	FilesSelectColumnMimeType,
-	FilesSelectColumnName,
-	FilesSelectColumnObjectKey,
-	FilesSelectColumnSize,
-	FilesSelectColumnUpdatedAt,
-	FilesSelectColumnUploadID,
-	FilesSelectColumnUploadedByUserID,
-}
-
-func (e FilesSelectColumn) IsValid() bool {
+var AllSharesSelectColumn = []SharesSelectColumn{
+	SharesSelectColumnAllowedTransformations,
+	SharesSelectColumnCreatedAt,
+	SharesSelectColumnCreatedByUserID,
+	SharesSelectColumnDownloadCount,
+	SharesSelectColumnExpiresAt,
+	SharesSelectColumnFileID,
+	SharesSelectColumnID,
+	SharesSelectColumnMaxDownloads,
+	SharesSelectColumnPasswordHash,
+	SharesSelectColumnTokenHash,
+	SharesSelectColumnUpdatedAt,
+}
+
+func (e SharesSelectColumn) IsValid() bool {
 	switch e {
-	case FilesSelectColumnBucketID, FilesSelectColumnChunkCount, FilesSelectColumnChunkSize, FilesSelectColumnCreatedAt, FilesSelectColumnEtag, FilesSelectColumnID, FilesSelectColumnIsUploaded, FilesSelectColumnMetadata, FilesSelectColumnMimeType, FilesSelectColumnName, FilesSelectColumnObjectKey, FilesSelectColumnSize, FilesSelectColumnUpdatedAt, FilesSelectColumnUploadID, FilesSelectColumnUploadedByUserID:
+	case SharesSelectColumnAllowedTransformations, SharesSelectColumnCreatedAt, SharesSelectColumnCreatedByUserID, SharesSelectColumnDownloadCount, SharesSelectColumnExpiresAt, SharesSelectColumnFileID, SharesSelectColumnID, SharesSelectColumnMaxDownloads, SharesSelectColumnPasswordHash, SharesSelectColumnTokenHash, SharesSelectColumnUpdatedAt:
 		return true
 	}
 	return false
 }
 
-func (e FilesSelectColumn) String() string {
+func (e SharesSelectColumn) String() string {
 	return string(e)
 }
 
-func (e *FilesSelectColumn) UnmarshalGQL(v interface{}) error {
+func (e *SharesSelectColumn) UnmarshalGQL(v interface{}) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = FilesSelectColumn(str)
+	*e = SharesSelectColumn(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid files_select_column", str)
+		return fmt.Errorf("%s is not a valid shares_select_column", str)
 	}
 	return nil
 }
 
-func (e FilesSelectColumn) MarshalGQL(w io.Writer) {
+func (e SharesSelectColumn) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-// select "files_aggregate_bool_exp_bool_and_arguments_columns" columns of table "storage.files"
-type FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumns string
+// update columns of table "storage.shares"
+type SharesUpdateColumn string
 
 const (
 	// column name
-	FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumnsIsUploaded FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumns = "isUploaded"
+	SharesUpdateColumnAllowedTransformations SharesUpdateColumn = "allowedTransformations"
+	// column name
+	SharesUpdateColumnCreatedAt SharesUpdateColumn = "createdAt"
+	// column name
+	SharesUpdateColumnCreatedByUserID SharesUpdateColumn = "createdByUserId"
+	// column name
+	SharesUpdateColumnDownloadCount SharesUpdateColumn = "downloadCount"
+	// column name
+	SharesUpdateColumnExpiresAt SharesUpdateColumn = "expiresAt"
+	// column name
+	SharesUpdateColumnFileID SharesUpdateColumn = "fileId"
+	// column name
+	SharesUpdateColumnID SharesUpdateColumn = "id"
+	// column name
+	SharesUpdateColumnMaxDownloads SharesUpdateColumn = "maxDownloads"
+	// column name
+	SharesUpdateColumnPasswordHash SharesUpdateColumn = "passwordHash"
+	// column name
+	SharesUpdateColumnTokenHash SharesUpdateColumn = "tokenHash"
+	// column name
+	SharesUpdateColumnUpdatedAt SharesUpdateColumn = "updatedAt"
 )
 
-var AllFilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumns = []FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumns{
-	FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumnsIsUploaded,
-}
-
-func (e FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumns) IsValid() bool {
+var AllSharesUpdateColumn = []SharesUpdateColumn{
+	SharesUpdateColumnAllowedTransformations,
+	SharesUpdateColumnCreatedAt,
+	SharesUpdateColumnCreatedByUserID,
+	SharesUpdateColumnDownloadCount,
+	SharesUpdateColumnExpiresAt,
+	SharesUpdateColumnFileID,
+	SharesUpdateColumnID,
+	SharesUpdateColumnMaxDownloads,
+	SharesUpdateColumnPasswordHash,
+	SharesUpdateColumnTokenHash,
+	SharesUpdateColumnUpdatedAt,
+}
+
+func (e SharesUpdateColumn) IsValid() bool {
 	switch e {
-	case FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumnsIsUploaded:
+	case SharesUpdateColumnAllowedTransformations, SharesUpdateColumnCreatedAt, SharesUpdateColumnCreatedByUserID, SharesUpdateColumnDownloadCount, SharesUpdateColumnExpiresAt, SharesUpdateColumnFileID, SharesUpdateColumnID, SharesUpdateColumnMaxDownloads, SharesUpdateColumnPasswordHash, SharesUpdateColumnTokenHash, SharesUpdateColumnUpdatedAt:
 		return true
 	}
 	return false
 }
 
-func (e FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumns) String() string {
+func (e SharesUpdateColumn) String() string {
 	return string(e)
 }
 
-func (e *FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumns) UnmarshalGQL(v interface{}) error {
+func (e *SharesUpdateColumn) UnmarshalGQL(v interface{}) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumns(str)
+	*e = SharesUpdateColumn(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid files_select_column_files_aggregate_bool_exp_bool_and_arguments_columns", str)
+		return fmt.Errorf("%s is not a valid shares_update_column", str)
 	}
 	return nil
 }
 
-func (e FilesSelectColumnFilesAggregateBoolExpBoolAndArgumentsColumns) MarshalGQL(w io.Writer) {
+func (e SharesUpdateColumn) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-// select "files_aggregate_bool_exp_bool_or_arguments_columns" columns of table "storage.files"
-type FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumns string
+// unique or primary key constraints on table "storage.usage"
+type UsagesConstraint string
 
 const (
-	// column name
-	FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumnsIsUploaded FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumns = "isUploaded"
+	// unique or primary key constraint on columns "bucketId", "userId"
+	UsagesConstraintUsagePkey UsagesConstraint = "usage_pkey"
 )
 
-var AllFilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumns = []FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumns{
-	FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumnsIsUploaded,
+var AllUsagesConstraint = []UsagesConstraint{
+	UsagesConstraintUsagePkey,
 }
 
-func (e FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumns) IsValid() bool {
+func (e UsagesConstraint) IsValid() bool {
 	switch e {
-	case FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumnsIsUploaded:
+	case UsagesConstraintUsagePkey:
 		return true
 	}
 	return false
 }
 
-func (e FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumns) String() string {
+func (e UsagesConstraint) String() string {
 	return string(e)
 }
 
-func (e *FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumns) UnmarshalGQL(v interface{}) error {
+func (e *UsagesConstraint) UnmarshalGQL(v interface{}) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumns(str)
+	*e = UsagesConstraint(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid files_select_column_files_aggregate_bool_exp_bool_or_arguments_columns", str)
+		return fmt.Errorf("%s is not a valid usages_constraint", str)
 	}
 	return nil
 }
 
-func (e FilesSelectColumnFilesAggregateBoolExpBoolOrArgumentsColumns) MarshalGQL(w io.Writer) {
+func (e UsagesConstraint) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-// update columns of table "storage.files"
-type FilesUpdateColumn string
+// select columns of table "storage.usage"
+type UsagesSelectColumn string
 
 const (
 	// column name
-	FilesUpdateColumnBucketID FilesUpdateColumn = "bucketId"
-	// column name
-	FilesUpdateColumnChunkCount FilesUpdateColumn = "chunkCount"
-	// column name
-	FilesUpdateColumnChunkSize FilesUpdateColumn = "chunkSize"
-	// column name
-	FilesUpdateColumnCreatedAt FilesUpdateColumn = "createdAt"
-	// column name
-	FilesUpdateColumnEtag FilesUpdateColumn = "etag"
-	// column name
-	FilesUpdateColumnID FilesUpdateColumn = "id"
-	// column name
-	FilesUpdateColumnIsUploaded FilesUpdateColumn = "isUploaded"
-	// column name
-	FilesUpdateColumnMetadata FilesUpdateColumn = "metadata"
-	// column name
-	FilesUpdateColumnMimeType FilesUpdateColumn = "mimeType"
-	// column name
-	FilesUpdateColumnName FilesUpdateColumn = "name"
-	// column name
-	FilesUpdateColumnObjectKey FilesUpdateColumn = "objectKey"
-	// column name
-	FilesUpdateColumnSize FilesUpdateColumn = "size"
+	UsagesSelectColumnBucketID UsagesSelectColumn = "bucketId"
 	// column name
-	FilesUpdateColumnUpdatedAt FilesUpdateColumn = "updatedAt"
+	UsagesSelectColumnBytes UsagesSelectColumn = "bytes"
 	// column name
-	FilesUpdateColumnUploadID FilesUpdateColumn = "uploadId"
+	UsagesSelectColumnFiles UsagesSelectColumn = "files"
 	// column name
-	FilesUpdateColumnUploadedByUserID FilesUpdateColumn = "uploadedByUserId"
+	UsagesSelectColumnUserID UsagesSelectColumn = "userId"
 )
 
-var AllFilesUpdateColumn = []FilesUpdateColumn{
-	FilesUpdateColumnBucketID,
-	FilesUpdateColumnChunkCount,
-	FilesUpdateColumnChunkSize,
-	FilesUpdateColumnCreatedAt,
-	FilesUpdateColumnEtag,
-	FilesUpdateColumnID,
-	FilesUpdateColumnIsUploaded,
-	FilesUpdateColumnMetadata,
-	FilesUpdateColumnMimeType,
-	FilesUpdateColumnName,
-	FilesUpdateColumnObjectKey,
-	FilesUpdateColumnSize,
-	FilesUpdateColumnUpdatedAt,
-	FilesUpdateColumnUploadID,
-	FilesUpdateColumnUploadedByUserID,
+var AllUsagesSelectColumn = []UsagesSelectColumn{
+	UsagesSelectColumnBucketID,
+	UsagesSelectColumnBytes,
+	UsagesSelectColumnFiles,
+	UsagesSelectColumnUserID,
 }
 
-func (e FilesUpdateColumn) IsValid() bool {
+func (e UsagesSelectColumn) IsValid() bool {
 	switch e {
-	case FilesUpdateColumnBucketID, FilesUpdateColumnChunkCount, FilesUpdateColumnChunkSize, FilesUpdateColumnCreatedAt, FilesUpdateColumnEtag, FilesUpdateColumnID, FilesUpdateColumnIsUploaded, FilesUpdateColumnMetadata, FilesUpdateColumnMimeType, FilesUpdateColumnName, FilesUpdateColumnObjectKey, FilesUpdateColumnSize, FilesUpdateColumnUpdatedAt, FilesUpdateColumnUploadID, FilesUpdateColumnUploadedByUserID:
+	case UsagesSelectColumnBucketID, UsagesSelectColumnBytes, UsagesSelectColumnFiles, UsagesSelectColumnUserID:
 		return true
 	}
 	return false
 }
 
-func (e FilesUpdateColumn) String() string {
+func (e UsagesSelectColumn) String() string {
 	return string(e)
 }
 
-func (e *FilesUpdateColumn) UnmarshalGQL(v interface{}) error {
+func (e *UsagesSelectColumn) UnmarshalGQL(v interface{}) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = FilesUpdateColumn(str)
+	*e = UsagesSelectColumn(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid files_update_column", str)
+		return fmt.Errorf("%s is not a valid usages_select_column", str)
 	}
 	return nil
 }
 
-func (e FilesUpdateColumn) MarshalGQL(w io.Writer) {
+func (e UsagesSelectColumn) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-// column ordering options
-type OrderBy string
+// update columns of table "storage.usage"
+type UsagesUpdateColumn string
 
 const (
-	// in ascending order, nulls last
-	OrderByAsc OrderBy = "asc"
-	// in ascending order, nulls first
-	OrderByAscNullsFirst OrderBy = "asc_nulls_first"
-	// in ascending order, nulls last
-	OrderByAscNullsLast OrderBy = "asc_nulls_last"
-	// in descending order, nulls first
-	OrderByDesc OrderBy = "desc"
-	// in descending order, nulls first
-	OrderByDescNullsFirst OrderBy = "desc_nulls_first"
-	// in descending order, nulls last
-	OrderByDescNullsLast OrderBy = "desc_nulls_last"
+	// column name
+	UsagesUpdateColumnBucketID UsagesUpdateColumn = "bucketId"
+	// column name
+	UsagesUpdateColumnBytes UsagesUpdateColumn = "bytes"
+	// column name
+	UsagesUpdateColumnFiles UsagesUpdateColumn = "files"
+	// column name
+	UsagesUpdateColumnUserID UsagesUpdateColumn = "userId"
 )
 
-var AllOrderBy = []OrderBy{
-	OrderByAsc,
-	OrderByAscNullsFirst,
-	OrderByAscNullsLast,
-	OrderByDesc,
-	OrderByDescNullsFirst,
-	OrderByDescNullsLast,
+var AllUsagesUpdateColumn = []UsagesUpdateColumn{
+	UsagesUpdateColumnBucketID,
+	UsagesUpdateColumnBytes,
+	UsagesUpdateColumnFiles,
+	UsagesUpdateColumnUserID,
 }
 
-func (e OrderBy) IsValid() bool {
+func (e UsagesUpdateColumn) IsValid() bool {
 	switch e {
-	case OrderByAsc, OrderByAscNullsFirst, OrderByAscNullsLast, OrderByDesc, OrderByDescNullsFirst, OrderByDescNullsLast:
+	case UsagesUpdateColumnBucketID, UsagesUpdateColumnBytes, UsagesUpdateColumnFiles, UsagesUpdateColumnUserID:
 		return true
 	}
 	return false
 }
 
-func (e OrderBy) String() string {
+func (e UsagesUpdateColumn) String() string {
 	return string(e)
 }
 
-func (e *OrderBy) UnmarshalGQL(v interface{}) error {
+func (e *UsagesUpdateColumn) UnmarshalGQL(v interface{}) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = OrderBy(str)
+	*e = UsagesUpdateColumn(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid order_by", str)
+		return fmt.Errorf("%s is not a valid usages_update_column", str)
 	}
 	return nil
 }
 
-func (e OrderBy) MarshalGQL(w io.Writer) {
+func (e UsagesUpdateColumn) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
@@ -1641,12 +3890,16 @@ const (
 	// column name
 	VirusSelectColumnCreatedAt VirusSelectColumn = "createdAt"
 	// column name
+	VirusSelectColumnFalsePositive VirusSelectColumn = "falsePositive"
+	// column name
 	VirusSelectColumnFileID VirusSelectColumn = "fileId"
 	// column name
 	VirusSelectColumnFilename VirusSelectColumn = "filename"
 	// column name
 	VirusSelectColumnID VirusSelectColumn = "id"
 	// column name
+	VirusSelectColumnQuarantine VirusSelectColumn = "quarantine"
+	// column name
 	VirusSelectColumnUpdatedAt VirusSelectColumn = "updatedAt"
 	// column name
 	VirusSelectColumnUserSession VirusSelectColumn = "userSession"
@@ -1656,9 +3909,11 @@ const (
 
 var AllVirusSelectColumn = []VirusSelectColumn{
 	VirusSelectColumnCreatedAt,
+	VirusSelectColumnFalsePositive,
 	VirusSelectColumnFileID,
 	VirusSelectColumnFilename,
 	VirusSelectColumnID,
+	VirusSelectColumnQuarantine,
 	VirusSelectColumnUpdatedAt,
 	VirusSelectColumnUserSession,
 	VirusSelectColumnVirus,
@@ -1666,7 +3921,7 @@ var AllVirusSelectColumn = []VirusSelectColumn{
 
 func (e VirusSelectColumn) IsValid() bool {
 	switch e {
-	case VirusSelectColumnCreatedAt, VirusSelectColumnFileID, VirusSelectColumnFilename, VirusSelectColumnID, VirusSelectColumnUpdatedAt, VirusSelectColumnUserSession, VirusSelectColumnVirus:
+	case VirusSelectColumnCreatedAt, VirusSelectColumnFalsePositive, VirusSelectColumnFileID, VirusSelectColumnFilename, VirusSelectColumnID, VirusSelectColumnQuarantine, VirusSelectColumnUpdatedAt, VirusSelectColumnUserSession, VirusSelectColumnVirus:
 		return true
 	}
 	return false
@@ -1700,12 +3955,16 @@ const (
 	// column name
 	VirusUpdateColumnCreatedAt VirusUpdateColumn = "createdAt"
 	// column name
+	VirusUpdateColumnFalsePositive VirusUpdateColumn = "falsePositive"
+	// column name
 	VirusUpdateColumnFileID VirusUpdateColumn = "fileId"
 	// column name
 	VirusUpdateColumnFilename VirusUpdateColumn = "filename"
 	// column name
 	VirusUpdateColumnID VirusUpdateColumn = "id"
 	// column name
+	VirusUpdateColumnQuarantine VirusUpdateColumn = "quarantine"
+	// column name
 	VirusUpdateColumnUpdatedAt VirusUpdateColumn = "updatedAt"
 	// column name
 	VirusUpdateColumnUserSession VirusUpdateColumn = "userSession"
@@ -1715,9 +3974,11 @@ const (
 
 var AllVirusUpdateColumn = []VirusUpdateColumn{
 	VirusUpdateColumnCreatedAt,
+	VirusUpdateColumnFalsePositive,
 	VirusUpdateColumnFileID,
 	VirusUpdateColumnFilename,
 	VirusUpdateColumnID,
+	VirusUpdateColumnQuarantine,
 	VirusUpdateColumnUpdatedAt,
 	VirusUpdateColumnUserSession,
 	VirusUpdateColumnVirus,
@@ -1725,7 +3986,7 @@ var AllVirusUpdateColumn = []VirusUpdateColumn{
 
 func (e VirusUpdateColumn) IsValid() bool {
 	switch e {
-	case VirusUpdateColumnCreatedAt, VirusUpdateColumnFileID, VirusUpdateColumnFilename, VirusUpdateColumnID, VirusUpdateColumnUpdatedAt, VirusUpdateColumnUserSession, VirusUpdateColumnVirus:
+	case VirusUpdateColumnCreatedAt, VirusUpdateColumnFalsePositive, VirusUpdateColumnFileID, VirusUpdateColumnFilename, VirusUpdateColumnID, VirusUpdateColumnQuarantine, VirusUpdateColumnUpdatedAt, VirusUpdateColumnUserSession, VirusUpdateColumnVirus:
 		return true
 	}
 	return false
@@ -1751,3 +4012,186 @@ func (e *VirusUpdateColumn) UnmarshalGQL(v interface{}) error {
 func (e VirusUpdateColumn) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+// unique or primary key constraints on table "storage.webhook_events"
+type WebhookEventsConstraint string
+
+const (
+	// unique or primary key constraint on columns "id"
+	WebhookEventsConstraintWebhookEventsPkey WebhookEventsConstraint = "webhook_events_pkey"
+)
+
+var AllWebhookEventsConstraint = []WebhookEventsConstraint{
+	WebhookEventsConstraintWebhookEventsPkey,
+}
+
+func (e WebhookEventsConstraint) IsValid() bool {
+	switch e {
+	case WebhookEventsConstraintWebhookEventsPkey:
+		return true
+	}
+	return false
+}
+
+func (e WebhookEventsConstraint) String() string {
+	return string(e)
+}
+
+func (e *WebhookEventsConstraint) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookEventsConstraint(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid webhookEvents_constraint", str)
+	}
+	return nil
+}
+
+func (e WebhookEventsConstraint) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// select columns of table "storage.webhook_events"
+type WebhookEventsSelectColumn string
+
+const (
+	// column name
+	WebhookEventsSelectColumnAttempts WebhookEventsSelectColumn = "attempts"
+	// column name
+	WebhookEventsSelectColumnBucketID WebhookEventsSelectColumn = "bucketId"
+	// column name
+	WebhookEventsSelectColumnCreatedAt WebhookEventsSelectColumn = "createdAt"
+	// column name
+	WebhookEventsSelectColumnDeliveredAt WebhookEventsSelectColumn = "deliveredAt"
+	// column name
+	WebhookEventsSelectColumnEventType WebhookEventsSelectColumn = "eventType"
+	// column name
+	WebhookEventsSelectColumnFileID WebhookEventsSelectColumn = "fileId"
+	// column name
+	WebhookEventsSelectColumnID WebhookEventsSelectColumn = "id"
+	// column name
+	WebhookEventsSelectColumnLastError WebhookEventsSelectColumn = "lastError"
+	// column name
+	WebhookEventsSelectColumnNextAttemptAt WebhookEventsSelectColumn = "nextAttemptAt"
+	// column name
+	WebhookEventsSelectColumnPayload WebhookEventsSelectColumn = "payload"
+	// column name
+	WebhookEventsSelectColumnUpdatedAt WebhookEventsSelectColumn = "updatedAt"
+)
+
+var AllWebhookEventsSelectColumn = []WebhookEventsSelectColumn{
+	WebhookEventsSelectColumnAttempts,
+	WebhookEventsSelectColumnBucketID,
+	WebhookEventsSelectColumnCreatedAt,
+	WebhookEventsSelectColumnDeliveredAt,
+	WebhookEventsSelectColumnEventType,
+	WebhookEventsSelectColumnFileID,
+	WebhookEventsSelectColumnID,
+	WebhookEventsSelectColumnLastError,
+	WebhookEventsSelectColumnNextAttemptAt,
+	WebhookEventsSelectColumnPayload,
+	WebhookEventsSelectColumnUpdatedAt,
+}
+
+func (e WebhookEventsSelectColumn) IsValid() bool {
+	switch e {
+	case WebhookEventsSelectColumnAttempts, WebhookEventsSelectColumnBucketID, WebhookEventsSelectColumnCreatedAt, WebhookEventsSelectColumnDeliveredAt, WebhookEventsSelectColumnEventType, WebhookEventsSelectColumnFileID, WebhookEventsSelectColumnID, WebhookEventsSelectColumnLastError, WebhookEventsSelectColumnNextAttemptAt, WebhookEventsSelectColumnPayload, WebhookEventsSelectColumnUpdatedAt:
+		return true
+	}
+	return false
+}
+
+func (e WebhookEventsSelectColumn) String() string {
+	return string(e)
+}
+
+func (e *WebhookEventsSelectColumn) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookEventsSelectColumn(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid webhookEvents_select_column", str)
+	}
+	return nil
+}
+
+func (e WebhookEventsSelectColumn) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// update columns of table "storage.webhook_events"
+type WebhookEventsUpdateColumn string
+
+const (
+	// column name
+	WebhookEventsUpdateColumnAttempts WebhookEventsUpdateColumn = "attempts"
+	// column name
+	WebhookEventsUpdateColumnBucketID WebhookEventsUpdateColumn = "bucketId"
+	// column name
+	WebhookEventsUpdateColumnCreatedAt WebhookEventsUpdateColumn = "createdAt"
+	// column name
+	WebhookEventsUpdateColumnDeliveredAt WebhookEventsUpdateColumn = "deliveredAt"
+	// column name
+	WebhookEventsUpdateColumnEventType WebhookEventsUpdateColumn = "eventType"
+	// column name
+	WebhookEventsUpdateColumnFileID WebhookEventsUpdateColumn = "fileId"
+	// column name
+	WebhookEventsUpdateColumnID WebhookEventsUpdateColumn = "id"
+	// column name
+	WebhookEventsUpdateColumnLastError WebhookEventsUpdateColumn = "lastError"
+	// column name
+	WebhookEventsUpdateColumnNextAttemptAt WebhookEventsUpdateColumn = "nextAttemptAt"
+	// column name
+	WebhookEventsUpdateColumnPayload WebhookEventsUpdateColumn = "payload"
+	// column name
+	WebhookEventsUpdateColumnUpdatedAt WebhookEventsUpdateColumn = "updatedAt"
+)
+
+var AllWebhookEventsUpdateColumn = []WebhookEventsUpdateColumn{
+	WebhookEventsUpdateColumnAttempts,
+	WebhookEventsUpdateColumnBucketID,
+	WebhookEventsUpdateColumnCreatedAt,
+	WebhookEventsUpdateColumnDeliveredAt,
+	WebhookEventsUpdateColumnEventType,
+	WebhookEventsUpdateColumnFileID,
+	WebhookEventsUpdateColumnID,
+	WebhookEventsUpdateColumnLastError,
+	WebhookEventsUpdateColumnNextAttemptAt,
+	WebhookEventsUpdateColumnPayload,
+	WebhookEventsUpdateColumnUpdatedAt,
+}
+
+func (e WebhookEventsUpdateColumn) IsValid() bool {
+	switch e {
+	case WebhookEventsUpdateColumnAttempts, WebhookEventsUpdateColumnBucketID, WebhookEventsUpdateColumnCreatedAt, WebhookEventsUpdateColumnDeliveredAt, WebhookEventsUpdateColumnEventType, WebhookEventsUpdateColumnFileID, WebhookEventsUpdateColumnID, WebhookEventsUpdateColumnLastError, WebhookEventsUpdateColumnNextAttemptAt, WebhookEventsUpdateColumnPayload, WebhookEventsUpdateColumnUpdatedAt:
+		return true
+	}
+	return false
+}
+
+func (e WebhookEventsUpdateColumn) String() string {
+	return string(e)
+}
+
+func (e *WebhookEventsUpdateColumn) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookEventsUpdateColumn(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid webhookEvents_update_column", str)
+	}
+	return nil
+}
+
+func (e WebhookEventsUpdateColumn) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
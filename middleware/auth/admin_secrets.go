@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// AdminSecrets holds the admin secrets accepted by the service. The first one is the
+// primary secret and it is used for the calls to hasura, the others are only accepted
+// in incoming requests so secrets can be rotated without downtime.
+type AdminSecrets struct {
+	mu      sync.RWMutex
+	secrets []string
+}
+
+func NewAdminSecrets(secrets ...string) *AdminSecrets {
+	s := &AdminSecrets{
+		mu:      sync.RWMutex{},
+		secrets: nil,
+	}
+	s.Set(secrets...)
+	return s
+}
+
+// Set replaces the secrets, empty and duplicated values are ignored.
+func (s *AdminSecrets) Set(secrets ...string) {
+	list := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		if secret != "" && !slices.Contains(list, secret) {
+			list = append(list, secret)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.secrets = list
+}
+
+func (s *AdminSecrets) Primary() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.secrets) == 0 {
+		return ""
+	}
+	return s.secrets[0]
+}
+
+// Valid returns true if the secret matches any of the admin secrets.
+func (s *AdminSecrets) Valid(secret string) bool {
+	if secret == "" {
+		return false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	valid := false
+	for _, candidate := range s.secrets {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(secret)) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
+
+// ParseAdminSecrets parses a file with one secret per line, the first one being the
+// primary secret. Empty lines and lines starting with # are ignored.
+func ParseAdminSecrets(b []byte) []string {
+	var secrets []string
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		secrets = append(secrets, line)
+	}
+	return secrets
+}
+
+// LoadFile replaces the secrets with the ones in the file. Files without secrets are
+// rejected so a truncated file doesn't lock everybody out.
+func (s *AdminSecrets) LoadFile(path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("problem reading admin secrets file: %w", err)
+	}
+
+	secrets := ParseAdminSecrets(b)
+	if len(secrets) == 0 {
+		return fmt.Errorf("no admin secrets found in %s", path) //nolint: goerr113
+	}
+
+	s.Set(secrets...)
+	return nil
+}
+
+// WatchFile reloads the secrets from the file every interval until the context is
+// cancelled. Errors are logged and the previous secrets are kept.
+func (s *AdminSecrets) WatchFile(
+	ctx context.Context, path string, interval time.Duration, logger *logrus.Logger,
+) {
+	var modTime time.Time
+	if info, err := os.Stat(path); err == nil {
+		modTime = info.ModTime()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			logger.WithError(err).Error("problem checking admin secrets file")
+			continue
+		}
+		if info.ModTime().Equal(modTime) {
+			continue
+		}
+
+		if err := s.LoadFile(path); err != nil {
+			logger.WithError(err).Error("problem reloading admin secrets")
+			continue
+		}
+		modTime = info.ModTime()
+		logger.Info("admin secrets reloaded")
+	}
+}
+
+// AcceptAdminSecrets replaces any valid admin secret in the request with the primary
+// one so the rest of the service, and hasura, only need to know about the primary.
+func AcceptAdminSecrets(secrets *AdminSecrets) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		header := ctx.Request.Header
+		if secret := header.Get("X-Hasura-Admin-Secret"); secrets.Valid(secret) {
+			header.Set("X-Hasura-Admin-Secret", secrets.Primary())
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,95 @@
+package auth_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/hasura-storage/middleware/auth"
+)
+
+func TestParseAdminSecrets(t *testing.T) {
+	t.Parallel()
+
+	got := auth.ParseAdminSecrets([]byte("# rotated on monday\nnext\n\n  current  \n"))
+	if diff := cmp.Diff([]string{"next", "current"}, got); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestAdminSecretsLoadFile(t *testing.T) {
+	t.Parallel()
+
+	secrets := auth.NewAdminSecrets("current", "", "current")
+	if !secrets.Valid("current") || secrets.Valid("") {
+		t.Fatal("unexpected secrets")
+	}
+
+	path := filepath.Join(t.TempDir(), "secrets")
+	if err := os.WriteFile(path, []byte("next\ncurrent\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := secrets.LoadFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := secrets.Primary(); got != "next" {
+		t.Errorf("primary = %s, want next", got)
+	}
+	if !secrets.Valid("current") {
+		t.Error("current secret should still be valid")
+	}
+
+	// an empty file keeps the previous secrets
+	if err := os.WriteFile(path, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := secrets.LoadFile(path); err == nil {
+		t.Error("expected an error")
+	}
+	if got := secrets.Primary(); got != "next" {
+		t.Errorf("primary = %s, want next", got)
+	}
+}
+
+func TestAcceptAdminSecrets(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		secret   string
+		expected string
+	}{
+		{name: "primary", secret: "current", expected: "current"},
+		{name: "secondary", secret: "next", expected: "current"},
+		{name: "invalid", secret: "wrong", expected: "wrong"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(auth.AcceptAdminSecrets(auth.NewAdminSecrets("current", "next")))
+
+			var got string
+			router.GET("/", func(ctx *gin.Context) {
+				got = ctx.Request.Header.Get("X-Hasura-Admin-Secret")
+				ctx.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("X-Hasura-Admin-Secret", tc.secret)
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tc.expected {
+				t.Errorf("admin secret = %s, want %s", got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	jwksDefaultTTL = time.Hour
+	// unknown key ids trigger a refresh so rotated keys are picked up right away, this
+	// limits how often that can happen
+	jwksMinRefreshInterval = 30 * time.Second
+	jwksTimeout            = 10 * time.Second
+)
+
+var ErrKeyNotFound = errors.New("key not found in jwks")
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return nil, fmt.Errorf("problem decoding value: %w", err)
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) { //nolint: ireturn
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv) //nolint: goerr113
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv) //nolint: goerr113
+		}
+		x, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(k.X, "="))
+		if err != nil {
+			return nil, fmt.Errorf("problem decoding value: %w", err)
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", k.Kty) //nolint: goerr113
+	}
+}
+
+// cacheTTL follows the caching headers of the response the same way hasura does.
+func cacheTTL(header http.Header, now time.Time) time.Duration {
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		if v, ok := strings.CutPrefix(strings.TrimSpace(directive), "max-age="); ok {
+			if seconds, err := strconv.Atoi(v); err == nil {
+				return time.Duration(seconds) * time.Second
+			}
+		}
+	}
+
+	if expires, err := http.ParseTime(header.Get("Expires")); err == nil {
+		return expires.Sub(now)
+	}
+
+	return jwksDefaultTTL
+}
+
+// JWKS fetches and caches the keys published at a JWKS URL. Keys are refreshed when
+// the cache expires or when a token is signed with a key we don't know about.
+type JWKS struct {
+	url        string
+	httpClient *http.Client
+	now        func() time.Time
+
+	mu          sync.Mutex
+	keys        map[string]crypto.PublicKey
+	expiresAt   time.Time
+	lastRefresh time.Time
+}
+
+func NewJWKS(url string) *JWKS {
+	return &JWKS{
+		url:         url,
+		httpClient:  &http.Client{Timeout: jwksTimeout}, //nolint: exhaustruct
+		now:         time.Now,
+		mu:          sync.Mutex{},
+		keys:        nil,
+		expiresAt:   time.Time{},
+		lastRefresh: time.Time{},
+	}
+}
+
+func (j *JWKS) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.url, nil)
+	if err != nil {
+		return fmt.Errorf("problem creating request: %w", err)
+	}
+
+	resp, err := j.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("problem fetching jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("problem fetching jwks, status code %d", resp.StatusCode) //nolint: goerr113
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("problem decoding jwks: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, err := k.publicKey()
+		if err != nil {
+			// keys we don't support shouldn't prevent using the others
+			continue
+		}
+		keys[k.Kid] = pub
+	}
+
+	now := j.now()
+	j.keys = keys
+	j.lastRefresh = now
+	j.expiresAt = now.Add(cacheTTL(resp.Header, now))
+
+	return nil
+}
+
+// Key returns the public key with the given id. If the id is empty and the set has a
+// single key that one is returned.
+func (j *JWKS) Key(ctx context.Context, kid string) (crypto.PublicKey, error) { //nolint: ireturn
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := j.now()
+	if j.keys == nil || now.After(j.expiresAt) {
+		if err := j.refresh(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if key, ok := j.lookup(kid); ok {
+		return key, nil
+	}
+
+	if now.Sub(j.lastRefresh) < jwksMinRefreshInterval {
+		return nil, ErrKeyNotFound
+	}
+
+	if err := j.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	if key, ok := j.lookup(kid); ok {
+		return key, nil
+	}
+
+	return nil, ErrKeyNotFound
+}
+
+func (j *JWKS) lookup(kid string) (crypto.PublicKey, bool) { //nolint: ireturn
+	if kid == "" && len(j.keys) == 1 {
+		for _, key := range j.keys {
+			return key, true
+		}
+	}
+	key, ok := j.keys[kid]
+	return key, ok
+}
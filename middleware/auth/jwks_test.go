@@ -0,0 +1,107 @@
+package auth //nolint: testpackage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestJWKSRotation(t *testing.T) {
+	t.Parallel()
+
+	var (
+		rotated  atomic.Bool
+		requests atomic.Int32
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		kid := "key-1"
+		if rotated.Load() {
+			kid = "key-2"
+		}
+		w.Header().Set("Cache-Control", "public, max-age=600")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []any{map[string]any{"kty": "RSA", "kid": kid, "n": "AQAB", "e": "AQAB"}},
+		})
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	jwks := NewJWKS(server.URL)
+	jwks.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	if _, err := jwks.Key(ctx, "key-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	rotated.Store(true)
+
+	// refreshes triggered by unknown keys are rate limited
+	if _, err := jwks.Key(ctx, "key-2"); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("err = %v, want %v", err, ErrKeyNotFound)
+	}
+
+	now = now.Add(jwksMinRefreshInterval)
+	if _, err := jwks.Key(ctx, "key-2"); err != nil {
+		t.Fatal(err)
+	}
+
+	// known keys are served from the cache until it expires
+	if _, err := jwks.Key(ctx, "key-2"); err != nil {
+		t.Fatal(err)
+	}
+	if got := requests.Load(); got != 2 { //nolint: mnd
+		t.Errorf("jwks fetched %d times, want 2", got)
+	}
+
+	rotated.Store(false)
+	now = now.Add(11 * time.Minute) //nolint: mnd
+	if _, err := jwks.Key(ctx, "key-2"); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("err = %v, want %v", err, ErrKeyNotFound)
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		header   http.Header
+		expected time.Duration
+	}{
+		{
+			name:     "max-age",
+			header:   http.Header{"Cache-Control": []string{"public, max-age=300, must-revalidate"}},
+			expected: 5 * time.Minute, //nolint: mnd
+		},
+		{
+			name:     "expires",
+			header:   http.Header{"Expires": []string{"Mon, 01 Jan 2024 00:02:00 GMT"}},
+			expected: 2 * time.Minute, //nolint: mnd
+		},
+		{
+			name:     "no headers",
+			header:   http.Header{},
+			expected: jwksDefaultTTL,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := cacheTTL(tc.header, now); got != tc.expected {
+				t.Errorf("cacheTTL() = %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const defaultClaimsNamespace = "https://hasura.io/jwt/claims"
+
+var ErrInvalidClaims = errors.New("invalid hasura claims")
+
+// JWTConfig uses the same format as hasura's HASURA_GRAPHQL_JWT_SECRET so the same
+// value can be used to configure both services.
+type JWTConfig struct {
+	Type                string          `json:"type"`
+	Key                 string          `json:"key"`
+	JWKURL              string          `json:"jwk_url"`               //nolint: tagliatelle
+	ClaimsNamespace     string          `json:"claims_namespace"`      //nolint: tagliatelle
+	ClaimsNamespacePath string          `json:"claims_namespace_path"` //nolint: tagliatelle
+	ClaimsFormat        string          `json:"claims_format"`         //nolint: tagliatelle
+	Audience            json.RawMessage `json:"audience"`
+	Issuer              string          `json:"issuer"`
+	AllowedSkew         int             `json:"allowed_skew"` //nolint: tagliatelle
+}
+
+func (c JWTConfig) audiences() ([]string, error) {
+	if len(c.Audience) == 0 {
+		return nil, nil
+	}
+
+	var aud string
+	if err := json.Unmarshal(c.Audience, &aud); err == nil {
+		return []string{aud}, nil
+	}
+
+	var auds []string
+	if err := json.Unmarshal(c.Audience, &auds); err != nil {
+		return nil, fmt.Errorf("audience must be a string or a list of strings: %w", err)
+	}
+	return auds, nil
+}
+
+// JWTVerifier verifies access tokens and extracts the hasura claims from them.
+type JWTVerifier struct {
+	config    JWTConfig
+	key       crypto.PublicKey
+	hmacKey   []byte
+	jwks      *JWKS
+	audiences []string
+	methods   []string
+}
+
+func parseKey(alg, key string) (any, []byte, error) {
+	switch {
+	case strings.HasPrefix(alg, "HS"):
+		return nil, []byte(key), nil
+	case strings.HasPrefix(alg, "RS"), strings.HasPrefix(alg, "PS"):
+		k, err := jwt.ParseRSAPublicKeyFromPEM([]byte(key))
+		if err != nil {
+			return nil, nil, fmt.Errorf("problem parsing rsa key: %w", err)
+		}
+		return k, nil, nil
+	case strings.HasPrefix(alg, "ES"):
+		k, err := jwt.ParseECPublicKeyFromPEM([]byte(key))
+		if err != nil {
+			return nil, nil, fmt.Errorf("problem parsing ecdsa key: %w", err)
+		}
+		return k, nil, nil
+	case alg == "Ed25519" || alg == "EdDSA":
+		k, err := jwt.ParseEdPublicKeyFromPEM([]byte(key))
+		if err != nil {
+			return nil, nil, fmt.Errorf("problem parsing ed25519 key: %w", err)
+		}
+		return k, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported algorithm %s", alg) //nolint: goerr113
+	}
+}
+
+func NewJWTVerifier(secret string) (*JWTVerifier, error) {
+	var config JWTConfig
+	if err := json.Unmarshal([]byte(secret), &config); err != nil {
+		return nil, fmt.Errorf("problem parsing jwt secret: %w", err)
+	}
+
+	audiences, err := config.audiences()
+	if err != nil {
+		return nil, err
+	}
+
+	verifier := &JWTVerifier{
+		config:    config,
+		key:       nil,
+		hmacKey:   nil,
+		jwks:      nil,
+		audiences: audiences,
+		methods:   nil,
+	}
+
+	switch {
+	case config.JWKURL != "":
+		verifier.jwks = NewJWKS(config.JWKURL)
+		verifier.methods = []string{
+			"RS256", "RS384", "RS512", "PS256", "PS384", "PS512",
+			"ES256", "ES384", "ES512", "EdDSA",
+		}
+	case config.Key != "":
+		verifier.key, verifier.hmacKey, err = parseKey(config.Type, config.Key)
+		if err != nil {
+			return nil, err
+		}
+		verifier.methods = []string{config.Type}
+		if config.Type == "Ed25519" {
+			verifier.methods = []string{"EdDSA"}
+		}
+	default:
+		return nil, errors.New("either key or jwk_url need to be set") //nolint: goerr113
+	}
+
+	return verifier, nil
+}
+
+func (v *JWTVerifier) keyFunc(ctx context.Context) jwt.Keyfunc {
+	return func(token *jwt.Token) (any, error) {
+		if v.jwks != nil {
+			kid, _ := token.Header["kid"].(string)
+			return v.jwks.Key(ctx, kid)
+		}
+		if v.hmacKey != nil {
+			return v.hmacKey, nil
+		}
+		return v.key, nil
+	}
+}
+
+// Verify checks the signature and the standard claims of the token and returns its
+// claims.
+func (v *JWTVerifier) Verify(ctx context.Context, token string) (jwt.MapClaims, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(v.methods),
+		jwt.WithLeeway(time.Duration(v.config.AllowedSkew) * time.Second),
+	}
+	if v.config.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(v.config.Issuer))
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := jwt.NewParser(opts...).ParseWithClaims(token, claims, v.keyFunc(ctx)); err != nil {
+		return nil, fmt.Errorf("problem verifying token: %w", err)
+	}
+
+	if len(v.audiences) > 0 && !v.audienceMatches(claims) {
+		return nil, fmt.Errorf("problem verifying token: %w", jwt.ErrTokenInvalidAudience)
+	}
+
+	return claims, nil
+}
+
+func (v *JWTVerifier) audienceMatches(claims jwt.MapClaims) bool {
+	aud, err := claims.GetAudience()
+	if err != nil {
+		return false
+	}
+	for _, want := range v.audiences {
+		for _, got := range aud {
+			if want == got {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func lookupPath(claims map[string]any, path string) (any, bool) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if path == "" {
+		return claims, true
+	}
+
+	var current any = claims
+	for _, key := range strings.Split(path, ".") {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// HasuraClaims returns the session variables found in the claims namespace, keys are
+// lower cased as hasura treats them case-insensitively.
+func (v *JWTVerifier) HasuraClaims(claims jwt.MapClaims) (map[string]any, error) {
+	var (
+		raw any
+		ok  bool
+	)
+	if v.config.ClaimsNamespacePath != "" {
+		raw, ok = lookupPath(claims, v.config.ClaimsNamespacePath)
+	} else {
+		namespace := v.config.ClaimsNamespace
+		if namespace == "" {
+			namespace = defaultClaimsNamespace
+		}
+		raw, ok = claims[namespace]
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: claims namespace not found", ErrInvalidClaims)
+	}
+
+	if s, isString := raw.(string); isString && v.config.ClaimsFormat == "stringified_json" {
+		var m map[string]any
+		if err := json.Unmarshal([]byte(s), &m); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidClaims, err)
+		}
+		raw = m
+	}
+
+	m, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: claims namespace isn't an object", ErrInvalidClaims)
+	}
+
+	res := make(map[string]any, len(m))
+	for k, val := range m {
+		res[strings.ToLower(k)] = val
+	}
+
+	return res, nil
+}
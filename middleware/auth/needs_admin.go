@@ -7,16 +7,16 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func isAdmin(hasuraAdminSecret string, header http.Header) bool {
-	return header.Get("X-Hasura-Admin-Secret") == hasuraAdminSecret &&
+func isAdmin(secrets *AdminSecrets, header http.Header) bool {
+	return secrets.Valid(header.Get("X-Hasura-Admin-Secret")) &&
 		(header.Get("X-Hasura-Role") == "admin" ||
 			header.Get("X-Hasura-Role") == "")
 }
 
-func NeedsAdmin(prefixPath, hasuraAdminSecret string) gin.HandlerFunc {
+func NeedsAdmin(prefixPath string, secrets *AdminSecrets) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		if strings.HasPrefix(ctx.Request.URL.Path, prefixPath) &&
-			!isAdmin(hasuraAdminSecret, ctx.Request.Header) {
+			!isAdmin(secrets, ctx.Request.Header) {
 			ctx.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
@@ -44,6 +44,13 @@ func TestIsAdmin(t *testing.T) {
 			},
 			isAdmin: false,
 		},
+		{
+			name: "secondary secret",
+			reqHeader: http.Header{
+				"X-Hasura-Admin-Secret": []string{"next"},
+			},
+			isAdmin: true,
+		},
 		{
 			name: "wrong secret and no role",
 			reqHeader: http.Header{
@@ -62,7 +69,7 @@ func TestIsAdmin(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			got := isAdmin("secret", tc.reqHeader)
+			got := isAdmin(NewAdminSecrets("secret", "next"), tc.reqHeader)
 			if got != tc.isAdmin {
 				t.Errorf("isAdmin() = %v, want %v", got, tc.isAdmin)
 			}
@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+func isSessionHeader(key string) bool {
+	key = http.CanonicalHeaderKey(key)
+	return strings.HasPrefix(key, "X-Hasura-") && key != "X-Hasura-Admin-Secret"
+}
+
+func removeSessionHeaders(header http.Header) {
+	for k := range header {
+		if isSessionHeader(k) {
+			header.Del(k)
+		}
+	}
+}
+
+type sessionKey struct{}
+
+// VerifiedSession returns the session variables of the access token verified by
+// VerifyJWT for the request.
+func VerifiedSession(ctx context.Context) (http.Header, bool) {
+	if c, ok := ctx.(*gin.Context); ok {
+		if c.Request == nil {
+			return nil, false
+		}
+		ctx = c.Request.Context()
+	}
+
+	session, ok := ctx.Value(sessionKey{}).(http.Header)
+	return session, ok
+}
+
+func allowedRoles(claims map[string]any) []string {
+	raw, _ := claims["x-hasura-allowed-roles"].([]any)
+	roles := make([]string, 0, len(raw))
+	for _, r := range raw {
+		if s, ok := r.(string); ok {
+			roles = append(roles, s)
+		}
+	}
+	return roles
+}
+
+// sessionHeaders computes the session variables of a verified token, the role can be
+// picked with the X-Hasura-Role header as long as it is one of the allowed roles.
+func sessionHeaders(claims map[string]any, requestedRole string) (http.Header, error) {
+	role := requestedRole
+	if role == "" {
+		role, _ = claims["x-hasura-default-role"].(string)
+	}
+	if role == "" {
+		return nil, fmt.Errorf("%w: no default role", ErrInvalidClaims)
+	}
+
+	allowed := false
+	for _, r := range allowedRoles(claims) {
+		if r == role {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, fmt.Errorf("%w: role %s not allowed", ErrInvalidClaims, role)
+	}
+
+	header := http.Header{}
+	for k, v := range claims {
+		if !strings.HasPrefix(k, "x-hasura-") ||
+			k == "x-hasura-allowed-roles" || k == "x-hasura-default-role" {
+			continue
+		}
+		if s, ok := v.(string); ok {
+			header.Set(k, s)
+		}
+	}
+	header.Set("X-Hasura-Role", role)
+
+	return header, nil
+}
+
+// VerifyJWT rejects requests with invalid access tokens. For valid tokens the hasura
+// session headers are replaced with the session variables found in the token so
+// handlers can trust them, they are also available with VerifiedSession. Requests
+// without a token can't set session variables either unless they are authenticated
+// with the admin secret.
+func VerifyJWT(verifier *JWTVerifier, secrets *AdminSecrets) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		header := ctx.Request.Header
+
+		if secrets.Valid(header.Get("X-Hasura-Admin-Secret")) {
+			ctx.Next()
+			return
+		}
+
+		authHeader := header.Get("Authorization")
+		if !strings.HasPrefix(authHeader, "Bearer ") {
+			removeSessionHeaders(header)
+			ctx.Next()
+			return
+		}
+
+		session, err := verifySession(ctx, verifier, authHeader[7:], header.Get("X-Hasura-Role"))
+		if err != nil {
+			_ = ctx.Error(fmt.Errorf("problem verifying access token: %w", err))
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"code":    http.StatusUnauthorized,
+				"message": "invalid access token",
+			})
+			return
+		}
+
+		removeSessionHeaders(header)
+		for k, v := range session {
+			header[k] = v
+		}
+		ctx.Request = ctx.Request.WithContext(
+			context.WithValue(ctx.Request.Context(), sessionKey{}, session),
+		)
+
+		ctx.Next()
+	}
+}
+
+func verifySession(
+	ctx *gin.Context, verifier *JWTVerifier, token, requestedRole string,
+) (http.Header, error) {
+	claims, err := verifier.Verify(ctx.Request.Context(), token)
+	if err != nil {
+		return nil, err
+	}
+
+	hasuraClaims, err := verifier.HasuraClaims(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return sessionHeaders(hasuraClaims, requestedRole)
+}
@@ -0,0 +1,326 @@
+package auth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/hasura-storage/middleware/auth"
+)
+
+const hmacSecret = `{"type":"HS256","key":"a-very-long-secret-used-only-for-testing-purposes"}`
+
+func hasuraClaims(role string, allowed ...string) map[string]any {
+	allowedRoles := make([]any, len(allowed))
+	for i, r := range allowed {
+		allowedRoles[i] = r
+	}
+	return map[string]any{
+		"x-hasura-allowed-roles": allowedRoles,
+		"x-hasura-default-role":  role,
+		"x-hasura-user-id":       "ab5ba58e-932a-40dc-87e8-733998794ec2",
+	}
+}
+
+func signHS256(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+
+	s, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(
+		[]byte("a-very-long-secret-used-only-for-testing-purposes"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func serve(
+	t *testing.T, verifier *auth.JWTVerifier, header http.Header,
+) (int, http.Header, http.Header) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(auth.VerifyJWT(verifier, auth.NewAdminSecrets("admin-secret")))
+
+	var got, session http.Header
+	router.GET("/", func(ctx *gin.Context) {
+		got = ctx.Request.Header.Clone()
+		session, _ = auth.VerifiedSession(ctx)
+		ctx.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header = header
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	return rec.Code, got, session
+}
+
+func TestVerifyJWT(t *testing.T) {
+	t.Parallel()
+
+	exp := time.Now().Add(time.Hour).Unix()
+
+	cases := []struct {
+		name           string
+		secret         string
+		header         func(t *testing.T) http.Header
+		expectedStatus int
+		expectedUserID string
+		expectedRole   string
+		verified       bool
+	}{
+		{
+			name:   "valid token",
+			secret: hmacSecret,
+			header: func(t *testing.T) http.Header {
+				t.Helper()
+				return http.Header{
+					"Authorization": []string{"Bearer " + signHS256(t, jwt.MapClaims{
+						"exp":                          exp,
+						"https://hasura.io/jwt/claims": hasuraClaims("user", "user", "me"),
+					})},
+					"X-Hasura-User-Id": []string{"spoofed"},
+				}
+			},
+			expectedStatus: http.StatusOK,
+			expectedUserID: "ab5ba58e-932a-40dc-87e8-733998794ec2",
+			expectedRole:   "user",
+			verified:       true,
+		},
+		{
+			name:   "requested role",
+			secret: hmacSecret,
+			header: func(t *testing.T) http.Header {
+				t.Helper()
+				return http.Header{
+					"Authorization": []string{"Bearer " + signHS256(t, jwt.MapClaims{
+						"exp":                          exp,
+						"https://hasura.io/jwt/claims": hasuraClaims("user", "user", "me"),
+					})},
+					"X-Hasura-Role": []string{"me"},
+				}
+			},
+			expectedStatus: http.StatusOK,
+			expectedUserID: "ab5ba58e-932a-40dc-87e8-733998794ec2",
+			expectedRole:   "me",
+			verified:       true,
+		},
+		{
+			name:   "role not allowed",
+			secret: hmacSecret,
+			header: func(t *testing.T) http.Header {
+				t.Helper()
+				return http.Header{
+					"Authorization": []string{"Bearer " + signHS256(t, jwt.MapClaims{
+						"exp":                          exp,
+						"https://hasura.io/jwt/claims": hasuraClaims("user", "user"),
+					})},
+					"X-Hasura-Role": []string{"admin"},
+				}
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "expired",
+			secret: hmacSecret,
+			header: func(t *testing.T) http.Header {
+				t.Helper()
+				return http.Header{
+					"Authorization": []string{"Bearer " + signHS256(t, jwt.MapClaims{
+						"exp":                          time.Now().Add(-time.Hour).Unix(),
+						"https://hasura.io/jwt/claims": hasuraClaims("user", "user"),
+					})},
+				}
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "wrong signature",
+			secret: `{"type":"HS256","key":"another-very-long-secret-used-only-for-testing"}`,
+			header: func(t *testing.T) http.Header {
+				t.Helper()
+				return http.Header{
+					"Authorization": []string{"Bearer " + signHS256(t, jwt.MapClaims{
+						"exp":                          exp,
+						"https://hasura.io/jwt/claims": hasuraClaims("user", "user"),
+					})},
+				}
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "wrong audience",
+			secret: `{"type":"HS256","key":"a-very-long-secret-used-only-for-testing-purposes","audience":["storage"]}`,
+			header: func(t *testing.T) http.Header {
+				t.Helper()
+				return http.Header{
+					"Authorization": []string{"Bearer " + signHS256(t, jwt.MapClaims{
+						"exp":                          exp,
+						"aud":                          "other",
+						"https://hasura.io/jwt/claims": hasuraClaims("user", "user"),
+					})},
+				}
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "claims namespace path and stringified claims",
+			secret: `{"type":"HS256","key":"a-very-long-secret-used-only-for-testing-purposes","claims_namespace_path":"$.app.hasura","claims_format":"stringified_json"}`, //nolint: lll
+			header: func(t *testing.T) http.Header {
+				t.Helper()
+				b, _ := json.Marshal(hasuraClaims("user", "user"))
+				return http.Header{
+					"Authorization": []string{"Bearer " + signHS256(t, jwt.MapClaims{
+						"exp": exp,
+						"app": map[string]any{"hasura": string(b)},
+					})},
+				}
+			},
+			expectedStatus: http.StatusOK,
+			expectedUserID: "ab5ba58e-932a-40dc-87e8-733998794ec2",
+			expectedRole:   "user",
+			verified:       true,
+		},
+		{
+			name:   "no token removes session headers",
+			secret: hmacSecret,
+			header: func(t *testing.T) http.Header {
+				t.Helper()
+				return http.Header{
+					"X-Hasura-User-Id": []string{"spoofed"},
+					"X-Hasura-Role":    []string{"admin"},
+				}
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "admin secret",
+			secret: hmacSecret,
+			header: func(t *testing.T) http.Header {
+				t.Helper()
+				return http.Header{
+					"Authorization":         []string{"Bearer garbage"},
+					"X-Hasura-Admin-Secret": []string{"admin-secret"},
+					"X-Hasura-User-Id":      []string{"ab5ba58e-932a-40dc-87e8-733998794ec2"},
+					"X-Hasura-Role":         []string{"admin"},
+				}
+			},
+			expectedStatus: http.StatusOK,
+			expectedUserID: "ab5ba58e-932a-40dc-87e8-733998794ec2",
+			expectedRole:   "admin",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			verifier, err := auth.NewJWTVerifier(tc.secret)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			status, header, session := serve(t, verifier, tc.header(t))
+			if status != tc.expectedStatus {
+				t.Fatalf("status = %d, want %d", status, tc.expectedStatus)
+			}
+
+			if status != http.StatusOK {
+				return
+			}
+
+			if diff := cmp.Diff(
+				[]string{tc.expectedUserID, tc.expectedRole},
+				[]string{header.Get("X-Hasura-User-Id"), header.Get("X-Hasura-Role")},
+			); diff != "" {
+				t.Error(diff)
+			}
+
+			if tc.verified != (session != nil) {
+				t.Fatalf("verified session = %v, want %v", session != nil, tc.verified)
+			}
+			if tc.verified && session.Get("X-Hasura-User-Id") != tc.expectedUserID {
+				t.Errorf("verified user id = %s, want %s", session.Get("X-Hasura-User-Id"), tc.expectedUserID)
+			}
+		})
+	}
+}
+
+func publicJWK(kid string, key *rsa.PrivateKey) map[string]any {
+	return map[string]any{
+		"kty": "RSA",
+		"kid": kid,
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func TestVerifyJWTWithJWKS(t *testing.T) {
+	t.Parallel()
+
+	key1, err := rsa.GenerateKey(rand.Reader, 2048) //nolint: mnd
+	if err != nil {
+		t.Fatal(err)
+	}
+	key2, err := rsa.GenerateKey(rand.Reader, 2048) //nolint: mnd
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Cache-Control", "max-age=3600")
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []any{publicJWK("key-1", key1)}})
+	}))
+	defer server.Close()
+
+	verifier, err := auth.NewJWTVerifier(`{"type":"RS256","jwk_url":"` + server.URL + `"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sign := func(kid string, key *rsa.PrivateKey) http.Header {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"exp":                          time.Now().Add(time.Hour).Unix(),
+			"https://hasura.io/jwt/claims": hasuraClaims("user", "user"),
+		})
+		token.Header["kid"] = kid
+		s, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return http.Header{"Authorization": []string{"Bearer " + s}}
+	}
+
+	if status, _, _ := serve(t, verifier, sign("key-1", key1)); status != http.StatusOK {
+		t.Fatalf("status = %d, want %d", status, http.StatusOK)
+	}
+
+	// cached
+	if status, _, _ := serve(t, verifier, sign("key-1", key1)); status != http.StatusOK {
+		t.Fatalf("status = %d, want %d", status, http.StatusOK)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("jwks fetched %d times, want 1", got)
+	}
+
+	// signed with a key that isn't published
+	if status, _, _ := serve(t, verifier, sign("key-1", key2)); status != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", status, http.StatusUnauthorized)
+	}
+}
@@ -78,16 +78,17 @@ type Table struct {
 	Name   string `json:"name"`
 }
 
+// CustomRootFields left empty aren't customized, views only have the select ones.
 type CustomRootFields struct {
-	Select          string `json:"select"`
-	SelectByPk      string `json:"select_by_pk"`     //nolint: tagliatelle
-	SelectAggregate string `json:"select_aggregate"` //nolint: tagliatelle
-	Insert          string `json:"insert"`
-	InsertOne       string `json:"insert_one"` //nolint: tagliatelle
-	Update          string `json:"update"`
-	UpdateByPk      string `json:"update_by_pk"` //nolint: tagliatelle
-	Delete          string `json:"delete"`
-	DeleteByPk      string `json:"delete_by_pk"` //nolint: tagliatelle
+	Select          string `json:"select,omitempty"`
+	SelectByPk      string `json:"select_by_pk,omitempty"`     //nolint: tagliatelle
+	SelectAggregate string `json:"select_aggregate,omitempty"` //nolint: tagliatelle
+	Insert          string `json:"insert,omitempty"`
+	InsertOne       string `json:"insert_one,omitempty"` //nolint: tagliatelle
+	Update          string `json:"update,omitempty"`
+	UpdateByPk      string `json:"update_by_pk,omitempty"` //nolint: tagliatelle
+	Delete          string `json:"delete,omitempty"`
+	DeleteByPk      string `json:"delete_by_pk,omitempty"` //nolint: tagliatelle
 }
 
 type Configuration struct {
@@ -183,6 +184,20 @@ func ApplyHasuraMetadata(url, hasuraSecret, hasuraDBName string) error { //nolin
 					"cache_control":          "cacheControl",
 					"presigned_urls_enabled": "presignedUrlsEnabled",
 					"upload_expiration":      "uploadExpiration",
+					"webhook_url":            "webhookUrl",
+					"allowed_mime_types":     "allowedMimeTypes",
+					"denied_mime_types":      "deniedMimeTypes",
+					"allowed_extensions":     "allowedExtensions",
+					"denied_extensions":      "deniedExtensions",
+					"redirect_downloads":     "redirectDownloads",
+					"versioning_enabled":     "versioningEnabled",
+					"max_versions":           "maxVersions",
+					"version_retention_days": "versionRetentionDays",
+					"soft_delete_enabled":    "softDeleteEnabled",
+					"trash_retention_days":   "trashRetentionDays",
+					"retention_days":         "retentionDays",
+					"retention_mode":         "retentionMode",
+					"default_ttl_seconds":    "defaultTtlSeconds",
 				},
 			},
 		},
@@ -224,11 +239,17 @@ func ApplyHasuraMetadata(url, hasuraSecret, hasuraDBName string) error { //nolin
 					"etag":                "etag",
 					"is_uploaded":         "isUploaded",
 					"uploaded_by_user_id": "uploadedByUserId",
+					"uploaded_by_role":    "uploadedByRole",
 					"metadata":            "metadata",
 					"object_key":          "objectKey",
 					"chunk_size":          "chunkSize",
 					"chunk_count":         "chunkCount",
 					"upload_id":           "uploadId",
+					"deleted_at":          "deletedAt",
+					"deleted_by_user_id":  "deletedByUserId",
+					"retain_until":        "retainUntil",
+					"legal_hold":          "legalHold",
+					"expires_at":          "expiresAt",
 				},
 			},
 		},
@@ -259,21 +280,420 @@ func ApplyHasuraMetadata(url, hasuraSecret, hasuraDBName string) error { //nolin
 					Delete:          "deleteViruses",
 					DeleteByPk:      "deleteVirus",
 				},
+				CustomColumnNames: map[string]string{
+					"id":             "id",
+					"created_at":     "createdAt",
+					"updated_at":     "updatedAt",
+					"file_id":        "fileId",
+					"filename":       "filename",
+					"virus":          "virus",
+					"user_session":   "userSession",
+					"false_positive": "falsePositive",
+					"quarantine":     "quarantine",
+				},
+			},
+		},
+	}
+
+	if err := postMetadata(url, hasuraSecret, virusTable); err != nil {
+		return fmt.Errorf("problem adding metadata for the virus table: %w", err)
+	}
+
+	webhookEventsTable := TrackTable{
+		Type: "pg_track_table",
+		Args: PgTrackTableArgs{
+			Source: hasuraDBName,
+			Table: Table{
+				Schema: "storage",
+				Name:   "webhook_events",
+			},
+			Configuration: Configuration{
+				CustomName: "webhookEvents",
+				CustomRootFields: CustomRootFields{
+					Select:          "webhookEvents",
+					SelectByPk:      "webhookEvent",
+					SelectAggregate: "webhookEventsAggregate",
+					Insert:          "insertWebhookEvents",
+					InsertOne:       "insertWebhookEvent",
+					Update:          "updateWebhookEvents",
+					UpdateByPk:      "updateWebhookEvent",
+					Delete:          "deleteWebhookEvents",
+					DeleteByPk:      "deleteWebhookEvent",
+				},
+				CustomColumnNames: map[string]string{
+					"id":              "id",
+					"created_at":      "createdAt",
+					"updated_at":      "updatedAt",
+					"event_type":      "eventType",
+					"bucket_id":       "bucketId",
+					"file_id":         "fileId",
+					"payload":         "payload",
+					"attempts":        "attempts",
+					"next_attempt_at": "nextAttemptAt",
+					"delivered_at":    "deliveredAt",
+					"last_error":      "lastError",
+				},
+			},
+		},
+	}
+
+	if err := postMetadata(url, hasuraSecret, webhookEventsTable); err != nil {
+		return fmt.Errorf("problem adding metadata for the webhook events table: %w", err)
+	}
+
+	webhookSecretsTable := TrackTable{
+		Type: "pg_track_table",
+		Args: PgTrackTableArgs{
+			Source: hasuraDBName,
+			Table: Table{
+				Schema: "storage",
+				Name:   "webhook_secrets",
+			},
+			Configuration: Configuration{
+				CustomName: "webhookSecrets",
+				CustomRootFields: CustomRootFields{
+					Select:          "webhookSecrets",
+					SelectByPk:      "webhookSecret",
+					SelectAggregate: "webhookSecretsAggregate",
+					Insert:          "insertWebhookSecrets",
+					InsertOne:       "insertWebhookSecret",
+					Update:          "updateWebhookSecrets",
+					UpdateByPk:      "updateWebhookSecret",
+					Delete:          "deleteWebhookSecrets",
+					DeleteByPk:      "deleteWebhookSecret",
+				},
+				CustomColumnNames: map[string]string{
+					"bucket_id": "bucketId",
+					"secret":    "secret",
+				},
+			},
+		},
+	}
+
+	if err := postMetadata(url, hasuraSecret, webhookSecretsTable); err != nil {
+		return fmt.Errorf("problem adding metadata for the webhook secrets table: %w", err)
+	}
+
+	quotasTable := TrackTable{
+		Type: "pg_track_table",
+		Args: PgTrackTableArgs{
+			Source: hasuraDBName,
+			Table: Table{
+				Schema: "storage",
+				Name:   "quotas",
+			},
+			Configuration: Configuration{
+				CustomName: "quotas",
+				CustomRootFields: CustomRootFields{
+					Select:          "quotas",
+					SelectByPk:      "quota",
+					SelectAggregate: "quotasAggregate",
+					Insert:          "insertQuotas",
+					InsertOne:       "insertQuota",
+					Update:          "updateQuotas",
+					UpdateByPk:      "updateQuota",
+					Delete:          "deleteQuotas",
+					DeleteByPk:      "deleteQuota",
+				},
+				CustomColumnNames: map[string]string{
+					"id":         "id",
+					"created_at": "createdAt",
+					"updated_at": "updatedAt",
+					"user_id":    "userId",
+					"role":       "role",
+					"bucket_id":  "bucketId",
+					"max_bytes":  "maxBytes",
+					"max_files":  "maxFiles",
+				},
+			},
+		},
+	}
+
+	if err := postMetadata(url, hasuraSecret, quotasTable); err != nil {
+		return fmt.Errorf("problem adding metadata for the quotas table: %w", err)
+	}
+
+	usageTable := TrackTable{
+		Type: "pg_track_table",
+		Args: PgTrackTableArgs{
+			Source: hasuraDBName,
+			Table: Table{
+				Schema: "storage",
+				Name:   "usage",
+			},
+			Configuration: Configuration{
+				CustomName: "usages",
+				CustomRootFields: CustomRootFields{
+					Select:          "usages",
+					SelectByPk:      "usage",
+					SelectAggregate: "usagesAggregate",
+					Insert:          "insertUsages",
+					InsertOne:       "insertUsage",
+					Update:          "updateUsages",
+					UpdateByPk:      "updateUsage",
+					Delete:          "deleteUsages",
+					DeleteByPk:      "deleteUsage",
+				},
+				CustomColumnNames: map[string]string{
+					"user_id":   "userId",
+					"bucket_id": "bucketId",
+					"bytes":     "bytes",
+					"files":     "files",
+				},
+			},
+		},
+	}
+
+	if err := postMetadata(url, hasuraSecret, usageTable); err != nil {
+		return fmt.Errorf("problem adding metadata for the usage table: %w", err)
+	}
+
+	apiKeysTable := TrackTable{
+		Type: "pg_track_table",
+		Args: PgTrackTableArgs{
+			Source: hasuraDBName,
+			Table: Table{
+				Schema: "storage",
+				Name:   "api_keys",
+			},
+			Configuration: Configuration{
+				CustomName: "apiKeys",
+				CustomRootFields: CustomRootFields{
+					Select:          "apiKeys",
+					SelectByPk:      "apiKey",
+					SelectAggregate: "apiKeysAggregate",
+					Insert:          "insertApiKeys",
+					InsertOne:       "insertApiKey",
+					Update:          "updateApiKeys",
+					UpdateByPk:      "updateApiKey",
+					Delete:          "deleteApiKeys",
+					DeleteByPk:      "deleteApiKey",
+				},
 				CustomColumnNames: map[string]string{
 					"id":           "id",
 					"created_at":   "createdAt",
 					"updated_at":   "updatedAt",
-					"file_id":      "fileId",
-					"filename":     "filename",
-					"virus":        "virus",
-					"user_session": "userSession",
+					"name":         "name",
+					"key_hash":     "keyHash",
+					"role":         "role",
+					"buckets":      "buckets",
+					"operations":   "operations",
+					"expires_at":   "expiresAt",
+					"last_used_at": "lastUsedAt",
 				},
 			},
 		},
 	}
 
-	if err := postMetadata(url, hasuraSecret, virusTable); err != nil {
-		return fmt.Errorf("problem adding metadata for the virus table: %w", err)
+	if err := postMetadata(url, hasuraSecret, apiKeysTable); err != nil {
+		return fmt.Errorf("problem adding metadata for the api keys table: %w", err)
+	}
+
+	sharesTable := TrackTable{
+		Type: "pg_track_table",
+		Args: PgTrackTableArgs{
+			Source: hasuraDBName,
+			Table: Table{
+				Schema: "storage",
+				Name:   "shares",
+			},
+			Configuration: Configuration{
+				CustomName: "shares",
+				CustomRootFields: CustomRootFields{
+					Select:          "shares",
+					SelectByPk:      "share",
+					SelectAggregate: "sharesAggregate",
+					Insert:          "insertShares",
+					InsertOne:       "insertShare",
+					Update:          "updateShares",
+					UpdateByPk:      "updateShare",
+					Delete:          "deleteShares",
+					DeleteByPk:      "deleteShare",
+				},
+				CustomColumnNames: map[string]string{
+					"id":                      "id",
+					"created_at":              "createdAt",
+					"updated_at":              "updatedAt",
+					"file_id":                 "fileId",
+					"token_hash":              "tokenHash",
+					"created_by_user_id":      "createdByUserId",
+					"expires_at":              "expiresAt",
+					"password_hash":           "passwordHash",
+					"max_downloads":           "maxDownloads",
+					"download_count":          "downloadCount",
+					"allowed_transformations": "allowedTransformations",
+				},
+			},
+		},
+	}
+
+	if err := postMetadata(url, hasuraSecret, sharesTable); err != nil {
+		return fmt.Errorf("problem adding metadata for the shares table: %w", err)
+	}
+
+	fileVersionsTable := TrackTable{
+		Type: "pg_track_table",
+		Args: PgTrackTableArgs{
+			Source: hasuraDBName,
+			Table: Table{
+				Schema: "storage",
+				Name:   "file_versions",
+			},
+			Configuration: Configuration{
+				CustomName: "fileVersions",
+				CustomRootFields: CustomRootFields{
+					Select:          "fileVersions",
+					SelectByPk:      "fileVersion",
+					SelectAggregate: "fileVersionsAggregate",
+					Insert:          "insertFileVersions",
+					InsertOne:       "insertFileVersion",
+					Update:          "updateFileVersions",
+					UpdateByPk:      "updateFileVersion",
+					Delete:          "deleteFileVersions",
+					DeleteByPk:      "deleteFileVersion",
+				},
+				CustomColumnNames: map[string]string{
+					"id":                  "id",
+					"created_at":          "createdAt",
+					"file_id":             "fileId",
+					"name":                "name",
+					"size":                "size",
+					"mime_type":           "mimeType",
+					"etag":                "etag",
+					"object_key":          "objectKey",
+					"metadata":            "metadata",
+					"uploaded_by_user_id": "uploadedByUserId",
+				},
+			},
+		},
+	}
+
+	if err := postMetadata(url, hasuraSecret, fileVersionsTable); err != nil {
+		return fmt.Errorf("problem adding metadata for the file versions table: %w", err)
+	}
+
+	expiredFileVersionsView := TrackTable{
+		Type: "pg_track_table",
+		Args: PgTrackTableArgs{
+			Source: hasuraDBName,
+			Table: Table{
+				Schema: "storage",
+				Name:   "expired_file_versions",
+			},
+			Configuration: Configuration{
+				CustomName: "expiredFileVersions",
+				CustomRootFields: CustomRootFields{ //nolint: exhaustruct
+					Select:          "expiredFileVersions",
+					SelectAggregate: "expiredFileVersionsAggregate",
+				},
+				CustomColumnNames: map[string]string{
+					"id":         "id",
+					"created_at": "createdAt",
+					"file_id":    "fileId",
+					"object_key": "objectKey",
+				},
+			},
+		},
+	}
+
+	if err := postMetadata(url, hasuraSecret, expiredFileVersionsView); err != nil {
+		return fmt.Errorf("problem adding metadata for the expired file versions view: %w", err)
+	}
+
+	expiredTrashView := TrackTable{
+		Type: "pg_track_table",
+		Args: PgTrackTableArgs{
+			Source: hasuraDBName,
+			Table: Table{
+				Schema: "storage",
+				Name:   "expired_trash",
+			},
+			Configuration: Configuration{
+				CustomName: "expiredTrash",
+				CustomRootFields: CustomRootFields{ //nolint: exhaustruct
+					Select:          "expiredTrash",
+					SelectAggregate: "expiredTrashAggregate",
+				},
+				CustomColumnNames: map[string]string{
+					"id":         "id",
+					"bucket_id":  "bucketId",
+					"object_key": "objectKey",
+					"updated_at": "updatedAt",
+					"deleted_at": "deletedAt",
+				},
+			},
+		},
+	}
+
+	if err := postMetadata(url, hasuraSecret, expiredTrashView); err != nil {
+		return fmt.Errorf("problem adding metadata for the expired trash view: %w", err)
+	}
+
+	expiredFilesView := TrackTable{
+		Type: "pg_track_table",
+		Args: PgTrackTableArgs{
+			Source: hasuraDBName,
+			Table: Table{
+				Schema: "storage",
+				Name:   "expired_files",
+			},
+			Configuration: Configuration{
+				CustomName: "expiredFiles",
+				CustomRootFields: CustomRootFields{ //nolint: exhaustruct
+					Select:          "expiredFiles",
+					SelectAggregate: "expiredFilesAggregate",
+				},
+				CustomColumnNames: map[string]string{
+					"id":         "id",
+					"bucket_id":  "bucketId",
+					"object_key": "objectKey",
+					"updated_at": "updatedAt",
+					"expires_at": "expiresAt",
+				},
+			},
+		},
+	}
+
+	if err := postMetadata(url, hasuraSecret, expiredFilesView); err != nil {
+		return fmt.Errorf("problem adding metadata for the expired files view: %w", err)
+	}
+
+	fileAuditEventsTable := TrackTable{
+		Type: "pg_track_table",
+		Args: PgTrackTableArgs{
+			Source: hasuraDBName,
+			Table: Table{
+				Schema: "storage",
+				Name:   "file_audit_events",
+			},
+			Configuration: Configuration{
+				CustomName: "fileAuditEvents",
+				CustomRootFields: CustomRootFields{
+					Select:          "fileAuditEvents",
+					SelectByPk:      "fileAuditEvent",
+					SelectAggregate: "fileAuditEventsAggregate",
+					Insert:          "insertFileAuditEvents",
+					InsertOne:       "insertFileAuditEvent",
+					Update:          "updateFileAuditEvents",
+					UpdateByPk:      "updateFileAuditEvent",
+					Delete:          "deleteFileAuditEvents",
+					DeleteByPk:      "deleteFileAuditEvent",
+				},
+				CustomColumnNames: map[string]string{
+					"id":         "id",
+					"created_at": "createdAt",
+					"file_id":    "fileId",
+					"action":     "action",
+					"reason":     "reason",
+					"user_id":    "userId",
+					"api_key_id": "apiKeyId",
+				},
+			},
+		},
+	}
+
+	if err := postMetadata(url, hasuraSecret, fileAuditEventsTable); err != nil {
+		return fmt.Errorf("problem adding metadata for the file audit events table: %w", err)
 	}
 
 	objRelationshipBuckets := CreateObjectRelationship{
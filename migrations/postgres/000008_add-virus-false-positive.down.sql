@@ -0,0 +1 @@
+ALTER TABLE "storage"."virus" DROP COLUMN IF EXISTS "false_positive";
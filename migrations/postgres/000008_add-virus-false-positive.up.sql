@@ -0,0 +1 @@
+ALTER TABLE "storage"."virus" ADD COLUMN IF NOT EXISTS "false_positive" BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS set_storage_webhook_events_updated_at ON storage.webhook_events;
+
+DROP TABLE IF EXISTS storage.webhook_events;
+
+ALTER TABLE "storage"."buckets" DROP COLUMN IF EXISTS "webhook_secret";
+ALTER TABLE "storage"."buckets" DROP COLUMN IF EXISTS "webhook_url";
@@ -0,0 +1,26 @@
+ALTER TABLE "storage"."buckets" ADD COLUMN IF NOT EXISTS "webhook_url" TEXT;
+ALTER TABLE "storage"."buckets" ADD COLUMN IF NOT EXISTS "webhook_secret" TEXT;
+
+CREATE TABLE IF NOT EXISTS storage.webhook_events (
+  id uuid DEFAULT public.gen_random_uuid () NOT NULL PRIMARY KEY,
+  created_at timestamp with time zone DEFAULT now() NOT NULL,
+  updated_at timestamp with time zone DEFAULT now() NOT NULL,
+  event_type TEXT NOT NULL,
+  -- when null the event is delivered to the global webhook
+  bucket_id TEXT REFERENCES storage.buckets(id) ON UPDATE CASCADE ON DELETE CASCADE,
+  payload JSONB NOT NULL,
+  attempts INT NOT NULL DEFAULT 0,
+  next_attempt_at timestamp with time zone DEFAULT now() NOT NULL,
+  delivered_at timestamp with time zone,
+  last_error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS webhook_events_pending_idx
+  ON storage.webhook_events (next_attempt_at)
+  WHERE delivered_at IS NULL;
+
+DROP TRIGGER IF EXISTS set_storage_webhook_events_updated_at ON storage.webhook_events;
+CREATE TRIGGER set_storage_webhook_events_updated_at
+  BEFORE UPDATE ON storage.webhook_events
+  FOR EACH ROW
+  EXECUTE FUNCTION storage.set_current_timestamp_updated_at ();
@@ -0,0 +1,4 @@
+ALTER TABLE "storage"."buckets" DROP COLUMN IF EXISTS "denied_extensions";
+ALTER TABLE "storage"."buckets" DROP COLUMN IF EXISTS "allowed_extensions";
+ALTER TABLE "storage"."buckets" DROP COLUMN IF EXISTS "denied_mime_types";
+ALTER TABLE "storage"."buckets" DROP COLUMN IF EXISTS "allowed_mime_types";
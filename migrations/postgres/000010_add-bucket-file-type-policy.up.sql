@@ -0,0 +1,5 @@
+-- comma separated lists, wildcards like image/* are allowed for mime types
+ALTER TABLE "storage"."buckets" ADD COLUMN IF NOT EXISTS "allowed_mime_types" TEXT;
+ALTER TABLE "storage"."buckets" ADD COLUMN IF NOT EXISTS "denied_mime_types" TEXT;
+ALTER TABLE "storage"."buckets" ADD COLUMN IF NOT EXISTS "allowed_extensions" TEXT;
+ALTER TABLE "storage"."buckets" ADD COLUMN IF NOT EXISTS "denied_extensions" TEXT;
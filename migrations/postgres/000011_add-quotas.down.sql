@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS update_storage_usage ON storage.files;
+
+DROP FUNCTION IF EXISTS storage.update_usage;
+
+DROP TABLE IF EXISTS storage.usage;
+
+DROP TRIGGER IF EXISTS set_storage_quotas_updated_at ON storage.quotas;
+
+DROP TABLE IF EXISTS storage.quotas;
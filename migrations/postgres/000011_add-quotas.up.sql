@@ -0,0 +1,62 @@
+-- null columns match everything, for instance a quota with only a role applies to all
+-- the users with that role across all buckets
+CREATE TABLE IF NOT EXISTS storage.quotas (
+  id uuid DEFAULT public.gen_random_uuid () NOT NULL PRIMARY KEY,
+  created_at timestamp with time zone DEFAULT now() NOT NULL,
+  updated_at timestamp with time zone DEFAULT now() NOT NULL,
+  user_id uuid,
+  role TEXT,
+  bucket_id TEXT REFERENCES storage.buckets(id) ON UPDATE CASCADE ON DELETE CASCADE,
+  max_bytes BIGINT,
+  max_files INT
+);
+
+DROP TRIGGER IF EXISTS set_storage_quotas_updated_at ON storage.quotas;
+CREATE TRIGGER set_storage_quotas_updated_at
+  BEFORE UPDATE ON storage.quotas
+  FOR EACH ROW
+  EXECUTE FUNCTION storage.set_current_timestamp_updated_at ();
+
+CREATE TABLE IF NOT EXISTS storage.usage (
+  user_id uuid NOT NULL,
+  bucket_id TEXT NOT NULL REFERENCES storage.buckets(id) ON UPDATE CASCADE ON DELETE CASCADE,
+  bytes BIGINT NOT NULL DEFAULT 0,
+  files INT NOT NULL DEFAULT 0,
+  PRIMARY KEY (user_id, bucket_id)
+);
+
+-- usage is maintained in the same transaction that modifies the files
+CREATE OR REPLACE FUNCTION storage.update_usage ()
+  RETURNS TRIGGER
+  LANGUAGE plpgsql
+  AS $a$
+BEGIN
+  IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.uploaded_by_user_id IS NOT NULL THEN
+    UPDATE storage.usage
+      SET bytes = bytes - COALESCE(OLD.size, 0), files = files - 1
+      WHERE user_id = OLD.uploaded_by_user_id AND bucket_id = OLD.bucket_id;
+  END IF;
+
+  IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.uploaded_by_user_id IS NOT NULL THEN
+    INSERT INTO storage.usage (user_id, bucket_id, bytes, files)
+      VALUES (NEW.uploaded_by_user_id, NEW.bucket_id, COALESCE(NEW.size, 0), 1)
+    ON CONFLICT (user_id, bucket_id) DO UPDATE
+      SET bytes = storage.usage.bytes + EXCLUDED.bytes, files = storage.usage.files + 1;
+  END IF;
+
+  RETURN NULL;
+END;
+$a$;
+
+DROP TRIGGER IF EXISTS update_storage_usage ON storage.files;
+CREATE TRIGGER update_storage_usage
+  AFTER INSERT OR DELETE OR UPDATE OF size, bucket_id, uploaded_by_user_id ON storage.files
+  FOR EACH ROW
+  EXECUTE FUNCTION storage.update_usage ();
+
+INSERT INTO storage.usage (user_id, bucket_id, bytes, files)
+  SELECT uploaded_by_user_id, bucket_id, COALESCE(SUM(size), 0), COUNT(*)
+  FROM storage.files
+  WHERE uploaded_by_user_id IS NOT NULL
+  GROUP BY uploaded_by_user_id, bucket_id
+ON CONFLICT (user_id, bucket_id) DO NOTHING;
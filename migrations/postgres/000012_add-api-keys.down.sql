@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS set_storage_api_keys_updated_at ON storage.api_keys;
+
+DROP TABLE IF EXISTS storage.api_keys;
@@ -0,0 +1,20 @@
+-- only the sha256 of the keys is stored. buckets and operations are comma separated
+-- lists, null buckets means all of them. Operations can be read, write, delete and ops
+CREATE TABLE IF NOT EXISTS storage.api_keys (
+  id uuid DEFAULT public.gen_random_uuid () NOT NULL PRIMARY KEY,
+  created_at timestamp with time zone DEFAULT now() NOT NULL,
+  updated_at timestamp with time zone DEFAULT now() NOT NULL,
+  name TEXT NOT NULL,
+  key_hash TEXT NOT NULL UNIQUE,
+  role TEXT NOT NULL,
+  buckets TEXT,
+  operations TEXT NOT NULL DEFAULT 'read',
+  expires_at timestamp with time zone,
+  last_used_at timestamp with time zone
+);
+
+DROP TRIGGER IF EXISTS set_storage_api_keys_updated_at ON storage.api_keys;
+CREATE TRIGGER set_storage_api_keys_updated_at
+  BEFORE UPDATE ON storage.api_keys
+  FOR EACH ROW
+  EXECUTE FUNCTION storage.set_current_timestamp_updated_at ();
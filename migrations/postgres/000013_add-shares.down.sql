@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS set_storage_shares_updated_at ON storage.shares;
+
+DROP TABLE IF EXISTS storage.shares;
@@ -0,0 +1,24 @@
+-- only hashes of the tokens and passwords are stored. allowed_transformations is a
+-- comma separated list of the image manipulation parameters (w, h, q, b) that can be
+-- used with the share
+CREATE TABLE IF NOT EXISTS storage.shares (
+  id uuid DEFAULT public.gen_random_uuid () NOT NULL PRIMARY KEY,
+  created_at timestamp with time zone DEFAULT now() NOT NULL,
+  updated_at timestamp with time zone DEFAULT now() NOT NULL,
+  file_id uuid NOT NULL REFERENCES storage.files (id) ON UPDATE CASCADE ON DELETE CASCADE,
+  token_hash TEXT NOT NULL UNIQUE,
+  created_by_user_id uuid,
+  expires_at timestamp with time zone,
+  password_hash TEXT,
+  max_downloads INT,
+  download_count INT NOT NULL DEFAULT 0,
+  allowed_transformations TEXT
+);
+
+CREATE INDEX IF NOT EXISTS shares_file_id_idx ON storage.shares (file_id);
+
+DROP TRIGGER IF EXISTS set_storage_shares_updated_at ON storage.shares;
+CREATE TRIGGER set_storage_shares_updated_at
+  BEFORE UPDATE ON storage.shares
+  FOR EACH ROW
+  EXECUTE FUNCTION storage.set_current_timestamp_updated_at ();
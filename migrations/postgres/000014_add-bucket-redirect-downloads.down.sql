@@ -0,0 +1 @@
+ALTER TABLE "storage"."buckets" DROP COLUMN IF EXISTS "redirect_downloads";
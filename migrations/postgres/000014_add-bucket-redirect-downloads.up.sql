@@ -0,0 +1,2 @@
+-- when true downloads are redirected to a presigned URL of the storage backend
+ALTER TABLE "storage"."buckets" ADD COLUMN IF NOT EXISTS "redirect_downloads" BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,7 @@
+DROP VIEW IF EXISTS storage.expired_file_versions;
+
+DROP TABLE IF EXISTS storage.file_versions;
+
+ALTER TABLE "storage"."buckets" DROP COLUMN IF EXISTS "version_retention_days";
+ALTER TABLE "storage"."buckets" DROP COLUMN IF EXISTS "max_versions";
+ALTER TABLE "storage"."buckets" DROP COLUMN IF EXISTS "versioning_enabled";
@@ -0,0 +1,41 @@
+-- max_versions and version_retention_days are the retention policy of the versions of
+-- the files in the bucket, 0 keeps them forever
+ALTER TABLE "storage"."buckets" ADD COLUMN IF NOT EXISTS "versioning_enabled" BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE "storage"."buckets" ADD COLUMN IF NOT EXISTS "max_versions" INT NOT NULL DEFAULT 0;
+ALTER TABLE "storage"."buckets" ADD COLUMN IF NOT EXISTS "version_retention_days" INT NOT NULL DEFAULT 0;
+
+-- previous contents of the files, object_key points to a copy of the object
+CREATE TABLE IF NOT EXISTS storage.file_versions (
+  id uuid DEFAULT public.gen_random_uuid () NOT NULL PRIMARY KEY,
+  created_at timestamp with time zone DEFAULT now() NOT NULL,
+  file_id uuid NOT NULL REFERENCES storage.files (id) ON UPDATE CASCADE ON DELETE CASCADE,
+  name TEXT,
+  size INT,
+  mime_type TEXT,
+  etag TEXT,
+  object_key TEXT NOT NULL,
+  metadata JSONB,
+  uploaded_by_user_id uuid
+);
+
+CREATE INDEX IF NOT EXISTS file_versions_file_id_idx ON storage.file_versions (file_id, created_at);
+
+-- versions that fall out of the retention policy of their bucket
+CREATE OR REPLACE VIEW storage.expired_file_versions AS
+SELECT
+  v.id,
+  v.created_at,
+  v.file_id,
+  v.object_key
+FROM (
+  SELECT
+    file_versions.*,
+    row_number() OVER (PARTITION BY file_versions.file_id ORDER BY file_versions.created_at DESC) AS position
+  FROM
+    storage.file_versions) v
+  JOIN storage.files f ON f.id = v.file_id
+  JOIN storage.buckets b ON b.id = f.bucket_id
+WHERE (b.max_versions > 0
+  AND v.position > b.max_versions)
+  OR (b.version_retention_days > 0
+    AND v.created_at < now() - make_interval(days => b.version_retention_days));
@@ -0,0 +1,9 @@
+DROP VIEW IF EXISTS storage.expired_trash;
+
+DROP INDEX IF EXISTS storage.files_deleted_at_idx;
+
+ALTER TABLE "storage"."files" DROP COLUMN IF EXISTS "deleted_by_user_id";
+ALTER TABLE "storage"."files" DROP COLUMN IF EXISTS "deleted_at";
+
+ALTER TABLE "storage"."buckets" DROP COLUMN IF EXISTS "trash_retention_days";
+ALTER TABLE "storage"."buckets" DROP COLUMN IF EXISTS "soft_delete_enabled";
@@ -0,0 +1,25 @@
+-- files deleted from buckets with soft_delete_enabled are kept in the trash for
+-- trash_retention_days, 0 keeps them forever
+ALTER TABLE "storage"."buckets" ADD COLUMN IF NOT EXISTS "soft_delete_enabled" BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE "storage"."buckets" ADD COLUMN IF NOT EXISTS "trash_retention_days" INT NOT NULL DEFAULT 30;
+
+ALTER TABLE "storage"."files" ADD COLUMN IF NOT EXISTS "deleted_at" timestamp with time zone;
+ALTER TABLE "storage"."files" ADD COLUMN IF NOT EXISTS "deleted_by_user_id" uuid;
+
+CREATE INDEX IF NOT EXISTS files_deleted_at_idx ON storage.files (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- files in the trash for longer than the retention period of their bucket
+CREATE OR REPLACE VIEW storage.expired_trash AS
+SELECT
+  f.id,
+  f.bucket_id,
+  f.object_key,
+  f.updated_at,
+  f.deleted_at
+FROM
+  storage.files f
+  JOIN storage.buckets b ON b.id = f.bucket_id
+WHERE
+  f.deleted_at IS NOT NULL
+  AND b.trash_retention_days > 0
+  AND f.deleted_at < now() - make_interval(days => b.trash_retention_days);
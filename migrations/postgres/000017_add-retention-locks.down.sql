@@ -0,0 +1,28 @@
+CREATE OR REPLACE VIEW storage.expired_trash AS
+SELECT
+  f.id,
+  f.bucket_id,
+  f.object_key,
+  f.updated_at,
+  f.deleted_at
+FROM
+  storage.files f
+  JOIN storage.buckets b ON b.id = f.bucket_id
+WHERE
+  f.deleted_at IS NOT NULL
+  AND b.trash_retention_days > 0
+  AND f.deleted_at < now() - make_interval(days => b.trash_retention_days);
+
+DROP TABLE IF EXISTS storage.file_audit_events;
+
+DROP TRIGGER IF EXISTS protect_storage_files_locked_delete ON storage.files;
+DROP FUNCTION IF EXISTS storage.protect_locked_file_delete;
+
+DROP TRIGGER IF EXISTS set_storage_files_retain_until ON storage.files;
+DROP FUNCTION IF EXISTS storage.set_retain_until;
+
+ALTER TABLE "storage"."files" DROP COLUMN IF EXISTS "legal_hold";
+ALTER TABLE "storage"."files" DROP COLUMN IF EXISTS "retain_until";
+
+ALTER TABLE "storage"."buckets" DROP COLUMN IF EXISTS "retention_mode";
+ALTER TABLE "storage"."buckets" DROP COLUMN IF EXISTS "retention_days";
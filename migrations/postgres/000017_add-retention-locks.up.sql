@@ -0,0 +1,92 @@
+-- files uploaded to buckets with retention_days can't be modified or deleted until
+-- the retention period is over, 0 disables it. retention_mode is the S3 Object Lock
+-- mode used for the objects when the storage backend supports it
+ALTER TABLE "storage"."buckets" ADD COLUMN IF NOT EXISTS "retention_days" INT NOT NULL DEFAULT 0;
+ALTER TABLE "storage"."buckets" ADD COLUMN IF NOT EXISTS "retention_mode" TEXT NOT NULL DEFAULT 'GOVERNANCE'
+  CHECK (retention_mode IN ('GOVERNANCE', 'COMPLIANCE'));
+
+-- files under legal hold can't be modified or deleted until the hold is cleared
+ALTER TABLE "storage"."files" ADD COLUMN IF NOT EXISTS "retain_until" timestamp with time zone;
+ALTER TABLE "storage"."files" ADD COLUMN IF NOT EXISTS "legal_hold" BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- the retention period starts every time new content is uploaded
+CREATE OR REPLACE FUNCTION storage.set_retain_until ()
+  RETURNS TRIGGER
+  LANGUAGE plpgsql
+  AS $a$
+DECLARE
+  _retention_days int;
+BEGIN
+  IF NOT NEW.is_uploaded OR (OLD.is_uploaded AND NEW.etag IS NOT DISTINCT FROM OLD.etag) THEN
+    RETURN NEW;
+  END IF;
+
+  SELECT retention_days INTO _retention_days FROM storage.buckets WHERE id = NEW.bucket_id;
+  IF _retention_days > 0 THEN
+    NEW.retain_until := GREATEST(
+      COALESCE(NEW.retain_until, now()), now() + make_interval(days => _retention_days)
+    );
+  END IF;
+
+  RETURN NEW;
+END;
+$a$;
+
+DROP TRIGGER IF EXISTS set_storage_files_retain_until ON storage.files;
+CREATE TRIGGER set_storage_files_retain_until
+  BEFORE UPDATE OF is_uploaded, etag ON storage.files
+  FOR EACH ROW
+  EXECUTE FUNCTION storage.set_retain_until ();
+
+-- locked files can't be deleted through hasura either
+CREATE OR REPLACE FUNCTION storage.protect_locked_file_delete ()
+  RETURNS TRIGGER
+  LANGUAGE plpgsql
+  AS $a$
+BEGIN
+  IF OLD.legal_hold THEN
+    RAISE EXCEPTION 'Can not delete file % under legal hold', OLD.id;
+  END IF;
+  IF OLD.retain_until > now() THEN
+    RAISE EXCEPTION 'Can not delete file % retained until %', OLD.id, OLD.retain_until;
+  END IF;
+  RETURN OLD;
+END;
+$a$;
+
+DROP TRIGGER IF EXISTS protect_storage_files_locked_delete ON storage.files;
+CREATE TRIGGER protect_storage_files_locked_delete
+  BEFORE DELETE ON storage.files
+  FOR EACH ROW
+  EXECUTE FUNCTION storage.protect_locked_file_delete ();
+
+-- changes to the legal hold of the files, entries outlive the files they refer to
+CREATE TABLE IF NOT EXISTS storage.file_audit_events (
+  id uuid DEFAULT public.gen_random_uuid () NOT NULL PRIMARY KEY,
+  created_at timestamp with time zone DEFAULT now() NOT NULL,
+  file_id uuid NOT NULL,
+  action TEXT NOT NULL,
+  reason TEXT,
+  user_id uuid,
+  api_key_id uuid
+);
+
+CREATE INDEX IF NOT EXISTS file_audit_events_file_id_idx ON storage.file_audit_events (file_id, created_at);
+
+-- locked files stay in the trash until they are unlocked
+CREATE OR REPLACE VIEW storage.expired_trash AS
+SELECT
+  f.id,
+  f.bucket_id,
+  f.object_key,
+  f.updated_at,
+  f.deleted_at
+FROM
+  storage.files f
+  JOIN storage.buckets b ON b.id = f.bucket_id
+WHERE
+  f.deleted_at IS NOT NULL
+  AND b.trash_retention_days > 0
+  AND f.deleted_at < now() - make_interval(days => b.trash_retention_days)
+  AND NOT f.legal_hold
+  AND (f.retain_until IS NULL OR f.retain_until < now());
@@ -0,0 +1,9 @@
+DROP VIEW IF EXISTS storage.expired_files;
+
+DROP INDEX IF EXISTS storage.files_expires_at_idx;
+
+DROP TRIGGER IF EXISTS set_storage_files_expires_at ON storage.files;
+DROP FUNCTION IF EXISTS storage.set_expires_at;
+
+ALTER TABLE "storage"."files" DROP COLUMN IF EXISTS "expires_at";
+ALTER TABLE "storage"."buckets" DROP COLUMN IF EXISTS "default_ttl_seconds";
@@ -0,0 +1,47 @@
+-- files with expires_at are deleted once it's reached. Buckets with default_ttl_seconds
+-- set it for the files uploaded without one, 0 disables it
+ALTER TABLE "storage"."buckets" ADD COLUMN IF NOT EXISTS "default_ttl_seconds" INT NOT NULL DEFAULT 0;
+ALTER TABLE "storage"."files" ADD COLUMN IF NOT EXISTS "expires_at" timestamp with time zone;
+
+CREATE OR REPLACE FUNCTION storage.set_expires_at ()
+  RETURNS TRIGGER
+  LANGUAGE plpgsql
+  AS $a$
+DECLARE
+  _default_ttl_seconds int;
+BEGIN
+  IF NEW.expires_at IS NOT NULL THEN
+    RETURN NEW;
+  END IF;
+
+  SELECT default_ttl_seconds INTO _default_ttl_seconds FROM storage.buckets WHERE id = NEW.bucket_id;
+  IF _default_ttl_seconds > 0 THEN
+    NEW.expires_at := now() + make_interval(secs => _default_ttl_seconds);
+  END IF;
+
+  RETURN NEW;
+END;
+$a$;
+
+DROP TRIGGER IF EXISTS set_storage_files_expires_at ON storage.files;
+CREATE TRIGGER set_storage_files_expires_at
+  BEFORE INSERT ON storage.files
+  FOR EACH ROW
+  EXECUTE FUNCTION storage.set_expires_at ();
+
+CREATE INDEX IF NOT EXISTS files_expires_at_idx ON storage.files (expires_at) WHERE expires_at IS NOT NULL;
+
+-- locked files are kept until they are unlocked
+CREATE OR REPLACE VIEW storage.expired_files AS
+SELECT
+  f.id,
+  f.bucket_id,
+  f.object_key,
+  f.updated_at,
+  f.expires_at
+FROM
+  storage.files f
+WHERE
+  f.expires_at < now()
+  AND NOT f.legal_hold
+  AND (f.retain_until IS NULL OR f.retain_until < now());
@@ -0,0 +1,21 @@
+CREATE OR REPLACE FUNCTION storage.update_usage ()
+  RETURNS TRIGGER
+  LANGUAGE plpgsql
+  AS $a$
+BEGIN
+  IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.uploaded_by_user_id IS NOT NULL THEN
+    UPDATE storage.usage
+      SET bytes = bytes - COALESCE(OLD.size, 0), files = files - 1
+      WHERE user_id = OLD.uploaded_by_user_id AND bucket_id = OLD.bucket_id;
+  END IF;
+
+  IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.uploaded_by_user_id IS NOT NULL THEN
+    INSERT INTO storage.usage (user_id, bucket_id, bytes, files)
+      VALUES (NEW.uploaded_by_user_id, NEW.bucket_id, COALESCE(NEW.size, 0), 1)
+    ON CONFLICT (user_id, bucket_id) DO UPDATE
+      SET bytes = storage.usage.bytes + EXCLUDED.bytes, files = storage.usage.files + 1;
+  END IF;
+
+  RETURN NULL;
+END;
+$a$;
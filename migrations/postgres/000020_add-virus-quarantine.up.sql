@@ -0,0 +1,3 @@
+-- content rejected by the antivirus is kept under its own object key, described here,
+-- so it never replaces the content of the file it was meant for
+ALTER TABLE "storage"."virus" ADD COLUMN IF NOT EXISTS "quarantine" JSONB;
@@ -0,0 +1 @@
+ALTER TABLE "storage"."webhook_events" DROP COLUMN IF EXISTS "file_id";
@@ -0,0 +1,3 @@
+-- events queued in the same transaction as the change they describe don't know the
+-- resulting file yet, it is looked up when the event is delivered
+ALTER TABLE "storage"."webhook_events" ADD COLUMN IF NOT EXISTS "file_id" UUID;
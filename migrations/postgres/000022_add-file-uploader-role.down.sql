@@ -0,0 +1,53 @@
+CREATE OR REPLACE FUNCTION storage.update_usage ()
+  RETURNS TRIGGER
+  LANGUAGE plpgsql
+  AS $a$
+DECLARE
+  _role text;
+BEGIN
+  IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.uploaded_by_user_id IS NOT NULL THEN
+    UPDATE storage.usage
+      SET bytes = bytes - COALESCE(OLD.size, 0), files = files - 1
+      WHERE user_id = OLD.uploaded_by_user_id AND bucket_id = OLD.bucket_id;
+  END IF;
+
+  IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.uploaded_by_user_id IS NOT NULL THEN
+    PERFORM pg_advisory_xact_lock(hashtext(NEW.uploaded_by_user_id::text));
+
+    INSERT INTO storage.usage (user_id, bucket_id, bytes, files)
+      VALUES (NEW.uploaded_by_user_id, NEW.bucket_id, COALESCE(NEW.size, 0), 1)
+    ON CONFLICT (user_id, bucket_id) DO UPDATE
+      SET bytes = storage.usage.bytes + EXCLUDED.bytes, files = storage.usage.files + 1;
+
+    -- files that shrink or stay the same are never rejected, even if over the quota
+    IF TG_OP = 'INSERT'
+      OR COALESCE(NEW.size, 0) > COALESCE(OLD.size, 0)
+      OR NEW.bucket_id IS DISTINCT FROM OLD.bucket_id
+      OR NEW.uploaded_by_user_id IS DISTINCT FROM OLD.uploaded_by_user_id THEN
+      _role := NULLIF(current_setting('hasura.user', true), '')::jsonb ->> 'x-hasura-role';
+
+      IF EXISTS (
+        SELECT 1
+        FROM storage.quotas q
+        CROSS JOIN LATERAL (
+          SELECT COALESCE(SUM(u.bytes), 0) AS bytes, COALESCE(SUM(u.files), 0) AS files
+          FROM storage.usage u
+          WHERE u.user_id = NEW.uploaded_by_user_id
+            AND (q.bucket_id IS NULL OR u.bucket_id = q.bucket_id)
+        ) used
+        WHERE (q.user_id IS NULL OR q.user_id = NEW.uploaded_by_user_id)
+          AND (q.role IS NULL OR q.role = _role)
+          AND (q.bucket_id IS NULL OR q.bucket_id = NEW.bucket_id)
+          AND (q.max_bytes > 0 AND used.bytes > q.max_bytes
+            OR q.max_files > 0 AND used.files > q.max_files)
+      ) THEN
+        RAISE EXCEPTION 'storage quota exceeded' USING ERRCODE = 'check_violation';
+      END IF;
+    END IF;
+  END IF;
+
+  RETURN NULL;
+END;
+$a$;
+
+ALTER TABLE storage.files DROP COLUMN IF EXISTS uploaded_by_role;
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS snapshot_storage_webhook_event_file ON storage.webhook_events;
+DROP FUNCTION IF EXISTS storage.snapshot_webhook_event_file ();
@@ -0,0 +1,53 @@
+-- events are inserted after the change they describe in the same mutation so the file
+-- is stored in the payload as it is at that point, later changes don't affect events
+-- waiting to be delivered. Only the id is sent if the file doesn't exist anymore. The
+-- keys match the file metadata returned by the API
+CREATE OR REPLACE FUNCTION storage.snapshot_webhook_event_file ()
+  RETURNS TRIGGER
+  LANGUAGE plpgsql
+  AS $a$
+BEGIN
+  IF NEW.file_id IS NULL OR NEW.payload ? 'data' THEN
+    RETURN NEW;
+  END IF;
+
+  NEW.payload := NEW.payload || jsonb_build_object('data', COALESCE(
+    (
+      SELECT jsonb_build_object(
+          'id', f.id,
+          'name', COALESCE(f.name, ''),
+          'size', COALESCE(f.size, 0),
+          'bucketId', f.bucket_id,
+          'etag', COALESCE(f.etag, ''),
+          'createdAt', f.created_at,
+          'updatedAt', f.updated_at,
+          'isUploaded', COALESCE(f.is_uploaded, FALSE),
+          'mimeType', COALESCE(f.mime_type, ''),
+          'uploadedByUserId', COALESCE(f.uploaded_by_user_id::text, ''),
+          'metadata', f.metadata,
+          'objectKey', COALESCE(f.object_key, ''),
+          'chunkSize', COALESCE(f.chunk_size, 0),
+          'chunkCount', COALESCE(f.chunk_count, 0),
+          'uploadId', COALESCE(f.upload_id, '')
+        ) || jsonb_strip_nulls(jsonb_build_object(
+          'deletedAt', f.deleted_at,
+          'deletedByUserId', f.deleted_by_user_id,
+          'retainUntil', f.retain_until,
+          'legalHold', NULLIF(f.legal_hold, FALSE),
+          'expiresAt', f.expires_at
+        ))
+      FROM storage.files f
+      WHERE f.id = NEW.file_id
+    ),
+    jsonb_build_object('id', NEW.file_id)
+  ));
+
+  RETURN NEW;
+END;
+$a$;
+
+DROP TRIGGER IF EXISTS snapshot_storage_webhook_event_file ON storage.webhook_events;
+CREATE TRIGGER snapshot_storage_webhook_event_file
+  BEFORE INSERT ON storage.webhook_events
+  FOR EACH ROW
+  EXECUTE FUNCTION storage.snapshot_webhook_event_file ();
@@ -0,0 +1,8 @@
+ALTER TABLE "storage"."buckets" ADD COLUMN IF NOT EXISTS "webhook_secret" TEXT;
+
+UPDATE storage.buckets b
+  SET webhook_secret = s.secret
+  FROM storage.webhook_secrets s
+  WHERE s.bucket_id = b.id;
+
+DROP TABLE IF EXISTS storage.webhook_secrets;
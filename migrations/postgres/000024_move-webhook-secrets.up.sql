@@ -0,0 +1,12 @@
+-- webhook secrets are kept out of storage.buckets so roles allowed to read buckets can't
+-- read them, the table is only meant to be accessed with the admin secret
+CREATE TABLE IF NOT EXISTS storage.webhook_secrets (
+  bucket_id TEXT NOT NULL PRIMARY KEY REFERENCES storage.buckets(id) ON UPDATE CASCADE ON DELETE CASCADE,
+  secret TEXT NOT NULL
+);
+
+INSERT INTO storage.webhook_secrets (bucket_id, secret)
+  SELECT id, webhook_secret FROM storage.buckets WHERE webhook_secret IS NOT NULL
+ON CONFLICT (bucket_id) DO NOTHING;
+
+ALTER TABLE "storage"."buckets" DROP COLUMN IF EXISTS "webhook_secret";
@@ -62,14 +62,18 @@ type S3 struct {
 	bucket     *string
 	rootFolder string
 	url        string
+	objectLock bool
 	logger     *logrus.Logger
 }
 
+// NewS3 returns a S3 content storage, objectLock enables the retention and legal hold
+// of objects and requires a bucket with S3 Object Lock enabled.
 func NewS3(
 	client *s3.Client,
 	bucket string,
 	rootFolder string,
 	url string,
+	objectLock bool,
 	logger *logrus.Logger,
 ) *S3 {
 	return &S3{
@@ -77,6 +81,7 @@ func NewS3(
 		bucket:     aws.String(bucket),
 		rootFolder: rootFolder,
 		url:        url,
+		objectLock: objectLock,
 		logger:     logger,
 	}
 }
@@ -114,6 +119,29 @@ func (s *S3) PutFile(
 	return *object.ETag, nil
 }
 
+// getObjectInput forwards the range and conditional headers of the request. If-Modified-Since
+// is left out as it is evaluated against the metadata, which may be updated without
+// modifying the object.
+func getObjectInput(bucket *string, key string, headers http.Header) *s3.GetObjectInput {
+	input := &s3.GetObjectInput{ //nolint:exhaustivestruct
+		Bucket: bucket,
+		Key:    aws.String(key),
+		Range:  aws.String(headers.Get("Range")),
+	}
+
+	if v := headers.Values("If-Match"); len(v) > 0 {
+		input.IfMatch = aws.String(strings.Join(v, ", "))
+	}
+	if v := headers.Values("If-None-Match"); len(v) > 0 {
+		input.IfNoneMatch = aws.String(strings.Join(v, ", "))
+	}
+	if t, err := time.Parse(time.RFC1123, headers.Get("If-Unmodified-Since")); err == nil {
+		input.IfUnmodifiedSince = &t
+	}
+
+	return input
+}
+
 func (s *S3) GetFile(
 	ctx context.Context,
 	filepath string,
@@ -124,18 +152,22 @@ func (s *S3) GetFile(
 		return nil, controller.InternalServerError(fmt.Errorf("problem joining path: %w", err))
 	}
 
-	object, err := s.client.GetObject(ctx,
-		&s3.GetObjectInput{
-			Bucket: s.bucket,
-			Key:    aws.String(key),
-			// IfMatch:           new(string),
-			// IfModifiedSince:   &time.Time{},
-			// IfNoneMatch:       new(string),
-			// IfUnmodifiedSince: &time.Time{},
-			Range: aws.String(headers.Get("Range")),
-		},
-	)
+	object, err := s.client.GetObject(ctx, getObjectInput(s.bucket, key, headers))
 	if err != nil {
+		var respErr interface{ HTTPStatusCode() int }
+		if errors.As(err, &respErr) {
+			switch code := respErr.HTTPStatusCode(); code {
+			case http.StatusNotModified, http.StatusPreconditionFailed:
+				return &controller.File{
+					ContentType:   "",
+					ContentLength: 0,
+					Etag:          "",
+					StatusCode:    code,
+					Body:          http.NoBody,
+					ExtraHeaders:  make(http.Header),
+				}, nil
+			}
+		}
 		return nil, controller.InternalServerError(fmt.Errorf("problem getting object: %w", err))
 	}
 
@@ -196,6 +228,37 @@ func (s *S3) CreateGetObjectPresignedURL(
 	return parts[1], nil
 }
 
+func (s *S3) CreateGetObjectRedirectURL(
+	ctx context.Context,
+	filepath string,
+	contentDisposition string,
+	expire time.Duration,
+) (string, *controller.APIError) {
+	key, err := url.JoinPath(s.rootFolder, filepath)
+	if err != nil {
+		return "", controller.InternalServerError(fmt.Errorf("problem joining path: %w", err))
+	}
+
+	presignClient := s3.NewPresignClient(s.client)
+	request, err := presignClient.PresignGetObject(ctx,
+		&s3.GetObjectInput{ //nolint:exhaustivestruct
+			Bucket:                     s.bucket,
+			Key:                        aws.String(key),
+			ResponseContentDisposition: aws.String(contentDisposition),
+		},
+		func(po *s3.PresignOptions) {
+			po.Expires = expire
+		},
+	)
+	if err != nil {
+		return "", controller.InternalServerError(
+			fmt.Errorf("problem generating pre-signed URL: %w", err),
+		)
+	}
+
+	return request.URL, nil
+}
+
 func (s *S3) GetFileWithPresignedURL(
 	ctx context.Context, filepath, signature string, headers http.Header,
 ) (*controller.File, *controller.APIError) {
@@ -232,7 +295,7 @@ func (s *S3) GetFileWithPresignedURL(
 			respHeaders["Content-Range"] = []string{resp.Header.Get("Content-Range")}
 		}
 
-		length, err = strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 32)
+		length, err = strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64)
 		if err != nil {
 			return nil, controller.InternalServerError(
 				fmt.Errorf("problem parsing Content-Length: %w", err),
@@ -297,6 +360,100 @@ func (s *S3) DeleteFile(ctx context.Context, filepath string) *controller.APIErr
 	return nil
 }
 
+// copyObjectMaxSize is the biggest object CopyObject can copy, bigger objects are
+// copied in parts.
+const (
+	copyObjectMaxSize = 5 * 1024 * 1024 * 1024
+	copyPartSize      = 512 * 1024 * 1024
+)
+
+func (s *S3) copyParts(
+	ctx context.Context, copySource, dstKey string, size int64,
+) (string, *controller.APIError) {
+	upload, err := s.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket: s.bucket,
+		Key:    aws.String(dstKey),
+	})
+	if err != nil {
+		return "", controller.InternalServerError(fmt.Errorf("problem create multipart upload in s3: %w", err))
+	}
+
+	abort := func() {
+		if _, err := s.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+			Bucket:   s.bucket,
+			Key:      aws.String(dstKey),
+			UploadId: upload.UploadId,
+		}); err != nil {
+			s.logger.WithError(err).Error("problem aborting multipart copy in s3")
+		}
+	}
+
+	parts := make([]types.CompletedPart, 0, size/copyPartSize+1)
+	for start := int64(0); start < size; start += copyPartSize {
+		end := min(start+copyPartSize, size) - 1
+		output, err := s.client.UploadPartCopy(ctx, &s3.UploadPartCopyInput{
+			Bucket:          s.bucket,
+			Key:             aws.String(dstKey),
+			UploadId:        upload.UploadId,
+			PartNumber:      aws.Int32(int32(len(parts) + 1)), //nolint: gosec
+			CopySource:      aws.String(copySource),
+			CopySourceRange: aws.String(fmt.Sprintf("bytes=%d-%d", start, end)),
+		})
+		if err != nil {
+			abort()
+			return "", controller.InternalServerError(fmt.Errorf("problem copying part in s3: %w", err))
+		}
+
+		parts = append(parts, types.CompletedPart{
+			ETag:       output.CopyPartResult.ETag,
+			PartNumber: aws.Int32(int32(len(parts) + 1)), //nolint: gosec
+		})
+	}
+
+	output, err := s.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          s.bucket,
+		Key:             aws.String(dstKey),
+		UploadId:        upload.UploadId,
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+	})
+	if err != nil {
+		abort()
+		return "", controller.InternalServerError(fmt.Errorf("problem complete multipart upload in s3: %w", err))
+	}
+
+	return *output.ETag, nil
+}
+
+func (s *S3) CopyFile(
+	ctx context.Context, srcPath, dstPath string, size int64,
+) (string, *controller.APIError) {
+	srcKey, err := url.JoinPath(s.rootFolder, srcPath)
+	if err != nil {
+		return "", controller.InternalServerError(fmt.Errorf("problem joining path: %w", err))
+	}
+	dstKey, err := url.JoinPath(s.rootFolder, dstPath)
+	if err != nil {
+		return "", controller.InternalServerError(fmt.Errorf("problem joining path: %w", err))
+	}
+
+	copySource := (&url.URL{Path: *s.bucket + "/" + srcKey}).EscapedPath() //nolint: exhaustruct
+
+	if size > copyObjectMaxSize {
+		return s.copyParts(ctx, copySource, dstKey, size)
+	}
+
+	output, err := s.client.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:     s.bucket,
+		Key:        aws.String(dstKey),
+		CopySource: aws.String(copySource),
+	})
+	if err != nil {
+		return "", controller.InternalServerError(fmt.Errorf("problem copying object in s3: %w", err))
+	}
+
+	return *output.CopyObjectResult.ETag, nil
+}
+
 func (s *S3) ListFiles(ctx context.Context) ([]string, *controller.APIError) {
 	objects, err := s.client.ListObjects(ctx,
 		&s3.ListObjectsInput{
@@ -550,3 +707,57 @@ func (s *S3) AbortMultipartUpload(
 
 	return nil
 }
+
+func (s *S3) SetObjectRetention(
+	ctx context.Context, filepath, mode string, retainUntil time.Time,
+) *controller.APIError {
+	if !s.objectLock {
+		return nil
+	}
+
+	key, err := url.JoinPath(s.rootFolder, filepath)
+	if err != nil {
+		return controller.InternalServerError(fmt.Errorf("problem joining path: %w", err))
+	}
+
+	if _, err := s.client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
+		Bucket: s.bucket,
+		Key:    aws.String(key),
+		Retention: &types.ObjectLockRetention{
+			Mode:            types.ObjectLockRetentionMode(mode),
+			RetainUntilDate: aws.Time(retainUntil),
+		},
+	}); err != nil {
+		return controller.InternalServerError(fmt.Errorf("problem setting object retention in s3: %w", err))
+	}
+
+	return nil
+}
+
+func (s *S3) SetObjectLegalHold(
+	ctx context.Context, filepath string, legalHold bool,
+) *controller.APIError {
+	if !s.objectLock {
+		return nil
+	}
+
+	key, err := url.JoinPath(s.rootFolder, filepath)
+	if err != nil {
+		return controller.InternalServerError(fmt.Errorf("problem joining path: %w", err))
+	}
+
+	status := types.ObjectLockLegalHoldStatusOff
+	if legalHold {
+		status = types.ObjectLockLegalHoldStatusOn
+	}
+
+	if _, err := s.client.PutObjectLegalHold(ctx, &s3.PutObjectLegalHoldInput{
+		Bucket:    s.bucket,
+		Key:       aws.String(key),
+		LegalHold: &types.ObjectLockLegalHold{Status: status},
+	}); err != nil {
+		return controller.InternalServerError(fmt.Errorf("problem setting object legal hold in s3: %w", err))
+	}
+
+	return nil
+}
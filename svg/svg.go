@@ -0,0 +1,137 @@
+package svg
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// elements that can run scripts or embed active content, they are removed with all
+// their children.
+var forbiddenElements = map[string]struct{}{
+	"script":        {},
+	"foreignobject": {},
+	"iframe":        {},
+	"embed":         {},
+	"object":        {},
+	"handler":       {},
+	"listener":      {},
+}
+
+func localName(name xml.Name) string {
+	// RawToken keeps the prefix in Space
+	return strings.ToLower(name.Local)
+}
+
+func qualifiedName(name xml.Name) xml.Name {
+	if name.Space == "" {
+		return name
+	}
+	return xml.Name{Space: "", Local: name.Space + ":" + name.Local}
+}
+
+func isURLAttr(name string) bool {
+	switch name {
+	case "href", "src", "action", "formaction", "from", "to", "values":
+		return true
+	}
+	return false
+}
+
+func isScriptURL(value string) bool {
+	v := strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(value))
+	return strings.HasPrefix(v, "javascript:") ||
+		strings.HasPrefix(v, "vbscript:") ||
+		strings.HasPrefix(v, "data:text/html")
+}
+
+func sanitizeAttrs(attrs []xml.Attr) []xml.Attr {
+	res := make([]xml.Attr, 0, len(attrs))
+	for _, attr := range attrs {
+		name := localName(attr.Name)
+		if strings.HasPrefix(name, "on") {
+			continue
+		}
+
+		if isURLAttr(name) && isScriptURL(attr.Value) {
+			continue
+		}
+
+		// <set attributeName="href" to="javascript:..."> and <animate> variants
+		if name == "attributename" && strings.HasPrefix(strings.ToLower(attr.Value), "on") {
+			continue
+		}
+
+		res = append(res, xml.Attr{Name: qualifiedName(attr.Name), Value: attr.Value})
+	}
+	return res
+}
+
+// Sanitize removes scripts, event handlers, javascript: URLs and embedded active
+// content from an SVG document. Doctypes are dropped as well so entities can't be
+// used to smuggle any of those back in.
+func Sanitize(r io.Reader) ([]byte, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.Strict = false
+
+	var buf bytes.Buffer
+	encoder := xml.NewEncoder(&buf)
+
+	skipDepth := 0
+	for {
+		token, err := decoder.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("problem parsing svg: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if skipDepth > 0 {
+				skipDepth++
+				continue
+			}
+			if _, ok := forbiddenElements[localName(t.Name)]; ok {
+				skipDepth = 1
+				continue
+			}
+			token = xml.StartElement{Name: qualifiedName(t.Name), Attr: sanitizeAttrs(t.Attr)}
+		case xml.EndElement:
+			if skipDepth > 0 {
+				skipDepth--
+				continue
+			}
+			token = xml.EndElement{Name: qualifiedName(t.Name)}
+		case xml.ProcInst:
+			if skipDepth > 0 || t.Target != "xml" {
+				continue
+			}
+		case xml.Directive:
+			continue
+		default:
+			if skipDepth > 0 {
+				continue
+			}
+		}
+
+		if err := encoder.EncodeToken(token); err != nil {
+			return nil, fmt.Errorf("problem encoding svg: %w", err)
+		}
+	}
+
+	if err := encoder.Flush(); err != nil {
+		return nil, fmt.Errorf("problem encoding svg: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,74 @@
+package svg_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/hasura-storage/svg"
+)
+
+func TestSanitize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "clean",
+			input:    `<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg" width="10"><circle r="4"></circle></svg>`,
+			expected: `<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg" width="10"><circle r="4"></circle></svg>`,
+		},
+		{
+			name:     "script",
+			input:    `<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script><SCRIPT/><rect/></svg>`,
+			expected: `<svg xmlns="http://www.w3.org/2000/svg"><rect></rect></svg>`,
+		},
+		{
+			name:     "event handlers",
+			input:    `<svg onload="alert(1)"><rect onClick="alert(2)" fill="red"/></svg>`,
+			expected: `<svg><rect fill="red"></rect></svg>`,
+		},
+		{
+			name: "javascript urls",
+			input: `<svg xmlns:xlink="http://www.w3.org/1999/xlink">` +
+				`<a xlink:href=" JavaScript:alert(1)"><text>x</text></a>` +
+				`<a href="https://example.com"><text>y</text></a></svg>`,
+			expected: `<svg xmlns:xlink="http://www.w3.org/1999/xlink">` +
+				`<a><text>x</text></a>` +
+				`<a href="https://example.com"><text>y</text></a></svg>`,
+		},
+		{
+			name:     "foreign object",
+			input:    `<svg><foreignObject><body><iframe src="x"></iframe></body></foreignObject><g/></svg>`,
+			expected: `<svg><g></g></svg>`,
+		},
+		{
+			name:     "doctype",
+			input:    `<!DOCTYPE svg [<!ENTITY x "y">]><svg><text>&amp;</text></svg>`,
+			expected: `<svg><text>&amp;</text></svg>`,
+		},
+		{
+			name:     "animated handler",
+			input:    `<svg><set attributeName="onmouseover" to="alert(1)"/></svg>`,
+			expected: `<svg><set to="alert(1)"></set></svg>`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := svg.Sanitize(strings.NewReader(tc.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.expected, string(got)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package uploadhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/webhook"
+)
+
+const requestTimeout = 5 * time.Second
+
+// Hook sends every upload to an HTTP endpoint before it is initialized. The endpoint
+// must respond with a 2xx status code and a JSON body with the decision, uploads fail
+// if the hook can't be reached or responds with anything else.
+type Hook struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+func New(url, secret string) *Hook {
+	return &Hook{
+		url:    url,
+		secret: secret,
+		client: &http.Client{ //nolint: exhaustruct
+			Timeout: requestTimeout,
+		},
+	}
+}
+
+func (h *Hook) CheckUpload(
+	ctx context.Context, req controller.UploadHookRequest,
+) (controller.UploadHookResponse, *controller.APIError) {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return controller.UploadHookResponse{}, controller.InternalServerError(
+			fmt.Errorf("problem marshalling request: %w", err),
+		)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(b))
+	if err != nil {
+		return controller.UploadHookResponse{}, controller.InternalServerError(
+			fmt.Errorf("problem creating request: %w", err),
+		)
+	}
+
+	timestamp := time.Now().Unix()
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set(webhook.HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	if h.secret != "" {
+		httpReq.Header.Set(webhook.HeaderSignature, webhook.Sign(h.secret, timestamp, b))
+	}
+
+	resp, err := h.client.Do(httpReq)
+	if err != nil {
+		return controller.UploadHookResponse{}, controller.InternalServerError(
+			fmt.Errorf("problem executing request: %w", err),
+		)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return controller.UploadHookResponse{}, controller.InternalServerError(
+			fmt.Errorf("pre-upload hook responded with status %s", resp.Status), //nolint: goerr113
+		)
+	}
+
+	var res controller.UploadHookResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return controller.UploadHookResponse{}, controller.InternalServerError(
+			fmt.Errorf("problem decoding response: %w", err),
+		)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,121 @@
+package uploadhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/uploadhook"
+	"github.com/nhost/hasura-storage/webhook"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestCheckUpload(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		statusCode  int
+		body        string
+		expected    controller.UploadHookResponse
+		expectedErr bool
+	}{
+		{
+			name:       "allowed",
+			statusCode: http.StatusOK,
+			body:       `{"allow":true,"objectPrefix":"tenant","metadata":{"tenant":"a"}}`,
+			expected: controller.UploadHookResponse{ //nolint: exhaustruct
+				Allow:        true,
+				ObjectPrefix: ptr("tenant"),
+				Metadata:     map[string]any{"tenant": "a"},
+			},
+		},
+		{
+			name:       "denied",
+			statusCode: http.StatusOK,
+			body:       `{"allow":false,"message":"not today"}`,
+			expected: controller.UploadHookResponse{ //nolint: exhaustruct
+				Allow:   false,
+				Message: "not today",
+			},
+		},
+		{
+			name:        "hook failing",
+			statusCode:  http.StatusInternalServerError,
+			body:        `{"allow":true}`,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Error(err)
+				}
+
+				timestamp, err := strconv.ParseInt(r.Header.Get(webhook.HeaderTimestamp), 10, 64)
+				if err != nil {
+					t.Error(err)
+				}
+
+				if diff := cmp.Diff(
+					webhook.Sign("hook-secret", timestamp, body),
+					r.Header.Get(webhook.HeaderSignature),
+				); diff != "" {
+					t.Error(diff)
+				}
+
+				var req controller.UploadHookRequest
+				if err := json.Unmarshal(body, &req); err != nil {
+					t.Error(err)
+				}
+
+				if diff := cmp.Diff("file.txt", req.Name); diff != "" {
+					t.Error(diff)
+				}
+
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			hook := uploadhook.New(server.URL, "hook-secret")
+
+			got, apiErr := hook.CheckUpload(context.Background(), controller.UploadHookRequest{ //nolint: exhaustruct
+				Operation: controller.UploadOperationUpload,
+				BucketID:  "default",
+				Name:      "file.txt",
+				Size:      10,
+				MimeType:  "text/plain",
+			})
+
+			if tc.expectedErr {
+				if apiErr == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+
+			if apiErr != nil {
+				t.Fatal(apiErr)
+			}
+
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
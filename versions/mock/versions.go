@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: versions.go
+//
+// Generated by this command:
+//
+//	mockgen -destination mock/versions.go -package mock -source=versions.go Storage ContentStorage
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	http "net/http"
+	reflect "reflect"
+
+	controller "github.com/nhost/hasura-storage/controller"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStorage is a mock of Storage interface.
+type MockStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageMockRecorder
+}
+
+// MockStorageMockRecorder is the mock recorder for MockStorage.
+type MockStorageMockRecorder struct {
+	mock *MockStorage
+}
+
+// NewMockStorage creates a new mock instance.
+func NewMockStorage(ctrl *gomock.Controller) *MockStorage {
+	mock := &MockStorage{ctrl: ctrl}
+	mock.recorder = &MockStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
+	return m.recorder
+}
+
+// DeleteFileVersion mocks base method.
+func (m *MockStorage) DeleteFileVersion(ctx context.Context, id string, headers http.Header) *controller.APIError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFileVersion", ctx, id, headers)
+	ret0, _ := ret[0].(*controller.APIError)
+	return ret0
+}
+
+// DeleteFileVersion indicates an expected call of DeleteFileVersion.
+func (mr *MockStorageMockRecorder) DeleteFileVersion(ctx, id, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFileVersion", reflect.TypeOf((*MockStorage)(nil).DeleteFileVersion), ctx, id, headers)
+}
+
+// ListExpiredFileVersions mocks base method.
+func (m *MockStorage) ListExpiredFileVersions(ctx context.Context, limit int, headers http.Header) ([]controller.FileVersion, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredFileVersions", ctx, limit, headers)
+	ret0, _ := ret[0].([]controller.FileVersion)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// ListExpiredFileVersions indicates an expected call of ListExpiredFileVersions.
+func (mr *MockStorageMockRecorder) ListExpiredFileVersions(ctx, limit, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredFileVersions", reflect.TypeOf((*MockStorage)(nil).ListExpiredFileVersions), ctx, limit, headers)
+}
+
+// MockContentStorage is a mock of ContentStorage interface.
+type MockContentStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockContentStorageMockRecorder
+}
+
+// MockContentStorageMockRecorder is the mock recorder for MockContentStorage.
+type MockContentStorageMockRecorder struct {
+	mock *MockContentStorage
+}
+
+// NewMockContentStorage creates a new mock instance.
+func NewMockContentStorage(ctrl *gomock.Controller) *MockContentStorage {
+	mock := &MockContentStorage{ctrl: ctrl}
+	mock.recorder = &MockContentStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContentStorage) EXPECT() *MockContentStorageMockRecorder {
+	return m.recorder
+}
+
+// DeleteFile mocks base method.
+func (m *MockContentStorage) DeleteFile(ctx context.Context, filepath string) *controller.APIError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFile", ctx, filepath)
+	ret0, _ := ret[0].(*controller.APIError)
+	return ret0
+}
+
+// DeleteFile indicates an expected call of DeleteFile.
+func (mr *MockContentStorageMockRecorder) DeleteFile(ctx, filepath interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockContentStorage)(nil).DeleteFile), ctx, filepath)
+}
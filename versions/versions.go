@@ -0,0 +1,108 @@
+//go:generate mockgen -destination mock/versions.go -package mock -source=versions.go Storage ContentStorage
+package versions
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/sirupsen/logrus"
+)
+
+const batchSize = 100
+
+type Storage interface {
+	ListExpiredFileVersions(
+		ctx context.Context, limit int, headers http.Header,
+	) ([]controller.FileVersion, *controller.APIError)
+	DeleteFileVersion(ctx context.Context, id string, headers http.Header) *controller.APIError
+}
+
+type ContentStorage interface {
+	DeleteFile(ctx context.Context, filepath string) *controller.APIError
+}
+
+// Pruner deletes the file versions that fall outside of the retention policy of their
+// bucket, either because the file has too many versions or because they are too old.
+type Pruner struct {
+	storage        Storage
+	contentStorage ContentStorage
+	adminSecret    controller.AdminSecret
+	logger         *logrus.Logger
+}
+
+func New(
+	storage Storage,
+	contentStorage ContentStorage,
+	hasuraAdminSecret controller.AdminSecret,
+	logger *logrus.Logger,
+) *Pruner {
+	return &Pruner{
+		storage:        storage,
+		contentStorage: contentStorage,
+		adminSecret:    hasuraAdminSecret,
+		logger:         logger,
+	}
+}
+
+func (p *Pruner) headers() http.Header {
+	return http.Header{"x-hasura-admin-secret": []string{p.adminSecret.Primary()}}
+}
+
+// prune deletes the object before the row so versions whose object couldn't be deleted
+// are retried in the next run.
+func (p *Pruner) prune(ctx context.Context, version controller.FileVersion) bool {
+	logger := p.logger.WithField("version_id", version.ID)
+
+	if apiErr := p.contentStorage.DeleteFile(ctx, version.ObjectKey); apiErr != nil {
+		logger.WithError(apiErr).Error("problem deleting file version from storage")
+		return false
+	}
+
+	if apiErr := p.storage.DeleteFileVersion(ctx, version.ID, p.headers()); apiErr != nil {
+		logger.WithError(apiErr).Error("problem deleting file version")
+		return false
+	}
+
+	return true
+}
+
+// PruneExpired deletes all the expired versions.
+func (p *Pruner) PruneExpired(ctx context.Context) {
+	for {
+		versions, apiErr := p.storage.ListExpiredFileVersions(ctx, batchSize, p.headers())
+		if apiErr != nil {
+			p.logger.WithError(apiErr).Error("problem listing expired file versions")
+			return
+		}
+
+		pruned := 0
+		for _, version := range versions {
+			if p.prune(ctx, version) {
+				pruned++
+			}
+		}
+
+		// failed versions would be listed again so they are left for the next run
+		if len(versions) < batchSize || pruned < len(versions) || ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// Run prunes expired versions periodically until the context is cancelled.
+func (p *Pruner) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		p.PruneExpired(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package versions_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/middleware/auth"
+	"github.com/nhost/hasura-storage/versions"
+	"github.com/nhost/hasura-storage/versions/mock"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func expiredVersions(n int) []controller.FileVersion {
+	versions := make([]controller.FileVersion, n)
+	for i := range versions {
+		versions[i] = controller.FileVersion{ //nolint: exhaustruct
+			ID:        fmt.Sprintf("version-%d", i),
+			ObjectKey: fmt.Sprintf(".versions/version-%d/file-id", i),
+		}
+	}
+	return versions
+}
+
+func TestPruneExpired(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		batches    [][]controller.FileVersion
+		contentErr *controller.APIError
+	}{
+		{
+			name:    "nothing expired",
+			batches: [][]controller.FileVersion{{}},
+		},
+		{
+			name:    "single batch",
+			batches: [][]controller.FileVersion{expiredVersions(2)},
+		},
+		{
+			name:    "full batch lists again",
+			batches: [][]controller.FileVersion{expiredVersions(100), expiredVersions(1)},
+		},
+		{
+			name:       "failed versions are left for the next run",
+			batches:    [][]controller.FileVersion{expiredVersions(100)},
+			contentErr: controller.InternalServerError(fmt.Errorf("s3 is down")), //nolint: goerr113
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			storage := mock.NewMockStorage(c)
+			contentStorage := mock.NewMockContentStorage(c)
+
+			calls := make([]any, 0, len(tc.batches))
+			for _, batch := range tc.batches {
+				calls = append(calls, storage.EXPECT().ListExpiredFileVersions(
+					gomock.Any(), 100, gomock.Any(),
+				).Return(batch, nil))
+
+				for _, v := range batch {
+					contentStorage.EXPECT().DeleteFile(
+						gomock.Any(), v.ObjectKey,
+					).Return(tc.contentErr)
+
+					if tc.contentErr == nil {
+						storage.EXPECT().DeleteFileVersion(
+							gomock.Any(), v.ID, gomock.Any(),
+						).Return(nil)
+					}
+				}
+			}
+			gomock.InOrder(calls...)
+
+			pruner := versions.New(
+				storage, contentStorage, auth.NewAdminSecrets("asdasd"), logger,
+			)
+			pruner.PruneExpired(context.Background())
+		})
+	}
+}
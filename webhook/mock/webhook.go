@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook.go
+//
+// Generated by this command:
+//
+//	mockgen -destination mock/webhook.go -package mock -source=webhook.go Storage
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	http "net/http"
+	reflect "reflect"
+	time "time"
+
+	controller "github.com/nhost/hasura-storage/controller"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStorage is a mock of Storage interface.
+type MockStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageMockRecorder
+}
+
+// MockStorageMockRecorder is the mock recorder for MockStorage.
+type MockStorageMockRecorder struct {
+	mock *MockStorage
+}
+
+// NewMockStorage creates a new mock instance.
+func NewMockStorage(ctrl *gomock.Controller) *MockStorage {
+	mock := &MockStorage{ctrl: ctrl}
+	mock.recorder = &MockStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
+	return m.recorder
+}
+
+// ClaimWebhookEvent mocks base method.
+func (m *MockStorage) ClaimWebhookEvent(ctx context.Context, id, nextAttemptAt string, leaseUntil time.Time, headers http.Header) (bool, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimWebhookEvent", ctx, id, nextAttemptAt, leaseUntil, headers)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// ClaimWebhookEvent indicates an expected call of ClaimWebhookEvent.
+func (mr *MockStorageMockRecorder) ClaimWebhookEvent(ctx, id, nextAttemptAt, leaseUntil, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWebhookEvent", reflect.TypeOf((*MockStorage)(nil).ClaimWebhookEvent), ctx, id, nextAttemptAt, leaseUntil, headers)
+}
+
+// GetBucketByID mocks base method.
+func (m *MockStorage) GetBucketByID(ctx context.Context, id string, headers http.Header) (controller.BucketMetadata, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBucketByID", ctx, id, headers)
+	ret0, _ := ret[0].(controller.BucketMetadata)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// GetBucketByID indicates an expected call of GetBucketByID.
+func (mr *MockStorageMockRecorder) GetBucketByID(ctx, id, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBucketByID", reflect.TypeOf((*MockStorage)(nil).GetBucketByID), ctx, id, headers)
+}
+
+// GetWebhookSecret mocks base method.
+func (m *MockStorage) GetWebhookSecret(ctx context.Context, bucketID string, headers http.Header) (string, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookSecret", ctx, bucketID, headers)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// GetWebhookSecret indicates an expected call of GetWebhookSecret.
+func (mr *MockStorageMockRecorder) GetWebhookSecret(ctx, bucketID, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookSecret", reflect.TypeOf((*MockStorage)(nil).GetWebhookSecret), ctx, bucketID, headers)
+}
+
+// InsertWebhookEvents mocks base method.
+func (m *MockStorage) InsertWebhookEvents(ctx context.Context, events []controller.WebhookEvent, headers http.Header) *controller.APIError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWebhookEvents", ctx, events, headers)
+	ret0, _ := ret[0].(*controller.APIError)
+	return ret0
+}
+
+// InsertWebhookEvents indicates an expected call of InsertWebhookEvents.
+func (mr *MockStorageMockRecorder) InsertWebhookEvents(ctx, events, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWebhookEvents", reflect.TypeOf((*MockStorage)(nil).InsertWebhookEvents), ctx, events, headers)
+}
+
+// ListPendingWebhookEvents mocks base method.
+func (m *MockStorage) ListPendingWebhookEvents(ctx context.Context, now time.Time, maxAttempts, limit int, headers http.Header) ([]controller.WebhookEvent, *controller.APIError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingWebhookEvents", ctx, now, maxAttempts, limit, headers)
+	ret0, _ := ret[0].([]controller.WebhookEvent)
+	ret1, _ := ret[1].(*controller.APIError)
+	return ret0, ret1
+}
+
+// ListPendingWebhookEvents indicates an expected call of ListPendingWebhookEvents.
+func (mr *MockStorageMockRecorder) ListPendingWebhookEvents(ctx, now, maxAttempts, limit, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingWebhookEvents", reflect.TypeOf((*MockStorage)(nil).ListPendingWebhookEvents), ctx, now, maxAttempts, limit, headers)
+}
+
+// SetWebhookEventDelivered mocks base method.
+func (m *MockStorage) SetWebhookEventDelivered(ctx context.Context, id string, attempts int, headers http.Header) *controller.APIError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWebhookEventDelivered", ctx, id, attempts, headers)
+	ret0, _ := ret[0].(*controller.APIError)
+	return ret0
+}
+
+// SetWebhookEventDelivered indicates an expected call of SetWebhookEventDelivered.
+func (mr *MockStorageMockRecorder) SetWebhookEventDelivered(ctx, id, attempts, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWebhookEventDelivered", reflect.TypeOf((*MockStorage)(nil).SetWebhookEventDelivered), ctx, id, attempts, headers)
+}
+
+// SetWebhookEventFailed mocks base method.
+func (m *MockStorage) SetWebhookEventFailed(ctx context.Context, id string, attempts int, nextAttemptAt time.Time, lastError string, headers http.Header) *controller.APIError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWebhookEventFailed", ctx, id, attempts, nextAttemptAt, lastError, headers)
+	ret0, _ := ret[0].(*controller.APIError)
+	return ret0
+}
+
+// SetWebhookEventFailed indicates an expected call of SetWebhookEventFailed.
+func (mr *MockStorageMockRecorder) SetWebhookEventFailed(ctx, id, attempts, nextAttemptAt, lastError, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWebhookEventFailed", reflect.TypeOf((*MockStorage)(nil).SetWebhookEventFailed), ctx, id, attempts, nextAttemptAt, lastError, headers)
+}
@@ -0,0 +1,337 @@
+//go:generate mockgen -destination mock/webhook.go -package mock -source=webhook.go Storage
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	HeaderID        = "X-Nhost-Webhook-Id"
+	HeaderEvent     = "X-Nhost-Webhook-Event"
+	HeaderTimestamp = "X-Nhost-Webhook-Timestamp"
+	HeaderSignature = "X-Nhost-Webhook-Signature"
+
+	defaultMaxAttempts = 10
+	batchSize          = 100
+	requestTimeout     = 10 * time.Second
+	minBackoff         = 10 * time.Second
+	maxBackoff         = time.Hour
+)
+
+type Storage interface {
+	GetBucketByID(
+		ctx context.Context, id string, headers http.Header,
+	) (controller.BucketMetadata, *controller.APIError)
+	GetWebhookSecret(
+		ctx context.Context, bucketID string, headers http.Header,
+	) (string, *controller.APIError)
+	InsertWebhookEvents(
+		ctx context.Context, events []controller.WebhookEvent, headers http.Header,
+	) *controller.APIError
+	ListPendingWebhookEvents(
+		ctx context.Context, now time.Time, maxAttempts, limit int, headers http.Header,
+	) ([]controller.WebhookEvent, *controller.APIError)
+	ClaimWebhookEvent(
+		ctx context.Context, id, nextAttemptAt string, leaseUntil time.Time, headers http.Header,
+	) (bool, *controller.APIError)
+	SetWebhookEventDelivered(
+		ctx context.Context, id string, attempts int, headers http.Header,
+	) *controller.APIError
+	SetWebhookEventFailed(
+		ctx context.Context,
+		id string,
+		attempts int,
+		nextAttemptAt time.Time,
+		lastError string,
+		headers http.Header,
+	) *controller.APIError
+}
+
+// Sign returns the value of the signature header for the given payload. Receivers
+// should compute the HMAC-SHA256 of "<timestamp>.<body>" with the shared secret and
+// compare it with the header.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func backoff(attempts int) time.Duration {
+	d := minBackoff
+	for i := 1; i < attempts && d < maxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, maxBackoff)
+}
+
+// Dispatcher stores events in a durable outbox and delivers them to the global webhook
+// and to the webhook of the bucket the event belongs to. Delivery is at-least-once so
+// receivers should deduplicate events using the id header.
+type Dispatcher struct {
+	storage     Storage
+	url         string
+	secret      string
+	adminSecret controller.AdminSecret
+	maxAttempts int
+	client      *http.Client
+	logger      *logrus.Logger
+}
+
+func New(
+	storage Storage,
+	url string,
+	secret string,
+	hasuraAdminSecret controller.AdminSecret,
+	logger *logrus.Logger,
+) *Dispatcher {
+	return &Dispatcher{
+		storage:     storage,
+		url:         url,
+		secret:      secret,
+		adminSecret: hasuraAdminSecret,
+		maxAttempts: defaultMaxAttempts,
+		client: &http.Client{ //nolint: exhaustruct
+			Timeout: requestTimeout,
+		},
+		logger: logger,
+	}
+}
+
+func (d *Dispatcher) headers() http.Header {
+	return http.Header{"x-hasura-admin-secret": []string{d.adminSecret.Primary()}}
+}
+
+// Events returns an outbox entry for the global webhook and another one for the webhook
+// of the bucket, when they are configured. The data of events with a FileID is left out
+// and filled in by the database with the file as it is when the event is stored.
+func (d *Dispatcher) Events(
+	ctx context.Context, event controller.Event,
+) ([]controller.WebhookEvent, *controller.APIError) {
+	payload := map[string]any{
+		"bucketId": event.BucketID,
+	}
+
+	if event.FileID == "" {
+		b, err := json.Marshal(event.Data)
+		if err != nil {
+			return nil, controller.InternalServerError(
+				fmt.Errorf("problem marshalling event data: %w", err),
+			)
+		}
+
+		var data any
+		if err := json.Unmarshal(b, &data); err != nil {
+			return nil, controller.InternalServerError(
+				fmt.Errorf("problem unmarshalling event data: %w", err),
+			)
+		}
+		payload["data"] = data
+	}
+
+	events := make([]controller.WebhookEvent, 0, 2) //nolint: mnd
+	if d.url != "" {
+		events = append(events, controller.WebhookEvent{ //nolint: exhaustruct
+			EventType: event.Type,
+			FileID:    event.FileID,
+			Payload:   payload,
+		})
+	}
+
+	if event.BucketID != "" {
+		bucket, apiErr := d.storage.GetBucketByID(ctx, event.BucketID, d.headers())
+		if apiErr != nil {
+			return nil, apiErr.ExtendError("problem getting bucket to notify")
+		}
+
+		if bucket.WebhookURL != "" {
+			events = append(events, controller.WebhookEvent{ //nolint: exhaustruct
+				EventType: event.Type,
+				BucketID:  event.BucketID,
+				FileID:    event.FileID,
+				Payload:   payload,
+			})
+		}
+	}
+
+	return events, nil
+}
+
+func (d *Dispatcher) Notify(ctx context.Context, event controller.Event) *controller.APIError {
+	events, apiErr := d.Events(ctx, event)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	return d.storage.InsertWebhookEvents(ctx, events, d.headers())
+}
+
+func (d *Dispatcher) target(
+	ctx context.Context, event controller.WebhookEvent,
+) (string, string, *controller.APIError) {
+	if event.BucketID == "" {
+		return d.url, d.secret, nil
+	}
+
+	bucket, apiErr := d.storage.GetBucketByID(ctx, event.BucketID, d.headers())
+	if apiErr != nil {
+		return "", "", apiErr
+	}
+
+	if bucket.WebhookURL == "" {
+		return "", "", nil
+	}
+
+	secret, apiErr := d.storage.GetWebhookSecret(ctx, event.BucketID, d.headers())
+	if apiErr != nil {
+		return "", "", apiErr
+	}
+
+	return bucket.WebhookURL, secret, nil
+}
+
+func (d *Dispatcher) send(ctx context.Context, event controller.WebhookEvent) error {
+	url, secret, apiErr := d.target(ctx, event)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	if url == "" {
+		return fmt.Errorf("webhook for event %s is no longer configured", event.ID) //nolint: goerr113
+	}
+
+	body := make(map[string]any, len(event.Payload)+3) //nolint: mnd
+	for k, v := range event.Payload {
+		body[k] = v
+	}
+	body["id"] = event.ID
+	body["type"] = event.EventType
+	body["createdAt"] = event.CreatedAt
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("problem marshalling body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("problem creating request: %w", err)
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderID, event.ID)
+	req.Header.Set(HeaderEvent, event.EventType)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	if secret != "" {
+		req.Header.Set(HeaderSignature, Sign(secret, timestamp, b))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("problem executing request: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %s", resp.Status) //nolint: goerr113
+	}
+
+	return nil
+}
+
+// deliver returns false if the event couldn't be claimed, it is then left for later.
+func (d *Dispatcher) deliver(ctx context.Context, event controller.WebhookEvent) bool {
+	logger := d.logger.WithFields(logrus.Fields{"event_id": event.ID, "event_type": event.EventType})
+
+	// claiming the event pushes the next attempt forward so other instances
+	// don't pick it up while we are delivering it
+	claimed, apiErr := d.storage.ClaimWebhookEvent(
+		ctx, event.ID, event.NextAttemptAt, time.Now().Add(2*requestTimeout), d.headers(),
+	)
+	if apiErr != nil {
+		logger.WithError(apiErr).Error("problem claiming webhook event")
+		return false
+	}
+	if !claimed {
+		return false
+	}
+
+	attempts := event.Attempts + 1
+	if err := d.send(ctx, event); err != nil {
+		logger.WithError(err).Warn("problem delivering webhook event")
+		if apiErr := d.storage.SetWebhookEventFailed(
+			ctx, event.ID, attempts, time.Now().Add(backoff(attempts)), err.Error(), d.headers(),
+		); apiErr != nil {
+			logger.WithError(apiErr).Error("problem flagging webhook event as failed")
+		}
+		return true
+	}
+
+	if apiErr := d.storage.SetWebhookEventDelivered(ctx, event.ID, attempts, d.headers()); apiErr != nil {
+		logger.WithError(apiErr).Error("problem flagging webhook event as delivered")
+	}
+
+	return true
+}
+
+// DeliverPending delivers all the events that are due.
+func (d *Dispatcher) DeliverPending(ctx context.Context) {
+	for {
+		events, apiErr := d.storage.ListPendingWebhookEvents(
+			ctx, time.Now(), d.maxAttempts, batchSize, d.headers(),
+		)
+		if apiErr != nil {
+			d.logger.WithError(apiErr).Error("problem listing pending webhook events")
+			return
+		}
+
+		claimed := 0
+		for _, event := range events {
+			if d.deliver(ctx, event) {
+				claimed++
+			}
+		}
+
+		// events that can't be claimed would be listed again so there is no point in
+		// going on if none were
+		if len(events) < batchSize || claimed == 0 || ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// Run delivers pending events periodically until the context is cancelled.
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		d.DeliverPending(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
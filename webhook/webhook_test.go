@@ -0,0 +1,321 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/hasura-storage/controller"
+	"github.com/nhost/hasura-storage/middleware/auth"
+	"github.com/nhost/hasura-storage/webhook"
+	"github.com/nhost/hasura-storage/webhook/mock"
+	"github.com/sirupsen/logrus"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestNotify(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		globalURL string
+		bucketID  string
+		bucket    controller.BucketMetadata
+		expected  []controller.WebhookEvent
+	}{
+		{
+			name:      "global and bucket",
+			globalURL: "https://example.com/global",
+			bucketID:  "default",
+			bucket: controller.BucketMetadata{ //nolint: exhaustruct
+				ID:         "default",
+				WebhookURL: "https://example.com/default",
+			},
+			expected: []controller.WebhookEvent{
+				{ //nolint: exhaustruct
+					EventType: controller.EventFileDeleted,
+					Payload: map[string]any{
+						"bucketId": "default",
+						"data":     map[string]any{"id": "some-id"},
+					},
+				},
+				{ //nolint: exhaustruct
+					EventType: controller.EventFileDeleted,
+					BucketID:  "default",
+					Payload: map[string]any{
+						"bucketId": "default",
+						"data":     map[string]any{"id": "some-id"},
+					},
+				},
+			},
+		},
+		{
+			name:      "only bucket",
+			globalURL: "",
+			bucketID:  "default",
+			bucket: controller.BucketMetadata{ //nolint: exhaustruct
+				ID:         "default",
+				WebhookURL: "https://example.com/default",
+			},
+			expected: []controller.WebhookEvent{
+				{ //nolint: exhaustruct
+					EventType: controller.EventFileDeleted,
+					BucketID:  "default",
+					Payload: map[string]any{
+						"bucketId": "default",
+						"data":     map[string]any{"id": "some-id"},
+					},
+				},
+			},
+		},
+		{
+			name:      "nothing configured",
+			globalURL: "",
+			bucketID:  "default",
+			bucket: controller.BucketMetadata{ //nolint: exhaustruct
+				ID: "default",
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			storage := mock.NewMockStorage(c)
+
+			storage.EXPECT().GetBucketByID(
+				gomock.Any(), tc.bucketID, gomock.Any(),
+			).Return(tc.bucket, nil)
+
+			if tc.expected != nil {
+				storage.EXPECT().InsertWebhookEvents(
+					gomock.Any(), tc.expected, gomock.Any(),
+				).Return(nil)
+			}
+
+			d := webhook.New(storage, tc.globalURL, "", auth.NewAdminSecrets("admin-secret"), logrus.New())
+
+			if err := d.Notify(context.Background(), controller.Event{
+				Type:     controller.EventFileDeleted,
+				BucketID: tc.bucketID,
+				Data:     map[string]string{"id": "some-id"},
+			}); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestEvents(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	storage := mock.NewMockStorage(c)
+
+	storage.EXPECT().GetBucketByID(
+		gomock.Any(), "default", gomock.Any(),
+	).Return(controller.BucketMetadata{ //nolint: exhaustruct
+		ID:         "default",
+		WebhookURL: "https://example.com/default",
+	}, nil)
+
+	d := webhook.New(storage, "", "", auth.NewAdminSecrets("admin-secret"), logrus.New())
+
+	// nothing is inserted, the caller queues the events along with the change
+	events, err := d.Events(context.Background(), controller.Event{ //nolint: exhaustruct
+		Type:     controller.EventFileUploaded,
+		BucketID: "default",
+		FileID:   "file-id",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []controller.WebhookEvent{
+		{ //nolint: exhaustruct
+			EventType: controller.EventFileUploaded,
+			BucketID:  "default",
+			FileID:    "file-id",
+			Payload:   map[string]any{"bucketId": "default"},
+		},
+	}
+	if diff := cmp.Diff(expected, events); diff != "" {
+		t.Error(diff)
+	}
+}
+
+type fileData struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestDeliverPending(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name         string
+		statusCode   int
+		payload      map[string]any
+		delivered    bool
+		expectedData fileData
+	}{
+		{
+			name:       "delivered",
+			statusCode: http.StatusOK,
+			payload: map[string]any{
+				"bucketId": "default",
+				"data":     map[string]any{"id": "file-id", "name": "a.txt"},
+			},
+			delivered:    true,
+			expectedData: fileData{ID: "file-id", Name: "a.txt"},
+		},
+		{
+			name:       "failed",
+			statusCode: http.StatusInternalServerError,
+			payload: map[string]any{
+				"bucketId": "default",
+				"data":     map[string]any{"id": "file-id", "name": "a.txt"},
+			},
+			delivered:    false,
+			expectedData: fileData{ID: "file-id", Name: "a.txt"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Error(err)
+				}
+
+				timestamp, err := strconv.ParseInt(r.Header.Get(webhook.HeaderTimestamp), 10, 64)
+				if err != nil {
+					t.Error(err)
+				}
+
+				if diff := cmp.Diff(
+					webhook.Sign("bucket-secret", timestamp, body),
+					r.Header.Get(webhook.HeaderSignature),
+				); diff != "" {
+					t.Error(diff)
+				}
+
+				if diff := cmp.Diff("event-id", r.Header.Get(webhook.HeaderID)); diff != "" {
+					t.Error(diff)
+				}
+
+				var got struct {
+					Data fileData `json:"data"`
+				}
+				if err := json.Unmarshal(body, &got); err != nil {
+					t.Error(err)
+				}
+
+				if diff := cmp.Diff(tc.expectedData, got.Data); diff != "" {
+					t.Error(diff)
+				}
+
+				w.WriteHeader(tc.statusCode)
+			}))
+			defer server.Close()
+
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			storage := mock.NewMockStorage(c)
+
+			event := controller.WebhookEvent{
+				ID:            "event-id",
+				CreatedAt:     "2024-01-02T03:04:05Z",
+				EventType:     controller.EventFileUploaded,
+				BucketID:      "default",
+				FileID:        "file-id",
+				Payload:       tc.payload,
+				Attempts:      2,
+				NextAttemptAt: "2024-01-02T03:04:05Z",
+			}
+
+			storage.EXPECT().ListPendingWebhookEvents(
+				gomock.Any(), gomock.Any(), 10, 100, gomock.Any(),
+			).Return([]controller.WebhookEvent{event}, nil)
+
+			storage.EXPECT().ClaimWebhookEvent(
+				gomock.Any(), "event-id", "2024-01-02T03:04:05Z", gomock.Any(), gomock.Any(),
+			).Return(true, nil)
+
+			storage.EXPECT().GetBucketByID(
+				gomock.Any(), "default", gomock.Any(),
+			).Return(controller.BucketMetadata{ //nolint: exhaustruct
+				ID:         "default",
+				WebhookURL: server.URL,
+			}, nil)
+
+			storage.EXPECT().GetWebhookSecret(
+				gomock.Any(), "default", gomock.Any(),
+			).Return("bucket-secret", nil)
+
+			if tc.delivered {
+				storage.EXPECT().SetWebhookEventDelivered(
+					gomock.Any(), "event-id", 3, gomock.Any(),
+				).Return(nil)
+			} else {
+				storage.EXPECT().SetWebhookEventFailed(
+					gomock.Any(), "event-id", 3, gomock.Any(), gomock.Any(), gomock.Any(),
+				).Return(nil)
+			}
+
+			logger := logrus.New()
+			logger.SetLevel(logrus.ErrorLevel)
+
+			d := webhook.New(storage, "", "", auth.NewAdminSecrets("admin-secret"), logger)
+			d.DeliverPending(context.Background())
+		})
+	}
+}
+
+func TestDeliverPendingNoProgress(t *testing.T) {
+	t.Parallel()
+
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	storage := mock.NewMockStorage(c)
+
+	events := make([]controller.WebhookEvent, 100)
+	for i := range events {
+		events[i] = controller.WebhookEvent{ //nolint: exhaustruct
+			ID:        "event-id-" + strconv.Itoa(i),
+			EventType: controller.EventFileUploaded,
+		}
+	}
+
+	// the batch is full but none of the events can be claimed so it isn't listed again
+	storage.EXPECT().ListPendingWebhookEvents(
+		gomock.Any(), gomock.Any(), 10, 100, gomock.Any(),
+	).Return(events, nil)
+	storage.EXPECT().ClaimWebhookEvent(
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+	).Return(false, controller.InternalServerError(errors.New("database is down"))).Times(100) //nolint: goerr113
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	d := webhook.New(storage, "", "", auth.NewAdminSecrets("admin-secret"), logger)
+	d.DeliverPending(context.Background())
+}